@@ -6,8 +6,14 @@ import (
 	"net/http"
 
 	ht "github.com/ogen-go/ogen/http"
+	"github.com/ogen-go/ogen/ogenregex"
 )
 
+var regexMap = map[string]ogenregex.Regexp{
+	"^[A-Z]{3}$":              ogenregex.MustCompile("^[A-Z]{3}$"),
+	"^[a-z][a-z0-9_-]{0,31}$": ogenregex.MustCompile("^[a-z][a-z0-9_-]{0,31}$"),
+}
+
 type (
 	optionFunc[C any] func(*C)
 )
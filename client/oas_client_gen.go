@@ -23,16 +23,94 @@ func trimTrailingSlashes(u *url.URL) {
 type Invoker interface {
 	// AddPet invokes addPet operation.
 	//
-	// Creates a new pet in the store. Duplicates are allowed.
+	// Creates a new pet in a store. Duplicates are allowed. Accounts
+	// and API keys confined to a store add pets to it; global ones
+	// must name the store.
 	//
 	// POST /pets
-	AddPet(ctx context.Context, request *NewPet) (*Pet, error)
+	AddPet(ctx context.Context, request *NewPet, params AddPetParams) (AddPetRes, error)
+	// CancelReservation invokes cancelReservation operation.
+	//
+	// Release one of the current user's active holds, making the pet available again.
+	//
+	// DELETE /auth/me/reservations/{id}
+	CancelReservation(ctx context.Context, params CancelReservationParams) (CancelReservationRes, error)
+	// ChangePassword invokes changePassword operation.
+	//
+	// Change the current user's password. Requires the current
+	// password. Every other session is signed out; this one gets
+	// a fresh access_token cookie.
+	//
+	// POST /auth/me/password
+	ChangePassword(ctx context.Context, request *ChangePasswordRequest, params ChangePasswordParams) (ChangePasswordRes, error)
+	// ConfirmEmailChange invokes confirmEmailChange operation.
+	//
+	// Apply an email change using the token from the confirmation email.
+	//
+	// POST /auth/email/confirm
+	ConfirmEmailChange(ctx context.Context, request *VerifyEmailRequest, params ConfirmEmailChangeParams) (ConfirmEmailChangeRes, error)
+	// ConfirmMFAEnrollment invokes confirmMFAEnrollment operation.
+	//
+	// Enable TOTP by proving the authenticator app produces valid
+	// codes. Returns recovery codes, which are shown only once.
+	//
+	// POST /auth/mfa/enroll/confirm
+	ConfirmMFAEnrollment(ctx context.Context, request *MFACodeRequest, params ConfirmMFAEnrollmentParams) (ConfirmMFAEnrollmentRes, error)
+	// CreateAPIKey invokes createAPIKey operation.
+	//
+	// Creates an API key. The secret is returned only in this response.
+	//
+	// POST /admin/api-keys
+	CreateAPIKey(ctx context.Context, request *NewAPIKey, params CreateAPIKeyParams) (CreateAPIKeyRes, error)
+	// CreateStore invokes createStore operation.
+	//
+	// Creates a shop location. Store names are unique.
+	//
+	// POST /admin/stores
+	CreateStore(ctx context.Context, request *NewStore, params CreateStoreParams) (CreateStoreRes, error)
+	// CreateWebhook invokes createWebhook operation.
+	//
+	// Subscribes a URL to pet events. Each event is POSTed as JSON
+	// with a Petstore-Signature header: "sha256=" and the hex
+	// HMAC-SHA256, keyed by the webhook's secret, of the
+	// Petstore-Timestamp header, a dot, and the body. The secret is
+	// returned only in this response.
+	//
+	// POST /admin/webhooks
+	CreateWebhook(ctx context.Context, request *NewWebhook, params CreateWebhookParams) (CreateWebhookRes, error)
 	// DeletePet invokes deletePet operation.
 	//
-	// Deletes a single pet based on the ID supplied.
+	// Deletes a single pet based on the ID supplied. The pet is
+	// soft-deleted: it disappears from findPets and findPetByID but
+	// keeps its history and can be restored by an admin.
 	//
 	// DELETE /pets/{id}
 	DeletePet(ctx context.Context, params DeletePetParams) error
+	// DeleteWebhook invokes deleteWebhook operation.
+	//
+	// Deletes a webhook and its delivery log. Queued deliveries are not sent.
+	//
+	// DELETE /admin/webhooks/{id}
+	DeleteWebhook(ctx context.Context, params DeleteWebhookParams) error
+	// EnrollMFA invokes enrollMFA operation.
+	//
+	// Generate a TOTP secret for the current user. TOTP is not
+	// enabled until confirmMFAEnrollment succeeds.
+	//
+	// POST /auth/mfa/enroll
+	EnrollMFA(ctx context.Context, params EnrollMFAParams) (EnrollMFARes, error)
+	// ExportPets invokes exportPets operation.
+	//
+	// Streams every pet matching the findPets filters, in ID order,
+	// as CSV (with a header row naming the columns), NDJSON, or a JSON
+	// array, chosen by the Accept header. The JSON array is served as
+	// `application/vnd.petstore.pets+json`, and also answers clients
+	// that accept `application/json`; it is the default when the
+	// client accepts any format equally. Rows are written as they are
+	// read, so the response has no Content-Length.
+	//
+	// GET /pets/export
+	ExportPets(ctx context.Context, params ExportPetsParams) (ExportPetsRes, error)
 	// FindPetByID invokes find pet by id operation.
 	//
 	// Returns a user based on a single ID, if the user does not have access to the pet.
@@ -61,30 +139,244 @@ type Invoker interface {
 	//
 	// GET /pets
 	FindPets(ctx context.Context, params FindPetsParams) ([]Pet, error)
+	// ForgotPassword invokes forgotPassword operation.
+	//
+	// Email a single-use password reset link to the account with
+	// the given address. The response is the same whether or not
+	// the address is registered.
+	//
+	// POST /auth/password/forgot
+	ForgotPassword(ctx context.Context, request *ForgotPasswordRequest, params ForgotPasswordParams) (ForgotPasswordRes, error)
 	// GetCurrentUser invokes getCurrentUser operation.
 	//
 	// Get the currently authenticated user.
 	//
 	// GET /auth/me
 	GetCurrentUser(ctx context.Context) (*AuthUser, error)
+	// GetJWKS invokes getJWKS operation.
+	//
+	// Returns the public keys that verify access tokens, as a
+	// JSON Web Key Set (RFC 7517). During a key rotation it lists
+	// both the current signing key and the keys being retired.
+	// Empty when tokens are signed with a shared HS256 secret.
+	//
+	// GET /.well-known/jwks.json
+	GetJWKS(ctx context.Context) (*JWKS, error)
+	// ImportPets invokes importPets operation.
+	//
+	// Creates pets from a CSV file (with a header row naming a `name`
+	// column and any of `tag`, `species`, `breed`, `birth_date`, `sex`,
+	// `description`, `price_amount`, `price_currency`, and `store_id`)
+	// or from NDJSON (one NewPet object per line). Each row is checked
+	// with the same rules as addPet; rows without a store go to
+	// `storeId`, or to the caller's store, or for a global caller to
+	// the default store (the seeded Main Store). A row naming an
+	// unknown store, or one the caller may not use, is invalid. In
+	// atomic mode nothing is written unless every row is valid, and
+	// invalid rows answer 422 with the report; in partial mode the
+	// valid rows are written and the invalid ones reported. A dry run
+	// checks the rows and reports the outcome without writing.
+	//
+	// POST /pets/import
+	ImportPets(ctx context.Context, request ImportPetsReq, params ImportPetsParams) (ImportPetsRes, error)
+	// ListAPIKeys invokes listAPIKeys operation.
+	//
+	// Returns all API keys, including revoked and expired ones. Secrets are never returned.
+	//
+	// GET /admin/api-keys
+	ListAPIKeys(ctx context.Context) ([]APIKey, error)
+	// ListAuditEvents invokes listAuditEvents operation.
+	//
+	// Returns audit events, newest first. Every filter is optional;
+	// page backwards by passing the smallest returned id as `before`.
+	//
+	// GET /admin/audit-events
+	ListAuditEvents(ctx context.Context, params ListAuditEventsParams) ([]AuditEvent, error)
+	// ListJobs invokes listJobs operation.
+	//
+	// Returns the background jobs and their counters as seen by the
+	// replica that serves the request. Counters reset when a server
+	// restarts, and only the elected leader runs jobs, so a follower
+	// reports leader false and no next run times.
+	//
+	// GET /admin/jobs
+	ListJobs(ctx context.Context) (*JobSchedulerStatus, error)
+	// ListMyReservations invokes listMyReservations operation.
+	//
+	// The current user's holds on pets, newest first.
+	//
+	// GET /auth/me/reservations
+	ListMyReservations(ctx context.Context) ([]Reservation, error)
+	// ListPetRevisions invokes listPetRevisions operation.
+	//
+	// Returns every revision of a pet, oldest first: its creation,
+	// each deletion and restore, and each hold placed,
+	// cancelled, or lapsed, with the acting user or API key (none for
+	// a lapse). Deleted pets keep their history.
+	//
+	// GET /admin/pets/{id}/history
+	ListPetRevisions(ctx context.Context, params ListPetRevisionsParams) ([]PetRevision, error)
+	// ListSpecies invokes listSpecies operation.
+	//
+	// Returns the species and breeds a pet may be given, sorted by
+	// name. The list is reference data maintained by migrations.
+	//
+	// GET /species
+	ListSpecies(ctx context.Context) ([]Species, error)
+	// ListStores invokes listStores operation.
+	//
+	// Returns the shop locations, sorted by name.
+	//
+	// GET /stores
+	ListStores(ctx context.Context) ([]Store, error)
+	// ListWebhookDeliveries invokes listWebhookDeliveries operation.
+	//
+	// Returns the webhook's delivery log, newest first: one entry per
+	// event sent to it, with its status and the outcome of its last
+	// attempt. Page backwards by passing the smallest returned id as
+	// `before`.
+	//
+	// GET /admin/webhooks/{id}/deliveries
+	ListWebhookDeliveries(ctx context.Context, params ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	// ListWebhooks invokes listWebhooks operation.
+	//
+	// Returns every webhook subscription. Signing secrets are never returned.
+	//
+	// GET /admin/webhooks
+	ListWebhooks(ctx context.Context) ([]Webhook, error)
 	// LoginUser invokes loginUser operation.
 	//
 	// Authenticate a user and set an access token cookie.
 	//
 	// POST /auth/login
-	LoginUser(ctx context.Context, request *LoginRequest) (LoginUserRes, error)
+	LoginUser(ctx context.Context, request *LoginRequest, params LoginUserParams) (LoginUserRes, error)
 	// LogoutUser invokes logoutUser operation.
 	//
 	// Log out the current user by clearing the access token cookie.
 	//
 	// POST /auth/logout
-	LogoutUser(ctx context.Context) error
+	LogoutUser(ctx context.Context, params LogoutUserParams) (LogoutUserRes, error)
+	// OidcCallback invokes oidcCallback operation.
+	//
+	// Redirect target for the identity provider. Validates the
+	// state, redeems the code, signs in the linked user (linking
+	// by verified email, or creating a customer on first login),
+	// sets the access_token cookie, and redirects to the frontend.
+	//
+	// GET /auth/oidc/callback
+	OidcCallback(ctx context.Context, params OidcCallbackParams) (OidcCallbackRes, error)
+	// RedeliverWebhookDelivery invokes redeliverWebhookDelivery operation.
+	//
+	// Queues a succeeded or dead-lettered delivery to be sent again
+	// at once, with a fresh set of attempts. Returns 409 while the
+	// delivery is still pending.
+	//
+	// POST /admin/webhooks/{id}/deliveries/{deliveryId}/redeliver
+	RedeliverWebhookDelivery(ctx context.Context, params RedeliverWebhookDeliveryParams) (RedeliverWebhookDeliveryRes, error)
 	// RegisterUser invokes registerUser operation.
 	//
 	// Register a new user account.
 	//
 	// POST /auth/register
-	RegisterUser(ctx context.Context, request *RegisterRequest) (RegisterUserRes, error)
+	RegisterUser(ctx context.Context, request *RegisterRequest, params RegisterUserParams) (RegisterUserRes, error)
+	// RequestEmailChange invokes requestEmailChange operation.
+	//
+	// Start changing the current user's email address. Requires the
+	// current password. A confirmation link is sent to the new
+	// address and a notice to the old one; the address changes only
+	// once the link is used.
+	//
+	// POST /auth/me/email
+	RequestEmailChange(ctx context.Context, request *ChangeEmailRequest, params RequestEmailChangeParams) (RequestEmailChangeRes, error)
+	// ResendVerificationEmail invokes resendVerificationEmail operation.
+	//
+	// Send a new verification link to the current user. Limited to
+	// one email per minute and five per hour.
+	//
+	// POST /auth/verify/resend
+	ResendVerificationEmail(ctx context.Context, params ResendVerificationEmailParams) (ResendVerificationEmailRes, error)
+	// ReservePet invokes reservePet operation.
+	//
+	// Place a 48-hour hold on a pet before coming in to see it.
+	// While the hold lasts the pet shows as reserved and other holds
+	// are rejected; it lapses on its own, or the holder cancels it.
+	// A user may hold at most 3 pets at a time, and may not hold a
+	// pet again until 24 hours after their last hold on it ended.
+	//
+	// POST /pets/{id}/reservations
+	ReservePet(ctx context.Context, params ReservePetParams) (ReservePetRes, error)
+	// ResetPassword invokes resetPassword operation.
+	//
+	// Set a new password using a token from a reset email.
+	//
+	// POST /auth/password/reset
+	ResetPassword(ctx context.Context, request *ResetPasswordRequest, params ResetPasswordParams) (ResetPasswordRes, error)
+	// RestorePet invokes restorePet operation.
+	//
+	// Undoes a soft delete. Returns 404 unless the pet exists and is deleted.
+	//
+	// POST /admin/pets/{id}/restore
+	RestorePet(ctx context.Context, params RestorePetParams) (RestorePetRes, error)
+	// RevokeAPIKey invokes revokeAPIKey operation.
+	//
+	// Revokes an API key immediately. Revoked keys stay listed.
+	//
+	// DELETE /admin/api-keys/{id}
+	RevokeAPIKey(ctx context.Context, params RevokeAPIKeyParams) error
+	// StartOIDCLogin invokes startOIDCLogin operation.
+	//
+	// Starts an OpenID Connect authorization code flow with PKCE.
+	// Redirects to the configured identity provider and sets a
+	// short-lived oidc_state cookie. Returns 404 when no provider
+	// is configured.
+	//
+	// GET /auth/oidc/login
+	StartOIDCLogin(ctx context.Context) (*StartOIDCLoginFound, error)
+	// StreamPetEvents invokes streamPetEvents operation.
+	//
+	// A Server-Sent Events stream of pet catalog changes, one event
+	// per pet revision, so storefronts need not poll `GET /pets`.
+	// Each event's `id` is the revision ID, its `event` the change
+	// (`created`, `deleted`, `restored`, `reserved`,
+	// `reservation_cancelled`, or `reservation_expired`), and its
+	// `data` a JSON object with `petId`, `name`, `tag`, and
+	// `createdAt`. A new connection receives only live events;
+	// sending Last-Event-ID first replays every later one. A
+	// `: heartbeat` comment is sent while the stream is idle.
+	//
+	// GET /pets/events
+	StreamPetEvents(ctx context.Context, params StreamPetEventsParams) (StreamPetEventsRes, error)
+	// UnlockUser invokes unlockUser operation.
+	//
+	// Clear the failed login counter and any temporary lock on a
+	// user account.
+	//
+	// POST /admin/users/{id}/unlock
+	UnlockUser(ctx context.Context, params UnlockUserParams) (UnlockUserRes, error)
+	// UpdateUser invokes updateUser operation.
+	//
+	// Set a user's role or store, disable the account, or re-enable
+	// it. A disabled user cannot log in. Existing sessions keep
+	// their claimed role and store until they expire unless the
+	// server runs with REQUIRE_FRESH_ROLES, which rejects them at
+	// once. Admins confined to a store manage only its users and
+	// cannot move them out of it.
+	//
+	// PATCH /admin/users/{id}
+	UpdateUser(ctx context.Context, request *UpdateUserRequest, params UpdateUserParams) (*AuthUser, error)
+	// VerifyEmail invokes verifyEmail operation.
+	//
+	// Confirm an email address using the token from a verification email.
+	//
+	// POST /auth/verify
+	VerifyEmail(ctx context.Context, request *VerifyEmailRequest, params VerifyEmailParams) (VerifyEmailRes, error)
+	// VerifyMFA invokes verifyMFA operation.
+	//
+	// Exchange the challenge token from loginUser and a TOTP or
+	// recovery code for an access_token cookie.
+	//
+	// POST /auth/mfa/verify
+	VerifyMFA(ctx context.Context, request *MFAVerifyRequest, params VerifyMFAParams) (VerifyMFARes, error)
 }
 
 // Client implements OAS client.
@@ -130,15 +422,26 @@ func (c *Client) requestURL(ctx context.Context) *url.URL {
 
 // AddPet invokes addPet operation.
 //
-// Creates a new pet in the store. Duplicates are allowed.
+// Creates a new pet in a store. Duplicates are allowed. Accounts
+// and API keys confined to a store add pets to it; global ones
+// must name the store.
 //
 // POST /pets
-func (c *Client) AddPet(ctx context.Context, request *NewPet) (*Pet, error) {
-	res, err := c.sendAddPet(ctx, request)
+func (c *Client) AddPet(ctx context.Context, request *NewPet, params AddPetParams) (AddPetRes, error) {
+	res, err := c.sendAddPet(ctx, request, params)
 	return res, err
 }
 
-func (c *Client) sendAddPet(ctx context.Context, request *NewPet) (res *Pet, err error) {
+func (c *Client) sendAddPet(ctx context.Context, request *NewPet, params AddPetParams) (res AddPetRes, err error) {
+	// Validate request before sending.
+	if err := func() error {
+		if err := request.Validate(); err != nil {
+			return err
+		}
+		return nil
+	}(); err != nil {
+		return res, errors.Wrap(err, "validate")
+	}
 
 	u := uri.Clone(c.requestURL(ctx))
 	var pathParts [1]string
@@ -153,6 +456,22 @@ func (c *Client) sendAddPet(ctx context.Context, request *NewPet) (res *Pet, err
 		return res, errors.Wrap(err, "encode request")
 	}
 
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Idempotency-Key",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IdempotencyKey.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
 	{
 		type bitset = [1]uint8
 		var satisfied bitset
@@ -167,11 +486,23 @@ func (c *Client) sendAddPet(ctx context.Context, request *NewPet) (res *Pet, err
 				return res, errors.Wrap(err, "security \"CookieAuth\"")
 			}
 		}
+		{
+
+			switch err := c.securityBearerAuth(ctx, AddPetOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 1
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
 
 		if ok := func() bool {
 		nextRequirement:
 			for _, requirement := range []bitset{
 				{0b00000001},
+				{0b00000010},
 			} {
 				for i, mask := range requirement {
 					if satisfied[i]&mask != mask {
@@ -200,21 +531,21 @@ func (c *Client) sendAddPet(ctx context.Context, request *NewPet) (res *Pet, err
 	return result, nil
 }
 
-// DeletePet invokes deletePet operation.
+// CancelReservation invokes cancelReservation operation.
 //
-// Deletes a single pet based on the ID supplied.
+// Release one of the current user's active holds, making the pet available again.
 //
-// DELETE /pets/{id}
-func (c *Client) DeletePet(ctx context.Context, params DeletePetParams) error {
-	_, err := c.sendDeletePet(ctx, params)
-	return err
+// DELETE /auth/me/reservations/{id}
+func (c *Client) CancelReservation(ctx context.Context, params CancelReservationParams) (CancelReservationRes, error) {
+	res, err := c.sendCancelReservation(ctx, params)
+	return res, err
 }
 
-func (c *Client) sendDeletePet(ctx context.Context, params DeletePetParams) (res *DeletePetNoContent, err error) {
+func (c *Client) sendCancelReservation(ctx context.Context, params CancelReservationParams) (res CancelReservationRes, err error) {
 
 	u := uri.Clone(c.requestURL(ctx))
 	var pathParts [2]string
-	pathParts[0] = "/pets/"
+	pathParts[0] = "/auth/me/reservations/"
 	{
 		// Encode "id" parameter.
 		e := uri.NewPathEncoder(uri.PathEncoderConfig{
@@ -245,7 +576,7 @@ func (c *Client) sendDeletePet(ctx context.Context, params DeletePetParams) (res
 		var satisfied bitset
 		{
 
-			switch err := c.securityCookieAuth(ctx, DeletePetOperation, r); {
+			switch err := c.securityCookieAuth(ctx, CancelReservationOperation, r); {
 			case err == nil: // if NO error
 				satisfied[0] |= 1 << 0
 			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
@@ -279,7 +610,7 @@ func (c *Client) sendDeletePet(ctx context.Context, params DeletePetParams) (res
 	}
 	defer resp.Body.Close()
 
-	result, err := decodeDeletePetResponse(resp)
+	result, err := decodeCancelReservationResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -287,44 +618,89 @@ func (c *Client) sendDeletePet(ctx context.Context, params DeletePetParams) (res
 	return result, nil
 }
 
-// FindPetByID invokes find pet by id operation.
+// ChangePassword invokes changePassword operation.
 //
-// Returns a user based on a single ID, if the user does not have access to the pet.
+// Change the current user's password. Requires the current
+// password. Every other session is signed out; this one gets
+// a fresh access_token cookie.
 //
-// GET /pets/{id}
-func (c *Client) FindPetByID(ctx context.Context, params FindPetByIDParams) (*Pet, error) {
-	res, err := c.sendFindPetByID(ctx, params)
+// POST /auth/me/password
+func (c *Client) ChangePassword(ctx context.Context, request *ChangePasswordRequest, params ChangePasswordParams) (ChangePasswordRes, error) {
+	res, err := c.sendChangePassword(ctx, request, params)
 	return res, err
 }
 
-func (c *Client) sendFindPetByID(ctx context.Context, params FindPetByIDParams) (res *Pet, err error) {
+func (c *Client) sendChangePassword(ctx context.Context, request *ChangePasswordRequest, params ChangePasswordParams) (res ChangePasswordRes, err error) {
+	// Validate request before sending.
+	if err := func() error {
+		if err := request.Validate(); err != nil {
+			return err
+		}
+		return nil
+	}(); err != nil {
+		return res, errors.Wrap(err, "validate")
+	}
 
 	u := uri.Clone(c.requestURL(ctx))
-	var pathParts [2]string
-	pathParts[0] = "/pets/"
+	var pathParts [1]string
+	pathParts[0] = "/auth/me/password"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeChangePasswordRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	h := uri.NewHeaderEncoder(r.Header)
 	{
-		// Encode "id" parameter.
-		e := uri.NewPathEncoder(uri.PathEncoderConfig{
-			Param:   "id",
-			Style:   uri.PathStyleSimple,
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Idempotency-Key",
 			Explode: false,
-		})
-		if err := func() error {
-			return e.EncodeValue(conv.Int64ToString(params.ID))
-		}(); err != nil {
-			return res, errors.Wrap(err, "encode path")
 		}
-		encoded, err := e.Result()
-		if err != nil {
-			return res, errors.Wrap(err, "encode path")
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IdempotencyKey.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
 		}
-		pathParts[1] = encoded
 	}
-	uri.AddPathParts(u, pathParts[:]...)
 
-	r, err := ht.NewRequest(ctx, "GET", u)
-	if err != nil {
-		return res, errors.Wrap(err, "create request")
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, ChangePasswordOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
 	}
 
 	resp, err := c.cfg.Client.Do(r)
@@ -333,7 +709,7 @@ func (c *Client) sendFindPetByID(ctx context.Context, params FindPetByIDParams)
 	}
 	defer resp.Body.Close()
 
-	result, err := decodeFindPetByIDResponse(resp)
+	result, err := decodeChangePasswordResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -341,91 +717,46 @@ func (c *Client) sendFindPetByID(ctx context.Context, params FindPetByIDParams)
 	return result, nil
 }
 
-// FindPets invokes findPets operation.
-//
-// Returns all pets from the system that the user has access to
-// Nam sed condimentum est. Maecenas tempor sagittis sapien, nec rhoncus sem sagittis sit amet.
-// Aenean at gravida augue, ac iaculis sem. Curabitur odio lorem, ornare eget elementum nec, cursus
-// id lectus. Duis mi turpis, pulvinar ac eros ac, tincidunt varius justo. In hac habitasse platea
-// dictumst. Integer at adipiscing ante, a sagittis ligula. Aenean pharetra tempor ante molestie
-// imperdiet. Vivamus id aliquam diam. Cras quis velit non tortor eleifend sagittis. Praesent at enim
-// pharetra urna volutpat venenatis eget eget mauris. In eleifend fermentum facilisis. Praesent enim
-// enim, gravida ac sodales sed, placerat id erat. Suspendisse lacus dolor, consectetur non augue vel,
+// ConfirmEmailChange invokes confirmEmailChange operation.
 //
-//	vehicula interdum libero. Morbi euismod sagittis libero sed lacinia.
-//
-// Sed tempus felis lobortis leo pulvinar rutrum. Nam mattis velit nisl, eu condimentum ligula luctus
-// nec. Phasellus semper velit eget aliquet faucibus. In a mattis elit. Phasellus vel urna viverra,
-// condimentum lorem id, rhoncus nibh. Ut pellentesque posuere elementum. Sed a varius odio. Morbi
-// rhoncus ligula libero, vel eleifend nunc tristique vitae. Fusce et sem dui. Aenean nec scelerisque
-// tortor. Fusce malesuada accumsan magna vel tempus. Quisque mollis felis eu dolor tristique, sit
-// amet auctor felis gravida. Sed libero lorem, molestie sed nisl in, accumsan tempor nisi. Fusce
-// sollicitudin massa ut lacinia mattis. Sed vel eleifend lorem. Pellentesque vitae felis pretium,
-// pulvinar elit eu, euismod sapien.
+// Apply an email change using the token from the confirmation email.
 //
-// GET /pets
-func (c *Client) FindPets(ctx context.Context, params FindPetsParams) ([]Pet, error) {
-	res, err := c.sendFindPets(ctx, params)
+// POST /auth/email/confirm
+func (c *Client) ConfirmEmailChange(ctx context.Context, request *VerifyEmailRequest, params ConfirmEmailChangeParams) (ConfirmEmailChangeRes, error) {
+	res, err := c.sendConfirmEmailChange(ctx, request, params)
 	return res, err
 }
 
-func (c *Client) sendFindPets(ctx context.Context, params FindPetsParams) (res []Pet, err error) {
+func (c *Client) sendConfirmEmailChange(ctx context.Context, request *VerifyEmailRequest, params ConfirmEmailChangeParams) (res ConfirmEmailChangeRes, err error) {
 
 	u := uri.Clone(c.requestURL(ctx))
 	var pathParts [1]string
-	pathParts[0] = "/pets"
+	pathParts[0] = "/auth/email/confirm"
 	uri.AddPathParts(u, pathParts[:]...)
 
-	q := uri.NewQueryEncoder()
-	{
-		// Encode "tags" parameter.
-		cfg := uri.QueryParameterEncodingConfig{
-			Name:    "tags",
-			Style:   uri.QueryStyleForm,
-			Explode: true,
-		}
-
-		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
-			if params.Tags != nil {
-				return e.EncodeArray(func(e uri.Encoder) error {
-					for i, item := range params.Tags {
-						if err := func() error {
-							return e.EncodeValue(conv.StringToString(item))
-						}(); err != nil {
-							return errors.Wrapf(err, "[%d]", i)
-						}
-					}
-					return nil
-				})
-			}
-			return nil
-		}); err != nil {
-			return res, errors.Wrap(err, "encode query")
-		}
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
 	}
+	if err := encodeConfirmEmailChangeRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	h := uri.NewHeaderEncoder(r.Header)
 	{
-		// Encode "limit" parameter.
-		cfg := uri.QueryParameterEncodingConfig{
-			Name:    "limit",
-			Style:   uri.QueryStyleForm,
-			Explode: true,
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Idempotency-Key",
+			Explode: false,
 		}
-
-		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
-			if val, ok := params.Limit.Get(); ok {
-				return e.EncodeValue(conv.Int32ToString(val))
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IdempotencyKey.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
 			}
 			return nil
 		}); err != nil {
-			return res, errors.Wrap(err, "encode query")
+			return res, errors.Wrap(err, "encode header")
 		}
 	}
-	u.RawQuery = q.Values().Encode()
-
-	r, err := ht.NewRequest(ctx, "GET", u)
-	if err != nil {
-		return res, errors.Wrap(err, "create request")
-	}
 
 	resp, err := c.cfg.Client.Do(r)
 	if err != nil {
@@ -433,7 +764,7 @@ func (c *Client) sendFindPets(ctx context.Context, params FindPetsParams) (res [
 	}
 	defer resp.Body.Close()
 
-	result, err := decodeFindPetsResponse(resp)
+	result, err := decodeConfirmEmailChangeResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -441,34 +772,63 @@ func (c *Client) sendFindPets(ctx context.Context, params FindPetsParams) (res [
 	return result, nil
 }
 
-// GetCurrentUser invokes getCurrentUser operation.
+// ConfirmMFAEnrollment invokes confirmMFAEnrollment operation.
 //
-// Get the currently authenticated user.
+// Enable TOTP by proving the authenticator app produces valid
+// codes. Returns recovery codes, which are shown only once.
 //
-// GET /auth/me
-func (c *Client) GetCurrentUser(ctx context.Context) (*AuthUser, error) {
-	res, err := c.sendGetCurrentUser(ctx)
+// POST /auth/mfa/enroll/confirm
+func (c *Client) ConfirmMFAEnrollment(ctx context.Context, request *MFACodeRequest, params ConfirmMFAEnrollmentParams) (ConfirmMFAEnrollmentRes, error) {
+	res, err := c.sendConfirmMFAEnrollment(ctx, request, params)
 	return res, err
 }
 
-func (c *Client) sendGetCurrentUser(ctx context.Context) (res *AuthUser, err error) {
+func (c *Client) sendConfirmMFAEnrollment(ctx context.Context, request *MFACodeRequest, params ConfirmMFAEnrollmentParams) (res ConfirmMFAEnrollmentRes, err error) {
+	// Validate request before sending.
+	if err := func() error {
+		if err := request.Validate(); err != nil {
+			return err
+		}
+		return nil
+	}(); err != nil {
+		return res, errors.Wrap(err, "validate")
+	}
 
 	u := uri.Clone(c.requestURL(ctx))
 	var pathParts [1]string
-	pathParts[0] = "/auth/me"
+	pathParts[0] = "/auth/mfa/enroll/confirm"
 	uri.AddPathParts(u, pathParts[:]...)
 
-	r, err := ht.NewRequest(ctx, "GET", u)
+	r, err := ht.NewRequest(ctx, "POST", u)
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
+	if err := encodeConfirmMFAEnrollmentRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Idempotency-Key",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IdempotencyKey.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
 
 	{
 		type bitset = [1]uint8
 		var satisfied bitset
 		{
 
-			switch err := c.securityCookieAuth(ctx, GetCurrentUserOperation, r); {
+			switch err := c.securityCookieAuth(ctx, ConfirmMFAEnrollmentOperation, r); {
 			case err == nil: // if NO error
 				satisfied[0] |= 1 << 0
 			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
@@ -502,7 +862,7 @@ func (c *Client) sendGetCurrentUser(ctx context.Context) (res *AuthUser, err err
 	}
 	defer resp.Body.Close()
 
-	result, err := decodeGetCurrentUserResponse(resp)
+	result, err := decodeConfirmMFAEnrollmentResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -510,17 +870,17 @@ func (c *Client) sendGetCurrentUser(ctx context.Context) (res *AuthUser, err err
 	return result, nil
 }
 
-// LoginUser invokes loginUser operation.
+// CreateAPIKey invokes createAPIKey operation.
 //
-// Authenticate a user and set an access token cookie.
+// Creates an API key. The secret is returned only in this response.
 //
-// POST /auth/login
-func (c *Client) LoginUser(ctx context.Context, request *LoginRequest) (LoginUserRes, error) {
-	res, err := c.sendLoginUser(ctx, request)
+// POST /admin/api-keys
+func (c *Client) CreateAPIKey(ctx context.Context, request *NewAPIKey, params CreateAPIKeyParams) (CreateAPIKeyRes, error) {
+	res, err := c.sendCreateAPIKey(ctx, request, params)
 	return res, err
 }
 
-func (c *Client) sendLoginUser(ctx context.Context, request *LoginRequest) (res LoginUserRes, err error) {
+func (c *Client) sendCreateAPIKey(ctx context.Context, request *NewAPIKey, params CreateAPIKeyParams) (res CreateAPIKeyRes, err error) {
 	// Validate request before sending.
 	if err := func() error {
 		if err := request.Validate(); err != nil {
@@ -533,24 +893,73 @@ func (c *Client) sendLoginUser(ctx context.Context, request *LoginRequest) (res
 
 	u := uri.Clone(c.requestURL(ctx))
 	var pathParts [1]string
-	pathParts[0] = "/auth/login"
+	pathParts[0] = "/admin/api-keys"
 	uri.AddPathParts(u, pathParts[:]...)
 
 	r, err := ht.NewRequest(ctx, "POST", u)
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
-	if err := encodeLoginUserRequest(request, r); err != nil {
+	if err := encodeCreateAPIKeyRequest(request, r); err != nil {
 		return res, errors.Wrap(err, "encode request")
 	}
 
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Idempotency-Key",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IdempotencyKey.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, CreateAPIKeyOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
 	resp, err := c.cfg.Client.Do(r)
 	if err != nil {
 		return res, errors.Wrap(err, "do request")
 	}
 	defer resp.Body.Close()
 
-	result, err := decodeLoginUserResponse(resp)
+	result, err := decodeCreateAPIKeyResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -558,34 +967,62 @@ func (c *Client) sendLoginUser(ctx context.Context, request *LoginRequest) (res
 	return result, nil
 }
 
-// LogoutUser invokes logoutUser operation.
+// CreateStore invokes createStore operation.
 //
-// Log out the current user by clearing the access token cookie.
+// Creates a shop location. Store names are unique.
 //
-// POST /auth/logout
-func (c *Client) LogoutUser(ctx context.Context) error {
-	_, err := c.sendLogoutUser(ctx)
-	return err
+// POST /admin/stores
+func (c *Client) CreateStore(ctx context.Context, request *NewStore, params CreateStoreParams) (CreateStoreRes, error) {
+	res, err := c.sendCreateStore(ctx, request, params)
+	return res, err
 }
 
-func (c *Client) sendLogoutUser(ctx context.Context) (res *LogoutUserNoContent, err error) {
+func (c *Client) sendCreateStore(ctx context.Context, request *NewStore, params CreateStoreParams) (res CreateStoreRes, err error) {
+	// Validate request before sending.
+	if err := func() error {
+		if err := request.Validate(); err != nil {
+			return err
+		}
+		return nil
+	}(); err != nil {
+		return res, errors.Wrap(err, "validate")
+	}
 
 	u := uri.Clone(c.requestURL(ctx))
 	var pathParts [1]string
-	pathParts[0] = "/auth/logout"
+	pathParts[0] = "/admin/stores"
 	uri.AddPathParts(u, pathParts[:]...)
 
 	r, err := ht.NewRequest(ctx, "POST", u)
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
+	if err := encodeCreateStoreRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Idempotency-Key",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IdempotencyKey.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
 
 	{
 		type bitset = [1]uint8
 		var satisfied bitset
 		{
 
-			switch err := c.securityCookieAuth(ctx, LogoutUserOperation, r); {
+			switch err := c.securityCookieAuth(ctx, CreateStoreOperation, r); {
 			case err == nil: // if NO error
 				satisfied[0] |= 1 << 0
 			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
@@ -619,7 +1056,7 @@ func (c *Client) sendLogoutUser(ctx context.Context) (res *LogoutUserNoContent,
 	}
 	defer resp.Body.Close()
 
-	result, err := decodeLogoutUserResponse(resp)
+	result, err := decodeCreateStoreResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -627,17 +1064,21 @@ func (c *Client) sendLogoutUser(ctx context.Context) (res *LogoutUserNoContent,
 	return result, nil
 }
 
-// RegisterUser invokes registerUser operation.
+// CreateWebhook invokes createWebhook operation.
 //
-// Register a new user account.
+// Subscribes a URL to pet events. Each event is POSTed as JSON
+// with a Petstore-Signature header: "sha256=" and the hex
+// HMAC-SHA256, keyed by the webhook's secret, of the
+// Petstore-Timestamp header, a dot, and the body. The secret is
+// returned only in this response.
 //
-// POST /auth/register
-func (c *Client) RegisterUser(ctx context.Context, request *RegisterRequest) (RegisterUserRes, error) {
-	res, err := c.sendRegisterUser(ctx, request)
+// POST /admin/webhooks
+func (c *Client) CreateWebhook(ctx context.Context, request *NewWebhook, params CreateWebhookParams) (CreateWebhookRes, error) {
+	res, err := c.sendCreateWebhook(ctx, request, params)
 	return res, err
 }
 
-func (c *Client) sendRegisterUser(ctx context.Context, request *RegisterRequest) (res RegisterUserRes, err error) {
+func (c *Client) sendCreateWebhook(ctx context.Context, request *NewWebhook, params CreateWebhookParams) (res CreateWebhookRes, err error) {
 	// Validate request before sending.
 	if err := func() error {
 		if err := request.Validate(); err != nil {
@@ -650,24 +1091,3600 @@ func (c *Client) sendRegisterUser(ctx context.Context, request *RegisterRequest)
 
 	u := uri.Clone(c.requestURL(ctx))
 	var pathParts [1]string
-	pathParts[0] = "/auth/register"
+	pathParts[0] = "/admin/webhooks"
 	uri.AddPathParts(u, pathParts[:]...)
 
 	r, err := ht.NewRequest(ctx, "POST", u)
 	if err != nil {
 		return res, errors.Wrap(err, "create request")
 	}
-	if err := encodeRegisterUserRequest(request, r); err != nil {
+	if err := encodeCreateWebhookRequest(request, r); err != nil {
 		return res, errors.Wrap(err, "encode request")
 	}
 
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Idempotency-Key",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IdempotencyKey.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, CreateWebhookOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
 	resp, err := c.cfg.Client.Do(r)
 	if err != nil {
 		return res, errors.Wrap(err, "do request")
 	}
 	defer resp.Body.Close()
 
-	result, err := decodeRegisterUserResponse(resp)
+	result, err := decodeCreateWebhookResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// DeletePet invokes deletePet operation.
+//
+// Deletes a single pet based on the ID supplied. The pet is
+// soft-deleted: it disappears from findPets and findPetByID but
+// keeps its history and can be restored by an admin.
+//
+// DELETE /pets/{id}
+func (c *Client) DeletePet(ctx context.Context, params DeletePetParams) error {
+	_, err := c.sendDeletePet(ctx, params)
+	return err
+}
+
+func (c *Client) sendDeletePet(ctx context.Context, params DeletePetParams) (res *DeletePetNoContent, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [2]string
+	pathParts[0] = "/pets/"
+	{
+		// Encode "id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.Int64ToString(params.ID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "DELETE", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, DeletePetOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+		{
+
+			switch err := c.securityBearerAuth(ctx, DeletePetOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 1
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+				{0b00000010},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeDeletePetResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// DeleteWebhook invokes deleteWebhook operation.
+//
+// Deletes a webhook and its delivery log. Queued deliveries are not sent.
+//
+// DELETE /admin/webhooks/{id}
+func (c *Client) DeleteWebhook(ctx context.Context, params DeleteWebhookParams) error {
+	_, err := c.sendDeleteWebhook(ctx, params)
+	return err
+}
+
+func (c *Client) sendDeleteWebhook(ctx context.Context, params DeleteWebhookParams) (res *DeleteWebhookNoContent, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [2]string
+	pathParts[0] = "/admin/webhooks/"
+	{
+		// Encode "id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.Int64ToString(params.ID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "DELETE", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, DeleteWebhookOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeDeleteWebhookResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// EnrollMFA invokes enrollMFA operation.
+//
+// Generate a TOTP secret for the current user. TOTP is not
+// enabled until confirmMFAEnrollment succeeds.
+//
+// POST /auth/mfa/enroll
+func (c *Client) EnrollMFA(ctx context.Context, params EnrollMFAParams) (EnrollMFARes, error) {
+	res, err := c.sendEnrollMFA(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendEnrollMFA(ctx context.Context, params EnrollMFAParams) (res EnrollMFARes, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/auth/mfa/enroll"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Idempotency-Key",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IdempotencyKey.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, EnrollMFAOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeEnrollMFAResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ExportPets invokes exportPets operation.
+//
+// Streams every pet matching the findPets filters, in ID order,
+// as CSV (with a header row naming the columns), NDJSON, or a JSON
+// array, chosen by the Accept header. The JSON array is served as
+// `application/vnd.petstore.pets+json`, and also answers clients
+// that accept `application/json`; it is the default when the
+// client accepts any format equally. Rows are written as they are
+// read, so the response has no Content-Length.
+//
+// GET /pets/export
+func (c *Client) ExportPets(ctx context.Context, params ExportPetsParams) (ExportPetsRes, error) {
+	res, err := c.sendExportPets(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendExportPets(ctx context.Context, params ExportPetsParams) (res ExportPetsRes, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/pets/export"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	q := uri.NewQueryEncoder()
+	{
+		// Encode "tags" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "tags",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if params.Tags != nil {
+				return e.EncodeArray(func(e uri.Encoder) error {
+					for i, item := range params.Tags {
+						if err := func() error {
+							return e.EncodeValue(conv.StringToString(item))
+						}(); err != nil {
+							return errors.Wrapf(err, "[%d]", i)
+						}
+					}
+					return nil
+				})
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "storeId" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "storeId",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.StoreId.Get(); ok {
+				return e.EncodeValue(conv.Int64ToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "species" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "species",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if params.Species != nil {
+				return e.EncodeArray(func(e uri.Encoder) error {
+					for i, item := range params.Species {
+						if err := func() error {
+							return e.EncodeValue(conv.StringToString(item))
+						}(); err != nil {
+							return errors.Wrapf(err, "[%d]", i)
+						}
+					}
+					return nil
+				})
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "breed" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "breed",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if params.Breed != nil {
+				return e.EncodeArray(func(e uri.Encoder) error {
+					for i, item := range params.Breed {
+						if err := func() error {
+							return e.EncodeValue(conv.StringToString(item))
+						}(); err != nil {
+							return errors.Wrapf(err, "[%d]", i)
+						}
+					}
+					return nil
+				})
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "sex" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "sex",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Sex.Get(); ok {
+				return e.EncodeValue(conv.StringToString(string(val)))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "bornAfter" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "bornAfter",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.BornAfter.Get(); ok {
+				return e.EncodeValue(conv.DateToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "bornBefore" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "bornBefore",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.BornBefore.Get(); ok {
+				return e.EncodeValue(conv.DateToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "currency" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "currency",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Currency.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "minPrice" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "minPrice",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.MinPrice.Get(); ok {
+				return e.EncodeValue(conv.Int64ToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "maxPrice" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "maxPrice",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.MaxPrice.Get(); ok {
+				return e.EncodeValue(conv.Int64ToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "near" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "near",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if params.Near != nil {
+				return e.EncodeArray(func(e uri.Encoder) error {
+					for i, item := range params.Near {
+						if err := func() error {
+							return e.EncodeValue(conv.Float64ToString(item))
+						}(); err != nil {
+							return errors.Wrapf(err, "[%d]", i)
+						}
+					}
+					return nil
+				})
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "radius_km" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "radius_km",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.RadiusKm.Get(); ok {
+				return e.EncodeValue(conv.Float64ToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "limit" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "limit",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Limit.Get(); ok {
+				return e.EncodeValue(conv.Int32ToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	u.RawQuery = q.Values().Encode()
+
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Accept",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Accept.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, ExportPetsOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+		{
+
+			switch err := c.securityBearerAuth(ctx, ExportPetsOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 1
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+				{0b00000010},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeExportPetsResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// FindPetByID invokes find pet by id operation.
+//
+// Returns a user based on a single ID, if the user does not have access to the pet.
+//
+// GET /pets/{id}
+func (c *Client) FindPetByID(ctx context.Context, params FindPetByIDParams) (*Pet, error) {
+	res, err := c.sendFindPetByID(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendFindPetByID(ctx context.Context, params FindPetByIDParams) (res *Pet, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [2]string
+	pathParts[0] = "/pets/"
+	{
+		// Encode "id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.Int64ToString(params.ID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeFindPetByIDResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// FindPets invokes findPets operation.
+//
+// Returns all pets from the system that the user has access to
+// Nam sed condimentum est. Maecenas tempor sagittis sapien, nec rhoncus sem sagittis sit amet.
+// Aenean at gravida augue, ac iaculis sem. Curabitur odio lorem, ornare eget elementum nec, cursus
+// id lectus. Duis mi turpis, pulvinar ac eros ac, tincidunt varius justo. In hac habitasse platea
+// dictumst. Integer at adipiscing ante, a sagittis ligula. Aenean pharetra tempor ante molestie
+// imperdiet. Vivamus id aliquam diam. Cras quis velit non tortor eleifend sagittis. Praesent at enim
+// pharetra urna volutpat venenatis eget eget mauris. In eleifend fermentum facilisis. Praesent enim
+// enim, gravida ac sodales sed, placerat id erat. Suspendisse lacus dolor, consectetur non augue vel,
+//
+//	vehicula interdum libero. Morbi euismod sagittis libero sed lacinia.
+//
+// Sed tempus felis lobortis leo pulvinar rutrum. Nam mattis velit nisl, eu condimentum ligula luctus
+// nec. Phasellus semper velit eget aliquet faucibus. In a mattis elit. Phasellus vel urna viverra,
+// condimentum lorem id, rhoncus nibh. Ut pellentesque posuere elementum. Sed a varius odio. Morbi
+// rhoncus ligula libero, vel eleifend nunc tristique vitae. Fusce et sem dui. Aenean nec scelerisque
+// tortor. Fusce malesuada accumsan magna vel tempus. Quisque mollis felis eu dolor tristique, sit
+// amet auctor felis gravida. Sed libero lorem, molestie sed nisl in, accumsan tempor nisi. Fusce
+// sollicitudin massa ut lacinia mattis. Sed vel eleifend lorem. Pellentesque vitae felis pretium,
+// pulvinar elit eu, euismod sapien.
+//
+// GET /pets
+func (c *Client) FindPets(ctx context.Context, params FindPetsParams) ([]Pet, error) {
+	res, err := c.sendFindPets(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendFindPets(ctx context.Context, params FindPetsParams) (res []Pet, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/pets"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	q := uri.NewQueryEncoder()
+	{
+		// Encode "tags" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "tags",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if params.Tags != nil {
+				return e.EncodeArray(func(e uri.Encoder) error {
+					for i, item := range params.Tags {
+						if err := func() error {
+							return e.EncodeValue(conv.StringToString(item))
+						}(); err != nil {
+							return errors.Wrapf(err, "[%d]", i)
+						}
+					}
+					return nil
+				})
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "storeId" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "storeId",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.StoreId.Get(); ok {
+				return e.EncodeValue(conv.Int64ToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "species" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "species",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if params.Species != nil {
+				return e.EncodeArray(func(e uri.Encoder) error {
+					for i, item := range params.Species {
+						if err := func() error {
+							return e.EncodeValue(conv.StringToString(item))
+						}(); err != nil {
+							return errors.Wrapf(err, "[%d]", i)
+						}
+					}
+					return nil
+				})
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "breed" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "breed",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if params.Breed != nil {
+				return e.EncodeArray(func(e uri.Encoder) error {
+					for i, item := range params.Breed {
+						if err := func() error {
+							return e.EncodeValue(conv.StringToString(item))
+						}(); err != nil {
+							return errors.Wrapf(err, "[%d]", i)
+						}
+					}
+					return nil
+				})
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "sex" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "sex",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Sex.Get(); ok {
+				return e.EncodeValue(conv.StringToString(string(val)))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "bornAfter" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "bornAfter",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.BornAfter.Get(); ok {
+				return e.EncodeValue(conv.DateToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "bornBefore" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "bornBefore",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.BornBefore.Get(); ok {
+				return e.EncodeValue(conv.DateToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "currency" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "currency",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Currency.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "minPrice" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "minPrice",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.MinPrice.Get(); ok {
+				return e.EncodeValue(conv.Int64ToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "maxPrice" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "maxPrice",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.MaxPrice.Get(); ok {
+				return e.EncodeValue(conv.Int64ToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "near" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "near",
+			Style:   uri.QueryStyleForm,
+			Explode: false,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if params.Near != nil {
+				return e.EncodeArray(func(e uri.Encoder) error {
+					for i, item := range params.Near {
+						if err := func() error {
+							return e.EncodeValue(conv.Float64ToString(item))
+						}(); err != nil {
+							return errors.Wrapf(err, "[%d]", i)
+						}
+					}
+					return nil
+				})
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "radius_km" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "radius_km",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.RadiusKm.Get(); ok {
+				return e.EncodeValue(conv.Float64ToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "limit" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "limit",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Limit.Get(); ok {
+				return e.EncodeValue(conv.Int32ToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	u.RawQuery = q.Values().Encode()
+
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeFindPetsResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ForgotPassword invokes forgotPassword operation.
+//
+// Email a single-use password reset link to the account with
+// the given address. The response is the same whether or not
+// the address is registered.
+//
+// POST /auth/password/forgot
+func (c *Client) ForgotPassword(ctx context.Context, request *ForgotPasswordRequest, params ForgotPasswordParams) (ForgotPasswordRes, error) {
+	res, err := c.sendForgotPassword(ctx, request, params)
+	return res, err
+}
+
+func (c *Client) sendForgotPassword(ctx context.Context, request *ForgotPasswordRequest, params ForgotPasswordParams) (res ForgotPasswordRes, err error) {
+	// Validate request before sending.
+	if err := func() error {
+		if err := request.Validate(); err != nil {
+			return err
+		}
+		return nil
+	}(); err != nil {
+		return res, errors.Wrap(err, "validate")
+	}
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/auth/password/forgot"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeForgotPasswordRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Idempotency-Key",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IdempotencyKey.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeForgotPasswordResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// GetCurrentUser invokes getCurrentUser operation.
+//
+// Get the currently authenticated user.
+//
+// GET /auth/me
+func (c *Client) GetCurrentUser(ctx context.Context) (*AuthUser, error) {
+	res, err := c.sendGetCurrentUser(ctx)
+	return res, err
+}
+
+func (c *Client) sendGetCurrentUser(ctx context.Context) (res *AuthUser, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/auth/me"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, GetCurrentUserOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeGetCurrentUserResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// GetJWKS invokes getJWKS operation.
+//
+// Returns the public keys that verify access tokens, as a
+// JSON Web Key Set (RFC 7517). During a key rotation it lists
+// both the current signing key and the keys being retired.
+// Empty when tokens are signed with a shared HS256 secret.
+//
+// GET /.well-known/jwks.json
+func (c *Client) GetJWKS(ctx context.Context) (*JWKS, error) {
+	res, err := c.sendGetJWKS(ctx)
+	return res, err
+}
+
+func (c *Client) sendGetJWKS(ctx context.Context) (res *JWKS, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/.well-known/jwks.json"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeGetJWKSResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ImportPets invokes importPets operation.
+//
+// Creates pets from a CSV file (with a header row naming a `name`
+// column and any of `tag`, `species`, `breed`, `birth_date`, `sex`,
+// `description`, `price_amount`, `price_currency`, and `store_id`)
+// or from NDJSON (one NewPet object per line). Each row is checked
+// with the same rules as addPet; rows without a store go to
+// `storeId`, or to the caller's store, or for a global caller to
+// the default store (the seeded Main Store). A row naming an
+// unknown store, or one the caller may not use, is invalid. In
+// atomic mode nothing is written unless every row is valid, and
+// invalid rows answer 422 with the report; in partial mode the
+// valid rows are written and the invalid ones reported. A dry run
+// checks the rows and reports the outcome without writing.
+//
+// POST /pets/import
+func (c *Client) ImportPets(ctx context.Context, request ImportPetsReq, params ImportPetsParams) (ImportPetsRes, error) {
+	res, err := c.sendImportPets(ctx, request, params)
+	return res, err
+}
+
+func (c *Client) sendImportPets(ctx context.Context, request ImportPetsReq, params ImportPetsParams) (res ImportPetsRes, err error) {
+	// Validate request before sending.
+	switch request := request.(type) {
+	case *ImportPetsReqApplicationXNdjson:
+		// Validation is not required for this type.
+	case *ImportPetsReqTextCsv:
+		// Validation is not required for this type.
+	default:
+		return res, errors.Errorf("unexpected request type: %T", request)
+	}
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/pets/import"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	q := uri.NewQueryEncoder()
+	{
+		// Encode "storeId" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "storeId",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.StoreId.Get(); ok {
+				return e.EncodeValue(conv.Int64ToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "mode" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "mode",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Mode.Get(); ok {
+				return e.EncodeValue(conv.StringToString(string(val)))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "dryRun" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "dryRun",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.DryRun.Get(); ok {
+				return e.EncodeValue(conv.BoolToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	u.RawQuery = q.Values().Encode()
+
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeImportPetsRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Idempotency-Key",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IdempotencyKey.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, ImportPetsOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+		{
+
+			switch err := c.securityBearerAuth(ctx, ImportPetsOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 1
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"BearerAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+				{0b00000010},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeImportPetsResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ListAPIKeys invokes listAPIKeys operation.
+//
+// Returns all API keys, including revoked and expired ones. Secrets are never returned.
+//
+// GET /admin/api-keys
+func (c *Client) ListAPIKeys(ctx context.Context) ([]APIKey, error) {
+	res, err := c.sendListAPIKeys(ctx)
+	return res, err
+}
+
+func (c *Client) sendListAPIKeys(ctx context.Context) (res []APIKey, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/admin/api-keys"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, ListAPIKeysOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeListAPIKeysResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ListAuditEvents invokes listAuditEvents operation.
+//
+// Returns audit events, newest first. Every filter is optional;
+// page backwards by passing the smallest returned id as `before`.
+//
+// GET /admin/audit-events
+func (c *Client) ListAuditEvents(ctx context.Context, params ListAuditEventsParams) ([]AuditEvent, error) {
+	res, err := c.sendListAuditEvents(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendListAuditEvents(ctx context.Context, params ListAuditEventsParams) (res []AuditEvent, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/admin/audit-events"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	q := uri.NewQueryEncoder()
+	{
+		// Encode "action" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "action",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Action.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "actorId" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "actorId",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.ActorId.Get(); ok {
+				return e.EncodeValue(conv.Int64ToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "outcome" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "outcome",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Outcome.Get(); ok {
+				return e.EncodeValue(conv.StringToString(string(val)))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "since" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "since",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Since.Get(); ok {
+				return e.EncodeValue(conv.DateTimeToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "until" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "until",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Until.Get(); ok {
+				return e.EncodeValue(conv.DateTimeToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "before" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "before",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Before.Get(); ok {
+				return e.EncodeValue(conv.Int64ToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "limit" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "limit",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Limit.Get(); ok {
+				return e.EncodeValue(conv.Int32ToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	u.RawQuery = q.Values().Encode()
+
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, ListAuditEventsOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeListAuditEventsResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ListJobs invokes listJobs operation.
+//
+// Returns the background jobs and their counters as seen by the
+// replica that serves the request. Counters reset when a server
+// restarts, and only the elected leader runs jobs, so a follower
+// reports leader false and no next run times.
+//
+// GET /admin/jobs
+func (c *Client) ListJobs(ctx context.Context) (*JobSchedulerStatus, error) {
+	res, err := c.sendListJobs(ctx)
+	return res, err
+}
+
+func (c *Client) sendListJobs(ctx context.Context) (res *JobSchedulerStatus, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/admin/jobs"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, ListJobsOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeListJobsResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ListMyReservations invokes listMyReservations operation.
+//
+// The current user's holds on pets, newest first.
+//
+// GET /auth/me/reservations
+func (c *Client) ListMyReservations(ctx context.Context) ([]Reservation, error) {
+	res, err := c.sendListMyReservations(ctx)
+	return res, err
+}
+
+func (c *Client) sendListMyReservations(ctx context.Context) (res []Reservation, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/auth/me/reservations"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, ListMyReservationsOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeListMyReservationsResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ListPetRevisions invokes listPetRevisions operation.
+//
+// Returns every revision of a pet, oldest first: its creation,
+// each deletion and restore, and each hold placed,
+// cancelled, or lapsed, with the acting user or API key (none for
+// a lapse). Deleted pets keep their history.
+//
+// GET /admin/pets/{id}/history
+func (c *Client) ListPetRevisions(ctx context.Context, params ListPetRevisionsParams) ([]PetRevision, error) {
+	res, err := c.sendListPetRevisions(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendListPetRevisions(ctx context.Context, params ListPetRevisionsParams) (res []PetRevision, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [3]string
+	pathParts[0] = "/admin/pets/"
+	{
+		// Encode "id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.Int64ToString(params.ID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	pathParts[2] = "/history"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, ListPetRevisionsOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeListPetRevisionsResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ListSpecies invokes listSpecies operation.
+//
+// Returns the species and breeds a pet may be given, sorted by
+// name. The list is reference data maintained by migrations.
+//
+// GET /species
+func (c *Client) ListSpecies(ctx context.Context) ([]Species, error) {
+	res, err := c.sendListSpecies(ctx)
+	return res, err
+}
+
+func (c *Client) sendListSpecies(ctx context.Context) (res []Species, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/species"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeListSpeciesResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ListStores invokes listStores operation.
+//
+// Returns the shop locations, sorted by name.
+//
+// GET /stores
+func (c *Client) ListStores(ctx context.Context) ([]Store, error) {
+	res, err := c.sendListStores(ctx)
+	return res, err
+}
+
+func (c *Client) sendListStores(ctx context.Context) (res []Store, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/stores"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeListStoresResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ListWebhookDeliveries invokes listWebhookDeliveries operation.
+//
+// Returns the webhook's delivery log, newest first: one entry per
+// event sent to it, with its status and the outcome of its last
+// attempt. Page backwards by passing the smallest returned id as
+// `before`.
+//
+// GET /admin/webhooks/{id}/deliveries
+func (c *Client) ListWebhookDeliveries(ctx context.Context, params ListWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	res, err := c.sendListWebhookDeliveries(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendListWebhookDeliveries(ctx context.Context, params ListWebhookDeliveriesParams) (res []WebhookDelivery, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [3]string
+	pathParts[0] = "/admin/webhooks/"
+	{
+		// Encode "id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.Int64ToString(params.ID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	pathParts[2] = "/deliveries"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	q := uri.NewQueryEncoder()
+	{
+		// Encode "status" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "status",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Status.Get(); ok {
+				return e.EncodeValue(conv.StringToString(string(val)))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "before" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "before",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Before.Get(); ok {
+				return e.EncodeValue(conv.Int64ToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "limit" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "limit",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Limit.Get(); ok {
+				return e.EncodeValue(conv.Int32ToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	u.RawQuery = q.Values().Encode()
+
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, ListWebhookDeliveriesOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeListWebhookDeliveriesResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ListWebhooks invokes listWebhooks operation.
+//
+// Returns every webhook subscription. Signing secrets are never returned.
+//
+// GET /admin/webhooks
+func (c *Client) ListWebhooks(ctx context.Context) ([]Webhook, error) {
+	res, err := c.sendListWebhooks(ctx)
+	return res, err
+}
+
+func (c *Client) sendListWebhooks(ctx context.Context) (res []Webhook, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/admin/webhooks"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, ListWebhooksOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeListWebhooksResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// LoginUser invokes loginUser operation.
+//
+// Authenticate a user and set an access token cookie.
+//
+// POST /auth/login
+func (c *Client) LoginUser(ctx context.Context, request *LoginRequest, params LoginUserParams) (LoginUserRes, error) {
+	res, err := c.sendLoginUser(ctx, request, params)
+	return res, err
+}
+
+func (c *Client) sendLoginUser(ctx context.Context, request *LoginRequest, params LoginUserParams) (res LoginUserRes, err error) {
+	// Validate request before sending.
+	if err := func() error {
+		if err := request.Validate(); err != nil {
+			return err
+		}
+		return nil
+	}(); err != nil {
+		return res, errors.Wrap(err, "validate")
+	}
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/auth/login"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeLoginUserRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Idempotency-Key",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IdempotencyKey.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeLoginUserResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// LogoutUser invokes logoutUser operation.
+//
+// Log out the current user by clearing the access token cookie.
+//
+// POST /auth/logout
+func (c *Client) LogoutUser(ctx context.Context, params LogoutUserParams) (LogoutUserRes, error) {
+	res, err := c.sendLogoutUser(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendLogoutUser(ctx context.Context, params LogoutUserParams) (res LogoutUserRes, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/auth/logout"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Idempotency-Key",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IdempotencyKey.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, LogoutUserOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeLogoutUserResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// OidcCallback invokes oidcCallback operation.
+//
+// Redirect target for the identity provider. Validates the
+// state, redeems the code, signs in the linked user (linking
+// by verified email, or creating a customer on first login),
+// sets the access_token cookie, and redirects to the frontend.
+//
+// GET /auth/oidc/callback
+func (c *Client) OidcCallback(ctx context.Context, params OidcCallbackParams) (OidcCallbackRes, error) {
+	res, err := c.sendOidcCallback(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendOidcCallback(ctx context.Context, params OidcCallbackParams) (res OidcCallbackRes, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/auth/oidc/callback"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	q := uri.NewQueryEncoder()
+	{
+		// Encode "code" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "code",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			return e.EncodeValue(conv.StringToString(params.Code))
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "state" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "state",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			return e.EncodeValue(conv.StringToString(params.State))
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	u.RawQuery = q.Values().Encode()
+
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	cookie := uri.NewCookieEncoder(r)
+	{
+		// Encode "oidc_state" parameter.
+		cfg := uri.CookieParameterEncodingConfig{
+			Name:    "oidc_state",
+			Explode: true,
+		}
+
+		if err := cookie.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.OidcState.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode cookie")
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeOidcCallbackResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// RedeliverWebhookDelivery invokes redeliverWebhookDelivery operation.
+//
+// Queues a succeeded or dead-lettered delivery to be sent again
+// at once, with a fresh set of attempts. Returns 409 while the
+// delivery is still pending.
+//
+// POST /admin/webhooks/{id}/deliveries/{deliveryId}/redeliver
+func (c *Client) RedeliverWebhookDelivery(ctx context.Context, params RedeliverWebhookDeliveryParams) (RedeliverWebhookDeliveryRes, error) {
+	res, err := c.sendRedeliverWebhookDelivery(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendRedeliverWebhookDelivery(ctx context.Context, params RedeliverWebhookDeliveryParams) (res RedeliverWebhookDeliveryRes, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [5]string
+	pathParts[0] = "/admin/webhooks/"
+	{
+		// Encode "id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.Int64ToString(params.ID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	pathParts[2] = "/deliveries/"
+	{
+		// Encode "deliveryId" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "deliveryId",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.Int64ToString(params.DeliveryId))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[3] = encoded
+	}
+	pathParts[4] = "/redeliver"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Idempotency-Key",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IdempotencyKey.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, RedeliverWebhookDeliveryOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeRedeliverWebhookDeliveryResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// RegisterUser invokes registerUser operation.
+//
+// Register a new user account.
+//
+// POST /auth/register
+func (c *Client) RegisterUser(ctx context.Context, request *RegisterRequest, params RegisterUserParams) (RegisterUserRes, error) {
+	res, err := c.sendRegisterUser(ctx, request, params)
+	return res, err
+}
+
+func (c *Client) sendRegisterUser(ctx context.Context, request *RegisterRequest, params RegisterUserParams) (res RegisterUserRes, err error) {
+	// Validate request before sending.
+	if err := func() error {
+		if err := request.Validate(); err != nil {
+			return err
+		}
+		return nil
+	}(); err != nil {
+		return res, errors.Wrap(err, "validate")
+	}
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/auth/register"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeRegisterUserRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Idempotency-Key",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IdempotencyKey.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeRegisterUserResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// RequestEmailChange invokes requestEmailChange operation.
+//
+// Start changing the current user's email address. Requires the
+// current password. A confirmation link is sent to the new
+// address and a notice to the old one; the address changes only
+// once the link is used.
+//
+// POST /auth/me/email
+func (c *Client) RequestEmailChange(ctx context.Context, request *ChangeEmailRequest, params RequestEmailChangeParams) (RequestEmailChangeRes, error) {
+	res, err := c.sendRequestEmailChange(ctx, request, params)
+	return res, err
+}
+
+func (c *Client) sendRequestEmailChange(ctx context.Context, request *ChangeEmailRequest, params RequestEmailChangeParams) (res RequestEmailChangeRes, err error) {
+	// Validate request before sending.
+	if err := func() error {
+		if err := request.Validate(); err != nil {
+			return err
+		}
+		return nil
+	}(); err != nil {
+		return res, errors.Wrap(err, "validate")
+	}
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/auth/me/email"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeRequestEmailChangeRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Idempotency-Key",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IdempotencyKey.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, RequestEmailChangeOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeRequestEmailChangeResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ResendVerificationEmail invokes resendVerificationEmail operation.
+//
+// Send a new verification link to the current user. Limited to
+// one email per minute and five per hour.
+//
+// POST /auth/verify/resend
+func (c *Client) ResendVerificationEmail(ctx context.Context, params ResendVerificationEmailParams) (ResendVerificationEmailRes, error) {
+	res, err := c.sendResendVerificationEmail(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendResendVerificationEmail(ctx context.Context, params ResendVerificationEmailParams) (res ResendVerificationEmailRes, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/auth/verify/resend"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Idempotency-Key",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IdempotencyKey.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, ResendVerificationEmailOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeResendVerificationEmailResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ReservePet invokes reservePet operation.
+//
+// Place a 48-hour hold on a pet before coming in to see it.
+// While the hold lasts the pet shows as reserved and other holds
+// are rejected; it lapses on its own, or the holder cancels it.
+// A user may hold at most 3 pets at a time, and may not hold a
+// pet again until 24 hours after their last hold on it ended.
+//
+// POST /pets/{id}/reservations
+func (c *Client) ReservePet(ctx context.Context, params ReservePetParams) (ReservePetRes, error) {
+	res, err := c.sendReservePet(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendReservePet(ctx context.Context, params ReservePetParams) (res ReservePetRes, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [3]string
+	pathParts[0] = "/pets/"
+	{
+		// Encode "id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.Int64ToString(params.ID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	pathParts[2] = "/reservations"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Idempotency-Key",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IdempotencyKey.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, ReservePetOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeReservePetResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// ResetPassword invokes resetPassword operation.
+//
+// Set a new password using a token from a reset email.
+//
+// POST /auth/password/reset
+func (c *Client) ResetPassword(ctx context.Context, request *ResetPasswordRequest, params ResetPasswordParams) (ResetPasswordRes, error) {
+	res, err := c.sendResetPassword(ctx, request, params)
+	return res, err
+}
+
+func (c *Client) sendResetPassword(ctx context.Context, request *ResetPasswordRequest, params ResetPasswordParams) (res ResetPasswordRes, err error) {
+	// Validate request before sending.
+	if err := func() error {
+		if err := request.Validate(); err != nil {
+			return err
+		}
+		return nil
+	}(); err != nil {
+		return res, errors.Wrap(err, "validate")
+	}
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/auth/password/reset"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeResetPasswordRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Idempotency-Key",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IdempotencyKey.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeResetPasswordResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// RestorePet invokes restorePet operation.
+//
+// Undoes a soft delete. Returns 404 unless the pet exists and is deleted.
+//
+// POST /admin/pets/{id}/restore
+func (c *Client) RestorePet(ctx context.Context, params RestorePetParams) (RestorePetRes, error) {
+	res, err := c.sendRestorePet(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendRestorePet(ctx context.Context, params RestorePetParams) (res RestorePetRes, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [3]string
+	pathParts[0] = "/admin/pets/"
+	{
+		// Encode "id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.Int64ToString(params.ID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	pathParts[2] = "/restore"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Idempotency-Key",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IdempotencyKey.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, RestorePetOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeRestorePetResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// RevokeAPIKey invokes revokeAPIKey operation.
+//
+// Revokes an API key immediately. Revoked keys stay listed.
+//
+// DELETE /admin/api-keys/{id}
+func (c *Client) RevokeAPIKey(ctx context.Context, params RevokeAPIKeyParams) error {
+	_, err := c.sendRevokeAPIKey(ctx, params)
+	return err
+}
+
+func (c *Client) sendRevokeAPIKey(ctx context.Context, params RevokeAPIKeyParams) (res *RevokeAPIKeyNoContent, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [2]string
+	pathParts[0] = "/admin/api-keys/"
+	{
+		// Encode "id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.Int64ToString(params.ID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "DELETE", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, RevokeAPIKeyOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeRevokeAPIKeyResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// StartOIDCLogin invokes startOIDCLogin operation.
+//
+// Starts an OpenID Connect authorization code flow with PKCE.
+// Redirects to the configured identity provider and sets a
+// short-lived oidc_state cookie. Returns 404 when no provider
+// is configured.
+//
+// GET /auth/oidc/login
+func (c *Client) StartOIDCLogin(ctx context.Context) (*StartOIDCLoginFound, error) {
+	res, err := c.sendStartOIDCLogin(ctx)
+	return res, err
+}
+
+func (c *Client) sendStartOIDCLogin(ctx context.Context) (res *StartOIDCLoginFound, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/auth/oidc/login"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeStartOIDCLoginResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// StreamPetEvents invokes streamPetEvents operation.
+//
+// A Server-Sent Events stream of pet catalog changes, one event
+// per pet revision, so storefronts need not poll `GET /pets`.
+// Each event's `id` is the revision ID, its `event` the change
+// (`created`, `deleted`, `restored`, `reserved`,
+// `reservation_cancelled`, or `reservation_expired`), and its
+// `data` a JSON object with `petId`, `name`, `tag`, and
+// `createdAt`. A new connection receives only live events;
+// sending Last-Event-ID first replays every later one. A
+// `: heartbeat` comment is sent while the stream is idle.
+//
+// GET /pets/events
+func (c *Client) StreamPetEvents(ctx context.Context, params StreamPetEventsParams) (StreamPetEventsRes, error) {
+	res, err := c.sendStreamPetEvents(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendStreamPetEvents(ctx context.Context, params StreamPetEventsParams) (res StreamPetEventsRes, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/pets/events"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Last-Event-ID",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.LastEventID.Get(); ok {
+				return e.EncodeValue(conv.Int64ToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeStreamPetEventsResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// UnlockUser invokes unlockUser operation.
+//
+// Clear the failed login counter and any temporary lock on a
+// user account.
+//
+// POST /admin/users/{id}/unlock
+func (c *Client) UnlockUser(ctx context.Context, params UnlockUserParams) (UnlockUserRes, error) {
+	res, err := c.sendUnlockUser(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendUnlockUser(ctx context.Context, params UnlockUserParams) (res UnlockUserRes, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [3]string
+	pathParts[0] = "/admin/users/"
+	{
+		// Encode "id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.Int64ToString(params.ID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	pathParts[2] = "/unlock"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Idempotency-Key",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IdempotencyKey.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, UnlockUserOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeUnlockUserResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// UpdateUser invokes updateUser operation.
+//
+// Set a user's role or store, disable the account, or re-enable
+// it. A disabled user cannot log in. Existing sessions keep
+// their claimed role and store until they expire unless the
+// server runs with REQUIRE_FRESH_ROLES, which rejects them at
+// once. Admins confined to a store manage only its users and
+// cannot move them out of it.
+//
+// PATCH /admin/users/{id}
+func (c *Client) UpdateUser(ctx context.Context, request *UpdateUserRequest, params UpdateUserParams) (*AuthUser, error) {
+	res, err := c.sendUpdateUser(ctx, request, params)
+	return res, err
+}
+
+func (c *Client) sendUpdateUser(ctx context.Context, request *UpdateUserRequest, params UpdateUserParams) (res *AuthUser, err error) {
+	// Validate request before sending.
+	if err := func() error {
+		if err := request.Validate(); err != nil {
+			return err
+		}
+		return nil
+	}(); err != nil {
+		return res, errors.Wrap(err, "validate")
+	}
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [2]string
+	pathParts[0] = "/admin/users/"
+	{
+		// Encode "id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.Int64ToString(params.ID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "PATCH", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeUpdateUserRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	{
+		type bitset = [1]uint8
+		var satisfied bitset
+		{
+
+			switch err := c.securityCookieAuth(ctx, UpdateUserOperation, r); {
+			case err == nil: // if NO error
+				satisfied[0] |= 1 << 0
+			case errors.Is(err, ogenerrors.ErrSkipClientSecurity):
+				// Skip this security.
+			default:
+				return res, errors.Wrap(err, "security \"CookieAuth\"")
+			}
+		}
+
+		if ok := func() bool {
+		nextRequirement:
+			for _, requirement := range []bitset{
+				{0b00000001},
+			} {
+				for i, mask := range requirement {
+					if satisfied[i]&mask != mask {
+						continue nextRequirement
+					}
+				}
+				return true
+			}
+			return false
+		}(); !ok {
+			return res, ogenerrors.ErrSecurityRequirementIsNotSatisfied
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeUpdateUserResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// VerifyEmail invokes verifyEmail operation.
+//
+// Confirm an email address using the token from a verification email.
+//
+// POST /auth/verify
+func (c *Client) VerifyEmail(ctx context.Context, request *VerifyEmailRequest, params VerifyEmailParams) (VerifyEmailRes, error) {
+	res, err := c.sendVerifyEmail(ctx, request, params)
+	return res, err
+}
+
+func (c *Client) sendVerifyEmail(ctx context.Context, request *VerifyEmailRequest, params VerifyEmailParams) (res VerifyEmailRes, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/auth/verify"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeVerifyEmailRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Idempotency-Key",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IdempotencyKey.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeVerifyEmailResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// VerifyMFA invokes verifyMFA operation.
+//
+// Exchange the challenge token from loginUser and a TOTP or
+// recovery code for an access_token cookie.
+//
+// POST /auth/mfa/verify
+func (c *Client) VerifyMFA(ctx context.Context, request *MFAVerifyRequest, params VerifyMFAParams) (VerifyMFARes, error) {
+	res, err := c.sendVerifyMFA(ctx, request, params)
+	return res, err
+}
+
+func (c *Client) sendVerifyMFA(ctx context.Context, request *MFAVerifyRequest, params VerifyMFAParams) (res VerifyMFARes, err error) {
+
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/auth/mfa/verify"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeVerifyMFARequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Idempotency-Key",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.IdempotencyKey.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	result, err := decodeVerifyMFAResponse(resp)
 	if err != nil {
 		return res, errors.Wrap(err, "decode response")
 	}
@@ -0,0 +1,11 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package client
+
+// setDefaults set default value of fields.
+func (s *ImportReport) setDefaults() {
+	{
+		val := ImportMode("atomic")
+		s.Mode = val
+	}
+}
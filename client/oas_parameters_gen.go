@@ -2,12 +2,129 @@
 
 package client
 
+// AddPetParams is parameters of addPet operation.
+type AddPetParams struct {
+	// Client-chosen key that makes the request safe to retry. The first
+	// request with a key runs; a retry with the same key and the same
+	// request gets the stored response back, marked with
+	// `Idempotent-Replayed: true`. Keys are kept for 24 hours.
+	IdempotencyKey OptString `json:",omitempty,omitzero"`
+}
+
+// CancelReservationParams is parameters of cancelReservation operation.
+type CancelReservationParams struct {
+	// ID of the reservation to cancel.
+	ID int64
+}
+
+// ChangePasswordParams is parameters of changePassword operation.
+type ChangePasswordParams struct {
+	// Client-chosen key that makes the request safe to retry. The first
+	// request with a key runs; a retry with the same key and the same
+	// request gets the stored response back, marked with
+	// `Idempotent-Replayed: true`. Keys are kept for 24 hours.
+	IdempotencyKey OptString `json:",omitempty,omitzero"`
+}
+
+// ConfirmEmailChangeParams is parameters of confirmEmailChange operation.
+type ConfirmEmailChangeParams struct {
+	// Client-chosen key that makes the request safe to retry. The first
+	// request with a key runs; a retry with the same key and the same
+	// request gets the stored response back, marked with
+	// `Idempotent-Replayed: true`. Keys are kept for 24 hours.
+	IdempotencyKey OptString `json:",omitempty,omitzero"`
+}
+
+// ConfirmMFAEnrollmentParams is parameters of confirmMFAEnrollment operation.
+type ConfirmMFAEnrollmentParams struct {
+	// Client-chosen key that makes the request safe to retry. The first
+	// request with a key runs; a retry with the same key and the same
+	// request gets the stored response back, marked with
+	// `Idempotent-Replayed: true`. Keys are kept for 24 hours.
+	IdempotencyKey OptString `json:",omitempty,omitzero"`
+}
+
+// CreateAPIKeyParams is parameters of createAPIKey operation.
+type CreateAPIKeyParams struct {
+	// Client-chosen key that makes the request safe to retry. The first
+	// request with a key runs; a retry with the same key and the same
+	// request gets the stored response back, marked with
+	// `Idempotent-Replayed: true`. Keys are kept for 24 hours.
+	IdempotencyKey OptString `json:",omitempty,omitzero"`
+}
+
+// CreateStoreParams is parameters of createStore operation.
+type CreateStoreParams struct {
+	// Client-chosen key that makes the request safe to retry. The first
+	// request with a key runs; a retry with the same key and the same
+	// request gets the stored response back, marked with
+	// `Idempotent-Replayed: true`. Keys are kept for 24 hours.
+	IdempotencyKey OptString `json:",omitempty,omitzero"`
+}
+
+// CreateWebhookParams is parameters of createWebhook operation.
+type CreateWebhookParams struct {
+	// Client-chosen key that makes the request safe to retry. The first
+	// request with a key runs; a retry with the same key and the same
+	// request gets the stored response back, marked with
+	// `Idempotent-Replayed: true`. Keys are kept for 24 hours.
+	IdempotencyKey OptString `json:",omitempty,omitzero"`
+}
+
 // DeletePetParams is parameters of deletePet operation.
 type DeletePetParams struct {
 	// ID of pet to delete.
 	ID int64
 }
 
+// DeleteWebhookParams is parameters of deleteWebhook operation.
+type DeleteWebhookParams struct {
+	// ID of the webhook to delete.
+	ID int64
+}
+
+// EnrollMFAParams is parameters of enrollMFA operation.
+type EnrollMFAParams struct {
+	// Client-chosen key that makes the request safe to retry. The first
+	// request with a key runs; a retry with the same key and the same
+	// request gets the stored response back, marked with
+	// `Idempotent-Replayed: true`. Keys are kept for 24 hours.
+	IdempotencyKey OptString `json:",omitempty,omitzero"`
+}
+
+// ExportPetsParams is parameters of exportPets operation.
+type ExportPetsParams struct {
+	// Text/csv, application/x-ndjson, or application/json.
+	Accept OptString `json:",omitempty,omitzero"`
+	// Tags to filter by.
+	Tags []string `json:",omitempty"`
+	// ID of the store to list pets of.
+	StoreId OptInt64 `json:",omitempty,omitzero"`
+	// Species codes to filter by.
+	Species []string `json:",omitempty"`
+	// Breed codes to filter by.
+	Breed []string `json:",omitempty"`
+	// Sex to filter by.
+	Sex OptPetSex `json:",omitempty,omitzero"`
+	// Earliest birth date, inclusive.
+	BornAfter OptDate `json:",omitempty,omitzero"`
+	// Latest birth date, inclusive.
+	BornBefore OptDate `json:",omitempty,omitzero"`
+	// Price currency, ISO 4217; required with minPrice or maxPrice.
+	Currency OptString `json:",omitempty,omitzero"`
+	// Lowest price in minor units, inclusive.
+	MinPrice OptInt64 `json:",omitempty,omitzero"`
+	// Highest price in minor units, inclusive.
+	MaxPrice OptInt64 `json:",omitempty,omitzero"`
+	// Latitude and longitude, as `lat,lng`, to order pets by the distance of their store from; stores
+	// without a location are left out.
+	Near []float64 `json:",omitempty"`
+	// Greatest distance from near, in kilometres; requires near.
+	RadiusKm OptFloat64 `json:",omitempty,omitzero"`
+	// Maximum number of pets to export.
+	Limit OptInt32 `json:",omitempty,omitzero"`
+}
+
 // FindPetByIDParams is parameters of find pet by id operation.
 type FindPetByIDParams struct {
 	// ID of pet to fetch.
@@ -18,6 +135,232 @@ type FindPetByIDParams struct {
 type FindPetsParams struct {
 	// Tags to filter by.
 	Tags []string `json:",omitempty"`
+	// ID of the store to list pets of.
+	StoreId OptInt64 `json:",omitempty,omitzero"`
+	// Species codes to filter by.
+	Species []string `json:",omitempty"`
+	// Breed codes to filter by.
+	Breed []string `json:",omitempty"`
+	// Sex to filter by.
+	Sex OptPetSex `json:",omitempty,omitzero"`
+	// Earliest birth date, inclusive.
+	BornAfter OptDate `json:",omitempty,omitzero"`
+	// Latest birth date, inclusive.
+	BornBefore OptDate `json:",omitempty,omitzero"`
+	// Price currency, ISO 4217; required with minPrice or maxPrice.
+	Currency OptString `json:",omitempty,omitzero"`
+	// Lowest price in minor units, inclusive.
+	MinPrice OptInt64 `json:",omitempty,omitzero"`
+	// Highest price in minor units, inclusive.
+	MaxPrice OptInt64 `json:",omitempty,omitzero"`
+	// Latitude and longitude, as `lat,lng`, to order pets by the distance of their store from; stores
+	// without a location are left out.
+	Near []float64 `json:",omitempty"`
+	// Greatest distance from near, in kilometres; requires near.
+	RadiusKm OptFloat64 `json:",omitempty,omitzero"`
 	// Maximum number of results to return.
 	Limit OptInt32 `json:",omitempty,omitzero"`
 }
+
+// ForgotPasswordParams is parameters of forgotPassword operation.
+type ForgotPasswordParams struct {
+	// Client-chosen key that makes the request safe to retry. The first
+	// request with a key runs; a retry with the same key and the same
+	// request gets the stored response back, marked with
+	// `Idempotent-Replayed: true`. Keys are kept for 24 hours.
+	IdempotencyKey OptString `json:",omitempty,omitzero"`
+}
+
+// ImportPetsParams is parameters of importPets operation.
+type ImportPetsParams struct {
+	// ID of the store for rows that do not name one.
+	StoreId OptInt64 `json:",omitempty,omitzero"`
+	// Whether invalid rows abort the import or are skipped.
+	Mode OptImportMode `json:",omitempty,omitzero"`
+	// Validate and report without writing.
+	DryRun OptBool `json:",omitempty,omitzero"`
+	// Client-chosen key that makes the request safe to retry. The first
+	// request with a key runs; a retry with the same key and the same
+	// request gets the stored response back, marked with
+	// `Idempotent-Replayed: true`. Keys are kept for 24 hours.
+	IdempotencyKey OptString `json:",omitempty,omitzero"`
+}
+
+// ListAuditEventsParams is parameters of listAuditEvents operation.
+type ListAuditEventsParams struct {
+	// Only events with this action, e.g. auth.login.
+	Action OptString `json:",omitempty,omitzero"`
+	// Only events performed by this user.
+	ActorId OptInt64 `json:",omitempty,omitzero"`
+	// Only successful or only failed events.
+	Outcome OptAuditOutcome `json:",omitempty,omitzero"`
+	// Only events at or after this time.
+	Since OptDateTime `json:",omitempty,omitzero"`
+	// Only events before this time.
+	Until OptDateTime `json:",omitempty,omitzero"`
+	// Only events with a smaller id.
+	Before OptInt64 `json:",omitempty,omitzero"`
+	// Maximum number of events to return.
+	Limit OptInt32 `json:",omitempty,omitzero"`
+}
+
+// ListPetRevisionsParams is parameters of listPetRevisions operation.
+type ListPetRevisionsParams struct {
+	// ID of the pet.
+	ID int64
+}
+
+// ListWebhookDeliveriesParams is parameters of listWebhookDeliveries operation.
+type ListWebhookDeliveriesParams struct {
+	// ID of the webhook.
+	ID int64
+	// Only deliveries with this status.
+	Status OptWebhookDeliveryStatus `json:",omitempty,omitzero"`
+	// Only deliveries with a smaller id.
+	Before OptInt64 `json:",omitempty,omitzero"`
+	// Maximum number of deliveries to return.
+	Limit OptInt32 `json:",omitempty,omitzero"`
+}
+
+// LoginUserParams is parameters of loginUser operation.
+type LoginUserParams struct {
+	// Client-chosen key that makes the request safe to retry. The first
+	// request with a key runs; a retry with the same key and the same
+	// request gets the stored response back, marked with
+	// `Idempotent-Replayed: true`. Keys are kept for 24 hours.
+	IdempotencyKey OptString `json:",omitempty,omitzero"`
+}
+
+// LogoutUserParams is parameters of logoutUser operation.
+type LogoutUserParams struct {
+	// Client-chosen key that makes the request safe to retry. The first
+	// request with a key runs; a retry with the same key and the same
+	// request gets the stored response back, marked with
+	// `Idempotent-Replayed: true`. Keys are kept for 24 hours.
+	IdempotencyKey OptString `json:",omitempty,omitzero"`
+}
+
+// OidcCallbackParams is parameters of oidcCallback operation.
+type OidcCallbackParams struct {
+	Code      string
+	State     string
+	OidcState OptString `json:",omitempty,omitzero"`
+}
+
+// RedeliverWebhookDeliveryParams is parameters of redeliverWebhookDelivery operation.
+type RedeliverWebhookDeliveryParams struct {
+	// ID of the webhook.
+	ID int64
+	// ID of the delivery to send again.
+	DeliveryId int64
+	// Client-chosen key that makes the request safe to retry. The first
+	// request with a key runs; a retry with the same key and the same
+	// request gets the stored response back, marked with
+	// `Idempotent-Replayed: true`. Keys are kept for 24 hours.
+	IdempotencyKey OptString `json:",omitempty,omitzero"`
+}
+
+// RegisterUserParams is parameters of registerUser operation.
+type RegisterUserParams struct {
+	// Client-chosen key that makes the request safe to retry. The first
+	// request with a key runs; a retry with the same key and the same
+	// request gets the stored response back, marked with
+	// `Idempotent-Replayed: true`. Keys are kept for 24 hours.
+	IdempotencyKey OptString `json:",omitempty,omitzero"`
+}
+
+// RequestEmailChangeParams is parameters of requestEmailChange operation.
+type RequestEmailChangeParams struct {
+	// Client-chosen key that makes the request safe to retry. The first
+	// request with a key runs; a retry with the same key and the same
+	// request gets the stored response back, marked with
+	// `Idempotent-Replayed: true`. Keys are kept for 24 hours.
+	IdempotencyKey OptString `json:",omitempty,omitzero"`
+}
+
+// ResendVerificationEmailParams is parameters of resendVerificationEmail operation.
+type ResendVerificationEmailParams struct {
+	// Client-chosen key that makes the request safe to retry. The first
+	// request with a key runs; a retry with the same key and the same
+	// request gets the stored response back, marked with
+	// `Idempotent-Replayed: true`. Keys are kept for 24 hours.
+	IdempotencyKey OptString `json:",omitempty,omitzero"`
+}
+
+// ReservePetParams is parameters of reservePet operation.
+type ReservePetParams struct {
+	// ID of the pet to reserve.
+	ID int64
+	// Client-chosen key that makes the request safe to retry. The first
+	// request with a key runs; a retry with the same key and the same
+	// request gets the stored response back, marked with
+	// `Idempotent-Replayed: true`. Keys are kept for 24 hours.
+	IdempotencyKey OptString `json:",omitempty,omitzero"`
+}
+
+// ResetPasswordParams is parameters of resetPassword operation.
+type ResetPasswordParams struct {
+	// Client-chosen key that makes the request safe to retry. The first
+	// request with a key runs; a retry with the same key and the same
+	// request gets the stored response back, marked with
+	// `Idempotent-Replayed: true`. Keys are kept for 24 hours.
+	IdempotencyKey OptString `json:",omitempty,omitzero"`
+}
+
+// RestorePetParams is parameters of restorePet operation.
+type RestorePetParams struct {
+	// ID of the pet to restore.
+	ID int64
+	// Client-chosen key that makes the request safe to retry. The first
+	// request with a key runs; a retry with the same key and the same
+	// request gets the stored response back, marked with
+	// `Idempotent-Replayed: true`. Keys are kept for 24 hours.
+	IdempotencyKey OptString `json:",omitempty,omitzero"`
+}
+
+// RevokeAPIKeyParams is parameters of revokeAPIKey operation.
+type RevokeAPIKeyParams struct {
+	// ID of the API key to revoke.
+	ID int64
+}
+
+// StreamPetEventsParams is parameters of streamPetEvents operation.
+type StreamPetEventsParams struct {
+	// ID of the last event received; the stream resumes after it.
+	LastEventID OptInt64 `json:",omitempty,omitzero"`
+}
+
+// UnlockUserParams is parameters of unlockUser operation.
+type UnlockUserParams struct {
+	// ID of the user to unlock.
+	ID int64
+	// Client-chosen key that makes the request safe to retry. The first
+	// request with a key runs; a retry with the same key and the same
+	// request gets the stored response back, marked with
+	// `Idempotent-Replayed: true`. Keys are kept for 24 hours.
+	IdempotencyKey OptString `json:",omitempty,omitzero"`
+}
+
+// UpdateUserParams is parameters of updateUser operation.
+type UpdateUserParams struct {
+	// ID of the user to update.
+	ID int64
+}
+
+// VerifyEmailParams is parameters of verifyEmail operation.
+type VerifyEmailParams struct {
+	// Client-chosen key that makes the request safe to retry. The first
+	// request with a key runs; a retry with the same key and the same
+	// request gets the stored response back, marked with
+	// `Idempotent-Replayed: true`. Keys are kept for 24 hours.
+	IdempotencyKey OptString `json:",omitempty,omitzero"`
+}
+
+// VerifyMFAParams is parameters of verifyMFA operation.
+type VerifyMFAParams struct {
+	// Client-chosen key that makes the request safe to retry. The first
+	// request with a key runs; a retry with the same key and the same
+	// request gets the stored response back, marked with
+	// `Idempotent-Replayed: true`. Keys are kept for 24 hours.
+	IdempotencyKey OptString `json:",omitempty,omitzero"`
+}
@@ -4,86 +4,143 @@ package client
 
 import (
 	"fmt"
+	"io"
+	"net/url"
+	"time"
 
 	"github.com/go-faster/errors"
 )
 
-func (s *ErrorStatusCode) Error() string {
+func (s *ProblemStatusCode) Error() string {
 	return fmt.Sprintf("code %d: %+v", s.StatusCode, s.Response)
 }
 
-// Ref: #/components/schemas/AuthUser
-type AuthUser struct {
-	ID    int64        `json:"id"`
-	Name  string       `json:"name"`
-	Email string       `json:"email"`
-	Role  AuthUserRole `json:"role"`
+// Ref: #/components/schemas/APIKey
+type APIKey struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// First characters of the key, for identification.
+	Prefix     string        `json:"prefix"`
+	Scopes     []APIKeyScope `json:"scopes"`
+	CreatedAt  time.Time     `json:"createdAt"`
+	ExpiresAt  OptDateTime   `json:"expiresAt"`
+	LastUsedAt OptDateTime   `json:"lastUsedAt"`
+	RevokedAt  OptDateTime   `json:"revokedAt"`
+	StoreId    OptInt64      `json:"storeId"`
 }
 
 // GetID returns the value of ID.
-func (s *AuthUser) GetID() int64 {
+func (s *APIKey) GetID() int64 {
 	return s.ID
 }
 
 // GetName returns the value of Name.
-func (s *AuthUser) GetName() string {
+func (s *APIKey) GetName() string {
 	return s.Name
 }
 
-// GetEmail returns the value of Email.
-func (s *AuthUser) GetEmail() string {
-	return s.Email
+// GetPrefix returns the value of Prefix.
+func (s *APIKey) GetPrefix() string {
+	return s.Prefix
 }
 
-// GetRole returns the value of Role.
-func (s *AuthUser) GetRole() AuthUserRole {
-	return s.Role
+// GetScopes returns the value of Scopes.
+func (s *APIKey) GetScopes() []APIKeyScope {
+	return s.Scopes
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *APIKey) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetExpiresAt returns the value of ExpiresAt.
+func (s *APIKey) GetExpiresAt() OptDateTime {
+	return s.ExpiresAt
+}
+
+// GetLastUsedAt returns the value of LastUsedAt.
+func (s *APIKey) GetLastUsedAt() OptDateTime {
+	return s.LastUsedAt
+}
+
+// GetRevokedAt returns the value of RevokedAt.
+func (s *APIKey) GetRevokedAt() OptDateTime {
+	return s.RevokedAt
+}
+
+// GetStoreId returns the value of StoreId.
+func (s *APIKey) GetStoreId() OptInt64 {
+	return s.StoreId
 }
 
 // SetID sets the value of ID.
-func (s *AuthUser) SetID(val int64) {
+func (s *APIKey) SetID(val int64) {
 	s.ID = val
 }
 
 // SetName sets the value of Name.
-func (s *AuthUser) SetName(val string) {
+func (s *APIKey) SetName(val string) {
 	s.Name = val
 }
 
-// SetEmail sets the value of Email.
-func (s *AuthUser) SetEmail(val string) {
-	s.Email = val
+// SetPrefix sets the value of Prefix.
+func (s *APIKey) SetPrefix(val string) {
+	s.Prefix = val
 }
 
-// SetRole sets the value of Role.
-func (s *AuthUser) SetRole(val AuthUserRole) {
-	s.Role = val
+// SetScopes sets the value of Scopes.
+func (s *APIKey) SetScopes(val []APIKeyScope) {
+	s.Scopes = val
 }
 
-func (*AuthUser) loginUserRes()    {}
-func (*AuthUser) registerUserRes() {}
+// SetCreatedAt sets the value of CreatedAt.
+func (s *APIKey) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetExpiresAt sets the value of ExpiresAt.
+func (s *APIKey) SetExpiresAt(val OptDateTime) {
+	s.ExpiresAt = val
+}
+
+// SetLastUsedAt sets the value of LastUsedAt.
+func (s *APIKey) SetLastUsedAt(val OptDateTime) {
+	s.LastUsedAt = val
+}
+
+// SetRevokedAt sets the value of RevokedAt.
+func (s *APIKey) SetRevokedAt(val OptDateTime) {
+	s.RevokedAt = val
+}
+
+// SetStoreId sets the value of StoreId.
+func (s *APIKey) SetStoreId(val OptInt64) {
+	s.StoreId = val
+}
 
-type AuthUserRole string
+// Ref: #/components/schemas/APIKeyScope
+type APIKeyScope string
 
 const (
-	AuthUserRoleAdmin    AuthUserRole = "admin"
-	AuthUserRoleCustomer AuthUserRole = "customer"
+	APIKeyScopePetsWrite APIKeyScope = "pets:write"
+	APIKeyScopePetsRead  APIKeyScope = "pets:read"
 )
 
-// AllValues returns all AuthUserRole values.
-func (AuthUserRole) AllValues() []AuthUserRole {
-	return []AuthUserRole{
-		AuthUserRoleAdmin,
-		AuthUserRoleCustomer,
+// AllValues returns all APIKeyScope values.
+func (APIKeyScope) AllValues() []APIKeyScope {
+	return []APIKeyScope{
+		APIKeyScopePetsWrite,
+		APIKeyScopePetsRead,
 	}
 }
 
 // MarshalText implements encoding.TextMarshaler.
-func (s AuthUserRole) MarshalText() ([]byte, error) {
+func (s APIKeyScope) MarshalText() ([]byte, error) {
 	switch s {
-	case AuthUserRoleAdmin:
+	case APIKeyScopePetsWrite:
 		return []byte(s), nil
-	case AuthUserRoleCustomer:
+	case APIKeyScopePetsRead:
 		return []byte(s), nil
 	default:
 		return nil, errors.Errorf("invalid value: %q", s)
@@ -91,320 +148,3979 @@ func (s AuthUserRole) MarshalText() ([]byte, error) {
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
-func (s *AuthUserRole) UnmarshalText(data []byte) error {
-	switch AuthUserRole(data) {
-	case AuthUserRoleAdmin:
-		*s = AuthUserRoleAdmin
+func (s *APIKeyScope) UnmarshalText(data []byte) error {
+	switch APIKeyScope(data) {
+	case APIKeyScopePetsWrite:
+		*s = APIKeyScopePetsWrite
 		return nil
-	case AuthUserRoleCustomer:
-		*s = AuthUserRoleCustomer
+	case APIKeyScopePetsRead:
+		*s = APIKeyScopePetsRead
 		return nil
 	default:
 		return errors.Errorf("invalid value: %q", data)
 	}
 }
 
-type CookieAuth struct {
-	APIKey string
-	Roles  []string
-}
+type AddPetConflict Problem
 
-// GetAPIKey returns the value of APIKey.
-func (s *CookieAuth) GetAPIKey() string {
-	return s.APIKey
-}
+func (*AddPetConflict) addPetRes() {}
 
-// GetRoles returns the value of Roles.
-func (s *CookieAuth) GetRoles() []string {
-	return s.Roles
-}
+type AddPetRequestEntityTooLarge Problem
 
-// SetAPIKey sets the value of APIKey.
-func (s *CookieAuth) SetAPIKey(val string) {
-	s.APIKey = val
-}
+func (*AddPetRequestEntityTooLarge) addPetRes() {}
 
-// SetRoles sets the value of Roles.
-func (s *CookieAuth) SetRoles(val []string) {
-	s.Roles = val
-}
+type AddPetUnprocessableEntity Problem
 
-// DeletePetNoContent is response for DeletePet operation.
-type DeletePetNoContent struct{}
+func (*AddPetUnprocessableEntity) addPetRes() {}
 
-// Ref: #/components/schemas/Error
-type Error struct {
-	Code    int32  `json:"code"`
-	Message string `json:"message"`
+// Ref: #/components/schemas/AuditEvent
+type AuditEvent struct {
+	ID int64 `json:"id"`
+	// What happened, e.g. auth.login or pet.delete.
+	Action  string       `json:"action"`
+	Outcome AuditOutcome `json:"outcome"`
+	// User who performed the action, when known.
+	ActorUserId OptInt64 `json:"actorUserId"`
+	// API key that performed the action, when one was used.
+	ActorApiKeyId OptInt64 `json:"actorApiKeyId"`
+	// What the action was applied to, e.g. pet:42.
+	Target        OptString `json:"target"`
+	IP            OptString `json:"ip"`
+	UserAgent     OptString `json:"userAgent"`
+	CorrelationId OptString `json:"correlationId"`
+	// Action-specific context. Never contains secrets.
+	Details   OptAuditEventDetails `json:"details"`
+	CreatedAt time.Time            `json:"createdAt"`
 }
 
-// GetCode returns the value of Code.
-func (s *Error) GetCode() int32 {
-	return s.Code
+// GetID returns the value of ID.
+func (s *AuditEvent) GetID() int64 {
+	return s.ID
 }
 
-// GetMessage returns the value of Message.
-func (s *Error) GetMessage() string {
-	return s.Message
+// GetAction returns the value of Action.
+func (s *AuditEvent) GetAction() string {
+	return s.Action
 }
 
-// SetCode sets the value of Code.
-func (s *Error) SetCode(val int32) {
-	s.Code = val
+// GetOutcome returns the value of Outcome.
+func (s *AuditEvent) GetOutcome() AuditOutcome {
+	return s.Outcome
 }
 
-// SetMessage sets the value of Message.
-func (s *Error) SetMessage(val string) {
-	s.Message = val
+// GetActorUserId returns the value of ActorUserId.
+func (s *AuditEvent) GetActorUserId() OptInt64 {
+	return s.ActorUserId
 }
 
-func (*Error) loginUserRes()    {}
-func (*Error) registerUserRes() {}
-
-// ErrorStatusCode wraps Error with StatusCode.
-type ErrorStatusCode struct {
-	StatusCode int
-	Response   Error
+// GetActorApiKeyId returns the value of ActorApiKeyId.
+func (s *AuditEvent) GetActorApiKeyId() OptInt64 {
+	return s.ActorApiKeyId
 }
 
-// GetStatusCode returns the value of StatusCode.
-func (s *ErrorStatusCode) GetStatusCode() int {
-	return s.StatusCode
+// GetTarget returns the value of Target.
+func (s *AuditEvent) GetTarget() OptString {
+	return s.Target
 }
 
-// GetResponse returns the value of Response.
-func (s *ErrorStatusCode) GetResponse() Error {
-	return s.Response
+// GetIP returns the value of IP.
+func (s *AuditEvent) GetIP() OptString {
+	return s.IP
 }
 
-// SetStatusCode sets the value of StatusCode.
-func (s *ErrorStatusCode) SetStatusCode(val int) {
-	s.StatusCode = val
+// GetUserAgent returns the value of UserAgent.
+func (s *AuditEvent) GetUserAgent() OptString {
+	return s.UserAgent
 }
 
-// SetResponse sets the value of Response.
-func (s *ErrorStatusCode) SetResponse(val Error) {
-	s.Response = val
+// GetCorrelationId returns the value of CorrelationId.
+func (s *AuditEvent) GetCorrelationId() OptString {
+	return s.CorrelationId
 }
 
-// Ref: #/components/schemas/LoginRequest
-type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+// GetDetails returns the value of Details.
+func (s *AuditEvent) GetDetails() OptAuditEventDetails {
+	return s.Details
 }
 
-// GetEmail returns the value of Email.
-func (s *LoginRequest) GetEmail() string {
-	return s.Email
+// GetCreatedAt returns the value of CreatedAt.
+func (s *AuditEvent) GetCreatedAt() time.Time {
+	return s.CreatedAt
 }
 
-// GetPassword returns the value of Password.
-func (s *LoginRequest) GetPassword() string {
-	return s.Password
+// SetID sets the value of ID.
+func (s *AuditEvent) SetID(val int64) {
+	s.ID = val
 }
 
-// SetEmail sets the value of Email.
-func (s *LoginRequest) SetEmail(val string) {
-	s.Email = val
+// SetAction sets the value of Action.
+func (s *AuditEvent) SetAction(val string) {
+	s.Action = val
 }
 
-// SetPassword sets the value of Password.
-func (s *LoginRequest) SetPassword(val string) {
-	s.Password = val
+// SetOutcome sets the value of Outcome.
+func (s *AuditEvent) SetOutcome(val AuditOutcome) {
+	s.Outcome = val
 }
 
-// LogoutUserNoContent is response for LogoutUser operation.
-type LogoutUserNoContent struct{}
+// SetActorUserId sets the value of ActorUserId.
+func (s *AuditEvent) SetActorUserId(val OptInt64) {
+	s.ActorUserId = val
+}
 
-// Ref: #/components/schemas/NewPet
-type NewPet struct {
-	Name string    `json:"name"`
-	Tag  OptString `json:"tag"`
+// SetActorApiKeyId sets the value of ActorApiKeyId.
+func (s *AuditEvent) SetActorApiKeyId(val OptInt64) {
+	s.ActorApiKeyId = val
 }
 
-// GetName returns the value of Name.
-func (s *NewPet) GetName() string {
-	return s.Name
+// SetTarget sets the value of Target.
+func (s *AuditEvent) SetTarget(val OptString) {
+	s.Target = val
 }
 
-// GetTag returns the value of Tag.
-func (s *NewPet) GetTag() OptString {
-	return s.Tag
+// SetIP sets the value of IP.
+func (s *AuditEvent) SetIP(val OptString) {
+	s.IP = val
 }
 
-// SetName sets the value of Name.
-func (s *NewPet) SetName(val string) {
-	s.Name = val
+// SetUserAgent sets the value of UserAgent.
+func (s *AuditEvent) SetUserAgent(val OptString) {
+	s.UserAgent = val
 }
 
-// SetTag sets the value of Tag.
-func (s *NewPet) SetTag(val OptString) {
-	s.Tag = val
+// SetCorrelationId sets the value of CorrelationId.
+func (s *AuditEvent) SetCorrelationId(val OptString) {
+	s.CorrelationId = val
 }
 
-// NewOptInt32 returns new OptInt32 with value set to v.
-func NewOptInt32(v int32) OptInt32 {
-	return OptInt32{
-		Value: v,
-		Set:   true,
-	}
+// SetDetails sets the value of Details.
+func (s *AuditEvent) SetDetails(val OptAuditEventDetails) {
+	s.Details = val
 }
 
-// OptInt32 is optional int32.
-type OptInt32 struct {
-	Value int32
-	Set   bool
+// SetCreatedAt sets the value of CreatedAt.
+func (s *AuditEvent) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
 }
 
-// IsSet returns true if OptInt32 was set.
-func (o OptInt32) IsSet() bool { return o.Set }
+// Action-specific context. Never contains secrets.
+type AuditEventDetails map[string]string
 
-// Reset unsets value.
-func (o *OptInt32) Reset() {
-	var v int32
-	o.Value = v
-	o.Set = false
+func (s *AuditEventDetails) init() AuditEventDetails {
+	m := *s
+	if m == nil {
+		m = map[string]string{}
+		*s = m
+	}
+	return m
 }
 
-// SetTo sets value to v.
-func (o *OptInt32) SetTo(v int32) {
-	o.Set = true
-	o.Value = v
-}
+// Ref: #/components/schemas/AuditOutcome
+type AuditOutcome string
 
-// Get returns value and boolean that denotes whether value was set.
-func (o OptInt32) Get() (v int32, ok bool) {
-	if !o.Set {
-		return v, false
+const (
+	AuditOutcomeSuccess AuditOutcome = "success"
+	AuditOutcomeFailure AuditOutcome = "failure"
+)
+
+// AllValues returns all AuditOutcome values.
+func (AuditOutcome) AllValues() []AuditOutcome {
+	return []AuditOutcome{
+		AuditOutcomeSuccess,
+		AuditOutcomeFailure,
 	}
-	return o.Value, true
 }
 
-// Or returns value if set, or given parameter if does not.
-func (o OptInt32) Or(d int32) int32 {
-	if v, ok := o.Get(); ok {
-		return v
+// MarshalText implements encoding.TextMarshaler.
+func (s AuditOutcome) MarshalText() ([]byte, error) {
+	switch s {
+	case AuditOutcomeSuccess:
+		return []byte(s), nil
+	case AuditOutcomeFailure:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
 	}
-	return d
 }
 
-// NewOptString returns new OptString with value set to v.
-func NewOptString(v string) OptString {
-	return OptString{
-		Value: v,
-		Set:   true,
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *AuditOutcome) UnmarshalText(data []byte) error {
+	switch AuditOutcome(data) {
+	case AuditOutcomeSuccess:
+		*s = AuditOutcomeSuccess
+		return nil
+	case AuditOutcomeFailure:
+		*s = AuditOutcomeFailure
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
 	}
 }
 
-// OptString is optional string.
-type OptString struct {
-	Value string
-	Set   bool
+// Ref: #/components/schemas/AuthUser
+type AuthUser struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	// Role granted to the user, such as admin, staff, or customer. Operations list the roles they admit
+	// in x-required-role.
+	Role          string `json:"role"`
+	EmailVerified bool   `json:"emailVerified"`
+	MfaEnabled    bool   `json:"mfaEnabled"`
+	Disabled      bool   `json:"disabled"`
+	// The store the user is confined to; absent for global users.
+	StoreId OptInt64 `json:"storeId"`
 }
 
-// IsSet returns true if OptString was set.
-func (o OptString) IsSet() bool { return o.Set }
+// GetID returns the value of ID.
+func (s *AuthUser) GetID() int64 {
+	return s.ID
+}
 
-// Reset unsets value.
-func (o *OptString) Reset() {
-	var v string
-	o.Value = v
-	o.Set = false
+// GetName returns the value of Name.
+func (s *AuthUser) GetName() string {
+	return s.Name
 }
 
-// SetTo sets value to v.
-func (o *OptString) SetTo(v string) {
-	o.Set = true
-	o.Value = v
+// GetEmail returns the value of Email.
+func (s *AuthUser) GetEmail() string {
+	return s.Email
 }
 
-// Get returns value and boolean that denotes whether value was set.
-func (o OptString) Get() (v string, ok bool) {
-	if !o.Set {
-		return v, false
-	}
-	return o.Value, true
+// GetRole returns the value of Role.
+func (s *AuthUser) GetRole() string {
+	return s.Role
 }
 
-// Or returns value if set, or given parameter if does not.
-func (o OptString) Or(d string) string {
-	if v, ok := o.Get(); ok {
-		return v
-	}
-	return d
+// GetEmailVerified returns the value of EmailVerified.
+func (s *AuthUser) GetEmailVerified() bool {
+	return s.EmailVerified
 }
 
-// Merged schema.
-// Ref: #/components/schemas/Pet
-type Pet struct {
-	Name string    `json:"name"`
-	Tag  OptString `json:"tag"`
-	ID   int64     `json:"id"`
+// GetMfaEnabled returns the value of MfaEnabled.
+func (s *AuthUser) GetMfaEnabled() bool {
+	return s.MfaEnabled
 }
 
-// GetName returns the value of Name.
-func (s *Pet) GetName() string {
-	return s.Name
+// GetDisabled returns the value of Disabled.
+func (s *AuthUser) GetDisabled() bool {
+	return s.Disabled
 }
 
-// GetTag returns the value of Tag.
-func (s *Pet) GetTag() OptString {
-	return s.Tag
+// GetStoreId returns the value of StoreId.
+func (s *AuthUser) GetStoreId() OptInt64 {
+	return s.StoreId
 }
 
-// GetID returns the value of ID.
-func (s *Pet) GetID() int64 {
-	return s.ID
+// SetID sets the value of ID.
+func (s *AuthUser) SetID(val int64) {
+	s.ID = val
 }
 
 // SetName sets the value of Name.
-func (s *Pet) SetName(val string) {
+func (s *AuthUser) SetName(val string) {
 	s.Name = val
 }
 
-// SetTag sets the value of Tag.
-func (s *Pet) SetTag(val OptString) {
-	s.Tag = val
+// SetEmail sets the value of Email.
+func (s *AuthUser) SetEmail(val string) {
+	s.Email = val
 }
 
-// SetID sets the value of ID.
-func (s *Pet) SetID(val int64) {
-	s.ID = val
+// SetRole sets the value of Role.
+func (s *AuthUser) SetRole(val string) {
+	s.Role = val
 }
 
-// Ref: #/components/schemas/RegisterRequest
-type RegisterRequest struct {
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	Password string `json:"password"`
+// SetEmailVerified sets the value of EmailVerified.
+func (s *AuthUser) SetEmailVerified(val bool) {
+	s.EmailVerified = val
 }
 
-// GetName returns the value of Name.
-func (s *RegisterRequest) GetName() string {
-	return s.Name
+// SetMfaEnabled sets the value of MfaEnabled.
+func (s *AuthUser) SetMfaEnabled(val bool) {
+	s.MfaEnabled = val
 }
 
-// GetEmail returns the value of Email.
-func (s *RegisterRequest) GetEmail() string {
-	return s.Email
+// SetDisabled sets the value of Disabled.
+func (s *AuthUser) SetDisabled(val bool) {
+	s.Disabled = val
 }
 
-// GetPassword returns the value of Password.
-func (s *RegisterRequest) GetPassword() string {
-	return s.Password
+// SetStoreId sets the value of StoreId.
+func (s *AuthUser) SetStoreId(val OptInt64) {
+	s.StoreId = val
 }
 
-// SetName sets the value of Name.
-func (s *RegisterRequest) SetName(val string) {
+func (*AuthUser) loginUserRes()    {}
+func (*AuthUser) registerUserRes() {}
+func (*AuthUser) verifyMFARes()    {}
+
+type BearerAuth struct {
+	Token string
+	Roles []string
+}
+
+// GetToken returns the value of Token.
+func (s *BearerAuth) GetToken() string {
+	return s.Token
+}
+
+// GetRoles returns the value of Roles.
+func (s *BearerAuth) GetRoles() []string {
+	return s.Roles
+}
+
+// SetToken sets the value of Token.
+func (s *BearerAuth) SetToken(val string) {
+	s.Token = val
+}
+
+// SetRoles sets the value of Roles.
+func (s *BearerAuth) SetRoles(val []string) {
+	s.Roles = val
+}
+
+// Ref: #/components/schemas/Breed
+type Breed struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// GetCode returns the value of Code.
+func (s *Breed) GetCode() string {
+	return s.Code
+}
+
+// GetName returns the value of Name.
+func (s *Breed) GetName() string {
+	return s.Name
+}
+
+// SetCode sets the value of Code.
+func (s *Breed) SetCode(val string) {
+	s.Code = val
+}
+
+// SetName sets the value of Name.
+func (s *Breed) SetName(val string) {
 	s.Name = val
 }
 
+// CancelReservationNoContent is response for CancelReservation operation.
+type CancelReservationNoContent struct{}
+
+func (*CancelReservationNoContent) cancelReservationRes() {}
+
+// Ref: #/components/schemas/ChangeEmailRequest
+type ChangeEmailRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// GetEmail returns the value of Email.
+func (s *ChangeEmailRequest) GetEmail() string {
+	return s.Email
+}
+
+// GetPassword returns the value of Password.
+func (s *ChangeEmailRequest) GetPassword() string {
+	return s.Password
+}
+
 // SetEmail sets the value of Email.
-func (s *RegisterRequest) SetEmail(val string) {
+func (s *ChangeEmailRequest) SetEmail(val string) {
 	s.Email = val
 }
 
 // SetPassword sets the value of Password.
-func (s *RegisterRequest) SetPassword(val string) {
+func (s *ChangeEmailRequest) SetPassword(val string) {
 	s.Password = val
 }
+
+type ChangePasswordBadRequest Problem
+
+func (*ChangePasswordBadRequest) changePasswordRes() {}
+
+type ChangePasswordConflict Problem
+
+func (*ChangePasswordConflict) changePasswordRes() {}
+
+// ChangePasswordNoContent is response for ChangePassword operation.
+type ChangePasswordNoContent struct{}
+
+func (*ChangePasswordNoContent) changePasswordRes() {}
+
+// Ref: #/components/schemas/ChangePasswordRequest
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+}
+
+// GetCurrentPassword returns the value of CurrentPassword.
+func (s *ChangePasswordRequest) GetCurrentPassword() string {
+	return s.CurrentPassword
+}
+
+// GetNewPassword returns the value of NewPassword.
+func (s *ChangePasswordRequest) GetNewPassword() string {
+	return s.NewPassword
+}
+
+// SetCurrentPassword sets the value of CurrentPassword.
+func (s *ChangePasswordRequest) SetCurrentPassword(val string) {
+	s.CurrentPassword = val
+}
+
+// SetNewPassword sets the value of NewPassword.
+func (s *ChangePasswordRequest) SetNewPassword(val string) {
+	s.NewPassword = val
+}
+
+type ChangePasswordRequestEntityTooLarge Problem
+
+func (*ChangePasswordRequestEntityTooLarge) changePasswordRes() {}
+
+type ChangePasswordUnprocessableEntity Problem
+
+func (*ChangePasswordUnprocessableEntity) changePasswordRes() {}
+
+type ConfirmEmailChangeBadRequest Problem
+
+func (*ConfirmEmailChangeBadRequest) confirmEmailChangeRes() {}
+
+type ConfirmEmailChangeConflict Problem
+
+func (*ConfirmEmailChangeConflict) confirmEmailChangeRes() {}
+
+// ConfirmEmailChangeNoContent is response for ConfirmEmailChange operation.
+type ConfirmEmailChangeNoContent struct{}
+
+func (*ConfirmEmailChangeNoContent) confirmEmailChangeRes() {}
+
+type ConfirmEmailChangeRequestEntityTooLarge Problem
+
+func (*ConfirmEmailChangeRequestEntityTooLarge) confirmEmailChangeRes() {}
+
+type ConfirmEmailChangeUnprocessableEntity Problem
+
+func (*ConfirmEmailChangeUnprocessableEntity) confirmEmailChangeRes() {}
+
+type ConfirmMFAEnrollmentBadRequest Problem
+
+func (*ConfirmMFAEnrollmentBadRequest) confirmMFAEnrollmentRes() {}
+
+type ConfirmMFAEnrollmentConflict Problem
+
+func (*ConfirmMFAEnrollmentConflict) confirmMFAEnrollmentRes() {}
+
+type ConfirmMFAEnrollmentRequestEntityTooLarge Problem
+
+func (*ConfirmMFAEnrollmentRequestEntityTooLarge) confirmMFAEnrollmentRes() {}
+
+type ConfirmMFAEnrollmentUnprocessableEntity Problem
+
+func (*ConfirmMFAEnrollmentUnprocessableEntity) confirmMFAEnrollmentRes() {}
+
+type CookieAuth struct {
+	APIKey string
+	Roles  []string
+}
+
+// GetAPIKey returns the value of APIKey.
+func (s *CookieAuth) GetAPIKey() string {
+	return s.APIKey
+}
+
+// GetRoles returns the value of Roles.
+func (s *CookieAuth) GetRoles() []string {
+	return s.Roles
+}
+
+// SetAPIKey sets the value of APIKey.
+func (s *CookieAuth) SetAPIKey(val string) {
+	s.APIKey = val
+}
+
+// SetRoles sets the value of Roles.
+func (s *CookieAuth) SetRoles(val []string) {
+	s.Roles = val
+}
+
+type CreateAPIKeyBadRequest Problem
+
+func (*CreateAPIKeyBadRequest) createAPIKeyRes() {}
+
+type CreateAPIKeyConflict Problem
+
+func (*CreateAPIKeyConflict) createAPIKeyRes() {}
+
+type CreateAPIKeyRequestEntityTooLarge Problem
+
+func (*CreateAPIKeyRequestEntityTooLarge) createAPIKeyRes() {}
+
+type CreateAPIKeyUnprocessableEntity Problem
+
+func (*CreateAPIKeyUnprocessableEntity) createAPIKeyRes() {}
+
+type CreateStoreConflict Problem
+
+func (*CreateStoreConflict) createStoreRes() {}
+
+type CreateStoreRequestEntityTooLarge Problem
+
+func (*CreateStoreRequestEntityTooLarge) createStoreRes() {}
+
+type CreateStoreUnprocessableEntity Problem
+
+func (*CreateStoreUnprocessableEntity) createStoreRes() {}
+
+type CreateWebhookBadRequest Problem
+
+func (*CreateWebhookBadRequest) createWebhookRes() {}
+
+type CreateWebhookConflict Problem
+
+func (*CreateWebhookConflict) createWebhookRes() {}
+
+type CreateWebhookRequestEntityTooLarge Problem
+
+func (*CreateWebhookRequestEntityTooLarge) createWebhookRes() {}
+
+type CreateWebhookUnprocessableEntity Problem
+
+func (*CreateWebhookUnprocessableEntity) createWebhookRes() {}
+
+// Merged schema.
+// Ref: #/components/schemas/CreatedAPIKey
+type CreatedAPIKey struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// First characters of the key, for identification.
+	Prefix     string        `json:"prefix"`
+	Scopes     []APIKeyScope `json:"scopes"`
+	CreatedAt  time.Time     `json:"createdAt"`
+	ExpiresAt  OptDateTime   `json:"expiresAt"`
+	LastUsedAt OptDateTime   `json:"lastUsedAt"`
+	RevokedAt  OptDateTime   `json:"revokedAt"`
+	StoreId    OptInt64      `json:"storeId"`
+	// The full API key. It cannot be retrieved again.
+	Key string `json:"key"`
+}
+
+// GetID returns the value of ID.
+func (s *CreatedAPIKey) GetID() int64 {
+	return s.ID
+}
+
+// GetName returns the value of Name.
+func (s *CreatedAPIKey) GetName() string {
+	return s.Name
+}
+
+// GetPrefix returns the value of Prefix.
+func (s *CreatedAPIKey) GetPrefix() string {
+	return s.Prefix
+}
+
+// GetScopes returns the value of Scopes.
+func (s *CreatedAPIKey) GetScopes() []APIKeyScope {
+	return s.Scopes
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *CreatedAPIKey) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetExpiresAt returns the value of ExpiresAt.
+func (s *CreatedAPIKey) GetExpiresAt() OptDateTime {
+	return s.ExpiresAt
+}
+
+// GetLastUsedAt returns the value of LastUsedAt.
+func (s *CreatedAPIKey) GetLastUsedAt() OptDateTime {
+	return s.LastUsedAt
+}
+
+// GetRevokedAt returns the value of RevokedAt.
+func (s *CreatedAPIKey) GetRevokedAt() OptDateTime {
+	return s.RevokedAt
+}
+
+// GetStoreId returns the value of StoreId.
+func (s *CreatedAPIKey) GetStoreId() OptInt64 {
+	return s.StoreId
+}
+
+// GetKey returns the value of Key.
+func (s *CreatedAPIKey) GetKey() string {
+	return s.Key
+}
+
+// SetID sets the value of ID.
+func (s *CreatedAPIKey) SetID(val int64) {
+	s.ID = val
+}
+
+// SetName sets the value of Name.
+func (s *CreatedAPIKey) SetName(val string) {
+	s.Name = val
+}
+
+// SetPrefix sets the value of Prefix.
+func (s *CreatedAPIKey) SetPrefix(val string) {
+	s.Prefix = val
+}
+
+// SetScopes sets the value of Scopes.
+func (s *CreatedAPIKey) SetScopes(val []APIKeyScope) {
+	s.Scopes = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *CreatedAPIKey) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetExpiresAt sets the value of ExpiresAt.
+func (s *CreatedAPIKey) SetExpiresAt(val OptDateTime) {
+	s.ExpiresAt = val
+}
+
+// SetLastUsedAt sets the value of LastUsedAt.
+func (s *CreatedAPIKey) SetLastUsedAt(val OptDateTime) {
+	s.LastUsedAt = val
+}
+
+// SetRevokedAt sets the value of RevokedAt.
+func (s *CreatedAPIKey) SetRevokedAt(val OptDateTime) {
+	s.RevokedAt = val
+}
+
+// SetStoreId sets the value of StoreId.
+func (s *CreatedAPIKey) SetStoreId(val OptInt64) {
+	s.StoreId = val
+}
+
+// SetKey sets the value of Key.
+func (s *CreatedAPIKey) SetKey(val string) {
+	s.Key = val
+}
+
+func (*CreatedAPIKey) createAPIKeyRes() {}
+
+// Merged schema.
+// Ref: #/components/schemas/CreatedWebhook
+type CreatedWebhook struct {
+	ID         int64              `json:"id"`
+	URL        string             `json:"url"`
+	EventTypes []WebhookEventType `json:"eventTypes"`
+	// Admin who created the webhook.
+	CreatedBy int64     `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Key that signs deliveries. It cannot be retrieved again.
+	Secret string `json:"secret"`
+}
+
+// GetID returns the value of ID.
+func (s *CreatedWebhook) GetID() int64 {
+	return s.ID
+}
+
+// GetURL returns the value of URL.
+func (s *CreatedWebhook) GetURL() string {
+	return s.URL
+}
+
+// GetEventTypes returns the value of EventTypes.
+func (s *CreatedWebhook) GetEventTypes() []WebhookEventType {
+	return s.EventTypes
+}
+
+// GetCreatedBy returns the value of CreatedBy.
+func (s *CreatedWebhook) GetCreatedBy() int64 {
+	return s.CreatedBy
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *CreatedWebhook) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetSecret returns the value of Secret.
+func (s *CreatedWebhook) GetSecret() string {
+	return s.Secret
+}
+
+// SetID sets the value of ID.
+func (s *CreatedWebhook) SetID(val int64) {
+	s.ID = val
+}
+
+// SetURL sets the value of URL.
+func (s *CreatedWebhook) SetURL(val string) {
+	s.URL = val
+}
+
+// SetEventTypes sets the value of EventTypes.
+func (s *CreatedWebhook) SetEventTypes(val []WebhookEventType) {
+	s.EventTypes = val
+}
+
+// SetCreatedBy sets the value of CreatedBy.
+func (s *CreatedWebhook) SetCreatedBy(val int64) {
+	s.CreatedBy = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *CreatedWebhook) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetSecret sets the value of Secret.
+func (s *CreatedWebhook) SetSecret(val string) {
+	s.Secret = val
+}
+
+func (*CreatedWebhook) createWebhookRes() {}
+
+// DeletePetNoContent is response for DeletePet operation.
+type DeletePetNoContent struct{}
+
+// DeleteWebhookNoContent is response for DeleteWebhook operation.
+type DeleteWebhookNoContent struct{}
+
+type EnrollMFAConflict Problem
+
+func (*EnrollMFAConflict) enrollMFARes() {}
+
+type EnrollMFARequestEntityTooLarge Problem
+
+func (*EnrollMFARequestEntityTooLarge) enrollMFARes() {}
+
+type EnrollMFAUnprocessableEntity Problem
+
+func (*EnrollMFAUnprocessableEntity) enrollMFARes() {}
+
+type ExportPetsOKApplicationVndPetstorePetsJSON struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ExportPetsOKApplicationVndPetstorePetsJSON) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*ExportPetsOKApplicationVndPetstorePetsJSON) exportPetsRes() {}
+
+type ExportPetsOKApplicationXNdjson struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ExportPetsOKApplicationXNdjson) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*ExportPetsOKApplicationXNdjson) exportPetsRes() {}
+
+type ExportPetsOKTextCsv struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ExportPetsOKTextCsv) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*ExportPetsOKTextCsv) exportPetsRes() {}
+
+// ForgotPasswordAccepted is response for ForgotPassword operation.
+type ForgotPasswordAccepted struct{}
+
+func (*ForgotPasswordAccepted) forgotPasswordRes() {}
+
+type ForgotPasswordConflict Problem
+
+func (*ForgotPasswordConflict) forgotPasswordRes() {}
+
+// Ref: #/components/schemas/ForgotPasswordRequest
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// GetEmail returns the value of Email.
+func (s *ForgotPasswordRequest) GetEmail() string {
+	return s.Email
+}
+
+// SetEmail sets the value of Email.
+func (s *ForgotPasswordRequest) SetEmail(val string) {
+	s.Email = val
+}
+
+type ForgotPasswordRequestEntityTooLarge Problem
+
+func (*ForgotPasswordRequestEntityTooLarge) forgotPasswordRes() {}
+
+type ForgotPasswordUnprocessableEntity Problem
+
+func (*ForgotPasswordUnprocessableEntity) forgotPasswordRes() {}
+
+// Ref: #/components/schemas/ImportMode
+type ImportMode string
+
+const (
+	ImportModeAtomic  ImportMode = "atomic"
+	ImportModePartial ImportMode = "partial"
+)
+
+// AllValues returns all ImportMode values.
+func (ImportMode) AllValues() []ImportMode {
+	return []ImportMode{
+		ImportModeAtomic,
+		ImportModePartial,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ImportMode) MarshalText() ([]byte, error) {
+	switch s {
+	case ImportModeAtomic:
+		return []byte(s), nil
+	case ImportModePartial:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ImportMode) UnmarshalText(data []byte) error {
+	switch ImportMode(data) {
+	case ImportModeAtomic:
+		*s = ImportModeAtomic
+		return nil
+	case ImportModePartial:
+		*s = ImportModePartial
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+type ImportPetsApplicationJSONUnprocessableEntity ImportReport
+
+func (*ImportPetsApplicationJSONUnprocessableEntity) importPetsRes() {}
+
+type ImportPetsApplicationProblemJSONUnprocessableEntity Problem
+
+func (*ImportPetsApplicationProblemJSONUnprocessableEntity) importPetsRes() {}
+
+type ImportPetsConflict Problem
+
+func (*ImportPetsConflict) importPetsRes() {}
+
+type ImportPetsOK ImportReport
+
+func (*ImportPetsOK) importPetsRes() {}
+
+type ImportPetsReqApplicationXNdjson struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ImportPetsReqApplicationXNdjson) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*ImportPetsReqApplicationXNdjson) importPetsReq() {}
+
+type ImportPetsReqTextCsv struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s ImportPetsReqTextCsv) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*ImportPetsReqTextCsv) importPetsReq() {}
+
+type ImportPetsRequestEntityTooLarge Problem
+
+func (*ImportPetsRequestEntityTooLarge) importPetsRes() {}
+
+// Ref: #/components/schemas/ImportReport
+type ImportReport struct {
+	Mode   ImportMode `json:"mode"`
+	DryRun bool       `json:"dryRun"`
+	// Data rows read, not counting the CSV header.
+	Rows int32 `json:"rows"`
+	// Pets created, or that would be on a dry run.
+	Imported int32 `json:"imported"`
+	// Rows rejected as invalid.
+	Failed int32            `json:"failed"`
+	Errors []ImportRowError `json:"errors"`
+}
+
+// GetMode returns the value of Mode.
+func (s *ImportReport) GetMode() ImportMode {
+	return s.Mode
+}
+
+// GetDryRun returns the value of DryRun.
+func (s *ImportReport) GetDryRun() bool {
+	return s.DryRun
+}
+
+// GetRows returns the value of Rows.
+func (s *ImportReport) GetRows() int32 {
+	return s.Rows
+}
+
+// GetImported returns the value of Imported.
+func (s *ImportReport) GetImported() int32 {
+	return s.Imported
+}
+
+// GetFailed returns the value of Failed.
+func (s *ImportReport) GetFailed() int32 {
+	return s.Failed
+}
+
+// GetErrors returns the value of Errors.
+func (s *ImportReport) GetErrors() []ImportRowError {
+	return s.Errors
+}
+
+// SetMode sets the value of Mode.
+func (s *ImportReport) SetMode(val ImportMode) {
+	s.Mode = val
+}
+
+// SetDryRun sets the value of DryRun.
+func (s *ImportReport) SetDryRun(val bool) {
+	s.DryRun = val
+}
+
+// SetRows sets the value of Rows.
+func (s *ImportReport) SetRows(val int32) {
+	s.Rows = val
+}
+
+// SetImported sets the value of Imported.
+func (s *ImportReport) SetImported(val int32) {
+	s.Imported = val
+}
+
+// SetFailed sets the value of Failed.
+func (s *ImportReport) SetFailed(val int32) {
+	s.Failed = val
+}
+
+// SetErrors sets the value of Errors.
+func (s *ImportReport) SetErrors(val []ImportRowError) {
+	s.Errors = val
+}
+
+// Ref: #/components/schemas/ImportRowError
+type ImportRowError struct {
+	// Line of the input the row starts on, counting from 1.
+	Line int32 `json:"line"`
+	// The invalid field, when the error concerns one.
+	Field   OptString `json:"field"`
+	Message string    `json:"message"`
+}
+
+// GetLine returns the value of Line.
+func (s *ImportRowError) GetLine() int32 {
+	return s.Line
+}
+
+// GetField returns the value of Field.
+func (s *ImportRowError) GetField() OptString {
+	return s.Field
+}
+
+// GetMessage returns the value of Message.
+func (s *ImportRowError) GetMessage() string {
+	return s.Message
+}
+
+// SetLine sets the value of Line.
+func (s *ImportRowError) SetLine(val int32) {
+	s.Line = val
+}
+
+// SetField sets the value of Field.
+func (s *ImportRowError) SetField(val OptString) {
+	s.Field = val
+}
+
+// SetMessage sets the value of Message.
+func (s *ImportRowError) SetMessage(val string) {
+	s.Message = val
+}
+
+// A public signing key (RFC 7517, RFC 8037).
+// Ref: #/components/schemas/JWK
+type JWK struct {
+	Kty JWKKty `json:"kty"`
+	// RFC 7638 thumbprint, matching the token's kid header.
+	Kid string `json:"kid"`
+	Use JWKUse `json:"use"`
+	Alg JWKAlg `json:"alg"`
+	// Curve name, for OKP keys.
+	Crv OptString `json:"crv"`
+	// Base64url public key, for OKP keys.
+	X OptString `json:"x"`
+	// Base64url modulus, for RSA keys.
+	N OptString `json:"n"`
+	// Base64url exponent, for RSA keys.
+	E OptString `json:"e"`
+}
+
+// GetKty returns the value of Kty.
+func (s *JWK) GetKty() JWKKty {
+	return s.Kty
+}
+
+// GetKid returns the value of Kid.
+func (s *JWK) GetKid() string {
+	return s.Kid
+}
+
+// GetUse returns the value of Use.
+func (s *JWK) GetUse() JWKUse {
+	return s.Use
+}
+
+// GetAlg returns the value of Alg.
+func (s *JWK) GetAlg() JWKAlg {
+	return s.Alg
+}
+
+// GetCrv returns the value of Crv.
+func (s *JWK) GetCrv() OptString {
+	return s.Crv
+}
+
+// GetX returns the value of X.
+func (s *JWK) GetX() OptString {
+	return s.X
+}
+
+// GetN returns the value of N.
+func (s *JWK) GetN() OptString {
+	return s.N
+}
+
+// GetE returns the value of E.
+func (s *JWK) GetE() OptString {
+	return s.E
+}
+
+// SetKty sets the value of Kty.
+func (s *JWK) SetKty(val JWKKty) {
+	s.Kty = val
+}
+
+// SetKid sets the value of Kid.
+func (s *JWK) SetKid(val string) {
+	s.Kid = val
+}
+
+// SetUse sets the value of Use.
+func (s *JWK) SetUse(val JWKUse) {
+	s.Use = val
+}
+
+// SetAlg sets the value of Alg.
+func (s *JWK) SetAlg(val JWKAlg) {
+	s.Alg = val
+}
+
+// SetCrv sets the value of Crv.
+func (s *JWK) SetCrv(val OptString) {
+	s.Crv = val
+}
+
+// SetX sets the value of X.
+func (s *JWK) SetX(val OptString) {
+	s.X = val
+}
+
+// SetN sets the value of N.
+func (s *JWK) SetN(val OptString) {
+	s.N = val
+}
+
+// SetE sets the value of E.
+func (s *JWK) SetE(val OptString) {
+	s.E = val
+}
+
+type JWKAlg string
+
+const (
+	JWKAlgEdDSA JWKAlg = "EdDSA"
+	JWKAlgRS256 JWKAlg = "RS256"
+)
+
+// AllValues returns all JWKAlg values.
+func (JWKAlg) AllValues() []JWKAlg {
+	return []JWKAlg{
+		JWKAlgEdDSA,
+		JWKAlgRS256,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s JWKAlg) MarshalText() ([]byte, error) {
+	switch s {
+	case JWKAlgEdDSA:
+		return []byte(s), nil
+	case JWKAlgRS256:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *JWKAlg) UnmarshalText(data []byte) error {
+	switch JWKAlg(data) {
+	case JWKAlgEdDSA:
+		*s = JWKAlgEdDSA
+		return nil
+	case JWKAlgRS256:
+		*s = JWKAlgRS256
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+type JWKKty string
+
+const (
+	JWKKtyOKP JWKKty = "OKP"
+	JWKKtyRSA JWKKty = "RSA"
+)
+
+// AllValues returns all JWKKty values.
+func (JWKKty) AllValues() []JWKKty {
+	return []JWKKty{
+		JWKKtyOKP,
+		JWKKtyRSA,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s JWKKty) MarshalText() ([]byte, error) {
+	switch s {
+	case JWKKtyOKP:
+		return []byte(s), nil
+	case JWKKtyRSA:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *JWKKty) UnmarshalText(data []byte) error {
+	switch JWKKty(data) {
+	case JWKKtyOKP:
+		*s = JWKKtyOKP
+		return nil
+	case JWKKtyRSA:
+		*s = JWKKtyRSA
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/JWKS
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// GetKeys returns the value of Keys.
+func (s *JWKS) GetKeys() []JWK {
+	return s.Keys
+}
+
+// SetKeys sets the value of Keys.
+func (s *JWKS) SetKeys(val []JWK) {
+	s.Keys = val
+}
+
+type JWKUse string
+
+const (
+	JWKUseSig JWKUse = "sig"
+)
+
+// AllValues returns all JWKUse values.
+func (JWKUse) AllValues() []JWKUse {
+	return []JWKUse{
+		JWKUseSig,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s JWKUse) MarshalText() ([]byte, error) {
+	switch s {
+	case JWKUseSig:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *JWKUse) UnmarshalText(data []byte) error {
+	switch JWKUse(data) {
+	case JWKUseSig:
+		*s = JWKUseSig
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/JobSchedulerStatus
+type JobSchedulerStatus struct {
+	// Whether this replica holds the scheduler lock and runs jobs.
+	Leader bool        `json:"leader"`
+	Jobs   []JobStatus `json:"jobs"`
+}
+
+// GetLeader returns the value of Leader.
+func (s *JobSchedulerStatus) GetLeader() bool {
+	return s.Leader
+}
+
+// GetJobs returns the value of Jobs.
+func (s *JobSchedulerStatus) GetJobs() []JobStatus {
+	return s.Jobs
+}
+
+// SetLeader sets the value of Leader.
+func (s *JobSchedulerStatus) SetLeader(val bool) {
+	s.Leader = val
+}
+
+// SetJobs sets the value of Jobs.
+func (s *JobSchedulerStatus) SetJobs(val []JobStatus) {
+	s.Jobs = val
+}
+
+// Ref: #/components/schemas/JobStatus
+type JobStatus struct {
+	Name string `json:"name"`
+	// Cron-like schedule, evaluated in UTC.
+	Schedule       string `json:"schedule"`
+	TimeoutSeconds int32  `json:"timeoutSeconds"`
+	Running        bool   `json:"running"`
+	// Completed runs since this server started.
+	Runs int64 `json:"runs"`
+	// Runs that returned an error or timed out.
+	Failures int64 `json:"failures"`
+	// Runs dropped because the previous run was still going.
+	Skipped        int64       `json:"skipped"`
+	LastStartedAt  OptDateTime `json:"lastStartedAt"`
+	LastDurationMs OptInt64    `json:"lastDurationMs"`
+	// Error of the last run, absent when it succeeded.
+	LastError       OptString   `json:"lastError"`
+	LastSucceededAt OptDateTime `json:"lastSucceededAt"`
+	// Next scheduled run, present only on the leader.
+	NextRunAt OptDateTime `json:"nextRunAt"`
+}
+
+// GetName returns the value of Name.
+func (s *JobStatus) GetName() string {
+	return s.Name
+}
+
+// GetSchedule returns the value of Schedule.
+func (s *JobStatus) GetSchedule() string {
+	return s.Schedule
+}
+
+// GetTimeoutSeconds returns the value of TimeoutSeconds.
+func (s *JobStatus) GetTimeoutSeconds() int32 {
+	return s.TimeoutSeconds
+}
+
+// GetRunning returns the value of Running.
+func (s *JobStatus) GetRunning() bool {
+	return s.Running
+}
+
+// GetRuns returns the value of Runs.
+func (s *JobStatus) GetRuns() int64 {
+	return s.Runs
+}
+
+// GetFailures returns the value of Failures.
+func (s *JobStatus) GetFailures() int64 {
+	return s.Failures
+}
+
+// GetSkipped returns the value of Skipped.
+func (s *JobStatus) GetSkipped() int64 {
+	return s.Skipped
+}
+
+// GetLastStartedAt returns the value of LastStartedAt.
+func (s *JobStatus) GetLastStartedAt() OptDateTime {
+	return s.LastStartedAt
+}
+
+// GetLastDurationMs returns the value of LastDurationMs.
+func (s *JobStatus) GetLastDurationMs() OptInt64 {
+	return s.LastDurationMs
+}
+
+// GetLastError returns the value of LastError.
+func (s *JobStatus) GetLastError() OptString {
+	return s.LastError
+}
+
+// GetLastSucceededAt returns the value of LastSucceededAt.
+func (s *JobStatus) GetLastSucceededAt() OptDateTime {
+	return s.LastSucceededAt
+}
+
+// GetNextRunAt returns the value of NextRunAt.
+func (s *JobStatus) GetNextRunAt() OptDateTime {
+	return s.NextRunAt
+}
+
+// SetName sets the value of Name.
+func (s *JobStatus) SetName(val string) {
+	s.Name = val
+}
+
+// SetSchedule sets the value of Schedule.
+func (s *JobStatus) SetSchedule(val string) {
+	s.Schedule = val
+}
+
+// SetTimeoutSeconds sets the value of TimeoutSeconds.
+func (s *JobStatus) SetTimeoutSeconds(val int32) {
+	s.TimeoutSeconds = val
+}
+
+// SetRunning sets the value of Running.
+func (s *JobStatus) SetRunning(val bool) {
+	s.Running = val
+}
+
+// SetRuns sets the value of Runs.
+func (s *JobStatus) SetRuns(val int64) {
+	s.Runs = val
+}
+
+// SetFailures sets the value of Failures.
+func (s *JobStatus) SetFailures(val int64) {
+	s.Failures = val
+}
+
+// SetSkipped sets the value of Skipped.
+func (s *JobStatus) SetSkipped(val int64) {
+	s.Skipped = val
+}
+
+// SetLastStartedAt sets the value of LastStartedAt.
+func (s *JobStatus) SetLastStartedAt(val OptDateTime) {
+	s.LastStartedAt = val
+}
+
+// SetLastDurationMs sets the value of LastDurationMs.
+func (s *JobStatus) SetLastDurationMs(val OptInt64) {
+	s.LastDurationMs = val
+}
+
+// SetLastError sets the value of LastError.
+func (s *JobStatus) SetLastError(val OptString) {
+	s.LastError = val
+}
+
+// SetLastSucceededAt sets the value of LastSucceededAt.
+func (s *JobStatus) SetLastSucceededAt(val OptDateTime) {
+	s.LastSucceededAt = val
+}
+
+// SetNextRunAt sets the value of NextRunAt.
+func (s *JobStatus) SetNextRunAt(val OptDateTime) {
+	s.NextRunAt = val
+}
+
+// Ref: #/components/schemas/Location
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// GetLatitude returns the value of Latitude.
+func (s *Location) GetLatitude() float64 {
+	return s.Latitude
+}
+
+// GetLongitude returns the value of Longitude.
+func (s *Location) GetLongitude() float64 {
+	return s.Longitude
+}
+
+// SetLatitude sets the value of Latitude.
+func (s *Location) SetLatitude(val float64) {
+	s.Latitude = val
+}
+
+// SetLongitude sets the value of Longitude.
+func (s *Location) SetLongitude(val float64) {
+	s.Longitude = val
+}
+
+// Ref: #/components/schemas/LoginRequest
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// GetEmail returns the value of Email.
+func (s *LoginRequest) GetEmail() string {
+	return s.Email
+}
+
+// GetPassword returns the value of Password.
+func (s *LoginRequest) GetPassword() string {
+	return s.Password
+}
+
+// SetEmail sets the value of Email.
+func (s *LoginRequest) SetEmail(val string) {
+	s.Email = val
+}
+
+// SetPassword sets the value of Password.
+func (s *LoginRequest) SetPassword(val string) {
+	s.Password = val
+}
+
+type LoginUserConflict Problem
+
+func (*LoginUserConflict) loginUserRes() {}
+
+type LoginUserForbidden Problem
+
+func (*LoginUserForbidden) loginUserRes() {}
+
+type LoginUserRequestEntityTooLarge Problem
+
+func (*LoginUserRequestEntityTooLarge) loginUserRes() {}
+
+type LoginUserUnauthorized Problem
+
+func (*LoginUserUnauthorized) loginUserRes() {}
+
+type LoginUserUnprocessableEntity Problem
+
+func (*LoginUserUnprocessableEntity) loginUserRes() {}
+
+type LogoutUserConflict Problem
+
+func (*LogoutUserConflict) logoutUserRes() {}
+
+// LogoutUserNoContent is response for LogoutUser operation.
+type LogoutUserNoContent struct{}
+
+func (*LogoutUserNoContent) logoutUserRes() {}
+
+type LogoutUserRequestEntityTooLarge Problem
+
+func (*LogoutUserRequestEntityTooLarge) logoutUserRes() {}
+
+type LogoutUserUnprocessableEntity Problem
+
+func (*LogoutUserUnprocessableEntity) logoutUserRes() {}
+
+// Ref: #/components/schemas/MFAChallenge
+type MFAChallenge struct {
+	// Short-lived token to pass to verifyMFA.
+	MfaToken string `json:"mfaToken"`
+}
+
+// GetMfaToken returns the value of MfaToken.
+func (s *MFAChallenge) GetMfaToken() string {
+	return s.MfaToken
+}
+
+// SetMfaToken sets the value of MfaToken.
+func (s *MFAChallenge) SetMfaToken(val string) {
+	s.MfaToken = val
+}
+
+func (*MFAChallenge) loginUserRes() {}
+
+// Ref: #/components/schemas/MFACodeRequest
+type MFACodeRequest struct {
+	Code string `json:"code"`
+}
+
+// GetCode returns the value of Code.
+func (s *MFACodeRequest) GetCode() string {
+	return s.Code
+}
+
+// SetCode sets the value of Code.
+func (s *MFACodeRequest) SetCode(val string) {
+	s.Code = val
+}
+
+// Ref: #/components/schemas/MFAEnrollment
+type MFAEnrollment struct {
+	// Base32 TOTP secret for manual entry.
+	Secret string `json:"secret"`
+	// Otpauth:// URI to render as a QR code.
+	ProvisioningUri string `json:"provisioningUri"`
+}
+
+// GetSecret returns the value of Secret.
+func (s *MFAEnrollment) GetSecret() string {
+	return s.Secret
+}
+
+// GetProvisioningUri returns the value of ProvisioningUri.
+func (s *MFAEnrollment) GetProvisioningUri() string {
+	return s.ProvisioningUri
+}
+
+// SetSecret sets the value of Secret.
+func (s *MFAEnrollment) SetSecret(val string) {
+	s.Secret = val
+}
+
+// SetProvisioningUri sets the value of ProvisioningUri.
+func (s *MFAEnrollment) SetProvisioningUri(val string) {
+	s.ProvisioningUri = val
+}
+
+func (*MFAEnrollment) enrollMFARes() {}
+
+// Ref: #/components/schemas/MFARecoveryCodes
+type MFARecoveryCodes struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// GetRecoveryCodes returns the value of RecoveryCodes.
+func (s *MFARecoveryCodes) GetRecoveryCodes() []string {
+	return s.RecoveryCodes
+}
+
+// SetRecoveryCodes sets the value of RecoveryCodes.
+func (s *MFARecoveryCodes) SetRecoveryCodes(val []string) {
+	s.RecoveryCodes = val
+}
+
+func (*MFARecoveryCodes) confirmMFAEnrollmentRes() {}
+
+// Ref: #/components/schemas/MFAVerifyRequest
+type MFAVerifyRequest struct {
+	MfaToken string `json:"mfaToken"`
+	// Six-digit TOTP code or a recovery code.
+	Code string `json:"code"`
+}
+
+// GetMfaToken returns the value of MfaToken.
+func (s *MFAVerifyRequest) GetMfaToken() string {
+	return s.MfaToken
+}
+
+// GetCode returns the value of Code.
+func (s *MFAVerifyRequest) GetCode() string {
+	return s.Code
+}
+
+// SetMfaToken sets the value of MfaToken.
+func (s *MFAVerifyRequest) SetMfaToken(val string) {
+	s.MfaToken = val
+}
+
+// SetCode sets the value of Code.
+func (s *MFAVerifyRequest) SetCode(val string) {
+	s.Code = val
+}
+
+// Ref: #/components/schemas/Money
+type Money struct {
+	// The amount in minor units of the currency, such as cents.
+	Amount int64 `json:"amount"`
+	// An ISO 4217 currency code.
+	Currency string `json:"currency"`
+}
+
+// GetAmount returns the value of Amount.
+func (s *Money) GetAmount() int64 {
+	return s.Amount
+}
+
+// GetCurrency returns the value of Currency.
+func (s *Money) GetCurrency() string {
+	return s.Currency
+}
+
+// SetAmount sets the value of Amount.
+func (s *Money) SetAmount(val int64) {
+	s.Amount = val
+}
+
+// SetCurrency sets the value of Currency.
+func (s *Money) SetCurrency(val string) {
+	s.Currency = val
+}
+
+// Ref: #/components/schemas/NewAPIKey
+type NewAPIKey struct {
+	Name      string        `json:"name"`
+	Scopes    []APIKeyScope `json:"scopes"`
+	ExpiresAt OptDateTime   `json:"expiresAt"`
+	// Confines the key to one store; omit for a global key.
+	StoreId OptInt64 `json:"storeId"`
+}
+
+// GetName returns the value of Name.
+func (s *NewAPIKey) GetName() string {
+	return s.Name
+}
+
+// GetScopes returns the value of Scopes.
+func (s *NewAPIKey) GetScopes() []APIKeyScope {
+	return s.Scopes
+}
+
+// GetExpiresAt returns the value of ExpiresAt.
+func (s *NewAPIKey) GetExpiresAt() OptDateTime {
+	return s.ExpiresAt
+}
+
+// GetStoreId returns the value of StoreId.
+func (s *NewAPIKey) GetStoreId() OptInt64 {
+	return s.StoreId
+}
+
+// SetName sets the value of Name.
+func (s *NewAPIKey) SetName(val string) {
+	s.Name = val
+}
+
+// SetScopes sets the value of Scopes.
+func (s *NewAPIKey) SetScopes(val []APIKeyScope) {
+	s.Scopes = val
+}
+
+// SetExpiresAt sets the value of ExpiresAt.
+func (s *NewAPIKey) SetExpiresAt(val OptDateTime) {
+	s.ExpiresAt = val
+}
+
+// SetStoreId sets the value of StoreId.
+func (s *NewAPIKey) SetStoreId(val OptInt64) {
+	s.StoreId = val
+}
+
+// Ref: #/components/schemas/NewPet
+type NewPet struct {
+	Name string    `json:"name"`
+	Tag  OptString `json:"tag"`
+	// A species code from listSpecies.
+	Species OptString `json:"species"`
+	// A breed code of the species; requires species.
+	Breed       OptString `json:"breed"`
+	BirthDate   OptDate   `json:"birthDate"`
+	Sex         OptPetSex `json:"sex"`
+	Description OptString `json:"description"`
+	Price       OptMoney  `json:"price"`
+	// The store the pet belongs to. Always set on a Pet; on a NewPet it defaults to the caller's store,
+	// or for a global caller to the default store (the seeded Main Store).
+	StoreId OptInt64 `json:"storeId"`
+}
+
+// GetName returns the value of Name.
+func (s *NewPet) GetName() string {
+	return s.Name
+}
+
+// GetTag returns the value of Tag.
+func (s *NewPet) GetTag() OptString {
+	return s.Tag
+}
+
+// GetSpecies returns the value of Species.
+func (s *NewPet) GetSpecies() OptString {
+	return s.Species
+}
+
+// GetBreed returns the value of Breed.
+func (s *NewPet) GetBreed() OptString {
+	return s.Breed
+}
+
+// GetBirthDate returns the value of BirthDate.
+func (s *NewPet) GetBirthDate() OptDate {
+	return s.BirthDate
+}
+
+// GetSex returns the value of Sex.
+func (s *NewPet) GetSex() OptPetSex {
+	return s.Sex
+}
+
+// GetDescription returns the value of Description.
+func (s *NewPet) GetDescription() OptString {
+	return s.Description
+}
+
+// GetPrice returns the value of Price.
+func (s *NewPet) GetPrice() OptMoney {
+	return s.Price
+}
+
+// GetStoreId returns the value of StoreId.
+func (s *NewPet) GetStoreId() OptInt64 {
+	return s.StoreId
+}
+
+// SetName sets the value of Name.
+func (s *NewPet) SetName(val string) {
+	s.Name = val
+}
+
+// SetTag sets the value of Tag.
+func (s *NewPet) SetTag(val OptString) {
+	s.Tag = val
+}
+
+// SetSpecies sets the value of Species.
+func (s *NewPet) SetSpecies(val OptString) {
+	s.Species = val
+}
+
+// SetBreed sets the value of Breed.
+func (s *NewPet) SetBreed(val OptString) {
+	s.Breed = val
+}
+
+// SetBirthDate sets the value of BirthDate.
+func (s *NewPet) SetBirthDate(val OptDate) {
+	s.BirthDate = val
+}
+
+// SetSex sets the value of Sex.
+func (s *NewPet) SetSex(val OptPetSex) {
+	s.Sex = val
+}
+
+// SetDescription sets the value of Description.
+func (s *NewPet) SetDescription(val OptString) {
+	s.Description = val
+}
+
+// SetPrice sets the value of Price.
+func (s *NewPet) SetPrice(val OptMoney) {
+	s.Price = val
+}
+
+// SetStoreId sets the value of StoreId.
+func (s *NewPet) SetStoreId(val OptInt64) {
+	s.StoreId = val
+}
+
+// Ref: #/components/schemas/NewStore
+type NewStore struct {
+	Name     string      `json:"name"`
+	Location OptLocation `json:"location"`
+}
+
+// GetName returns the value of Name.
+func (s *NewStore) GetName() string {
+	return s.Name
+}
+
+// GetLocation returns the value of Location.
+func (s *NewStore) GetLocation() OptLocation {
+	return s.Location
+}
+
+// SetName sets the value of Name.
+func (s *NewStore) SetName(val string) {
+	s.Name = val
+}
+
+// SetLocation sets the value of Location.
+func (s *NewStore) SetLocation(val OptLocation) {
+	s.Location = val
+}
+
+// Ref: #/components/schemas/NewWebhook
+type NewWebhook struct {
+	// Absolute http or https URL to POST events to.
+	URL        string             `json:"url"`
+	EventTypes []WebhookEventType `json:"eventTypes"`
+}
+
+// GetURL returns the value of URL.
+func (s *NewWebhook) GetURL() string {
+	return s.URL
+}
+
+// GetEventTypes returns the value of EventTypes.
+func (s *NewWebhook) GetEventTypes() []WebhookEventType {
+	return s.EventTypes
+}
+
+// SetURL sets the value of URL.
+func (s *NewWebhook) SetURL(val string) {
+	s.URL = val
+}
+
+// SetEventTypes sets the value of EventTypes.
+func (s *NewWebhook) SetEventTypes(val []WebhookEventType) {
+	s.EventTypes = val
+}
+
+type OidcCallbackBadRequest Problem
+
+func (*OidcCallbackBadRequest) oidcCallbackRes() {}
+
+// OidcCallbackFound is response for OidcCallback operation.
+type OidcCallbackFound struct {
+	Location url.URL
+}
+
+// GetLocation returns the value of Location.
+func (s *OidcCallbackFound) GetLocation() url.URL {
+	return s.Location
+}
+
+// SetLocation sets the value of Location.
+func (s *OidcCallbackFound) SetLocation(val url.URL) {
+	s.Location = val
+}
+
+func (*OidcCallbackFound) oidcCallbackRes() {}
+
+type OidcCallbackUnauthorized Problem
+
+func (*OidcCallbackUnauthorized) oidcCallbackRes() {}
+
+// NewOptAuditEventDetails returns new OptAuditEventDetails with value set to v.
+func NewOptAuditEventDetails(v AuditEventDetails) OptAuditEventDetails {
+	return OptAuditEventDetails{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptAuditEventDetails is optional AuditEventDetails.
+type OptAuditEventDetails struct {
+	Value AuditEventDetails
+	Set   bool
+}
+
+// IsSet returns true if OptAuditEventDetails was set.
+func (o OptAuditEventDetails) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptAuditEventDetails) Reset() {
+	var v AuditEventDetails
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptAuditEventDetails) SetTo(v AuditEventDetails) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptAuditEventDetails) Get() (v AuditEventDetails, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptAuditEventDetails) Or(d AuditEventDetails) AuditEventDetails {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptAuditOutcome returns new OptAuditOutcome with value set to v.
+func NewOptAuditOutcome(v AuditOutcome) OptAuditOutcome {
+	return OptAuditOutcome{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptAuditOutcome is optional AuditOutcome.
+type OptAuditOutcome struct {
+	Value AuditOutcome
+	Set   bool
+}
+
+// IsSet returns true if OptAuditOutcome was set.
+func (o OptAuditOutcome) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptAuditOutcome) Reset() {
+	var v AuditOutcome
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptAuditOutcome) SetTo(v AuditOutcome) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptAuditOutcome) Get() (v AuditOutcome, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptAuditOutcome) Or(d AuditOutcome) AuditOutcome {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptBool returns new OptBool with value set to v.
+func NewOptBool(v bool) OptBool {
+	return OptBool{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptBool is optional bool.
+type OptBool struct {
+	Value bool
+	Set   bool
+}
+
+// IsSet returns true if OptBool was set.
+func (o OptBool) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptBool) Reset() {
+	var v bool
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptBool) SetTo(v bool) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptBool) Get() (v bool, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptBool) Or(d bool) bool {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptDate returns new OptDate with value set to v.
+func NewOptDate(v time.Time) OptDate {
+	return OptDate{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptDate is optional time.Time.
+type OptDate struct {
+	Value time.Time
+	Set   bool
+}
+
+// IsSet returns true if OptDate was set.
+func (o OptDate) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptDate) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptDate) SetTo(v time.Time) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptDate) Get() (v time.Time, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptDate) Or(d time.Time) time.Time {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptDateTime returns new OptDateTime with value set to v.
+func NewOptDateTime(v time.Time) OptDateTime {
+	return OptDateTime{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptDateTime is optional time.Time.
+type OptDateTime struct {
+	Value time.Time
+	Set   bool
+}
+
+// IsSet returns true if OptDateTime was set.
+func (o OptDateTime) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptDateTime) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptDateTime) SetTo(v time.Time) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptDateTime) Get() (v time.Time, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptDateTime) Or(d time.Time) time.Time {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptFloat64 returns new OptFloat64 with value set to v.
+func NewOptFloat64(v float64) OptFloat64 {
+	return OptFloat64{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptFloat64 is optional float64.
+type OptFloat64 struct {
+	Value float64
+	Set   bool
+}
+
+// IsSet returns true if OptFloat64 was set.
+func (o OptFloat64) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptFloat64) Reset() {
+	var v float64
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptFloat64) SetTo(v float64) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptFloat64) Get() (v float64, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptFloat64) Or(d float64) float64 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptImportMode returns new OptImportMode with value set to v.
+func NewOptImportMode(v ImportMode) OptImportMode {
+	return OptImportMode{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptImportMode is optional ImportMode.
+type OptImportMode struct {
+	Value ImportMode
+	Set   bool
+}
+
+// IsSet returns true if OptImportMode was set.
+func (o OptImportMode) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptImportMode) Reset() {
+	var v ImportMode
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptImportMode) SetTo(v ImportMode) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptImportMode) Get() (v ImportMode, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptImportMode) Or(d ImportMode) ImportMode {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptInt32 returns new OptInt32 with value set to v.
+func NewOptInt32(v int32) OptInt32 {
+	return OptInt32{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptInt32 is optional int32.
+type OptInt32 struct {
+	Value int32
+	Set   bool
+}
+
+// IsSet returns true if OptInt32 was set.
+func (o OptInt32) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInt32) Reset() {
+	var v int32
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptInt32) SetTo(v int32) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt32) Get() (v int32, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptInt32) Or(d int32) int32 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptInt64 returns new OptInt64 with value set to v.
+func NewOptInt64(v int64) OptInt64 {
+	return OptInt64{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptInt64 is optional int64.
+type OptInt64 struct {
+	Value int64
+	Set   bool
+}
+
+// IsSet returns true if OptInt64 was set.
+func (o OptInt64) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInt64) Reset() {
+	var v int64
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptInt64) SetTo(v int64) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt64) Get() (v int64, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptInt64) Or(d int64) int64 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptLocation returns new OptLocation with value set to v.
+func NewOptLocation(v Location) OptLocation {
+	return OptLocation{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptLocation is optional Location.
+type OptLocation struct {
+	Value Location
+	Set   bool
+}
+
+// IsSet returns true if OptLocation was set.
+func (o OptLocation) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptLocation) Reset() {
+	var v Location
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptLocation) SetTo(v Location) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptLocation) Get() (v Location, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptLocation) Or(d Location) Location {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptMoney returns new OptMoney with value set to v.
+func NewOptMoney(v Money) OptMoney {
+	return OptMoney{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptMoney is optional Money.
+type OptMoney struct {
+	Value Money
+	Set   bool
+}
+
+// IsSet returns true if OptMoney was set.
+func (o OptMoney) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptMoney) Reset() {
+	var v Money
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptMoney) SetTo(v Money) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptMoney) Get() (v Money, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptMoney) Or(d Money) Money {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptNilInt64 returns new OptNilInt64 with value set to v.
+func NewOptNilInt64(v int64) OptNilInt64 {
+	return OptNilInt64{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptNilInt64 is optional nullable int64.
+type OptNilInt64 struct {
+	Value int64
+	Set   bool
+	Null  bool
+}
+
+// IsSet returns true if OptNilInt64 was set.
+func (o OptNilInt64) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptNilInt64) Reset() {
+	var v int64
+	o.Value = v
+	o.Set = false
+	o.Null = false
+}
+
+// SetTo sets value to v.
+func (o *OptNilInt64) SetTo(v int64) {
+	o.Set = true
+	o.Null = false
+	o.Value = v
+}
+
+// IsNull returns true if value is Null.
+func (o OptNilInt64) IsNull() bool { return o.Null }
+
+// SetToNull sets value to null.
+func (o *OptNilInt64) SetToNull() {
+	o.Set = true
+	o.Null = true
+	var v int64
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptNilInt64) Get() (v int64, ok bool) {
+	if o.Null {
+		return v, false
+	}
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptNilInt64) Or(d int64) int64 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptPetSex returns new OptPetSex with value set to v.
+func NewOptPetSex(v PetSex) OptPetSex {
+	return OptPetSex{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptPetSex is optional PetSex.
+type OptPetSex struct {
+	Value PetSex
+	Set   bool
+}
+
+// IsSet returns true if OptPetSex was set.
+func (o OptPetSex) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptPetSex) Reset() {
+	var v PetSex
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptPetSex) SetTo(v PetSex) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptPetSex) Get() (v PetSex, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptPetSex) Or(d PetSex) PetSex {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptString returns new OptString with value set to v.
+func NewOptString(v string) OptString {
+	return OptString{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptString is optional string.
+type OptString struct {
+	Value string
+	Set   bool
+}
+
+// IsSet returns true if OptString was set.
+func (o OptString) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptString) Reset() {
+	var v string
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptString) SetTo(v string) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptString) Get() (v string, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptString) Or(d string) string {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptWebhookDeliveryStatus returns new OptWebhookDeliveryStatus with value set to v.
+func NewOptWebhookDeliveryStatus(v WebhookDeliveryStatus) OptWebhookDeliveryStatus {
+	return OptWebhookDeliveryStatus{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptWebhookDeliveryStatus is optional WebhookDeliveryStatus.
+type OptWebhookDeliveryStatus struct {
+	Value WebhookDeliveryStatus
+	Set   bool
+}
+
+// IsSet returns true if OptWebhookDeliveryStatus was set.
+func (o OptWebhookDeliveryStatus) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptWebhookDeliveryStatus) Reset() {
+	var v WebhookDeliveryStatus
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptWebhookDeliveryStatus) SetTo(v WebhookDeliveryStatus) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptWebhookDeliveryStatus) Get() (v WebhookDeliveryStatus, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptWebhookDeliveryStatus) Or(d WebhookDeliveryStatus) WebhookDeliveryStatus {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// Merged schema.
+// Ref: #/components/schemas/Pet
+type Pet struct {
+	Name string    `json:"name"`
+	Tag  OptString `json:"tag"`
+	// A species code from listSpecies.
+	Species OptString `json:"species"`
+	// A breed code of the species; requires species.
+	Breed       OptString `json:"breed"`
+	BirthDate   OptDate   `json:"birthDate"`
+	Sex         OptPetSex `json:"sex"`
+	Description OptString `json:"description"`
+	Price       OptMoney  `json:"price"`
+	// The store the pet belongs to. Always set on a Pet; on a NewPet it defaults to the caller's store,
+	// or for a global caller to the default store (the seeded Main Store).
+	StoreId OptInt64 `json:"storeId"`
+	ID      int64    `json:"id"`
+	// Distance of the pet's store from the near point of findPets or exportPets, in kilometres; set only
+	// when near is given.
+	DistanceKm OptFloat64 `json:"distanceKm"`
+	// When the pet's current hold lapses; set only while the pet is reserved.
+	ReservedUntil OptDateTime `json:"reservedUntil"`
+}
+
+// GetName returns the value of Name.
+func (s *Pet) GetName() string {
+	return s.Name
+}
+
+// GetTag returns the value of Tag.
+func (s *Pet) GetTag() OptString {
+	return s.Tag
+}
+
+// GetSpecies returns the value of Species.
+func (s *Pet) GetSpecies() OptString {
+	return s.Species
+}
+
+// GetBreed returns the value of Breed.
+func (s *Pet) GetBreed() OptString {
+	return s.Breed
+}
+
+// GetBirthDate returns the value of BirthDate.
+func (s *Pet) GetBirthDate() OptDate {
+	return s.BirthDate
+}
+
+// GetSex returns the value of Sex.
+func (s *Pet) GetSex() OptPetSex {
+	return s.Sex
+}
+
+// GetDescription returns the value of Description.
+func (s *Pet) GetDescription() OptString {
+	return s.Description
+}
+
+// GetPrice returns the value of Price.
+func (s *Pet) GetPrice() OptMoney {
+	return s.Price
+}
+
+// GetStoreId returns the value of StoreId.
+func (s *Pet) GetStoreId() OptInt64 {
+	return s.StoreId
+}
+
+// GetID returns the value of ID.
+func (s *Pet) GetID() int64 {
+	return s.ID
+}
+
+// GetDistanceKm returns the value of DistanceKm.
+func (s *Pet) GetDistanceKm() OptFloat64 {
+	return s.DistanceKm
+}
+
+// GetReservedUntil returns the value of ReservedUntil.
+func (s *Pet) GetReservedUntil() OptDateTime {
+	return s.ReservedUntil
+}
+
+// SetName sets the value of Name.
+func (s *Pet) SetName(val string) {
+	s.Name = val
+}
+
+// SetTag sets the value of Tag.
+func (s *Pet) SetTag(val OptString) {
+	s.Tag = val
+}
+
+// SetSpecies sets the value of Species.
+func (s *Pet) SetSpecies(val OptString) {
+	s.Species = val
+}
+
+// SetBreed sets the value of Breed.
+func (s *Pet) SetBreed(val OptString) {
+	s.Breed = val
+}
+
+// SetBirthDate sets the value of BirthDate.
+func (s *Pet) SetBirthDate(val OptDate) {
+	s.BirthDate = val
+}
+
+// SetSex sets the value of Sex.
+func (s *Pet) SetSex(val OptPetSex) {
+	s.Sex = val
+}
+
+// SetDescription sets the value of Description.
+func (s *Pet) SetDescription(val OptString) {
+	s.Description = val
+}
+
+// SetPrice sets the value of Price.
+func (s *Pet) SetPrice(val OptMoney) {
+	s.Price = val
+}
+
+// SetStoreId sets the value of StoreId.
+func (s *Pet) SetStoreId(val OptInt64) {
+	s.StoreId = val
+}
+
+// SetID sets the value of ID.
+func (s *Pet) SetID(val int64) {
+	s.ID = val
+}
+
+// SetDistanceKm sets the value of DistanceKm.
+func (s *Pet) SetDistanceKm(val OptFloat64) {
+	s.DistanceKm = val
+}
+
+// SetReservedUntil sets the value of ReservedUntil.
+func (s *Pet) SetReservedUntil(val OptDateTime) {
+	s.ReservedUntil = val
+}
+
+func (*Pet) addPetRes()     {}
+func (*Pet) restorePetRes() {}
+
+// Ref: #/components/schemas/PetRevision
+type PetRevision struct {
+	ID     int64             `json:"id"`
+	PetId  int64             `json:"petId"`
+	Action PetRevisionAction `json:"action"`
+	// The pet's name after the change.
+	Name string `json:"name"`
+	// The pet's tag after the change.
+	Tag           OptString `json:"tag"`
+	ActorUserId   OptInt64  `json:"actorUserId"`
+	ActorApiKeyId OptInt64  `json:"actorApiKeyId"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// GetID returns the value of ID.
+func (s *PetRevision) GetID() int64 {
+	return s.ID
+}
+
+// GetPetId returns the value of PetId.
+func (s *PetRevision) GetPetId() int64 {
+	return s.PetId
+}
+
+// GetAction returns the value of Action.
+func (s *PetRevision) GetAction() PetRevisionAction {
+	return s.Action
+}
+
+// GetName returns the value of Name.
+func (s *PetRevision) GetName() string {
+	return s.Name
+}
+
+// GetTag returns the value of Tag.
+func (s *PetRevision) GetTag() OptString {
+	return s.Tag
+}
+
+// GetActorUserId returns the value of ActorUserId.
+func (s *PetRevision) GetActorUserId() OptInt64 {
+	return s.ActorUserId
+}
+
+// GetActorApiKeyId returns the value of ActorApiKeyId.
+func (s *PetRevision) GetActorApiKeyId() OptInt64 {
+	return s.ActorApiKeyId
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *PetRevision) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// SetID sets the value of ID.
+func (s *PetRevision) SetID(val int64) {
+	s.ID = val
+}
+
+// SetPetId sets the value of PetId.
+func (s *PetRevision) SetPetId(val int64) {
+	s.PetId = val
+}
+
+// SetAction sets the value of Action.
+func (s *PetRevision) SetAction(val PetRevisionAction) {
+	s.Action = val
+}
+
+// SetName sets the value of Name.
+func (s *PetRevision) SetName(val string) {
+	s.Name = val
+}
+
+// SetTag sets the value of Tag.
+func (s *PetRevision) SetTag(val OptString) {
+	s.Tag = val
+}
+
+// SetActorUserId sets the value of ActorUserId.
+func (s *PetRevision) SetActorUserId(val OptInt64) {
+	s.ActorUserId = val
+}
+
+// SetActorApiKeyId sets the value of ActorApiKeyId.
+func (s *PetRevision) SetActorApiKeyId(val OptInt64) {
+	s.ActorApiKeyId = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *PetRevision) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+type PetRevisionAction string
+
+const (
+	PetRevisionActionCreate            PetRevisionAction = "create"
+	PetRevisionActionDelete            PetRevisionAction = "delete"
+	PetRevisionActionRestore           PetRevisionAction = "restore"
+	PetRevisionActionReserve           PetRevisionAction = "reserve"
+	PetRevisionActionCancelReservation PetRevisionAction = "cancel_reservation"
+	PetRevisionActionExpireReservation PetRevisionAction = "expire_reservation"
+)
+
+// AllValues returns all PetRevisionAction values.
+func (PetRevisionAction) AllValues() []PetRevisionAction {
+	return []PetRevisionAction{
+		PetRevisionActionCreate,
+		PetRevisionActionDelete,
+		PetRevisionActionRestore,
+		PetRevisionActionReserve,
+		PetRevisionActionCancelReservation,
+		PetRevisionActionExpireReservation,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s PetRevisionAction) MarshalText() ([]byte, error) {
+	switch s {
+	case PetRevisionActionCreate:
+		return []byte(s), nil
+	case PetRevisionActionDelete:
+		return []byte(s), nil
+	case PetRevisionActionRestore:
+		return []byte(s), nil
+	case PetRevisionActionReserve:
+		return []byte(s), nil
+	case PetRevisionActionCancelReservation:
+		return []byte(s), nil
+	case PetRevisionActionExpireReservation:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *PetRevisionAction) UnmarshalText(data []byte) error {
+	switch PetRevisionAction(data) {
+	case PetRevisionActionCreate:
+		*s = PetRevisionActionCreate
+		return nil
+	case PetRevisionActionDelete:
+		*s = PetRevisionActionDelete
+		return nil
+	case PetRevisionActionRestore:
+		*s = PetRevisionActionRestore
+		return nil
+	case PetRevisionActionReserve:
+		*s = PetRevisionActionReserve
+		return nil
+	case PetRevisionActionCancelReservation:
+		*s = PetRevisionActionCancelReservation
+		return nil
+	case PetRevisionActionExpireReservation:
+		*s = PetRevisionActionExpireReservation
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/PetSex
+type PetSex string
+
+const (
+	PetSexFemale PetSex = "female"
+	PetSexMale   PetSex = "male"
+)
+
+// AllValues returns all PetSex values.
+func (PetSex) AllValues() []PetSex {
+	return []PetSex{
+		PetSexFemale,
+		PetSexMale,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s PetSex) MarshalText() ([]byte, error) {
+	switch s {
+	case PetSexFemale:
+		return []byte(s), nil
+	case PetSexMale:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *PetSex) UnmarshalText(data []byte) error {
+	switch PetSex(data) {
+	case PetSexFemale:
+		*s = PetSexFemale
+		return nil
+	case PetSexMale:
+		*s = PetSexMale
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// An RFC 7807 problem details object. `type` identifies the kind
+// of problem and is stable; `title` is its short summary and
+// `detail` explains this occurrence. Internal errors never expose
+// their underlying message.
+// Ref: #/components/schemas/Problem
+type Problem struct {
+	// A URI identifying the problem, e.g. `urn:petstore:problem:not-found`.
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int32  `json:"status"`
+	Detail string `json:"detail"`
+	// The request's X-Correlation-ID, for matching server logs.
+	CorrelationId OptString `json:"correlationId"`
+	// Invalid fields, for validation problems.
+	Errors []ProblemField `json:"errors"`
+}
+
+// GetType returns the value of Type.
+func (s *Problem) GetType() string {
+	return s.Type
+}
+
+// GetTitle returns the value of Title.
+func (s *Problem) GetTitle() string {
+	return s.Title
+}
+
+// GetStatus returns the value of Status.
+func (s *Problem) GetStatus() int32 {
+	return s.Status
+}
+
+// GetDetail returns the value of Detail.
+func (s *Problem) GetDetail() string {
+	return s.Detail
+}
+
+// GetCorrelationId returns the value of CorrelationId.
+func (s *Problem) GetCorrelationId() OptString {
+	return s.CorrelationId
+}
+
+// GetErrors returns the value of Errors.
+func (s *Problem) GetErrors() []ProblemField {
+	return s.Errors
+}
+
+// SetType sets the value of Type.
+func (s *Problem) SetType(val string) {
+	s.Type = val
+}
+
+// SetTitle sets the value of Title.
+func (s *Problem) SetTitle(val string) {
+	s.Title = val
+}
+
+// SetStatus sets the value of Status.
+func (s *Problem) SetStatus(val int32) {
+	s.Status = val
+}
+
+// SetDetail sets the value of Detail.
+func (s *Problem) SetDetail(val string) {
+	s.Detail = val
+}
+
+// SetCorrelationId sets the value of CorrelationId.
+func (s *Problem) SetCorrelationId(val OptString) {
+	s.CorrelationId = val
+}
+
+// SetErrors sets the value of Errors.
+func (s *Problem) SetErrors(val []ProblemField) {
+	s.Errors = val
+}
+
+func (*Problem) cancelReservationRes() {}
+func (*Problem) streamPetEventsRes()   {}
+
+// Ref: #/components/schemas/ProblemField
+type ProblemField struct {
+	// Path to the invalid value: a dotted body path such as
+	// `scopes[0]`, or `query.limit` / `path.id` for parameters.
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// GetField returns the value of Field.
+func (s *ProblemField) GetField() string {
+	return s.Field
+}
+
+// GetMessage returns the value of Message.
+func (s *ProblemField) GetMessage() string {
+	return s.Message
+}
+
+// SetField sets the value of Field.
+func (s *ProblemField) SetField(val string) {
+	s.Field = val
+}
+
+// SetMessage sets the value of Message.
+func (s *ProblemField) SetMessage(val string) {
+	s.Message = val
+}
+
+// ProblemStatusCode wraps Problem with StatusCode.
+type ProblemStatusCode struct {
+	StatusCode int
+	Response   Problem
+}
+
+// GetStatusCode returns the value of StatusCode.
+func (s *ProblemStatusCode) GetStatusCode() int {
+	return s.StatusCode
+}
+
+// GetResponse returns the value of Response.
+func (s *ProblemStatusCode) GetResponse() Problem {
+	return s.Response
+}
+
+// SetStatusCode sets the value of StatusCode.
+func (s *ProblemStatusCode) SetStatusCode(val int) {
+	s.StatusCode = val
+}
+
+// SetResponse sets the value of Response.
+func (s *ProblemStatusCode) SetResponse(val Problem) {
+	s.Response = val
+}
+
+type RedeliverWebhookDeliveryConflict Problem
+
+func (*RedeliverWebhookDeliveryConflict) redeliverWebhookDeliveryRes() {}
+
+type RedeliverWebhookDeliveryRequestEntityTooLarge Problem
+
+func (*RedeliverWebhookDeliveryRequestEntityTooLarge) redeliverWebhookDeliveryRes() {}
+
+type RedeliverWebhookDeliveryUnprocessableEntity Problem
+
+func (*RedeliverWebhookDeliveryUnprocessableEntity) redeliverWebhookDeliveryRes() {}
+
+// Ref: #/components/schemas/RegisterRequest
+type RegisterRequest struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// GetName returns the value of Name.
+func (s *RegisterRequest) GetName() string {
+	return s.Name
+}
+
+// GetEmail returns the value of Email.
+func (s *RegisterRequest) GetEmail() string {
+	return s.Email
+}
+
+// GetPassword returns the value of Password.
+func (s *RegisterRequest) GetPassword() string {
+	return s.Password
+}
+
+// SetName sets the value of Name.
+func (s *RegisterRequest) SetName(val string) {
+	s.Name = val
+}
+
+// SetEmail sets the value of Email.
+func (s *RegisterRequest) SetEmail(val string) {
+	s.Email = val
+}
+
+// SetPassword sets the value of Password.
+func (s *RegisterRequest) SetPassword(val string) {
+	s.Password = val
+}
+
+type RegisterUserConflict Problem
+
+func (*RegisterUserConflict) registerUserRes() {}
+
+type RegisterUserRequestEntityTooLarge Problem
+
+func (*RegisterUserRequestEntityTooLarge) registerUserRes() {}
+
+type RegisterUserUnprocessableEntity Problem
+
+func (*RegisterUserUnprocessableEntity) registerUserRes() {}
+
+// RequestEmailChangeAccepted is response for RequestEmailChange operation.
+type RequestEmailChangeAccepted struct{}
+
+func (*RequestEmailChangeAccepted) requestEmailChangeRes() {}
+
+type RequestEmailChangeBadRequest Problem
+
+func (*RequestEmailChangeBadRequest) requestEmailChangeRes() {}
+
+type RequestEmailChangeConflict Problem
+
+func (*RequestEmailChangeConflict) requestEmailChangeRes() {}
+
+type RequestEmailChangeRequestEntityTooLarge Problem
+
+func (*RequestEmailChangeRequestEntityTooLarge) requestEmailChangeRes() {}
+
+type RequestEmailChangeUnprocessableEntity Problem
+
+func (*RequestEmailChangeUnprocessableEntity) requestEmailChangeRes() {}
+
+// ResendVerificationEmailAccepted is response for ResendVerificationEmail operation.
+type ResendVerificationEmailAccepted struct{}
+
+func (*ResendVerificationEmailAccepted) resendVerificationEmailRes() {}
+
+type ResendVerificationEmailConflict Problem
+
+func (*ResendVerificationEmailConflict) resendVerificationEmailRes() {}
+
+type ResendVerificationEmailRequestEntityTooLarge Problem
+
+func (*ResendVerificationEmailRequestEntityTooLarge) resendVerificationEmailRes() {}
+
+type ResendVerificationEmailTooManyRequests Problem
+
+func (*ResendVerificationEmailTooManyRequests) resendVerificationEmailRes() {}
+
+type ResendVerificationEmailUnprocessableEntity Problem
+
+func (*ResendVerificationEmailUnprocessableEntity) resendVerificationEmailRes() {}
+
+// Ref: #/components/schemas/Reservation
+type Reservation struct {
+	ID          int64             `json:"id"`
+	PetId       int64             `json:"petId"`
+	Status      ReservationStatus `json:"status"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	ExpiresAt   time.Time         `json:"expiresAt"`
+	CancelledAt OptDateTime       `json:"cancelledAt"`
+}
+
+// GetID returns the value of ID.
+func (s *Reservation) GetID() int64 {
+	return s.ID
+}
+
+// GetPetId returns the value of PetId.
+func (s *Reservation) GetPetId() int64 {
+	return s.PetId
+}
+
+// GetStatus returns the value of Status.
+func (s *Reservation) GetStatus() ReservationStatus {
+	return s.Status
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *Reservation) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// GetExpiresAt returns the value of ExpiresAt.
+func (s *Reservation) GetExpiresAt() time.Time {
+	return s.ExpiresAt
+}
+
+// GetCancelledAt returns the value of CancelledAt.
+func (s *Reservation) GetCancelledAt() OptDateTime {
+	return s.CancelledAt
+}
+
+// SetID sets the value of ID.
+func (s *Reservation) SetID(val int64) {
+	s.ID = val
+}
+
+// SetPetId sets the value of PetId.
+func (s *Reservation) SetPetId(val int64) {
+	s.PetId = val
+}
+
+// SetStatus sets the value of Status.
+func (s *Reservation) SetStatus(val ReservationStatus) {
+	s.Status = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *Reservation) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// SetExpiresAt sets the value of ExpiresAt.
+func (s *Reservation) SetExpiresAt(val time.Time) {
+	s.ExpiresAt = val
+}
+
+// SetCancelledAt sets the value of CancelledAt.
+func (s *Reservation) SetCancelledAt(val OptDateTime) {
+	s.CancelledAt = val
+}
+
+func (*Reservation) reservePetRes() {}
+
+// Ref: #/components/schemas/ReservationStatus
+type ReservationStatus string
+
+const (
+	ReservationStatusActive    ReservationStatus = "active"
+	ReservationStatusCancelled ReservationStatus = "cancelled"
+	ReservationStatusExpired   ReservationStatus = "expired"
+)
+
+// AllValues returns all ReservationStatus values.
+func (ReservationStatus) AllValues() []ReservationStatus {
+	return []ReservationStatus{
+		ReservationStatusActive,
+		ReservationStatusCancelled,
+		ReservationStatusExpired,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ReservationStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case ReservationStatusActive:
+		return []byte(s), nil
+	case ReservationStatusCancelled:
+		return []byte(s), nil
+	case ReservationStatusExpired:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ReservationStatus) UnmarshalText(data []byte) error {
+	switch ReservationStatus(data) {
+	case ReservationStatusActive:
+		*s = ReservationStatusActive
+		return nil
+	case ReservationStatusCancelled:
+		*s = ReservationStatusCancelled
+		return nil
+	case ReservationStatusExpired:
+		*s = ReservationStatusExpired
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+type ReservePetConflict Problem
+
+func (*ReservePetConflict) reservePetRes() {}
+
+type ReservePetForbidden Problem
+
+func (*ReservePetForbidden) reservePetRes() {}
+
+type ReservePetNotFound Problem
+
+func (*ReservePetNotFound) reservePetRes() {}
+
+type ReservePetRequestEntityTooLarge Problem
+
+func (*ReservePetRequestEntityTooLarge) reservePetRes() {}
+
+type ReservePetTooManyRequests Problem
+
+func (*ReservePetTooManyRequests) reservePetRes() {}
+
+type ReservePetUnprocessableEntity Problem
+
+func (*ReservePetUnprocessableEntity) reservePetRes() {}
+
+type ResetPasswordBadRequest Problem
+
+func (*ResetPasswordBadRequest) resetPasswordRes() {}
+
+type ResetPasswordConflict Problem
+
+func (*ResetPasswordConflict) resetPasswordRes() {}
+
+// ResetPasswordNoContent is response for ResetPassword operation.
+type ResetPasswordNoContent struct{}
+
+func (*ResetPasswordNoContent) resetPasswordRes() {}
+
+// Ref: #/components/schemas/ResetPasswordRequest
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// GetToken returns the value of Token.
+func (s *ResetPasswordRequest) GetToken() string {
+	return s.Token
+}
+
+// GetPassword returns the value of Password.
+func (s *ResetPasswordRequest) GetPassword() string {
+	return s.Password
+}
+
+// SetToken sets the value of Token.
+func (s *ResetPasswordRequest) SetToken(val string) {
+	s.Token = val
+}
+
+// SetPassword sets the value of Password.
+func (s *ResetPasswordRequest) SetPassword(val string) {
+	s.Password = val
+}
+
+type ResetPasswordRequestEntityTooLarge Problem
+
+func (*ResetPasswordRequestEntityTooLarge) resetPasswordRes() {}
+
+type ResetPasswordUnprocessableEntity Problem
+
+func (*ResetPasswordUnprocessableEntity) resetPasswordRes() {}
+
+type RestorePetConflict Problem
+
+func (*RestorePetConflict) restorePetRes() {}
+
+type RestorePetRequestEntityTooLarge Problem
+
+func (*RestorePetRequestEntityTooLarge) restorePetRes() {}
+
+type RestorePetUnprocessableEntity Problem
+
+func (*RestorePetUnprocessableEntity) restorePetRes() {}
+
+// RevokeAPIKeyNoContent is response for RevokeAPIKey operation.
+type RevokeAPIKeyNoContent struct{}
+
+// Ref: #/components/schemas/Species
+type Species struct {
+	Code   string  `json:"code"`
+	Name   string  `json:"name"`
+	Breeds []Breed `json:"breeds"`
+}
+
+// GetCode returns the value of Code.
+func (s *Species) GetCode() string {
+	return s.Code
+}
+
+// GetName returns the value of Name.
+func (s *Species) GetName() string {
+	return s.Name
+}
+
+// GetBreeds returns the value of Breeds.
+func (s *Species) GetBreeds() []Breed {
+	return s.Breeds
+}
+
+// SetCode sets the value of Code.
+func (s *Species) SetCode(val string) {
+	s.Code = val
+}
+
+// SetName sets the value of Name.
+func (s *Species) SetName(val string) {
+	s.Name = val
+}
+
+// SetBreeds sets the value of Breeds.
+func (s *Species) SetBreeds(val []Breed) {
+	s.Breeds = val
+}
+
+// StartOIDCLoginFound is response for StartOIDCLogin operation.
+type StartOIDCLoginFound struct {
+	Location url.URL
+}
+
+// GetLocation returns the value of Location.
+func (s *StartOIDCLoginFound) GetLocation() url.URL {
+	return s.Location
+}
+
+// SetLocation sets the value of Location.
+func (s *StartOIDCLoginFound) SetLocation(val url.URL) {
+	s.Location = val
+}
+
+// Ref: #/components/schemas/Store
+type Store struct {
+	ID        int64       `json:"id"`
+	Name      string      `json:"name"`
+	Location  OptLocation `json:"location"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+// GetID returns the value of ID.
+func (s *Store) GetID() int64 {
+	return s.ID
+}
+
+// GetName returns the value of Name.
+func (s *Store) GetName() string {
+	return s.Name
+}
+
+// GetLocation returns the value of Location.
+func (s *Store) GetLocation() OptLocation {
+	return s.Location
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *Store) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// SetID sets the value of ID.
+func (s *Store) SetID(val int64) {
+	s.ID = val
+}
+
+// SetName sets the value of Name.
+func (s *Store) SetName(val string) {
+	s.Name = val
+}
+
+// SetLocation sets the value of Location.
+func (s *Store) SetLocation(val OptLocation) {
+	s.Location = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *Store) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+func (*Store) createStoreRes() {}
+
+type StreamPetEventsOK struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s StreamPetEventsOK) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+// StreamPetEventsOKHeaders wraps StreamPetEventsOK with response headers.
+type StreamPetEventsOKHeaders struct {
+	CacheControl string
+	Response     StreamPetEventsOK
+}
+
+// GetCacheControl returns the value of CacheControl.
+func (s *StreamPetEventsOKHeaders) GetCacheControl() string {
+	return s.CacheControl
+}
+
+// GetResponse returns the value of Response.
+func (s *StreamPetEventsOKHeaders) GetResponse() StreamPetEventsOK {
+	return s.Response
+}
+
+// SetCacheControl sets the value of CacheControl.
+func (s *StreamPetEventsOKHeaders) SetCacheControl(val string) {
+	s.CacheControl = val
+}
+
+// SetResponse sets the value of Response.
+func (s *StreamPetEventsOKHeaders) SetResponse(val StreamPetEventsOK) {
+	s.Response = val
+}
+
+func (*StreamPetEventsOKHeaders) streamPetEventsRes() {}
+
+type UnlockUserConflict Problem
+
+func (*UnlockUserConflict) unlockUserRes() {}
+
+// UnlockUserNoContent is response for UnlockUser operation.
+type UnlockUserNoContent struct{}
+
+func (*UnlockUserNoContent) unlockUserRes() {}
+
+type UnlockUserRequestEntityTooLarge Problem
+
+func (*UnlockUserRequestEntityTooLarge) unlockUserRes() {}
+
+type UnlockUserUnprocessableEntity Problem
+
+func (*UnlockUserUnprocessableEntity) unlockUserRes() {}
+
+// Ref: #/components/schemas/UpdateUserRequest
+type UpdateUserRequest struct {
+	Role     OptString `json:"role"`
+	Disabled OptBool   `json:"disabled"`
+	// Confine the user to a store, or null to make them global.
+	StoreId OptNilInt64 `json:"storeId"`
+}
+
+// GetRole returns the value of Role.
+func (s *UpdateUserRequest) GetRole() OptString {
+	return s.Role
+}
+
+// GetDisabled returns the value of Disabled.
+func (s *UpdateUserRequest) GetDisabled() OptBool {
+	return s.Disabled
+}
+
+// GetStoreId returns the value of StoreId.
+func (s *UpdateUserRequest) GetStoreId() OptNilInt64 {
+	return s.StoreId
+}
+
+// SetRole sets the value of Role.
+func (s *UpdateUserRequest) SetRole(val OptString) {
+	s.Role = val
+}
+
+// SetDisabled sets the value of Disabled.
+func (s *UpdateUserRequest) SetDisabled(val OptBool) {
+	s.Disabled = val
+}
+
+// SetStoreId sets the value of StoreId.
+func (s *UpdateUserRequest) SetStoreId(val OptNilInt64) {
+	s.StoreId = val
+}
+
+type VerifyEmailBadRequest Problem
+
+func (*VerifyEmailBadRequest) verifyEmailRes() {}
+
+type VerifyEmailConflict Problem
+
+func (*VerifyEmailConflict) verifyEmailRes() {}
+
+// VerifyEmailNoContent is response for VerifyEmail operation.
+type VerifyEmailNoContent struct{}
+
+func (*VerifyEmailNoContent) verifyEmailRes() {}
+
+// Ref: #/components/schemas/VerifyEmailRequest
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+// GetToken returns the value of Token.
+func (s *VerifyEmailRequest) GetToken() string {
+	return s.Token
+}
+
+// SetToken sets the value of Token.
+func (s *VerifyEmailRequest) SetToken(val string) {
+	s.Token = val
+}
+
+type VerifyEmailRequestEntityTooLarge Problem
+
+func (*VerifyEmailRequestEntityTooLarge) verifyEmailRes() {}
+
+type VerifyEmailUnprocessableEntity Problem
+
+func (*VerifyEmailUnprocessableEntity) verifyEmailRes() {}
+
+type VerifyMFABadRequest Problem
+
+func (*VerifyMFABadRequest) verifyMFARes() {}
+
+type VerifyMFAConflict Problem
+
+func (*VerifyMFAConflict) verifyMFARes() {}
+
+type VerifyMFARequestEntityTooLarge Problem
+
+func (*VerifyMFARequestEntityTooLarge) verifyMFARes() {}
+
+type VerifyMFAUnauthorized Problem
+
+func (*VerifyMFAUnauthorized) verifyMFARes() {}
+
+type VerifyMFAUnprocessableEntity Problem
+
+func (*VerifyMFAUnprocessableEntity) verifyMFARes() {}
+
+// Ref: #/components/schemas/Webhook
+type Webhook struct {
+	ID         int64              `json:"id"`
+	URL        string             `json:"url"`
+	EventTypes []WebhookEventType `json:"eventTypes"`
+	// Admin who created the webhook.
+	CreatedBy int64     `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// GetID returns the value of ID.
+func (s *Webhook) GetID() int64 {
+	return s.ID
+}
+
+// GetURL returns the value of URL.
+func (s *Webhook) GetURL() string {
+	return s.URL
+}
+
+// GetEventTypes returns the value of EventTypes.
+func (s *Webhook) GetEventTypes() []WebhookEventType {
+	return s.EventTypes
+}
+
+// GetCreatedBy returns the value of CreatedBy.
+func (s *Webhook) GetCreatedBy() int64 {
+	return s.CreatedBy
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *Webhook) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// SetID sets the value of ID.
+func (s *Webhook) SetID(val int64) {
+	s.ID = val
+}
+
+// SetURL sets the value of URL.
+func (s *Webhook) SetURL(val string) {
+	s.URL = val
+}
+
+// SetEventTypes sets the value of EventTypes.
+func (s *Webhook) SetEventTypes(val []WebhookEventType) {
+	s.EventTypes = val
+}
+
+// SetCreatedBy sets the value of CreatedBy.
+func (s *Webhook) SetCreatedBy(val int64) {
+	s.CreatedBy = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *Webhook) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+// Ref: #/components/schemas/WebhookDelivery
+type WebhookDelivery struct {
+	ID        int64 `json:"id"`
+	WebhookId int64 `json:"webhookId"`
+	// The pet revision reported, sent as the payload id.
+	EventId   int64                 `json:"eventId"`
+	EventType WebhookEventType      `json:"eventType"`
+	Status    WebhookDeliveryStatus `json:"status"`
+	// Attempts made since the delivery was queued.
+	Attempts int32 `json:"attempts"`
+	// When a pending delivery is next attempted.
+	NextAttemptAt time.Time   `json:"nextAttemptAt"`
+	LastAttemptAt OptDateTime `json:"lastAttemptAt"`
+	// HTTP status of the last attempt, absent when it got no response.
+	LastStatusCode OptInt32 `json:"lastStatusCode"`
+	// Why the last attempt failed.
+	LastError   OptString   `json:"lastError"`
+	DeliveredAt OptDateTime `json:"deliveredAt"`
+	CreatedAt   time.Time   `json:"createdAt"`
+}
+
+// GetID returns the value of ID.
+func (s *WebhookDelivery) GetID() int64 {
+	return s.ID
+}
+
+// GetWebhookId returns the value of WebhookId.
+func (s *WebhookDelivery) GetWebhookId() int64 {
+	return s.WebhookId
+}
+
+// GetEventId returns the value of EventId.
+func (s *WebhookDelivery) GetEventId() int64 {
+	return s.EventId
+}
+
+// GetEventType returns the value of EventType.
+func (s *WebhookDelivery) GetEventType() WebhookEventType {
+	return s.EventType
+}
+
+// GetStatus returns the value of Status.
+func (s *WebhookDelivery) GetStatus() WebhookDeliveryStatus {
+	return s.Status
+}
+
+// GetAttempts returns the value of Attempts.
+func (s *WebhookDelivery) GetAttempts() int32 {
+	return s.Attempts
+}
+
+// GetNextAttemptAt returns the value of NextAttemptAt.
+func (s *WebhookDelivery) GetNextAttemptAt() time.Time {
+	return s.NextAttemptAt
+}
+
+// GetLastAttemptAt returns the value of LastAttemptAt.
+func (s *WebhookDelivery) GetLastAttemptAt() OptDateTime {
+	return s.LastAttemptAt
+}
+
+// GetLastStatusCode returns the value of LastStatusCode.
+func (s *WebhookDelivery) GetLastStatusCode() OptInt32 {
+	return s.LastStatusCode
+}
+
+// GetLastError returns the value of LastError.
+func (s *WebhookDelivery) GetLastError() OptString {
+	return s.LastError
+}
+
+// GetDeliveredAt returns the value of DeliveredAt.
+func (s *WebhookDelivery) GetDeliveredAt() OptDateTime {
+	return s.DeliveredAt
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *WebhookDelivery) GetCreatedAt() time.Time {
+	return s.CreatedAt
+}
+
+// SetID sets the value of ID.
+func (s *WebhookDelivery) SetID(val int64) {
+	s.ID = val
+}
+
+// SetWebhookId sets the value of WebhookId.
+func (s *WebhookDelivery) SetWebhookId(val int64) {
+	s.WebhookId = val
+}
+
+// SetEventId sets the value of EventId.
+func (s *WebhookDelivery) SetEventId(val int64) {
+	s.EventId = val
+}
+
+// SetEventType sets the value of EventType.
+func (s *WebhookDelivery) SetEventType(val WebhookEventType) {
+	s.EventType = val
+}
+
+// SetStatus sets the value of Status.
+func (s *WebhookDelivery) SetStatus(val WebhookDeliveryStatus) {
+	s.Status = val
+}
+
+// SetAttempts sets the value of Attempts.
+func (s *WebhookDelivery) SetAttempts(val int32) {
+	s.Attempts = val
+}
+
+// SetNextAttemptAt sets the value of NextAttemptAt.
+func (s *WebhookDelivery) SetNextAttemptAt(val time.Time) {
+	s.NextAttemptAt = val
+}
+
+// SetLastAttemptAt sets the value of LastAttemptAt.
+func (s *WebhookDelivery) SetLastAttemptAt(val OptDateTime) {
+	s.LastAttemptAt = val
+}
+
+// SetLastStatusCode sets the value of LastStatusCode.
+func (s *WebhookDelivery) SetLastStatusCode(val OptInt32) {
+	s.LastStatusCode = val
+}
+
+// SetLastError sets the value of LastError.
+func (s *WebhookDelivery) SetLastError(val OptString) {
+	s.LastError = val
+}
+
+// SetDeliveredAt sets the value of DeliveredAt.
+func (s *WebhookDelivery) SetDeliveredAt(val OptDateTime) {
+	s.DeliveredAt = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *WebhookDelivery) SetCreatedAt(val time.Time) {
+	s.CreatedAt = val
+}
+
+func (*WebhookDelivery) redeliverWebhookDeliveryRes() {}
+
+// Ref: #/components/schemas/WebhookDeliveryStatus
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryStatusDead      WebhookDeliveryStatus = "dead"
+)
+
+// AllValues returns all WebhookDeliveryStatus values.
+func (WebhookDeliveryStatus) AllValues() []WebhookDeliveryStatus {
+	return []WebhookDeliveryStatus{
+		WebhookDeliveryStatusPending,
+		WebhookDeliveryStatusSucceeded,
+		WebhookDeliveryStatusDead,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s WebhookDeliveryStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case WebhookDeliveryStatusPending:
+		return []byte(s), nil
+	case WebhookDeliveryStatusSucceeded:
+		return []byte(s), nil
+	case WebhookDeliveryStatusDead:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *WebhookDeliveryStatus) UnmarshalText(data []byte) error {
+	switch WebhookDeliveryStatus(data) {
+	case WebhookDeliveryStatusPending:
+		*s = WebhookDeliveryStatusPending
+		return nil
+	case WebhookDeliveryStatusSucceeded:
+		*s = WebhookDeliveryStatusSucceeded
+		return nil
+	case WebhookDeliveryStatusDead:
+		*s = WebhookDeliveryStatusDead
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/WebhookEventType
+type WebhookEventType string
+
+const (
+	WebhookEventTypePetCreated              WebhookEventType = "pet.created"
+	WebhookEventTypePetDeleted              WebhookEventType = "pet.deleted"
+	WebhookEventTypePetRestored             WebhookEventType = "pet.restored"
+	WebhookEventTypePetReserved             WebhookEventType = "pet.reserved"
+	WebhookEventTypePetReservationCancelled WebhookEventType = "pet.reservation_cancelled"
+	WebhookEventTypePetReservationExpired   WebhookEventType = "pet.reservation_expired"
+)
+
+// AllValues returns all WebhookEventType values.
+func (WebhookEventType) AllValues() []WebhookEventType {
+	return []WebhookEventType{
+		WebhookEventTypePetCreated,
+		WebhookEventTypePetDeleted,
+		WebhookEventTypePetRestored,
+		WebhookEventTypePetReserved,
+		WebhookEventTypePetReservationCancelled,
+		WebhookEventTypePetReservationExpired,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s WebhookEventType) MarshalText() ([]byte, error) {
+	switch s {
+	case WebhookEventTypePetCreated:
+		return []byte(s), nil
+	case WebhookEventTypePetDeleted:
+		return []byte(s), nil
+	case WebhookEventTypePetRestored:
+		return []byte(s), nil
+	case WebhookEventTypePetReserved:
+		return []byte(s), nil
+	case WebhookEventTypePetReservationCancelled:
+		return []byte(s), nil
+	case WebhookEventTypePetReservationExpired:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *WebhookEventType) UnmarshalText(data []byte) error {
+	switch WebhookEventType(data) {
+	case WebhookEventTypePetCreated:
+		*s = WebhookEventTypePetCreated
+		return nil
+	case WebhookEventTypePetDeleted:
+		*s = WebhookEventTypePetDeleted
+		return nil
+	case WebhookEventTypePetRestored:
+		*s = WebhookEventTypePetRestored
+		return nil
+	case WebhookEventTypePetReserved:
+		*s = WebhookEventTypePetReserved
+		return nil
+	case WebhookEventTypePetReservationCancelled:
+		*s = WebhookEventTypePetReservationCancelled
+		return nil
+	case WebhookEventTypePetReservationExpired:
+		*s = WebhookEventTypePetReservationExpired
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
@@ -11,11 +11,24 @@ import (
 
 // SecuritySource is provider of security values (tokens, passwords, etc.).
 type SecuritySource interface {
+	// BearerAuth provides bearerAuth security value.
+	// API key for service-to-service calls, sent as
+	// `Authorization: Bearer psk_...`. Keys are created by admins and
+	// carry scopes; an operation's x-required-scope must be among them.
+	BearerAuth(ctx context.Context, operationName OperationName) (BearerAuth, error)
 	// CookieAuth provides cookieAuth security value.
 	// JWT access token in an HttpOnly cookie.
 	CookieAuth(ctx context.Context, operationName OperationName) (CookieAuth, error)
 }
 
+func (s *Client) securityBearerAuth(ctx context.Context, operationName OperationName, req *http.Request) error {
+	t, err := s.sec.BearerAuth(ctx, operationName)
+	if err != nil {
+		return errors.Wrap(err, "security source \"BearerAuth\"")
+	}
+	req.Header.Set("Authorization", "Bearer "+t.Token)
+	return nil
+}
 func (s *Client) securityCookieAuth(ctx context.Context, operationName OperationName, req *http.Request) error {
 	t, err := s.sec.CookieAuth(ctx, operationName)
 	if err != nil {
@@ -1,10 +1,110 @@
 // Code generated by ogen, DO NOT EDIT.
 package api
 
+type AddPetRes interface {
+	addPetRes()
+}
+
+type CancelReservationRes interface {
+	cancelReservationRes()
+}
+
+type ChangePasswordRes interface {
+	changePasswordRes()
+}
+
+type ConfirmEmailChangeRes interface {
+	confirmEmailChangeRes()
+}
+
+type ConfirmMFAEnrollmentRes interface {
+	confirmMFAEnrollmentRes()
+}
+
+type CreateAPIKeyRes interface {
+	createAPIKeyRes()
+}
+
+type CreateStoreRes interface {
+	createStoreRes()
+}
+
+type CreateWebhookRes interface {
+	createWebhookRes()
+}
+
+type EnrollMFARes interface {
+	enrollMFARes()
+}
+
+type ExportPetsRes interface {
+	exportPetsRes()
+}
+
+type ForgotPasswordRes interface {
+	forgotPasswordRes()
+}
+
+type ImportPetsReq interface {
+	importPetsReq()
+}
+
+type ImportPetsRes interface {
+	importPetsRes()
+}
+
 type LoginUserRes interface {
 	loginUserRes()
 }
 
+type LogoutUserRes interface {
+	logoutUserRes()
+}
+
+type OidcCallbackRes interface {
+	oidcCallbackRes()
+}
+
+type RedeliverWebhookDeliveryRes interface {
+	redeliverWebhookDeliveryRes()
+}
+
 type RegisterUserRes interface {
 	registerUserRes()
 }
+
+type RequestEmailChangeRes interface {
+	requestEmailChangeRes()
+}
+
+type ResendVerificationEmailRes interface {
+	resendVerificationEmailRes()
+}
+
+type ReservePetRes interface {
+	reservePetRes()
+}
+
+type ResetPasswordRes interface {
+	resetPasswordRes()
+}
+
+type RestorePetRes interface {
+	restorePetRes()
+}
+
+type StreamPetEventsRes interface {
+	streamPetEventsRes()
+}
+
+type UnlockUserRes interface {
+	unlockUserRes()
+}
+
+type VerifyEmailRes interface {
+	verifyEmailRes()
+}
+
+type VerifyMFARes interface {
+	verifyMFARes()
+}
@@ -6,12 +6,48 @@ package api
 type OperationName = string
 
 const (
-	AddPetOperation         OperationName = "AddPet"
-	DeletePetOperation      OperationName = "DeletePet"
-	FindPetByIDOperation    OperationName = "FindPetByID"
-	FindPetsOperation       OperationName = "FindPets"
-	GetCurrentUserOperation OperationName = "GetCurrentUser"
-	LoginUserOperation      OperationName = "LoginUser"
-	LogoutUserOperation     OperationName = "LogoutUser"
-	RegisterUserOperation   OperationName = "RegisterUser"
+	AddPetOperation                   OperationName = "AddPet"
+	CancelReservationOperation        OperationName = "CancelReservation"
+	ChangePasswordOperation           OperationName = "ChangePassword"
+	ConfirmEmailChangeOperation       OperationName = "ConfirmEmailChange"
+	ConfirmMFAEnrollmentOperation     OperationName = "ConfirmMFAEnrollment"
+	CreateAPIKeyOperation             OperationName = "CreateAPIKey"
+	CreateStoreOperation              OperationName = "CreateStore"
+	CreateWebhookOperation            OperationName = "CreateWebhook"
+	DeletePetOperation                OperationName = "DeletePet"
+	DeleteWebhookOperation            OperationName = "DeleteWebhook"
+	EnrollMFAOperation                OperationName = "EnrollMFA"
+	ExportPetsOperation               OperationName = "ExportPets"
+	FindPetByIDOperation              OperationName = "FindPetByID"
+	FindPetsOperation                 OperationName = "FindPets"
+	ForgotPasswordOperation           OperationName = "ForgotPassword"
+	GetCurrentUserOperation           OperationName = "GetCurrentUser"
+	GetJWKSOperation                  OperationName = "GetJWKS"
+	ImportPetsOperation               OperationName = "ImportPets"
+	ListAPIKeysOperation              OperationName = "ListAPIKeys"
+	ListAuditEventsOperation          OperationName = "ListAuditEvents"
+	ListJobsOperation                 OperationName = "ListJobs"
+	ListMyReservationsOperation       OperationName = "ListMyReservations"
+	ListPetRevisionsOperation         OperationName = "ListPetRevisions"
+	ListSpeciesOperation              OperationName = "ListSpecies"
+	ListStoresOperation               OperationName = "ListStores"
+	ListWebhookDeliveriesOperation    OperationName = "ListWebhookDeliveries"
+	ListWebhooksOperation             OperationName = "ListWebhooks"
+	LoginUserOperation                OperationName = "LoginUser"
+	LogoutUserOperation               OperationName = "LogoutUser"
+	OidcCallbackOperation             OperationName = "OidcCallback"
+	RedeliverWebhookDeliveryOperation OperationName = "RedeliverWebhookDelivery"
+	RegisterUserOperation             OperationName = "RegisterUser"
+	RequestEmailChangeOperation       OperationName = "RequestEmailChange"
+	ResendVerificationEmailOperation  OperationName = "ResendVerificationEmail"
+	ReservePetOperation               OperationName = "ReservePet"
+	ResetPasswordOperation            OperationName = "ResetPassword"
+	RestorePetOperation               OperationName = "RestorePet"
+	RevokeAPIKeyOperation             OperationName = "RevokeAPIKey"
+	StartOIDCLoginOperation           OperationName = "StartOIDCLogin"
+	StreamPetEventsOperation          OperationName = "StreamPetEvents"
+	UnlockUserOperation               OperationName = "UnlockUser"
+	UpdateUserOperation               OperationName = "UpdateUser"
+	VerifyEmailOperation              OperationName = "VerifyEmail"
+	VerifyMFAOperation                OperationName = "VerifyMFA"
 )
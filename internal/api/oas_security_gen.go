@@ -13,6 +13,11 @@ import (
 
 // SecurityHandler is handler for security parameters.
 type SecurityHandler interface {
+	// HandleBearerAuth handles bearerAuth security.
+	// API key for service-to-service calls, sent as
+	// `Authorization: Bearer psk_...`. Keys are created by admins and
+	// carry scopes; an operation's x-required-scope must be among them.
+	HandleBearerAuth(ctx context.Context, operationName OperationName, t BearerAuth) (context.Context, error)
 	// HandleCookieAuth handles cookieAuth security.
 	// JWT access token in an HttpOnly cookie.
 	HandleCookieAuth(ctx context.Context, operationName OperationName, t CookieAuth) (context.Context, error)
@@ -33,11 +38,60 @@ func findAuthorization(h http.Header, prefix string) (string, bool) {
 	return "", false
 }
 
+var operationRolesBearerAuth = map[string][]string{
+	AddPetOperation:     []string{},
+	DeletePetOperation:  []string{},
+	ExportPetsOperation: []string{},
+	ImportPetsOperation: []string{},
+}
+
+func (s *Server) securityBearerAuth(ctx context.Context, operationName OperationName, req *http.Request) (context.Context, bool, error) {
+	var t BearerAuth
+	token, ok := findAuthorization(req.Header, "Bearer")
+	if !ok {
+		return ctx, false, nil
+	}
+	t.Token = token
+	t.Roles = operationRolesBearerAuth[operationName]
+	rctx, err := s.sec.HandleBearerAuth(ctx, operationName, t)
+	if errors.Is(err, ogenerrors.ErrSkipServerSecurity) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return rctx, true, err
+}
+
 var operationRolesCookieAuth = map[string][]string{
-	AddPetOperation:         []string{},
-	DeletePetOperation:      []string{},
-	GetCurrentUserOperation: []string{},
-	LogoutUserOperation:     []string{},
+	AddPetOperation:                   []string{},
+	CancelReservationOperation:        []string{},
+	ChangePasswordOperation:           []string{},
+	ConfirmMFAEnrollmentOperation:     []string{},
+	CreateAPIKeyOperation:             []string{},
+	CreateStoreOperation:              []string{},
+	CreateWebhookOperation:            []string{},
+	DeletePetOperation:                []string{},
+	DeleteWebhookOperation:            []string{},
+	EnrollMFAOperation:                []string{},
+	ExportPetsOperation:               []string{},
+	GetCurrentUserOperation:           []string{},
+	ImportPetsOperation:               []string{},
+	ListAPIKeysOperation:              []string{},
+	ListAuditEventsOperation:          []string{},
+	ListJobsOperation:                 []string{},
+	ListMyReservationsOperation:       []string{},
+	ListPetRevisionsOperation:         []string{},
+	ListWebhookDeliveriesOperation:    []string{},
+	ListWebhooksOperation:             []string{},
+	LogoutUserOperation:               []string{},
+	RedeliverWebhookDeliveryOperation: []string{},
+	RequestEmailChangeOperation:       []string{},
+	ResendVerificationEmailOperation:  []string{},
+	ReservePetOperation:               []string{},
+	RestorePetOperation:               []string{},
+	RevokeAPIKeyOperation:             []string{},
+	UnlockUserOperation:               []string{},
+	UpdateUserOperation:               []string{},
 }
 
 func (s *Server) securityCookieAuth(ctx context.Context, operationName OperationName, req *http.Request) (context.Context, bool, error) {
@@ -10,16 +10,94 @@ import (
 type Handler interface {
 	// AddPet implements addPet operation.
 	//
-	// Creates a new pet in the store. Duplicates are allowed.
+	// Creates a new pet in a store. Duplicates are allowed. Accounts
+	// and API keys confined to a store add pets to it; global ones
+	// must name the store.
 	//
 	// POST /pets
-	AddPet(ctx context.Context, req *NewPet) (*Pet, error)
+	AddPet(ctx context.Context, req *NewPet, params AddPetParams) (AddPetRes, error)
+	// CancelReservation implements cancelReservation operation.
+	//
+	// Release one of the current user's active holds, making the pet available again.
+	//
+	// DELETE /auth/me/reservations/{id}
+	CancelReservation(ctx context.Context, params CancelReservationParams) (CancelReservationRes, error)
+	// ChangePassword implements changePassword operation.
+	//
+	// Change the current user's password. Requires the current
+	// password. Every other session is signed out; this one gets
+	// a fresh access_token cookie.
+	//
+	// POST /auth/me/password
+	ChangePassword(ctx context.Context, req *ChangePasswordRequest, params ChangePasswordParams) (ChangePasswordRes, error)
+	// ConfirmEmailChange implements confirmEmailChange operation.
+	//
+	// Apply an email change using the token from the confirmation email.
+	//
+	// POST /auth/email/confirm
+	ConfirmEmailChange(ctx context.Context, req *VerifyEmailRequest, params ConfirmEmailChangeParams) (ConfirmEmailChangeRes, error)
+	// ConfirmMFAEnrollment implements confirmMFAEnrollment operation.
+	//
+	// Enable TOTP by proving the authenticator app produces valid
+	// codes. Returns recovery codes, which are shown only once.
+	//
+	// POST /auth/mfa/enroll/confirm
+	ConfirmMFAEnrollment(ctx context.Context, req *MFACodeRequest, params ConfirmMFAEnrollmentParams) (ConfirmMFAEnrollmentRes, error)
+	// CreateAPIKey implements createAPIKey operation.
+	//
+	// Creates an API key. The secret is returned only in this response.
+	//
+	// POST /admin/api-keys
+	CreateAPIKey(ctx context.Context, req *NewAPIKey, params CreateAPIKeyParams) (CreateAPIKeyRes, error)
+	// CreateStore implements createStore operation.
+	//
+	// Creates a shop location. Store names are unique.
+	//
+	// POST /admin/stores
+	CreateStore(ctx context.Context, req *NewStore, params CreateStoreParams) (CreateStoreRes, error)
+	// CreateWebhook implements createWebhook operation.
+	//
+	// Subscribes a URL to pet events. Each event is POSTed as JSON
+	// with a Petstore-Signature header: "sha256=" and the hex
+	// HMAC-SHA256, keyed by the webhook's secret, of the
+	// Petstore-Timestamp header, a dot, and the body. The secret is
+	// returned only in this response.
+	//
+	// POST /admin/webhooks
+	CreateWebhook(ctx context.Context, req *NewWebhook, params CreateWebhookParams) (CreateWebhookRes, error)
 	// DeletePet implements deletePet operation.
 	//
-	// Deletes a single pet based on the ID supplied.
+	// Deletes a single pet based on the ID supplied. The pet is
+	// soft-deleted: it disappears from findPets and findPetByID but
+	// keeps its history and can be restored by an admin.
 	//
 	// DELETE /pets/{id}
 	DeletePet(ctx context.Context, params DeletePetParams) error
+	// DeleteWebhook implements deleteWebhook operation.
+	//
+	// Deletes a webhook and its delivery log. Queued deliveries are not sent.
+	//
+	// DELETE /admin/webhooks/{id}
+	DeleteWebhook(ctx context.Context, params DeleteWebhookParams) error
+	// EnrollMFA implements enrollMFA operation.
+	//
+	// Generate a TOTP secret for the current user. TOTP is not
+	// enabled until confirmMFAEnrollment succeeds.
+	//
+	// POST /auth/mfa/enroll
+	EnrollMFA(ctx context.Context, params EnrollMFAParams) (EnrollMFARes, error)
+	// ExportPets implements exportPets operation.
+	//
+	// Streams every pet matching the findPets filters, in ID order,
+	// as CSV (with a header row naming the columns), NDJSON, or a JSON
+	// array, chosen by the Accept header. The JSON array is served as
+	// `application/vnd.petstore.pets+json`, and also answers clients
+	// that accept `application/json`; it is the default when the
+	// client accepts any format equally. Rows are written as they are
+	// read, so the response has no Content-Length.
+	//
+	// GET /pets/export
+	ExportPets(ctx context.Context, params ExportPetsParams) (ExportPetsRes, error)
 	// FindPetByID implements find pet by id operation.
 	//
 	// Returns a user based on a single ID, if the user does not have access to the pet.
@@ -48,34 +126,248 @@ type Handler interface {
 	//
 	// GET /pets
 	FindPets(ctx context.Context, params FindPetsParams) ([]Pet, error)
+	// ForgotPassword implements forgotPassword operation.
+	//
+	// Email a single-use password reset link to the account with
+	// the given address. The response is the same whether or not
+	// the address is registered.
+	//
+	// POST /auth/password/forgot
+	ForgotPassword(ctx context.Context, req *ForgotPasswordRequest, params ForgotPasswordParams) (ForgotPasswordRes, error)
 	// GetCurrentUser implements getCurrentUser operation.
 	//
 	// Get the currently authenticated user.
 	//
 	// GET /auth/me
 	GetCurrentUser(ctx context.Context) (*AuthUser, error)
+	// GetJWKS implements getJWKS operation.
+	//
+	// Returns the public keys that verify access tokens, as a
+	// JSON Web Key Set (RFC 7517). During a key rotation it lists
+	// both the current signing key and the keys being retired.
+	// Empty when tokens are signed with a shared HS256 secret.
+	//
+	// GET /.well-known/jwks.json
+	GetJWKS(ctx context.Context) (*JWKS, error)
+	// ImportPets implements importPets operation.
+	//
+	// Creates pets from a CSV file (with a header row naming a `name`
+	// column and any of `tag`, `species`, `breed`, `birth_date`, `sex`,
+	// `description`, `price_amount`, `price_currency`, and `store_id`)
+	// or from NDJSON (one NewPet object per line). Each row is checked
+	// with the same rules as addPet; rows without a store go to
+	// `storeId`, or to the caller's store, or for a global caller to
+	// the default store (the seeded Main Store). A row naming an
+	// unknown store, or one the caller may not use, is invalid. In
+	// atomic mode nothing is written unless every row is valid, and
+	// invalid rows answer 422 with the report; in partial mode the
+	// valid rows are written and the invalid ones reported. A dry run
+	// checks the rows and reports the outcome without writing.
+	//
+	// POST /pets/import
+	ImportPets(ctx context.Context, req ImportPetsReq, params ImportPetsParams) (ImportPetsRes, error)
+	// ListAPIKeys implements listAPIKeys operation.
+	//
+	// Returns all API keys, including revoked and expired ones. Secrets are never returned.
+	//
+	// GET /admin/api-keys
+	ListAPIKeys(ctx context.Context) ([]APIKey, error)
+	// ListAuditEvents implements listAuditEvents operation.
+	//
+	// Returns audit events, newest first. Every filter is optional;
+	// page backwards by passing the smallest returned id as `before`.
+	//
+	// GET /admin/audit-events
+	ListAuditEvents(ctx context.Context, params ListAuditEventsParams) ([]AuditEvent, error)
+	// ListJobs implements listJobs operation.
+	//
+	// Returns the background jobs and their counters as seen by the
+	// replica that serves the request. Counters reset when a server
+	// restarts, and only the elected leader runs jobs, so a follower
+	// reports leader false and no next run times.
+	//
+	// GET /admin/jobs
+	ListJobs(ctx context.Context) (*JobSchedulerStatus, error)
+	// ListMyReservations implements listMyReservations operation.
+	//
+	// The current user's holds on pets, newest first.
+	//
+	// GET /auth/me/reservations
+	ListMyReservations(ctx context.Context) ([]Reservation, error)
+	// ListPetRevisions implements listPetRevisions operation.
+	//
+	// Returns every revision of a pet, oldest first: its creation,
+	// each deletion and restore, and each hold placed,
+	// cancelled, or lapsed, with the acting user or API key (none for
+	// a lapse). Deleted pets keep their history.
+	//
+	// GET /admin/pets/{id}/history
+	ListPetRevisions(ctx context.Context, params ListPetRevisionsParams) ([]PetRevision, error)
+	// ListSpecies implements listSpecies operation.
+	//
+	// Returns the species and breeds a pet may be given, sorted by
+	// name. The list is reference data maintained by migrations.
+	//
+	// GET /species
+	ListSpecies(ctx context.Context) ([]Species, error)
+	// ListStores implements listStores operation.
+	//
+	// Returns the shop locations, sorted by name.
+	//
+	// GET /stores
+	ListStores(ctx context.Context) ([]Store, error)
+	// ListWebhookDeliveries implements listWebhookDeliveries operation.
+	//
+	// Returns the webhook's delivery log, newest first: one entry per
+	// event sent to it, with its status and the outcome of its last
+	// attempt. Page backwards by passing the smallest returned id as
+	// `before`.
+	//
+	// GET /admin/webhooks/{id}/deliveries
+	ListWebhookDeliveries(ctx context.Context, params ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	// ListWebhooks implements listWebhooks operation.
+	//
+	// Returns every webhook subscription. Signing secrets are never returned.
+	//
+	// GET /admin/webhooks
+	ListWebhooks(ctx context.Context) ([]Webhook, error)
 	// LoginUser implements loginUser operation.
 	//
 	// Authenticate a user and set an access token cookie.
 	//
 	// POST /auth/login
-	LoginUser(ctx context.Context, req *LoginRequest) (LoginUserRes, error)
+	LoginUser(ctx context.Context, req *LoginRequest, params LoginUserParams) (LoginUserRes, error)
 	// LogoutUser implements logoutUser operation.
 	//
 	// Log out the current user by clearing the access token cookie.
 	//
 	// POST /auth/logout
-	LogoutUser(ctx context.Context) error
+	LogoutUser(ctx context.Context, params LogoutUserParams) (LogoutUserRes, error)
+	// OidcCallback implements oidcCallback operation.
+	//
+	// Redirect target for the identity provider. Validates the
+	// state, redeems the code, signs in the linked user (linking
+	// by verified email, or creating a customer on first login),
+	// sets the access_token cookie, and redirects to the frontend.
+	//
+	// GET /auth/oidc/callback
+	OidcCallback(ctx context.Context, params OidcCallbackParams) (OidcCallbackRes, error)
+	// RedeliverWebhookDelivery implements redeliverWebhookDelivery operation.
+	//
+	// Queues a succeeded or dead-lettered delivery to be sent again
+	// at once, with a fresh set of attempts. Returns 409 while the
+	// delivery is still pending.
+	//
+	// POST /admin/webhooks/{id}/deliveries/{deliveryId}/redeliver
+	RedeliverWebhookDelivery(ctx context.Context, params RedeliverWebhookDeliveryParams) (RedeliverWebhookDeliveryRes, error)
 	// RegisterUser implements registerUser operation.
 	//
 	// Register a new user account.
 	//
 	// POST /auth/register
-	RegisterUser(ctx context.Context, req *RegisterRequest) (RegisterUserRes, error)
-	// NewError creates *ErrorStatusCode from error returned by handler.
+	RegisterUser(ctx context.Context, req *RegisterRequest, params RegisterUserParams) (RegisterUserRes, error)
+	// RequestEmailChange implements requestEmailChange operation.
+	//
+	// Start changing the current user's email address. Requires the
+	// current password. A confirmation link is sent to the new
+	// address and a notice to the old one; the address changes only
+	// once the link is used.
+	//
+	// POST /auth/me/email
+	RequestEmailChange(ctx context.Context, req *ChangeEmailRequest, params RequestEmailChangeParams) (RequestEmailChangeRes, error)
+	// ResendVerificationEmail implements resendVerificationEmail operation.
+	//
+	// Send a new verification link to the current user. Limited to
+	// one email per minute and five per hour.
+	//
+	// POST /auth/verify/resend
+	ResendVerificationEmail(ctx context.Context, params ResendVerificationEmailParams) (ResendVerificationEmailRes, error)
+	// ReservePet implements reservePet operation.
+	//
+	// Place a 48-hour hold on a pet before coming in to see it.
+	// While the hold lasts the pet shows as reserved and other holds
+	// are rejected; it lapses on its own, or the holder cancels it.
+	// A user may hold at most 3 pets at a time, and may not hold a
+	// pet again until 24 hours after their last hold on it ended.
+	//
+	// POST /pets/{id}/reservations
+	ReservePet(ctx context.Context, params ReservePetParams) (ReservePetRes, error)
+	// ResetPassword implements resetPassword operation.
+	//
+	// Set a new password using a token from a reset email.
+	//
+	// POST /auth/password/reset
+	ResetPassword(ctx context.Context, req *ResetPasswordRequest, params ResetPasswordParams) (ResetPasswordRes, error)
+	// RestorePet implements restorePet operation.
+	//
+	// Undoes a soft delete. Returns 404 unless the pet exists and is deleted.
+	//
+	// POST /admin/pets/{id}/restore
+	RestorePet(ctx context.Context, params RestorePetParams) (RestorePetRes, error)
+	// RevokeAPIKey implements revokeAPIKey operation.
+	//
+	// Revokes an API key immediately. Revoked keys stay listed.
+	//
+	// DELETE /admin/api-keys/{id}
+	RevokeAPIKey(ctx context.Context, params RevokeAPIKeyParams) error
+	// StartOIDCLogin implements startOIDCLogin operation.
+	//
+	// Starts an OpenID Connect authorization code flow with PKCE.
+	// Redirects to the configured identity provider and sets a
+	// short-lived oidc_state cookie. Returns 404 when no provider
+	// is configured.
+	//
+	// GET /auth/oidc/login
+	StartOIDCLogin(ctx context.Context) (*StartOIDCLoginFound, error)
+	// StreamPetEvents implements streamPetEvents operation.
+	//
+	// A Server-Sent Events stream of pet catalog changes, one event
+	// per pet revision, so storefronts need not poll `GET /pets`.
+	// Each event's `id` is the revision ID, its `event` the change
+	// (`created`, `deleted`, `restored`, `reserved`,
+	// `reservation_cancelled`, or `reservation_expired`), and its
+	// `data` a JSON object with `petId`, `name`, `tag`, and
+	// `createdAt`. A new connection receives only live events;
+	// sending Last-Event-ID first replays every later one. A
+	// `: heartbeat` comment is sent while the stream is idle.
+	//
+	// GET /pets/events
+	StreamPetEvents(ctx context.Context, params StreamPetEventsParams) (StreamPetEventsRes, error)
+	// UnlockUser implements unlockUser operation.
+	//
+	// Clear the failed login counter and any temporary lock on a
+	// user account.
+	//
+	// POST /admin/users/{id}/unlock
+	UnlockUser(ctx context.Context, params UnlockUserParams) (UnlockUserRes, error)
+	// UpdateUser implements updateUser operation.
+	//
+	// Set a user's role or store, disable the account, or re-enable
+	// it. A disabled user cannot log in. Existing sessions keep
+	// their claimed role and store until they expire unless the
+	// server runs with REQUIRE_FRESH_ROLES, which rejects them at
+	// once. Admins confined to a store manage only its users and
+	// cannot move them out of it.
+	//
+	// PATCH /admin/users/{id}
+	UpdateUser(ctx context.Context, req *UpdateUserRequest, params UpdateUserParams) (*AuthUser, error)
+	// VerifyEmail implements verifyEmail operation.
+	//
+	// Confirm an email address using the token from a verification email.
+	//
+	// POST /auth/verify
+	VerifyEmail(ctx context.Context, req *VerifyEmailRequest, params VerifyEmailParams) (VerifyEmailRes, error)
+	// VerifyMFA implements verifyMFA operation.
+	//
+	// Exchange the challenge token from loginUser and a TOTP or
+	// recovery code for an access_token cookie.
+	//
+	// POST /auth/mfa/verify
+	VerifyMFA(ctx context.Context, req *MFAVerifyRequest, params VerifyMFAParams) (VerifyMFARes, error)
+	// NewError creates *ProblemStatusCode from error returned by handler.
 	//
 	// Used for common default response.
-	NewError(ctx context.Context, err error) *ErrorStatusCode
+	NewError(ctx context.Context, err error) *ProblemStatusCode
 }
 
 // Server implements http server based on OpenAPI v3 specification and
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// AnyRole in x-required-role admits every authenticated
+// user, whatever their role.
+const AnyRole = "*"
+
+// Policy is the access policy of a secured operation,
+// generated from its x-required-role, x-required-scope, and
+// x-global extensions.
+type Policy struct {
+	// Roles lists the roles allowed to call the operation
+	// with a user session, or holds AnyRole.
+	Roles []string
+	// Scope is the scope an API key needs for the operation;
+	// empty if the operation does not accept API keys.
+	Scope string
+	// Global marks an operation that concerns every store,
+	// so accounts and API keys confined to one are refused.
+	Global bool
+}
+
+// Allows reports whether a user with the given role may
+// call the operation.
+func (p Policy) Allows(role string) bool {
+	return slices.Contains(p.Roles, AnyRole) ||
+		slices.Contains(p.Roles, role)
+}
+
+// Restricted reports whether the policy limits the
+// operation to particular roles.
+func (p Policy) Restricted() bool {
+	return !slices.Contains(p.Roles, AnyRole)
+}
+
+// OperationPolicy returns the access policy of the named
+// operation. ok is false for public operations.
+func OperationPolicy(name OperationName) (p Policy, ok bool) {
+	p, ok = operationPolicies[name]
+	return p, ok
+}
+
+// CheckPolicies returns an error listing every operation the
+// spec secures without a matching policy: cookieAuth without
+// x-required-role, or bearerAuth without x-required-scope.
+// Either means a new operation was added without deciding
+// who may call it, or policy_gen.go is stale.
+func CheckPolicies() error {
+	var missing []string
+	for name := range operationRolesCookieAuth {
+		if len(operationPolicies[name].Roles) == 0 {
+			missing = append(missing, name+" (x-required-role)")
+		}
+	}
+	for name := range operationRolesBearerAuth {
+		if operationPolicies[name].Scope == "" {
+			missing = append(missing, name+" (x-required-scope)")
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	slices.Sort(missing)
+	return fmt.Errorf(
+		"secured operations without an access policy: %s",
+		strings.Join(missing, ", "),
+	)
+}
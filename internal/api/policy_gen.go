@@ -0,0 +1,37 @@
+// Code generated by policygen, DO NOT EDIT.
+
+package api
+
+// operationPolicies holds the x-required-role,
+// x-required-scope, and x-global extensions from api.yml.
+var operationPolicies = map[OperationName]Policy{
+	AddPetOperation:                   {Roles: []string{"admin", "staff"}, Scope: "pets:write"},
+	CancelReservationOperation:        {Roles: []string{"*"}},
+	ChangePasswordOperation:           {Roles: []string{"*"}},
+	ConfirmMFAEnrollmentOperation:     {Roles: []string{"*"}},
+	CreateAPIKeyOperation:             {Roles: []string{"admin"}, Global: true},
+	CreateStoreOperation:              {Roles: []string{"admin"}, Global: true},
+	CreateWebhookOperation:            {Roles: []string{"admin"}, Global: true},
+	DeletePetOperation:                {Roles: []string{"admin"}, Scope: "pets:write"},
+	DeleteWebhookOperation:            {Roles: []string{"admin"}, Global: true},
+	EnrollMFAOperation:                {Roles: []string{"*"}},
+	ExportPetsOperation:               {Roles: []string{"admin"}, Scope: "pets:read"},
+	GetCurrentUserOperation:           {Roles: []string{"*"}},
+	ImportPetsOperation:               {Roles: []string{"admin"}, Scope: "pets:write"},
+	ListAPIKeysOperation:              {Roles: []string{"admin"}, Global: true},
+	ListAuditEventsOperation:          {Roles: []string{"admin"}, Global: true},
+	ListJobsOperation:                 {Roles: []string{"admin"}, Global: true},
+	ListMyReservationsOperation:       {Roles: []string{"*"}},
+	ListPetRevisionsOperation:         {Roles: []string{"admin"}},
+	ListWebhookDeliveriesOperation:    {Roles: []string{"admin"}, Global: true},
+	ListWebhooksOperation:             {Roles: []string{"admin"}, Global: true},
+	LogoutUserOperation:               {Roles: []string{"*"}},
+	RedeliverWebhookDeliveryOperation: {Roles: []string{"admin"}, Global: true},
+	RequestEmailChangeOperation:       {Roles: []string{"*"}},
+	ResendVerificationEmailOperation:  {Roles: []string{"*"}},
+	ReservePetOperation:               {Roles: []string{"*"}},
+	RestorePetOperation:               {Roles: []string{"admin"}},
+	RevokeAPIKeyOperation:             {Roles: []string{"admin"}, Global: true},
+	UnlockUserOperation:               {Roles: []string{"admin"}},
+	UpdateUserOperation:               {Roles: []string{"admin"}},
+}
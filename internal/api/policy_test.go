@@ -0,0 +1,79 @@
+package api_test
+
+import (
+	"testing"
+
+	"github.com/hhubris/petstore/internal/api"
+)
+
+func TestCheckPolicies(t *testing.T) {
+	if err := api.CheckPolicies(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPolicyAllows(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     api.Policy
+		role       string
+		want       bool
+		restricted bool
+	}{
+		{
+			name:       "listed role",
+			policy:     api.Policy{Roles: []string{"admin", "staff"}},
+			role:       "staff",
+			want:       true,
+			restricted: true,
+		},
+		{
+			name:       "unlisted role",
+			policy:     api.Policy{Roles: []string{"admin"}},
+			role:       "staff",
+			restricted: true,
+		},
+		{
+			name:   "any role",
+			policy: api.Policy{Roles: []string{api.AnyRole}},
+			role:   "customer",
+			want:   true,
+		},
+		{
+			name:       "no roles",
+			policy:     api.Policy{Scope: "pets:write"},
+			role:       "admin",
+			restricted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Allows(tt.role); got != tt.want {
+				t.Errorf("Allows(%q) = %v, want %v",
+					tt.role, got, tt.want)
+			}
+			if got := tt.policy.Restricted(); got != tt.restricted {
+				t.Errorf("Restricted() = %v, want %v",
+					got, tt.restricted)
+			}
+		})
+	}
+}
+
+func TestOperationPolicy(t *testing.T) {
+	p, ok := api.OperationPolicy(api.DeletePetOperation)
+	if !ok {
+		t.Fatal("deletePet has no policy")
+	}
+	if p.Allows("staff") || !p.Allows("admin") {
+		t.Errorf("deletePet roles = %v, want admin only", p.Roles)
+	}
+	if p.Scope != "pets:write" {
+		t.Errorf("deletePet scope = %q, want pets:write", p.Scope)
+	}
+
+	if _, ok := api.OperationPolicy(api.FindPetsOperation); ok {
+		t.Error("public findPets has a policy")
+	}
+}
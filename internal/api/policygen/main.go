@@ -0,0 +1,201 @@
+// Command policygen writes the access policy table for the
+// ogen server from the x-required-role, x-required-scope,
+// and x-global extensions in an OpenAPI spec. ogen ignores vendor
+// extensions, so without it the policies would be copied by
+// hand into the security handler and drift from the spec.
+//
+// Usage:
+//
+//	policygen -o policy_gen.go api.yml
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/go-faster/yaml"
+)
+
+// httpMethods are the path item keys that hold operations.
+var httpMethods = []string{
+	"get", "put", "post", "delete", "options", "head", "patch", "trace",
+}
+
+// operation holds the fields of an OpenAPI operation that
+// policygen reads.
+type operation struct {
+	OperationID string       `yaml:"operationId"`
+	Role        roles        `yaml:"x-required-role"`
+	Scope       string       `yaml:"x-required-scope"`
+	Global      bool         `yaml:"x-global"`
+	Security    *[]yaml.Node `yaml:"security"`
+}
+
+// roles accepts x-required-role as a single role or a list.
+type roles []string
+
+func (r *roles) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind == yaml.ScalarNode {
+		*r = roles{n.Value}
+		return nil
+	}
+	var list []string
+	if err := n.Decode(&list); err != nil {
+		return fmt.Errorf("x-required-role: %w", err)
+	}
+	*r = list
+	return nil
+}
+
+// policy is one generated table entry.
+type policy struct {
+	name   string
+	roles  []string
+	scope  string
+	global bool
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("policygen: ")
+	out := flag.String("o", "policy_gen.go", "output file")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		log.Fatal("usage: policygen -o file.go spec.yml")
+	}
+
+	spec, err := os.ReadFile(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	policies, err := parse(spec)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := render(policies)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parse returns the policy of every operation that declares
+// x-required-role or x-required-scope, sorted by name.
+// Whether every secured operation has one is checked at
+// startup by api.CheckPolicies, against ogen's own list.
+func parse(spec []byte) ([]policy, error) {
+	var doc struct {
+		Paths map[string]map[string]yaml.Node `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(spec, &doc); err != nil {
+		return nil, err
+	}
+
+	var policies []policy
+	for path, item := range doc.Paths {
+		for _, method := range httpMethods {
+			node, ok := item[method]
+			if !ok {
+				continue
+			}
+			var op operation
+			if err := node.Decode(&op); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+			if len(op.Role) == 0 && op.Scope == "" {
+				if op.Global {
+					return nil, fmt.Errorf(
+						"%s: x-global without x-required-role or x-required-scope",
+						op.OperationID,
+					)
+				}
+				continue
+			}
+			if op.Security != nil && len(*op.Security) == 0 {
+				return nil, fmt.Errorf(
+					"%s: public operation has an access policy",
+					op.OperationID,
+				)
+			}
+			for _, r := range op.Role {
+				if r == "" {
+					return nil, fmt.Errorf(
+						"%s: empty role in x-required-role",
+						op.OperationID,
+					)
+				}
+			}
+			policies = append(policies, policy{
+				name:   goName(op.OperationID),
+				roles:  op.Role,
+				scope:  op.Scope,
+				global: op.Global,
+			})
+		}
+	}
+	slices.SortFunc(policies, func(a, b policy) int {
+		return strings.Compare(a.name, b.name)
+	})
+	return policies, nil
+}
+
+// goName converts an operationId to the name ogen gives the
+// operation: words are capitalized and joined, and "id"
+// becomes "ID". A mismatch does not go unnoticed: the
+// generated file refers to ogen's <Name>Operation constants
+// and fails to compile.
+func goName(operationID string) string {
+	words := strings.FieldsFunc(operationID, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, w := range words {
+		if strings.EqualFold(w, "id") {
+			b.WriteString("ID")
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
+
+// render returns the formatted Go source of the table.
+func render(policies []policy) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by policygen, DO NOT EDIT.\n\n")
+	b.WriteString("package api\n\n")
+	b.WriteString("// operationPolicies holds the x-required-role,\n")
+	b.WriteString("// x-required-scope, and x-global extensions from api.yml.\n")
+	b.WriteString("var operationPolicies = map[OperationName]Policy{\n")
+	for _, p := range policies {
+		fmt.Fprintf(&b, "\t%sOperation: {", p.name)
+		var fields []string
+		if len(p.roles) > 0 {
+			quoted := make([]string, len(p.roles))
+			for i, r := range p.roles {
+				quoted[i] = fmt.Sprintf("%q", r)
+			}
+			fields = append(fields,
+				"Roles: []string{"+strings.Join(quoted, ", ")+"}")
+		}
+		if p.scope != "" {
+			fields = append(fields, fmt.Sprintf("Scope: %q", p.scope))
+		}
+		if p.global {
+			fields = append(fields, "Global: true")
+		}
+		b.WriteString(strings.Join(fields, ", "))
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
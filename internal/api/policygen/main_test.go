@@ -0,0 +1,138 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	spec := `
+paths:
+  /pets:
+    parameters:
+      - name: x
+        in: query
+    get:
+      operationId: findPets
+      security: []
+    post:
+      operationId: addPet
+      x-required-role: [admin, staff]
+      x-required-scope: pets:write
+  /pets/{id}:
+    get:
+      operationId: find pet by id
+      x-required-role: "*"
+    delete:
+      operationId: deletePet
+      x-required-role: admin
+      security:
+        - cookieAuth: []
+  /admin/jobs:
+    get:
+      operationId: listJobs
+      x-required-role: admin
+      x-global: true
+`
+	got, err := parse([]byte(spec))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := []policy{
+		{name: "AddPet", roles: []string{"admin", "staff"}, scope: "pets:write"},
+		{name: "DeletePet", roles: []string{"admin"}},
+		{name: "FindPetByID", roles: []string{"*"}},
+		{name: "ListJobs", roles: []string{"admin"}, global: true},
+	}
+	if !slices.EqualFunc(got, want, func(a, b policy) bool {
+		return a.name == b.name && a.scope == b.scope &&
+			a.global == b.global && slices.Equal(a.roles, b.roles)
+	}) {
+		t.Errorf("parse = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string
+	}{
+		{
+			name: "policy on public operation",
+			spec: `
+paths:
+  /pets:
+    get:
+      operationId: findPets
+      x-required-role: admin
+      security: []
+`,
+			wantErr: "public operation",
+		},
+		{
+			name: "empty role",
+			spec: `
+paths:
+  /pets:
+    post:
+      operationId: addPet
+      x-required-role: [admin, ""]
+`,
+			wantErr: "empty role",
+		},
+		{
+			name: "role map",
+			spec: `
+paths:
+  /pets:
+    post:
+      operationId: addPet
+      x-required-role: {admin: true}
+`,
+			wantErr: "cannot unmarshal",
+		},
+		{
+			name: "global without a policy",
+			spec: `
+paths:
+  /pets:
+    get:
+      operationId: findPets
+      x-global: true
+`,
+			wantErr: "x-global without",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parse([]byte(tt.spec))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	src, err := render([]policy{
+		{name: "AddPet", roles: []string{"admin", "staff"}, scope: "pets:write"},
+		{name: "ListJobs", roles: []string{"admin"}, global: true},
+		{name: "LogoutUser", roles: []string{"*"}},
+	})
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	for _, want := range []string{
+		"// Code generated by policygen, DO NOT EDIT.",
+		`AddPetOperation:     {Roles: []string{"admin", "staff"}, Scope: "pets:write"},`,
+		`ListJobsOperation:   {Roles: []string{"admin"}, Global: true},`,
+		`LogoutUserOperation: {Roles: []string{"*"}},`,
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("output lacks %q:\n%s", want, src)
+		}
+	}
+}
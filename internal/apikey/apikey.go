@@ -0,0 +1,24 @@
+// Package apikey manages bearer API keys for
+// service-to-service access.
+package apikey
+
+import "time"
+
+// APIKey is the domain model for an API key. The secret
+// itself is never stored; KeyHash is its SHA-256.
+type APIKey struct {
+	ID        int64
+	Name      string
+	Prefix    string
+	KeyHash   string
+	Scopes    []string
+	CreatedBy int64
+	CreatedAt time.Time
+	// ExpiresAt is nil for keys that never expire.
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	// StoreID confines the key to a store; nil keys are
+	// global.
+	StoreID *int64
+}
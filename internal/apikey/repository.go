@@ -0,0 +1,184 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/store"
+)
+
+// dbtx is the database interface required by
+// APIKeyRepository. Satisfied by *pgxpool.Pool, pgx.Tx, and
+// pgxmock.
+type dbtx interface {
+	Query(ctx context.Context, sql string,
+		args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string,
+		args ...any) pgx.Row
+	Exec(ctx context.Context, sql string,
+		args ...any) (pgconn.CommandTag, error)
+}
+
+// storeForeignKey is the constraint tying a key to its
+// store.
+const storeForeignKey = "api_keys_store_id_fkey"
+
+// keyColumns is the column list scanned by scanKey.
+const keyColumns = "id, name, prefix, key_hash, scopes, created_by, " +
+	"created_at, expires_at, last_used_at, revoked_at, store_id"
+
+// scanKey scans a row selected with keyColumns.
+func scanKey(row pgx.Row) (APIKey, error) {
+	var k APIKey
+	err := row.Scan(
+		&k.ID, &k.Name, &k.Prefix, &k.KeyHash, &k.Scopes,
+		&k.CreatedBy, &k.CreatedAt, &k.ExpiresAt, &k.LastUsedAt,
+		&k.RevokedAt, &k.StoreID,
+	)
+	return k, err
+}
+
+// APIKeyRepository provides database access for API keys.
+type APIKeyRepository struct {
+	db dbtx
+}
+
+// NewAPIKeyRepository returns an APIKeyRepository backed by
+// the given database connection.
+func NewAPIKeyRepository(conn dbtx) *APIKeyRepository {
+	return &APIKeyRepository{db: conn}
+}
+
+// Create inserts a new API key and returns it with the
+// generated ID and timestamps. Returns store.ErrUnknownStore
+// if the key names a store that does not exist.
+func (r *APIKeyRepository) Create(
+	ctx context.Context,
+	k APIKey,
+) (APIKey, error) {
+	created, err := scanKey(r.db.QueryRow(ctx,
+		"INSERT INTO api_keys "+
+			"(name, prefix, key_hash, scopes, created_by, expires_at, "+
+			"store_id) "+
+			"VALUES ($1, $2, $3, $4, $5, $6, $7) "+
+			"RETURNING "+keyColumns,
+		k.Name, k.Prefix, k.KeyHash, k.Scopes, k.CreatedBy, k.ExpiresAt,
+		k.StoreID,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) &&
+			pgErr.ConstraintName == storeForeignKey {
+			return APIKey{}, store.ErrUnknownStore
+		}
+		return APIKey{}, fmt.Errorf("create api key: %w", err)
+	}
+	return created, nil
+}
+
+// FindAll returns every API key, newest first.
+func (r *APIKeyRepository) FindAll(
+	ctx context.Context,
+) ([]APIKey, error) {
+	rows, err := r.db.Query(ctx,
+		"SELECT "+keyColumns+" FROM api_keys ORDER BY id DESC",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("find api keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []APIKey
+	for rows.Next() {
+		k, err := scanKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan api key: %w", err)
+		}
+		keys = append(keys, k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate api keys: %w", err)
+	}
+	return keys, nil
+}
+
+// FindActiveByHash returns the unrevoked, unexpired key
+// with the given hash, or db.ErrNotFound.
+func (r *APIKeyRepository) FindActiveByHash(
+	ctx context.Context,
+	keyHash string,
+) (APIKey, error) {
+	k, err := scanKey(r.db.QueryRow(ctx,
+		"SELECT "+keyColumns+" FROM api_keys "+
+			"WHERE key_hash = $1 AND revoked_at IS NULL "+
+			"AND (expires_at IS NULL OR expires_at > now())",
+		keyHash,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return APIKey{}, db.ErrNotFound
+		}
+		return APIKey{}, fmt.Errorf("find api key: %w", err)
+	}
+	return k, nil
+}
+
+// Revoke marks the key as revoked. Returns db.ErrNotFound
+// if the key does not exist or is already revoked.
+func (r *APIKeyRepository) Revoke(
+	ctx context.Context,
+	id int64,
+) error {
+	tag, err := r.db.Exec(ctx,
+		"UPDATE api_keys SET revoked_at = now() "+
+			"WHERE id = $1 AND revoked_at IS NULL",
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("revoke api key: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteRevoked removes keys that were revoked or expired
+// before the given time, and returns how many were removed.
+// History keeps the IDs of keys that acted.
+func (r *APIKeyRepository) DeleteRevoked(
+	ctx context.Context,
+	before time.Time,
+) (int64, error) {
+	tag, err := r.db.Exec(ctx,
+		"DELETE FROM api_keys WHERE revoked_at < $1 OR expires_at < $1",
+		before,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("delete revoked api keys: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// TouchLastUsed records that the key was used at the given
+// time.
+func (r *APIKeyRepository) TouchLastUsed(
+	ctx context.Context,
+	id int64,
+	at time.Time,
+) error {
+	_, err := r.db.Exec(ctx,
+		"UPDATE api_keys SET last_used_at = $2 WHERE id = $1",
+		id, at,
+	)
+	if err != nil {
+		return fmt.Errorf("touch api key: %w", err)
+	}
+	return nil
+}
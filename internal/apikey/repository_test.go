@@ -0,0 +1,247 @@
+package apikey_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+
+	"github.com/hhubris/petstore/internal/apikey"
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/store"
+)
+
+// keyRowColumns matches the repository's keyColumns.
+var keyRowColumns = []string{
+	"id", "name", "prefix", "key_hash", "scopes", "created_by",
+	"created_at", "expires_at", "last_used_at", "revoked_at", "store_id",
+}
+
+func TestRepositoryCreate(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	now := time.Now()
+	scopes := []string{"pets:write"}
+	mock.ExpectQuery("INSERT INTO api_keys").
+		WithArgs("ci", "psk_abcdefgh", "hash", scopes, int64(1),
+			(*time.Time)(nil), (*int64)(nil)).
+		WillReturnRows(pgxmock.NewRows(keyRowColumns).AddRow(
+			int64(5), "ci", "psk_abcdefgh", "hash", scopes, int64(1),
+			now, (*time.Time)(nil), (*time.Time)(nil), (*time.Time)(nil),
+			(*int64)(nil),
+		))
+
+	repo := apikey.NewAPIKeyRepository(mock)
+	got, err := repo.Create(context.Background(), apikey.APIKey{
+		Name: "ci", Prefix: "psk_abcdefgh", KeyHash: "hash",
+		Scopes: scopes, CreatedBy: 1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ID != 5 || got.Name != "ci" {
+		t.Errorf("got %+v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepositoryCreateUnknownStore(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	storeID := int64(99)
+	scopes := []string{"pets:write"}
+	mock.ExpectQuery("INSERT INTO api_keys").
+		WithArgs("ci", "psk_abcdefgh", "hash", scopes, int64(1),
+			(*time.Time)(nil), &storeID).
+		WillReturnError(&pgconn.PgError{
+			Code:           "23503",
+			ConstraintName: "api_keys_store_id_fkey",
+		})
+
+	repo := apikey.NewAPIKeyRepository(mock)
+	_, err = repo.Create(context.Background(), apikey.APIKey{
+		Name: "ci", Prefix: "psk_abcdefgh", KeyHash: "hash",
+		Scopes: scopes, CreatedBy: 1, StoreID: &storeID,
+	})
+	if !errors.Is(err, store.ErrUnknownStore) {
+		t.Fatalf("err = %v, want ErrUnknownStore", err)
+	}
+}
+
+func TestRepositoryFindAll(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT .+ FROM api_keys ORDER BY id DESC").
+		WillReturnRows(pgxmock.NewRows(keyRowColumns).
+			AddRow(int64(2), "b", "psk_bbbbbbbb", "h2",
+				[]string{"pets:write"}, int64(1), now,
+				(*time.Time)(nil), &now, &now, (*int64)(nil)).
+			AddRow(int64(1), "a", "psk_aaaaaaaa", "h1",
+				[]string{"pets:write"}, int64(1), now,
+				(*time.Time)(nil), (*time.Time)(nil), (*time.Time)(nil),
+				(*int64)(nil)),
+		)
+
+	repo := apikey.NewAPIKeyRepository(mock)
+	got, err := repo.FindAll(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != 2 || got[0].RevokedAt == nil {
+		t.Errorf("got %+v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepositoryFindActiveByHash(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(m pgxmock.PgxPoolIface)
+		wantErr error
+	}{
+		{
+			name: "found",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("SELECT .+ FROM api_keys WHERE key_hash").
+					WithArgs("hash").
+					WillReturnRows(pgxmock.NewRows(keyRowColumns).AddRow(
+						int64(5), "ci", "psk_abcdefgh", "hash",
+						[]string{"pets:write"}, int64(1), time.Now(),
+						(*time.Time)(nil), (*time.Time)(nil),
+						(*time.Time)(nil), (*int64)(nil),
+					))
+			},
+		},
+		{
+			name: "not found",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("SELECT .+ FROM api_keys WHERE key_hash").
+					WithArgs("hash").
+					WillReturnRows(pgxmock.NewRows(keyRowColumns))
+			},
+			wantErr: db.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+			tt.mock(mock)
+
+			repo := apikey.NewAPIKeyRepository(mock)
+			got, err := repo.FindActiveByHash(context.Background(), "hash")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got.ID != 5 {
+				t.Errorf("ID = %d, want 5", got.ID)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestRepositoryRevoke(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    int64
+		wantErr error
+	}{
+		{name: "revoked", rows: 1},
+		{name: "missing or already revoked", rows: 0, wantErr: db.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+			mock.ExpectExec("UPDATE api_keys SET revoked_at").
+				WithArgs(int64(5)).
+				WillReturnResult(pgxmock.NewResult("UPDATE", tt.rows))
+
+			repo := apikey.NewAPIKeyRepository(mock)
+			err = repo.Revoke(context.Background(), 5)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestRepositoryDeleteRevoked(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	before := time.Now()
+	mock.ExpectExec("DELETE FROM api_keys WHERE revoked_at < \\$1 OR expires_at < \\$1").
+		WithArgs(before).
+		WillReturnResult(pgxmock.NewResult("DELETE", 2))
+
+	repo := apikey.NewAPIKeyRepository(mock)
+	n, err := repo.DeleteRevoked(context.Background(), before)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("deleted %d, want 2", n)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepositoryTouchLastUsed(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	now := time.Now()
+	mock.ExpectExec("UPDATE api_keys SET last_used_at").
+		WithArgs(int64(5), now).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	repo := apikey.NewAPIKeyRepository(mock)
+	if err := repo.TouchLastUsed(context.Background(), 5, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package apikey_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hhubris/petstore/internal/apikey"
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/db"
+)
+
+// mockRepo is a hand-written mock of apikey.Repository.
+type mockRepo struct {
+	createFn           func(ctx context.Context, k apikey.APIKey) (apikey.APIKey, error)
+	findAllFn          func(ctx context.Context) ([]apikey.APIKey, error)
+	findActiveByHashFn func(ctx context.Context, keyHash string) (apikey.APIKey, error)
+	revokeFn           func(ctx context.Context, id int64) error
+	touchLastUsedFn    func(ctx context.Context, id int64, at time.Time) error
+}
+
+func (m *mockRepo) Create(
+	ctx context.Context, k apikey.APIKey,
+) (apikey.APIKey, error) {
+	return m.createFn(ctx, k)
+}
+
+func (m *mockRepo) FindAll(ctx context.Context) ([]apikey.APIKey, error) {
+	return m.findAllFn(ctx)
+}
+
+func (m *mockRepo) FindActiveByHash(
+	ctx context.Context, keyHash string,
+) (apikey.APIKey, error) {
+	return m.findActiveByHashFn(ctx, keyHash)
+}
+
+func (m *mockRepo) Revoke(ctx context.Context, id int64) error {
+	return m.revokeFn(ctx, id)
+}
+
+func (m *mockRepo) TouchLastUsed(
+	ctx context.Context, id int64, at time.Time,
+) error {
+	return m.touchLastUsedFn(ctx, id, at)
+}
+
+func TestCreateAndAuthenticate(t *testing.T) {
+	var stored apikey.APIKey
+	touched := 0
+	repo := &mockRepo{
+		createFn: func(_ context.Context, k apikey.APIKey) (apikey.APIKey, error) {
+			k.ID = 9
+			stored = k
+			return k, nil
+		},
+		findActiveByHashFn: func(_ context.Context, h string) (apikey.APIKey, error) {
+			if h != stored.KeyHash {
+				return apikey.APIKey{}, db.ErrNotFound
+			}
+			return stored, nil
+		},
+		touchLastUsedFn: func(_ context.Context, id int64, at time.Time) error {
+			touched++
+			stored.LastUsedAt = &at
+			return nil
+		},
+	}
+	svc := apikey.NewService(repo)
+	ctx := context.Background()
+
+	storeID := int64(2)
+	k, secret, err := svc.Create(ctx, 1, "ci", []string{"pets:write"},
+		nil, &storeID)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if !strings.HasPrefix(secret, k.Prefix) || !strings.HasPrefix(secret, "psk_") {
+		t.Errorf("secret %q does not start with prefix %q", secret, k.Prefix)
+	}
+	if strings.Contains(stored.KeyHash, secret) {
+		t.Error("secret stored in plaintext")
+	}
+
+	for range 2 {
+		claims, err := svc.AuthenticateAPIKey(ctx, secret)
+		if err != nil {
+			t.Fatalf("AuthenticateAPIKey: %v", err)
+		}
+		if claims.APIKeyID != 9 || !claims.HasScope("pets:write") ||
+			claims.StoreID == nil || *claims.StoreID != storeID {
+			t.Errorf("got claims %+v", claims)
+		}
+	}
+	if touched != 1 {
+		t.Errorf("last_used_at written %d times, want 1", touched)
+	}
+
+	for _, bad := range []string{"psk_wrong", "not-a-key", ""} {
+		if _, err := svc.AuthenticateAPIKey(ctx, bad); !errors.Is(err, auth.ErrInvalidToken) {
+			t.Errorf("AuthenticateAPIKey(%q) err = %v, want ErrInvalidToken",
+				bad, err)
+		}
+	}
+}
+
+func TestCreateInvalidExpiry(t *testing.T) {
+	svc := apikey.NewService(&mockRepo{})
+	past := time.Now().Add(-time.Minute)
+	_, _, err := svc.Create(context.Background(), 1, "ci",
+		[]string{"pets:write"}, &past, nil)
+	if !errors.Is(err, apikey.ErrInvalidExpiry) {
+		t.Errorf("err = %v, want ErrInvalidExpiry", err)
+	}
+}
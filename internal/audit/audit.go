@@ -0,0 +1,80 @@
+// Package audit records security-relevant events: logins,
+// registrations, credential changes, and admin mutations.
+package audit
+
+import "time"
+
+// Actions recorded by the handlers.
+const (
+	ActionRegister          = "auth.register"
+	ActionLogin             = "auth.login"
+	ActionMFAVerify         = "auth.mfa_verify"
+	ActionOIDCLogin         = "auth.oidc_login"
+	ActionLogout            = "auth.logout"
+	ActionPasswordChange    = "auth.password_change"
+	ActionPasswordReset     = "auth.password_reset"
+	ActionPasswordForgot    = "auth.password_forgot"
+	ActionEmailVerify       = "auth.email_verify"
+	ActionEmailChange       = "auth.email_change"
+	ActionMFAEnable         = "auth.mfa_enable"
+	ActionUserUnlock        = "user.unlock"
+	ActionUserUpdate        = "user.update"
+	ActionAPIKeyCreate      = "api_key.create"
+	ActionAPIKeyRevoke      = "api_key.revoke"
+	ActionPetCreate         = "pet.create"
+	ActionPetImport         = "pet.import"
+	ActionPetDelete         = "pet.delete"
+	ActionPetRestore        = "pet.restore"
+	ActionWebhookCreate     = "webhook.create"
+	ActionWebhookDelete     = "webhook.delete"
+	ActionWebhookRedeliver  = "webhook.redeliver"
+	ActionStoreCreate       = "store.create"
+	ActionReservationCreate = "reservation.create"
+	ActionReservationCancel = "reservation.cancel"
+)
+
+// Outcome is whether the audited action succeeded.
+type Outcome string
+
+// Outcomes of an audited action.
+const (
+	OutcomeSuccess Outcome = "success"
+	OutcomeFailure Outcome = "failure"
+)
+
+// Event is a single audit log entry. Events are never
+// updated or deleted once written.
+type Event struct {
+	ID      int64
+	Action  string
+	Outcome Outcome
+	// ActorUserID and ActorAPIKeyID identify who performed
+	// the action; both are nil for anonymous callers, such
+	// as a failed login.
+	ActorUserID   *int64
+	ActorAPIKeyID *int64
+	// Target names what the action applied to, such as
+	// "pet:42" or "user:7".
+	Target        string
+	IP            string
+	UserAgent     string
+	CorrelationID string
+	// Details holds action-specific context. It must never
+	// contain passwords, tokens, or other secrets.
+	Details   map[string]string
+	CreatedAt time.Time
+}
+
+// Filter selects events for Service.List. Zero fields do
+// not filter.
+type Filter struct {
+	Action      string
+	ActorUserID *int64
+	Outcome     Outcome
+	Since       *time.Time
+	Until       *time.Time
+	// Before returns only events with a smaller ID, for
+	// paging backwards through the log.
+	Before *int64
+	Limit  int
+}
@@ -0,0 +1,129 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// dbtx is the database interface required by
+// EventRepository. Satisfied by *pgxpool.Pool, pgx.Tx, and
+// pgxmock.
+type dbtx interface {
+	Query(ctx context.Context, sql string,
+		args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string,
+		args ...any) pgx.Row
+	Exec(ctx context.Context, sql string,
+		args ...any) (pgconn.CommandTag, error)
+}
+
+// eventColumns is the column list scanned by scanEvent.
+const eventColumns = "id, action, outcome, actor_user_id, " +
+	"actor_api_key_id, target, ip, user_agent, correlation_id, " +
+	"details, created_at"
+
+// scanEvent scans a row selected with eventColumns.
+func scanEvent(row pgx.Row) (Event, error) {
+	var e Event
+	err := row.Scan(
+		&e.ID, &e.Action, &e.Outcome, &e.ActorUserID,
+		&e.ActorAPIKeyID, &e.Target, &e.IP, &e.UserAgent,
+		&e.CorrelationID, &e.Details, &e.CreatedAt,
+	)
+	return e, err
+}
+
+// EventRepository provides database access for audit
+// events. It only ever inserts and reads; the table is
+// append-only.
+type EventRepository struct {
+	db dbtx
+}
+
+// NewEventRepository returns an EventRepository backed by
+// the given database connection.
+func NewEventRepository(conn dbtx) *EventRepository {
+	return &EventRepository{db: conn}
+}
+
+// Insert appends an event to the log.
+func (r *EventRepository) Insert(ctx context.Context, e Event) error {
+	details := e.Details
+	if details == nil {
+		details = map[string]string{}
+	}
+	_, err := r.db.Exec(ctx,
+		"INSERT INTO audit_events "+
+			"(action, outcome, actor_user_id, actor_api_key_id, target, "+
+			"ip, user_agent, correlation_id, details) "+
+			"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+		e.Action, e.Outcome, e.ActorUserID, e.ActorAPIKeyID, e.Target,
+		e.IP, e.UserAgent, e.CorrelationID, details,
+	)
+	if err != nil {
+		return fmt.Errorf("insert audit event: %w", err)
+	}
+	return nil
+}
+
+// Find returns the events matching f, newest first, up to
+// f.Limit of them.
+func (r *EventRepository) Find(
+	ctx context.Context,
+	f Filter,
+) ([]Event, error) {
+	var where []string
+	var args []any
+	add := func(cond string, v any) {
+		args = append(args, v)
+		where = append(where, fmt.Sprintf(cond, len(args)))
+	}
+	if f.Action != "" {
+		add("action = $%d", f.Action)
+	}
+	if f.ActorUserID != nil {
+		add("actor_user_id = $%d", *f.ActorUserID)
+	}
+	if f.Outcome != "" {
+		add("outcome = $%d", f.Outcome)
+	}
+	if f.Since != nil {
+		add("created_at >= $%d", *f.Since)
+	}
+	if f.Until != nil {
+		add("created_at < $%d", *f.Until)
+	}
+	if f.Before != nil {
+		add("id < $%d", *f.Before)
+	}
+
+	q := "SELECT " + eventColumns + " FROM audit_events"
+	if len(where) > 0 {
+		q += " WHERE " + strings.Join(where, " AND ")
+	}
+	args = append(args, f.Limit)
+	q += fmt.Sprintf(" ORDER BY id DESC LIMIT $%d", len(args))
+
+	rows, err := r.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("find audit events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan audit event: %w", err)
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate audit events: %w", err)
+	}
+	return events, nil
+}
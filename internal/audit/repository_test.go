@@ -0,0 +1,158 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+
+	"github.com/hhubris/petstore/internal/audit"
+)
+
+// eventRowColumns matches the repository's eventColumns.
+var eventRowColumns = []string{
+	"id", "action", "outcome", "actor_user_id", "actor_api_key_id",
+	"target", "ip", "user_agent", "correlation_id", "details",
+	"created_at",
+}
+
+func TestRepositoryInsert(t *testing.T) {
+	actor := int64(3)
+	tests := []struct {
+		name    string
+		event   audit.Event
+		details map[string]string
+		err     error
+		wantErr bool
+	}{
+		{
+			name: "with details",
+			event: audit.Event{
+				Action: audit.ActionLogin, Outcome: audit.OutcomeSuccess,
+				ActorUserID: &actor, Target: "user:3", IP: "203.0.113.7",
+				UserAgent: "curl/8.0", CorrelationID: "cid",
+				Details: map[string]string{"email": "a@example.com"},
+			},
+			details: map[string]string{"email": "a@example.com"},
+		},
+		{
+			name: "nil details stored as empty object",
+			event: audit.Event{
+				Action: audit.ActionLogin, Outcome: audit.OutcomeFailure,
+			},
+			details: map[string]string{},
+		},
+		{
+			name: "database error",
+			event: audit.Event{
+				Action: audit.ActionLogin, Outcome: audit.OutcomeFailure,
+			},
+			details: map[string]string{},
+			err:     errors.New("connection refused"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			e := tt.event
+			exp := mock.ExpectExec("INSERT INTO audit_events").
+				WithArgs(e.Action, e.Outcome, e.ActorUserID,
+					e.ActorAPIKeyID, e.Target, e.IP, e.UserAgent,
+					e.CorrelationID, tt.details)
+			if tt.err != nil {
+				exp.WillReturnError(tt.err)
+			} else {
+				exp.WillReturnResult(pgxmock.NewResult("INSERT", 1))
+			}
+
+			repo := audit.NewEventRepository(mock)
+			err = repo.Insert(context.Background(), e)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestRepositoryFind(t *testing.T) {
+	actor := int64(3)
+	before := int64(50)
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := since.Add(24 * time.Hour)
+
+	tests := []struct {
+		name   string
+		filter audit.Filter
+		query  string
+		args   []any
+	}{
+		{
+			name:   "no filters",
+			filter: audit.Filter{Limit: 100},
+			query: `SELECT .+ FROM audit_events ` +
+				`ORDER BY id DESC LIMIT \$1`,
+			args: []any{100},
+		},
+		{
+			name: "every filter",
+			filter: audit.Filter{
+				Action: audit.ActionLogin, ActorUserID: &actor,
+				Outcome: audit.OutcomeFailure, Since: &since,
+				Until: &until, Before: &before, Limit: 10,
+			},
+			query: `SELECT .+ FROM audit_events WHERE action = \$1 ` +
+				`AND actor_user_id = \$2 AND outcome = \$3 ` +
+				`AND created_at >= \$4 AND created_at < \$5 ` +
+				`AND id < \$6 ORDER BY id DESC LIMIT \$7`,
+			args: []any{
+				audit.ActionLogin, actor, audit.OutcomeFailure,
+				since, until, before, 10,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			now := time.Now()
+			mock.ExpectQuery(tt.query).
+				WithArgs(tt.args...).
+				WillReturnRows(pgxmock.NewRows(eventRowColumns).AddRow(
+					int64(7), audit.ActionLogin, audit.OutcomeFailure,
+					(*int64)(nil), (*int64)(nil), "", "203.0.113.7",
+					"curl/8.0", "cid",
+					map[string]string{"email": "a@example.com"}, now,
+				))
+
+			repo := audit.NewEventRepository(mock)
+			got, err := repo.Find(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != 1 || got[0].ID != 7 ||
+				got[0].Details["email"] != "a@example.com" {
+				t.Errorf("got %+v", got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
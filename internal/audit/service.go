@@ -0,0 +1,115 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/middleware"
+)
+
+const (
+	// defaultLimit is the page size when a Filter has none.
+	defaultLimit = 100
+
+	// maxLimit caps the page size of a single query.
+	maxLimit = 500
+)
+
+// Repository is the persistence interface the service
+// depends on. EventRepository satisfies it via duck typing.
+type Repository interface {
+	Insert(ctx context.Context, e Event) error
+	Find(ctx context.Context, f Filter) ([]Event, error)
+}
+
+// Service records and queries audit events.
+type Service struct {
+	repo Repository
+}
+
+// NewService returns a Service wired to the given
+// repository.
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Record writes e to the log at Info and appends it to the
+// repository. The actor, client IP, user agent, and
+// correlation ID are filled in from ctx when e does not set
+// them. Recording never fails the caller's request: a
+// repository error is logged and dropped.
+func (s *Service) Record(ctx context.Context, e Event) {
+	if claims, ok := auth.ClaimsFromContext(ctx); ok {
+		if e.ActorUserID == nil && claims.UserID != 0 {
+			id := claims.UserID
+			e.ActorUserID = &id
+		}
+		if e.ActorAPIKeyID == nil && claims.APIKeyID != 0 {
+			id := claims.APIKeyID
+			e.ActorAPIKeyID = &id
+		}
+	}
+	if e.Outcome == "" {
+		e.Outcome = OutcomeSuccess
+	}
+	if e.IP == "" {
+		e.IP = middleware.GetClientIP(ctx)
+	}
+	if e.UserAgent == "" {
+		e.UserAgent = middleware.GetUserAgent(ctx)
+	}
+	if e.CorrelationID == "" {
+		e.CorrelationID = middleware.GetCorrelationID(ctx)
+	}
+
+	attrs := []slog.Attr{
+		slog.String("action", e.Action),
+		slog.String("outcome", string(e.Outcome)),
+	}
+	if e.ActorUserID != nil {
+		attrs = append(attrs, slog.Int64("actor_user_id", *e.ActorUserID))
+	}
+	if e.ActorAPIKeyID != nil {
+		attrs = append(attrs,
+			slog.Int64("actor_api_key_id", *e.ActorAPIKeyID),
+		)
+	}
+	if e.Target != "" {
+		attrs = append(attrs, slog.String("target", e.Target))
+	}
+	attrs = append(attrs,
+		slog.String("ip", e.IP),
+		slog.String("user_agent", e.UserAgent),
+		slog.String("correlation_id", e.CorrelationID),
+	)
+	if len(e.Details) > 0 {
+		var details []any
+		for _, k := range slices.Sorted(maps.Keys(e.Details)) {
+			details = append(details, slog.String(k, e.Details[k]))
+		}
+		attrs = append(attrs, slog.Group("details", details...))
+	}
+	slog.LogAttrs(ctx, slog.LevelInfo, "audit", attrs...)
+
+	// The event is written even if the client has gone away.
+	if err := s.repo.Insert(context.WithoutCancel(ctx), e); err != nil {
+		slog.ErrorContext(ctx, "writing audit event",
+			"action", e.Action, "err", err,
+		)
+	}
+}
+
+// List returns the events matching f, newest first. A zero
+// or oversized f.Limit is clamped to a sensible page size.
+func (s *Service) List(ctx context.Context, f Filter) ([]Event, error) {
+	if f.Limit <= 0 {
+		f.Limit = defaultLimit
+	}
+	if f.Limit > maxLimit {
+		f.Limit = maxLimit
+	}
+	return s.repo.Find(ctx, f)
+}
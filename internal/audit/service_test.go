@@ -0,0 +1,224 @@
+package audit_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/audit"
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/middleware"
+)
+
+// mockRepo is a hand-written mock of audit.Repository.
+type mockRepo struct {
+	insertFn func(ctx context.Context, e audit.Event) error
+	findFn   func(ctx context.Context, f audit.Filter) ([]audit.Event, error)
+}
+
+func (m *mockRepo) Insert(ctx context.Context, e audit.Event) error {
+	return m.insertFn(ctx, e)
+}
+
+func (m *mockRepo) Find(
+	ctx context.Context, f audit.Filter,
+) ([]audit.Event, error) {
+	return m.findFn(ctx, f)
+}
+
+// captureLogs redirects the default logger to a buffer for
+// the duration of the test.
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+	return &buf
+}
+
+// requestContext runs the request-scoped middleware over a
+// request and returns the context a handler would see.
+func requestContext(t *testing.T) context.Context {
+	t.Helper()
+	var ctx context.Context
+	inner := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	})
+	req := httptest.NewRequest(http.MethodPost, "/pets", nil)
+	req.RemoteAddr = "203.0.113.7:51234"
+	req.Header.Set("User-Agent", "curl/8.0")
+	req.Header.Set("X-Correlation-ID", "cid-1")
+	middleware.Chain(inner,
+		middleware.CorrelationID(),
+		middleware.ClientInfo(),
+	).ServeHTTP(httptest.NewRecorder(), req)
+	return ctx
+}
+
+func TestRecord(t *testing.T) {
+	tests := []struct {
+		name          string
+		claims        *auth.Claims
+		event         audit.Event
+		wantUser      int64
+		wantKey       int64
+		wantOutcome   audit.Outcome
+		wantLogSubstr []string
+	}{
+		{
+			name:        "user actor from claims",
+			claims:      &auth.Claims{UserID: 4, Role: "admin"},
+			event:       audit.Event{Action: audit.ActionPetDelete, Target: "pet:9"},
+			wantUser:    4,
+			wantOutcome: audit.OutcomeSuccess,
+			wantLogSubstr: []string{
+				"msg=audit", "action=pet.delete", "actor_user_id=4",
+				"target=pet:9", "ip=203.0.113.7", "correlation_id=cid-1",
+			},
+		},
+		{
+			name:        "api key actor from claims",
+			claims:      &auth.Claims{APIKeyID: 2, Scopes: []string{"pets:write"}},
+			event:       audit.Event{Action: audit.ActionPetCreate},
+			wantKey:     2,
+			wantOutcome: audit.OutcomeSuccess,
+			wantLogSubstr: []string{
+				"actor_api_key_id=2",
+			},
+		},
+		{
+			name: "anonymous failure keeps explicit fields",
+			event: audit.Event{
+				Action:  audit.ActionLogin,
+				Outcome: audit.OutcomeFailure,
+				Details: map[string]string{"email": "a@example.com"},
+			},
+			wantOutcome: audit.OutcomeFailure,
+			wantLogSubstr: []string{
+				"outcome=failure", "details.email=a@example.com",
+				`user_agent=curl/8.0`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := captureLogs(t)
+			var got audit.Event
+			svc := audit.NewService(&mockRepo{
+				insertFn: func(_ context.Context, e audit.Event) error {
+					got = e
+					return nil
+				},
+			})
+
+			ctx := requestContext(t)
+			if tt.claims != nil {
+				ctx = auth.ContextWithClaims(ctx, *tt.claims)
+			}
+			svc.Record(ctx, tt.event)
+
+			if got.Outcome != tt.wantOutcome {
+				t.Errorf("outcome = %q, want %q", got.Outcome, tt.wantOutcome)
+			}
+			if tt.wantUser != 0 &&
+				(got.ActorUserID == nil || *got.ActorUserID != tt.wantUser) {
+				t.Errorf("actor user = %v, want %d", got.ActorUserID, tt.wantUser)
+			}
+			if tt.wantUser == 0 && got.ActorUserID != nil {
+				t.Errorf("actor user = %d, want nil", *got.ActorUserID)
+			}
+			if tt.wantKey != 0 &&
+				(got.ActorAPIKeyID == nil || *got.ActorAPIKeyID != tt.wantKey) {
+				t.Errorf("actor key = %v, want %d", got.ActorAPIKeyID, tt.wantKey)
+			}
+			if got.IP != "203.0.113.7" || got.UserAgent != "curl/8.0" ||
+				got.CorrelationID != "cid-1" {
+				t.Errorf("request metadata = %q %q %q",
+					got.IP, got.UserAgent, got.CorrelationID)
+			}
+			out := logs.String()
+			if !strings.Contains(out, "level=INFO") {
+				t.Errorf("log not at INFO: %s", out)
+			}
+			for _, s := range tt.wantLogSubstr {
+				if !strings.Contains(out, s) {
+					t.Errorf("log missing %q: %s", s, out)
+				}
+			}
+		})
+	}
+}
+
+func TestRecordRepositoryError(t *testing.T) {
+	logs := captureLogs(t)
+	svc := audit.NewService(&mockRepo{
+		insertFn: func(context.Context, audit.Event) error {
+			return errors.New("connection refused")
+		},
+	})
+
+	svc.Record(context.Background(), audit.Event{Action: audit.ActionLogout})
+
+	out := logs.String()
+	if !strings.Contains(out, "level=ERROR") ||
+		!strings.Contains(out, "connection refused") {
+		t.Errorf("repository error not logged: %s", out)
+	}
+}
+
+func TestRecordOutlivesCancelledRequest(t *testing.T) {
+	captureLogs(t)
+	var insertErr error
+	svc := audit.NewService(&mockRepo{
+		insertFn: func(ctx context.Context, _ audit.Event) error {
+			insertErr = ctx.Err()
+			return nil
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	svc.Record(ctx, audit.Event{Action: audit.ActionLogout})
+
+	if insertErr != nil {
+		t.Errorf("insert saw cancelled context: %v", insertErr)
+	}
+}
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		wantLimit int
+	}{
+		{name: "default", limit: 0, wantLimit: 100},
+		{name: "within range", limit: 20, wantLimit: 20},
+		{name: "clamped", limit: 5000, wantLimit: 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotLimit int
+			svc := audit.NewService(&mockRepo{
+				findFn: func(_ context.Context, f audit.Filter) ([]audit.Event, error) {
+					gotLimit = f.Limit
+					return nil, nil
+				},
+			})
+			if _, err := svc.List(context.Background(),
+				audit.Filter{Limit: tt.limit}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gotLimit != tt.wantLimit {
+				t.Errorf("limit = %d, want %d", gotLimit, tt.wantLimit)
+			}
+		})
+	}
+}
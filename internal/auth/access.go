@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hhubris/petstore/internal/store"
+)
+
+// ErrAccountDisabled is returned when a disabled user tries
+// to log in. It is only returned once the credentials have
+// been checked.
+var ErrAccountDisabled = errors.New("account disabled")
+
+// WithUserCache lets the service drop a user's cached record
+// whenever it changes their role, disabled state, or session
+// version, so a SecurityHandler sharing the cache sees the
+// change on the next request.
+func WithUserCache(c *UserCache) Option {
+	return func(s *Service) { s.cache = c }
+}
+
+// AccessChange is a change to a user's access. Nil fields
+// are left unchanged.
+type AccessChange struct {
+	Role     *string
+	Disabled *bool
+	// StoreID confines the user to a store. Global makes
+	// them a global user instead; it is not combined with
+	// StoreID.
+	StoreID *int64
+	Global  bool
+}
+
+// UpdateUserAccess applies c to a user. A caller confined to
+// a store may change only that store's users and cannot move
+// them out of it, which returns store.ErrOtherStore. Returns
+// db.ErrNotFound if the user does not exist or is outside
+// the caller's store, and store.ErrUnknownStore for a store
+// that does not exist.
+func (s *Service) UpdateUserAccess(
+	ctx context.Context, id int64, c AccessChange,
+) (User, error) {
+	scope := StoreScope(ctx)
+	if scope != nil && (c.Global ||
+		(c.StoreID != nil && *c.StoreID != *scope)) {
+		return User{}, store.ErrOtherStore
+	}
+	user, err := s.repo.UpdateAccess(ctx, id, c, scope)
+	if err != nil {
+		return User{}, err
+	}
+	s.invalidate(id)
+	return user, nil
+}
+
+// invalidate drops the user's cached record, if any.
+func (s *Service) invalidate(id int64) {
+	if s.cache != nil {
+		s.cache.Invalidate(id)
+	}
+}
@@ -0,0 +1,147 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/store"
+)
+
+func TestUpdateUserAccess(t *testing.T) {
+	staff := "staff"
+	disabled := true
+
+	scope := int64(2)
+	other := int64(3)
+
+	tests := []struct {
+		name    string
+		scope   *int64
+		change  auth.AccessChange
+		repoErr error
+		wantErr error
+	}{
+		{name: "success"},
+		{name: "scoped", scope: &scope},
+		{
+			name:    "unknown user",
+			repoErr: db.ErrNotFound,
+			wantErr: db.ErrNotFound,
+		},
+		{
+			name:    "scoped to another store",
+			scope:   &scope,
+			change:  auth.AccessChange{StoreID: &other},
+			wantErr: store.ErrOtherStore,
+		},
+		{
+			name:    "scoped made global",
+			scope:   &scope,
+			change:  auth.AccessChange{Global: true},
+			wantErr: store.ErrOtherStore,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role := "customer"
+			lookups := 0
+			repo := &mockRepo{
+				findByIDFn: func(
+					_ context.Context, id int64,
+				) (auth.User, error) {
+					lookups++
+					return auth.User{ID: id, Role: role}, nil
+				},
+				updateAccessFn: func(
+					_ context.Context, id int64,
+					c auth.AccessChange, s *int64,
+				) (auth.User, error) {
+					if c.Role != &staff || c.Disabled != &disabled {
+						t.Error("role or disabled not passed through")
+					}
+					if s != tt.scope {
+						t.Errorf("scope = %v, want %v", s, tt.scope)
+					}
+					r := c.Role
+					if tt.repoErr != nil {
+						return auth.User{}, tt.repoErr
+					}
+					role = *r
+					return auth.User{ID: id, Role: role}, nil
+				},
+			}
+			cache := auth.NewUserCache(repo, time.Minute)
+			svc := newTestService(t, repo, auth.WithUserCache(cache))
+			ctx := context.Background()
+			if tt.scope != nil {
+				ctx = auth.ContextWithClaims(ctx,
+					auth.Claims{UserID: 1, StoreID: tt.scope})
+			}
+
+			if _, err := cache.FindByID(ctx, 5); err != nil {
+				t.Fatalf("FindByID: %v", err)
+			}
+			c := tt.change
+			c.Role, c.Disabled = &staff, &disabled
+			u, err := svc.UpdateUserAccess(ctx, 5, c)
+			if !errorIs(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if u.Role != "staff" {
+				t.Errorf("Role = %q, want staff", u.Role)
+			}
+
+			cached, err := cache.FindByID(ctx, 5)
+			if err != nil {
+				t.Fatalf("FindByID: %v", err)
+			}
+			if cached.Role != "staff" || lookups != 2 {
+				t.Errorf("cache not invalidated: role %q after %d lookups",
+					cached.Role, lookups)
+			}
+		})
+	}
+}
+
+func TestLoginDisabledAccount(t *testing.T) {
+	hash, _ := bcrypt.GenerateFromPassword(
+		[]byte("s3cret"), bcrypt.MinCost,
+	)
+	disabledAt := time.Now().Add(-time.Hour)
+	repo := &mockRepo{
+		findByEmailFn: func(
+			_ context.Context, email string,
+		) (auth.User, error) {
+			return auth.User{
+				ID: 1, Email: email, Role: "customer",
+				PasswordHash: string(hash),
+				DisabledAt:   &disabledAt,
+			}, nil
+		},
+		recordLoginFailureFn: func(context.Context, int64) (int, error) {
+			return 1, nil
+		},
+	}
+	svc := newTestService(t, repo)
+
+	_, err := svc.Login(context.Background(), "a@example.com", "s3cret")
+	if !errorIs(err, auth.ErrAccountDisabled) {
+		t.Fatalf("err = %v, want ErrAccountDisabled", err)
+	}
+
+	// A wrong password is reported as such, so the disabled
+	// state does not leak to someone without the password.
+	_, err = svc.Login(context.Background(), "a@example.com", "guess")
+	if !errorIs(err, auth.ErrInvalidCredentials) {
+		t.Fatalf("err = %v, want ErrInvalidCredentials", err)
+	}
+}
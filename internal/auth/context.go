@@ -24,3 +24,10 @@ func ClaimsFromContext(
 	c, ok := ctx.Value(claimsKey{}).(Claims)
 	return c, ok
 }
+
+// StoreScope returns the store the caller in ctx is
+// confined to, or nil for a global caller or none at all.
+func StoreScope(ctx context.Context) *int64 {
+	c, _ := ClaimsFromContext(ctx)
+	return c.StoreID
+}
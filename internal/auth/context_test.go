@@ -2,6 +2,7 @@ package auth_test
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	"github.com/hhubris/petstore/internal/auth"
@@ -43,7 +44,7 @@ func TestClaimsContext(t *testing.T) {
 					ok, tt.wantOK,
 				)
 			}
-			if got != tt.wantClaim {
+			if !reflect.DeepEqual(got, tt.wantClaim) {
 				t.Fatalf(
 					"ClaimsFromContext() = %+v, want %+v",
 					got, tt.wantClaim,
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/mail"
+)
+
+// emailChangeTokenTTL is how long an email change
+// confirmation link stays valid.
+const emailChangeTokenTTL = 24 * time.Hour
+
+// ErrInvalidEmailChangeToken is returned when an email
+// change token is unknown, expired, or already used.
+var ErrInvalidEmailChangeToken = errors.New(
+	"invalid or expired email change token",
+)
+
+// RequestEmailChange emails a confirmation link to newEmail
+// after checking the user's password, and tells the old
+// address about the request. The email is only changed by
+// ConfirmEmailChange. Returns db.ErrConflict if newEmail is
+// already registered, and ErrIncorrectPassword as
+// ChangePassword does.
+func (s *Service) RequestEmailChange(
+	ctx context.Context,
+	userID int64,
+	newEmail, password string,
+) error {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.confirmPassword(ctx, user, password); err != nil {
+		return err
+	}
+	switch _, err := s.repo.FindByEmail(ctx, newEmail); {
+	case err == nil:
+		return db.ErrConflict
+	case !errors.Is(err, db.ErrNotFound):
+		return err
+	}
+
+	token, hash, err := newOpaqueToken()
+	if err != nil {
+		return err
+	}
+	if err := s.repo.CreateEmailChangeToken(
+		ctx, user.ID, newEmail, hash,
+		s.timeNow().Add(emailChangeTokenTTL),
+	); err != nil {
+		return err
+	}
+
+	link := s.appURL + "/confirm-email?token=" + url.QueryEscape(token)
+	if err := s.mailer.Send(ctx, mail.Message{
+		To:      newEmail,
+		Subject: "Confirm your new Pet Store email address",
+		Body: "Hi " + user.Name + ",\n\n" +
+			"To start using this address for your Pet Store " +
+			"account, open this link within 24 hours:\n\n" +
+			link + "\n\n" +
+			"If you didn't ask for this, you can ignore this " +
+			"email.\n",
+	}); err != nil {
+		return fmt.Errorf("sending email change confirmation: %w", err)
+	}
+
+	// The notice is a courtesy; the change already needs the
+	// password and the new inbox.
+	if err := s.mailer.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "Your Pet Store email address is changing",
+		Body: "Someone signed in to your Pet Store account " +
+			"asked to change its email address to " +
+			newEmail + ".\n\n" +
+			"If this wasn't you, reset your password now.\n",
+	}); err != nil {
+		slog.ErrorContext(ctx, "sending email change notice",
+			"user_id", user.ID, "err", err,
+		)
+	}
+	return nil
+}
+
+// ConfirmEmailChange applies the email change behind
+// token. Returns ErrInvalidEmailChangeToken if the token is
+// unknown, expired, or already used, and db.ErrConflict if
+// the address was registered in the meantime.
+func (s *Service) ConfirmEmailChange(
+	ctx context.Context,
+	token string,
+) error {
+	err := s.repo.UpdateEmail(ctx, hashToken(token))
+	if errors.Is(err, db.ErrNotFound) {
+		return ErrInvalidEmailChangeToken
+	}
+	return err
+}
@@ -0,0 +1,167 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/db"
+)
+
+func TestRequestEmailChange(t *testing.T) {
+	hash, _ := bcrypt.GenerateFromPassword(
+		[]byte("s3cret"), bcrypt.MinCost,
+	)
+
+	tests := []struct {
+		name     string
+		password string
+		findErr  error
+		wantErr  error
+		wantSent int
+	}{
+		{
+			name:     "success",
+			password: "s3cret",
+			findErr:  db.ErrNotFound,
+			wantSent: 2,
+		},
+		{
+			name:     "email taken",
+			password: "s3cret",
+			wantErr:  db.ErrConflict,
+		},
+		{
+			name:     "wrong password",
+			password: "wrong",
+			wantErr:  auth.ErrIncorrectPassword,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var storedHash string
+			repo := &mockRepo{
+				findByIDFn: func(
+					_ context.Context, id int64,
+				) (auth.User, error) {
+					return auth.User{
+						ID: id, Name: "Alice",
+						Email:        "alice@example.com",
+						PasswordHash: string(hash),
+					}, nil
+				},
+				findByEmailFn: func(
+					_ context.Context, email string,
+				) (auth.User, error) {
+					if email != "new@example.com" {
+						t.Errorf("email = %q", email)
+					}
+					return auth.User{ID: 2}, tt.findErr
+				},
+				recordLoginFailureFn: func(
+					context.Context, int64,
+				) (int, error) {
+					return 1, nil
+				},
+				createEmailChangeTokenFn: func(
+					_ context.Context, userID int64,
+					newEmail, tokenHash string, expiresAt time.Time,
+				) error {
+					if userID != 1 || newEmail != "new@example.com" {
+						t.Errorf("token for %d %q", userID, newEmail)
+					}
+					if time.Until(expiresAt) < 23*time.Hour {
+						t.Errorf("expiresAt = %v", expiresAt)
+					}
+					storedHash = tokenHash
+					return nil
+				},
+			}
+			mailer := &recordingMailer{}
+			svc := newTestService(t, repo, auth.WithMailer(mailer))
+
+			err := svc.RequestEmailChange(
+				context.Background(), 1, "new@example.com", tt.password,
+			)
+			if !errorIs(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if len(mailer.sent) != tt.wantSent {
+				t.Fatalf("sent %d emails, want %d",
+					len(mailer.sent), tt.wantSent)
+			}
+			if tt.wantSent == 0 {
+				return
+			}
+
+			confirm, notice := mailer.sent[0], mailer.sent[1]
+			if confirm.To != "new@example.com" {
+				t.Errorf("confirmation sent to %q", confirm.To)
+			}
+			token := tokenFromLink(t, confirm.Body)
+			sum := sha256.Sum256([]byte(token))
+			if storedHash != hex.EncodeToString(sum[:]) {
+				t.Error("emailed token does not match stored hash")
+			}
+			if notice.To != "alice@example.com" {
+				t.Errorf("notice sent to %q", notice.To)
+			}
+		})
+	}
+}
+
+func TestConfirmEmailChange(t *testing.T) {
+	errDB := errors.New("connection reset")
+
+	tests := []struct {
+		name    string
+		repoErr error
+		wantErr error
+	}{
+		{name: "success"},
+		{
+			name:    "unknown or used token",
+			repoErr: db.ErrNotFound,
+			wantErr: auth.ErrInvalidEmailChangeToken,
+		},
+		{
+			name:    "address taken meanwhile",
+			repoErr: db.ErrConflict,
+			wantErr: db.ErrConflict,
+		},
+		{
+			name:    "repository error",
+			repoErr: errDB,
+			wantErr: errDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockRepo{
+				updateEmailFn: func(
+					_ context.Context, tokenHash string,
+				) error {
+					sum := sha256.Sum256([]byte("tok"))
+					if tokenHash != hex.EncodeToString(sum[:]) {
+						t.Errorf("tokenHash = %q", tokenHash)
+					}
+					return tt.repoErr
+				},
+			}
+			svc := newTestService(t, repo)
+
+			err := svc.ConfirmEmailChange(context.Background(), "tok")
+			if !errorIs(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/db"
+)
+
+func TestVerifyEmail(t *testing.T) {
+	errDB := errors.New("connection reset")
+
+	// wantLookups counts the user loads through the cache
+	// before and after verifying: a second load means the
+	// cached record was dropped.
+	tests := []struct {
+		name        string
+		repoErr     error
+		wantErr     error
+		wantID      int64
+		wantLookups int
+	}{
+		{name: "success", wantID: 5, wantLookups: 2},
+		{
+			name:        "unknown or used token",
+			repoErr:     db.ErrNotFound,
+			wantErr:     auth.ErrInvalidVerificationToken,
+			wantLookups: 1,
+		},
+		{
+			name:        "repository error",
+			repoErr:     errDB,
+			wantErr:     errDB,
+			wantLookups: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookups := 0
+			repo := &mockRepo{
+				verifyEmailFn: func(
+					_ context.Context, tokenHash string,
+				) (int64, error) {
+					sum := sha256.Sum256([]byte("tok"))
+					if tokenHash != hex.EncodeToString(sum[:]) {
+						t.Errorf("tokenHash = %q", tokenHash)
+					}
+					return 5, tt.repoErr
+				},
+				findByIDFn: func(_ context.Context, id int64) (auth.User, error) {
+					lookups++
+					return auth.User{ID: id}, nil
+				},
+			}
+			cache := auth.NewUserCache(repo, time.Minute)
+			svc := newTestService(t, repo, auth.WithUserCache(cache))
+			ctx := context.Background()
+			if _, err := cache.FindByID(ctx, 5); err != nil {
+				t.Fatalf("FindByID: %v", err)
+			}
+
+			id, err := svc.VerifyEmail(ctx, "tok")
+			if !errorIs(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if id != tt.wantID {
+				t.Errorf("id = %d, want %d", id, tt.wantID)
+			}
+			if _, err := cache.FindByID(ctx, 5); err != nil {
+				t.Fatalf("FindByID: %v", err)
+			}
+			if lookups != tt.wantLookups {
+				t.Errorf("lookups = %d, want %d", lookups, tt.wantLookups)
+			}
+		})
+	}
+}
+
+func TestResendVerification(t *testing.T) {
+	verified := time.Now()
+	alice := auth.User{ID: 7, Name: "Alice", Email: "alice@example.com"}
+
+	tests := []struct {
+		name     string
+		user     auth.User
+		recent   int
+		hourly   int
+		wantErr  error
+		wantMail bool
+	}{
+		{
+			name:     "success",
+			user:     alice,
+			hourly:   2,
+			wantMail: true,
+		},
+		{
+			name: "already verified",
+			user: auth.User{
+				ID: 7, Email: alice.Email,
+				EmailVerifiedAt: &verified,
+			},
+			wantErr: auth.ErrEmailAlreadyVerified,
+		},
+		{
+			name:    "within a minute of the last email",
+			user:    alice,
+			recent:  1,
+			hourly:  1,
+			wantErr: auth.ErrTooManyRequests,
+		},
+		{
+			name:    "hourly limit reached",
+			user:    alice,
+			hourly:  5,
+			wantErr: auth.ErrTooManyRequests,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var storedHash string
+			repo := &mockRepo{
+				findByIDFn: func(
+					context.Context, int64,
+				) (auth.User, error) {
+					return tt.user, nil
+				},
+				countVerificationTokensSinceFn: func(
+					_ context.Context, _ int64, since time.Time,
+				) (int, error) {
+					if time.Since(since) > 2*time.Minute {
+						return tt.hourly, nil
+					}
+					return tt.recent, nil
+				},
+				createVerificationTokenFn: func(
+					_ context.Context, _ int64,
+					hash string, exp time.Time,
+				) error {
+					storedHash = hash
+					if d := time.Until(exp); d <= 23*time.Hour ||
+						d > 24*time.Hour {
+						t.Errorf("expiry %v not about 24h away", exp)
+					}
+					return nil
+				},
+			}
+			mailer := &recordingMailer{}
+			svc := newTestService(t, repo,
+				auth.WithMailer(mailer),
+				auth.WithAppURL("https://shop.test"),
+			)
+
+			err := svc.ResendVerification(context.Background(), 7)
+			if !errorIs(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if !tt.wantMail {
+				if len(mailer.sent) != 0 {
+					t.Fatalf("sent %d messages, want 0",
+						len(mailer.sent))
+				}
+				return
+			}
+			if len(mailer.sent) != 1 {
+				t.Fatalf("sent %d messages, want 1",
+					len(mailer.sent))
+			}
+			msg := mailer.sent[0]
+			if !strings.Contains(msg.Body,
+				"https://shop.test/verify-email?token=") {
+				t.Errorf("body lacks verify link:\n%s", msg.Body)
+			}
+			token := tokenFromLink(t, msg.Body)
+			sum := sha256.Sum256([]byte(token))
+			if storedHash != hex.EncodeToString(sum[:]) {
+				t.Error("stored hash does not match emailed token")
+			}
+		})
+	}
+}
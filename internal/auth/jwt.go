@@ -3,6 +3,7 @@ package auth
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"time"
 
@@ -13,54 +14,226 @@ import (
 // or fails validation.
 var ErrInvalidToken = errors.New("invalid token")
 
+// mfaTokenTTL is how long an MFA challenge token issued
+// after the password step stays valid.
+const mfaTokenTTL = 5 * time.Minute
+
+// mfaPurpose marks MFA challenge tokens so they cannot be
+// used as access tokens, and vice versa.
+const mfaPurpose = "mfa"
+
+// oidcStateTTL bounds how long a user may spend at the
+// identity provider before the login attempt expires.
+const oidcStateTTL = 10 * time.Minute
+
+// oidcPurpose marks OIDC login state tokens.
+const oidcPurpose = "oidc"
+
 // Claims holds the application-level claims extracted from
 // a validated JWT.
 type Claims struct {
-	UserID int64
-	Role   string
+	UserID        int64
+	Role          string
+	EmailVerified bool
+	// AMR lists the authentication methods used to obtain
+	// the token (RFC 8176): "pwd", plus "otp" after a
+	// second factor.
+	AMR []string
+	// SessionVersion is the user's session version when the
+	// token was issued; see User.SessionVersion.
+	SessionVersion int
+	// APIKeyID and Scopes are set instead of UserID and Role
+	// when the request authenticated with an API key. They
+	// are never encoded into JWTs.
+	APIKeyID int64
+	Scopes   []string
+	// StoreID is the store the user or API key is confined
+	// to, or nil for a global one.
+	StoreID *int64
 }
 
-// TokenConfig holds the signing key and expiry duration used
-// to create and parse JWTs.
+// HasAMR reports whether method is among the token's
+// authentication methods.
+func (c Claims) HasAMR(method string) bool {
+	return slices.Contains(c.AMR, method)
+}
+
+// HasScope reports whether the API key that produced these
+// claims was granted scope.
+func (c Claims) HasScope(scope string) bool {
+	return slices.Contains(c.Scopes, scope)
+}
+
+// TokenConfig holds the keys and expiry duration used to
+// create and parse JWTs.
 type TokenConfig struct {
-	signingKey []byte
-	expiry     time.Duration
+	signing Key
+	// verify holds every key that may have signed a live
+	// token, keyed by kid, including signing.
+	verify map[string]Key
+	// keyOrder lists verify's kids with signing first, for
+	// a stable JWKS.
+	keyOrder []string
+	expiry   time.Duration
 	// timeNow is used for testing; defaults to time.Now.
 	timeNow func() time.Time
 }
 
-// NewTokenConfig validates the secret and returns a
+// NewTokenConfig validates the secret and returns an HS256
 // TokenConfig with a default 1-hour expiry.
 func NewTokenConfig(secret []byte) (*TokenConfig, error) {
-	if len(secret) < 32 {
-		return nil, fmt.Errorf(
-			"jwt secret must be at least 32 bytes, got %d",
-			len(secret),
-		)
+	k, err := NewHMACKey(secret)
+	if err != nil {
+		return nil, err
 	}
-	return &TokenConfig{
-		signingKey: secret,
-		expiry:     time.Hour,
-		timeNow:    time.Now,
-	}, nil
+	return NewKeyTokenConfig(k)
+}
+
+// NewKeyTokenConfig returns a TokenConfig that signs with
+// signing and also accepts tokens signed by any of verify.
+// Listing the previous signing key in verify keeps its
+// tokens valid until they expire, so keys can be rotated
+// without logging users out.
+func NewKeyTokenConfig(
+	signing Key, verify ...Key,
+) (*TokenConfig, error) {
+	if !signing.CanSign() {
+		return nil, errors.New("signing key has no private key")
+	}
+	tc := &TokenConfig{
+		signing: signing,
+		verify:  make(map[string]Key, len(verify)+1),
+		expiry:  time.Hour,
+		timeNow: time.Now,
+	}
+	for _, k := range append([]Key{signing}, verify...) {
+		if _, dup := tc.verify[k.id]; dup {
+			continue
+		}
+		tc.verify[k.id] = k
+		tc.keyOrder = append(tc.keyOrder, k.id)
+	}
+	return tc, nil
+}
+
+// PublicKeys returns the asymmetric verification keys for
+// publishing as a JWKS, current signing key first. HMAC
+// secrets are never included.
+func (tc *TokenConfig) PublicKeys() []JWK {
+	keys := []JWK{}
+	for _, kid := range tc.keyOrder {
+		if j := tc.verify[kid].JWK(); j.Kty != "" {
+			keys = append(keys, j)
+		}
+	}
+	return keys
 }
 
-// CreateToken signs a JWT containing the given user ID and
-// role. The token uses HS256 and includes sub, role, iat,
-// and exp claims.
-func (tc *TokenConfig) CreateToken(
-	userID int64,
-	role string,
-) (string, error) {
+// CreateToken signs a JWT carrying the given claims. The
+// token includes sub, role, email_verified, amr, sv, store,
+// iat, and exp claims, and the signing key's kid header.
+func (tc *TokenConfig) CreateToken(c Claims) (string, error) {
 	now := tc.timeNow()
 	claims := jwt.MapClaims{
-		"sub":  strconv.FormatInt(userID, 10),
-		"role": role,
-		"iat":  jwt.NewNumericDate(now),
-		"exp":  jwt.NewNumericDate(now.Add(tc.expiry)),
+		"sub":            strconv.FormatInt(c.UserID, 10),
+		"role":           c.Role,
+		"email_verified": c.EmailVerified,
+		"iat":            jwt.NewNumericDate(now),
+		"exp":            jwt.NewNumericDate(now.Add(tc.expiry)),
+	}
+	if len(c.AMR) > 0 {
+		claims["amr"] = c.AMR
+	}
+	if c.SessionVersion != 0 {
+		claims["sv"] = c.SessionVersion
+	}
+	if c.StoreID != nil {
+		claims["store"] = *c.StoreID
+	}
+	return tc.sign(claims)
+}
+
+// CreateMFAToken signs a short-lived challenge token for a
+// user who has passed the password step and must still
+// present a second factor. ParseToken rejects it.
+func (tc *TokenConfig) CreateMFAToken(userID int64) (string, error) {
+	now := tc.timeNow()
+	return tc.sign(jwt.MapClaims{
+		"sub":     strconv.FormatInt(userID, 10),
+		"purpose": mfaPurpose,
+		"iat":     jwt.NewNumericDate(now),
+		"exp":     jwt.NewNumericDate(now.Add(mfaTokenTTL)),
+	})
+}
+
+// ParseMFAToken validates a token from CreateMFAToken and
+// returns the user ID it was issued for.
+func (tc *TokenConfig) ParseMFAToken(tokenString string) (int64, error) {
+	mapClaims, err := tc.parse(tokenString)
+	if err != nil {
+		return 0, err
+	}
+	if p, _ := mapClaims["purpose"].(string); p != mfaPurpose {
+		return 0, fmt.Errorf(
+			"%w: not an mfa token", ErrInvalidToken,
+		)
+	}
+	return subject(mapClaims)
+}
+
+// oidcState is the per-attempt secret state of an OIDC
+// login, carried in a signed cookie across the redirect to
+// the identity provider.
+type oidcState struct {
+	State    string
+	Nonce    string
+	Verifier string
+}
+
+// createOIDCStateToken signs st into a short-lived token.
+// ParseToken rejects it.
+func (tc *TokenConfig) createOIDCStateToken(st oidcState) (string, error) {
+	now := tc.timeNow()
+	return tc.sign(jwt.MapClaims{
+		"purpose":  oidcPurpose,
+		"state":    st.State,
+		"nonce":    st.Nonce,
+		"verifier": st.Verifier,
+		"iat":      jwt.NewNumericDate(now),
+		"exp":      jwt.NewNumericDate(now.Add(oidcStateTTL)),
+	})
+}
+
+// parseOIDCStateToken validates a token from
+// createOIDCStateToken.
+func (tc *TokenConfig) parseOIDCStateToken(tokenString string) (oidcState, error) {
+	mapClaims, err := tc.parse(tokenString)
+	if err != nil {
+		return oidcState{}, err
+	}
+	if p, _ := mapClaims["purpose"].(string); p != oidcPurpose {
+		return oidcState{}, fmt.Errorf(
+			"%w: not an oidc state token", ErrInvalidToken,
+		)
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := token.SignedString(tc.signingKey)
+	var st oidcState
+	st.State, _ = mapClaims["state"].(string)
+	st.Nonce, _ = mapClaims["nonce"].(string)
+	st.Verifier, _ = mapClaims["verifier"].(string)
+	if st.State == "" || st.Nonce == "" || st.Verifier == "" {
+		return oidcState{}, fmt.Errorf(
+			"%w: incomplete oidc state", ErrInvalidToken,
+		)
+	}
+	return st, nil
+}
+
+// sign returns claims signed with the signing key, with its
+// kid in the header.
+func (tc *TokenConfig) sign(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(tc.signing.method, claims)
+	token.Header["kid"] = tc.signing.id
+	signed, err := token.SignedString(tc.signing.private)
 	if err != nil {
 		return "", fmt.Errorf("signing token: %w", err)
 	}
@@ -69,53 +242,147 @@ func (tc *TokenConfig) CreateToken(
 
 // ParseToken validates the token string and returns the
 // extracted claims. It checks the signing method, signature,
-// and expiry.
+// and expiry, and rejects MFA challenge tokens.
 func (tc *TokenConfig) ParseToken(
 	tokenString string,
 ) (Claims, error) {
+	mapClaims, err := tc.parse(tokenString)
+	if err != nil {
+		return Claims{}, err
+	}
+	if _, ok := mapClaims["purpose"]; ok {
+		return Claims{}, fmt.Errorf(
+			"%w: not an access token", ErrInvalidToken,
+		)
+	}
+
+	userID, err := subject(mapClaims)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	role, ok := mapClaims["role"].(string)
+	if !ok {
+		return Claims{}, fmt.Errorf(
+			"%w: missing role claim", ErrInvalidToken,
+		)
+	}
+
+	// Tokens issued before email verification existed lack
+	// the claim; treat them as unverified.
+	verified, _ := mapClaims["email_verified"].(bool)
+
+	var amr []string
+	if list, ok := mapClaims["amr"].([]any); ok {
+		for _, v := range list {
+			if m, ok := v.(string); ok {
+				amr = append(amr, m)
+			}
+		}
+	}
+
+	// Tokens issued before session versions existed lack
+	// the claim, which matches the column's default of 0.
+	sv, _ := mapClaims["sv"].(float64)
+
+	// Tokens of global users, and those issued before stores
+	// existed, lack the claim.
+	var storeID *int64
+	if v, ok := mapClaims["store"].(float64); ok {
+		id := int64(v)
+		storeID = &id
+	}
+
+	return Claims{
+		UserID:         userID,
+		Role:           role,
+		EmailVerified:  verified,
+		AMR:            amr,
+		SessionVersion: int(sv),
+		StoreID:        storeID,
+	}, nil
+}
+
+// parse verifies the signature, signing method, and expiry
+// of tokenString and returns its claims.
+func (tc *TokenConfig) parse(
+	tokenString string,
+) (jwt.MapClaims, error) {
 	token, err := jwt.Parse(
 		tokenString,
-		func(t *jwt.Token) (any, error) {
-			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf(
-					"unexpected signing method: %v",
-					t.Header["alg"],
-				)
-			}
-			return tc.signingKey, nil
-		},
+		tc.verificationKey,
 		jwt.WithTimeFunc(tc.timeNow),
 	)
 	if err != nil {
-		return Claims{}, fmt.Errorf(
+		return nil, fmt.Errorf(
 			"%w: %w", ErrInvalidToken, err,
 		)
 	}
 
 	mapClaims, ok := token.Claims.(jwt.MapClaims)
 	if !ok || !token.Valid {
-		return Claims{}, ErrInvalidToken
+		return nil, ErrInvalidToken
 	}
+	return mapClaims, nil
+}
 
+// verificationKey is the jwt.Keyfunc for parse. It selects
+// the key named by the kid header and requires the token's
+// alg to match it, so a public key can never be used as an
+// HMAC secret.
+func (tc *TokenConfig) verificationKey(t *jwt.Token) (any, error) {
+	kid, _ := t.Header["kid"].(string)
+	if kid == "" {
+		return tc.hmacKeys(t)
+	}
+	k, ok := tc.verify[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown kid %q", kid)
+	}
+	if t.Method.Alg() != k.method.Alg() {
+		return nil, fmt.Errorf(
+			"unexpected signing method: %v", t.Header["alg"],
+		)
+	}
+	return k.public, nil
+}
+
+// hmacKeys returns every configured HS256 key for a token
+// without a kid. Tokens issued before kid headers were
+// added carry none and were signed with an HS256 secret,
+// which may be the current one or, during a rotation, the
+// previous one.
+func (tc *TokenConfig) hmacKeys(t *jwt.Token) (any, error) {
+	if t.Method != jwt.SigningMethodHS256 {
+		return nil, fmt.Errorf(
+			"unexpected signing method: %v", t.Header["alg"],
+		)
+	}
+	var set jwt.VerificationKeySet
+	for _, kid := range tc.keyOrder {
+		if k := tc.verify[kid]; k.method == jwt.SigningMethodHS256 {
+			set.Keys = append(set.Keys, k.public)
+		}
+	}
+	if len(set.Keys) == 0 {
+		return nil, errors.New("no key for a token without kid")
+	}
+	return set, nil
+}
+
+// subject returns the user ID from the sub claim.
+func subject(mapClaims jwt.MapClaims) (int64, error) {
 	sub, err := mapClaims.GetSubject()
 	if err != nil {
-		return Claims{}, fmt.Errorf(
+		return 0, fmt.Errorf(
 			"%w: missing sub claim", ErrInvalidToken,
 		)
 	}
 	userID, err := strconv.ParseInt(sub, 10, 64)
 	if err != nil {
-		return Claims{}, fmt.Errorf(
+		return 0, fmt.Errorf(
 			"%w: invalid sub claim: %w", ErrInvalidToken, err,
 		)
 	}
-
-	role, ok := mapClaims["role"].(string)
-	if !ok {
-		return Claims{}, fmt.Errorf(
-			"%w: missing role claim", ErrInvalidToken,
-		)
-	}
-
-	return Claims{UserID: userID, Role: role}, nil
+	return userID, nil
 }
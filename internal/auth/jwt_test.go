@@ -1,7 +1,10 @@
 package auth
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -55,14 +58,16 @@ func TestNewTokenConfig(t *testing.T) {
 
 func TestJWTRoundTrip(t *testing.T) {
 	tests := []struct {
-		name   string
-		userID int64
-		role   string
+		name     string
+		userID   int64
+		role     string
+		verified bool
 	}{
 		{
-			name:   "admin user",
-			userID: 42,
-			role:   "admin",
+			name:     "admin user",
+			userID:   42,
+			role:     "admin",
+			verified: true,
 		},
 		{
 			name:   "regular user",
@@ -83,7 +88,11 @@ func TestJWTRoundTrip(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			token, err := cfg.CreateToken(tt.userID, tt.role)
+			token, err := cfg.CreateToken(Claims{
+				UserID:        tt.userID,
+				Role:          tt.role,
+				EmailVerified: tt.verified,
+			})
 			if err != nil {
 				t.Fatalf("CreateToken: %v", err)
 			}
@@ -105,6 +114,12 @@ func TestJWTRoundTrip(t *testing.T) {
 					claims.Role, tt.role,
 				)
 			}
+			if claims.EmailVerified != tt.verified {
+				t.Errorf(
+					"EmailVerified = %v, want %v",
+					claims.EmailVerified, tt.verified,
+				)
+			}
 		})
 	}
 }
@@ -119,7 +134,7 @@ func TestJWTExpiredToken(t *testing.T) {
 	past := time.Now().Add(-2 * time.Hour)
 	cfg.timeNow = func() time.Time { return past }
 
-	token, err := cfg.CreateToken(1, "user")
+	token, err := cfg.CreateToken(Claims{UserID: 1, Role: "user"})
 	if err != nil {
 		t.Fatalf("CreateToken: %v", err)
 	}
@@ -141,13 +156,21 @@ func TestJWTTamperedToken(t *testing.T) {
 		t.Fatalf("NewTokenConfig: %v", err)
 	}
 
-	token, err := cfg.CreateToken(1, "user")
+	token, err := cfg.CreateToken(Claims{UserID: 1, Role: "user"})
 	if err != nil {
 		t.Fatalf("CreateToken: %v", err)
 	}
 
-	// Tamper with the token by flipping a character.
-	tampered := token[:len(token)-1] + "X"
+	// Tamper with the signature by changing its first
+	// character. The last character is not used because its
+	// low bits are padding, so some substitutions decode to
+	// the same signature bytes.
+	sigStart := strings.LastIndex(token, ".") + 1
+	c := "A"
+	if token[sigStart] == 'A' {
+		c = "B"
+	}
+	tampered := token[:sigStart] + c + token[sigStart+1:]
 
 	_, err = cfg.ParseToken(tampered)
 	if err == nil {
@@ -170,7 +193,7 @@ func TestJWTWrongSigningKey(t *testing.T) {
 		t.Fatalf("NewTokenConfig: %v", err)
 	}
 
-	token, err := cfg1.CreateToken(1, "user")
+	token, err := cfg1.CreateToken(Claims{UserID: 1, Role: "user"})
 	if err != nil {
 		t.Fatalf("CreateToken: %v", err)
 	}
@@ -248,3 +271,333 @@ func TestJWTSigningMethodEnforced(t *testing.T) {
 		t.Errorf("expected ErrInvalidToken, got: %v", err)
 	}
 }
+
+func TestJWTAMRRoundTrip(t *testing.T) {
+	cfg, err := NewTokenConfig(validSecret)
+	if err != nil {
+		t.Fatalf("NewTokenConfig: %v", err)
+	}
+
+	token, err := cfg.CreateToken(Claims{
+		UserID: 1, Role: "admin", AMR: []string{"pwd", "otp"},
+	})
+	if err != nil {
+		t.Fatalf("CreateToken: %v", err)
+	}
+	claims, err := cfg.ParseToken(token)
+	if err != nil {
+		t.Fatalf("ParseToken: %v", err)
+	}
+	if !claims.HasAMR("otp") || !claims.HasAMR("pwd") {
+		t.Errorf("AMR = %v, want [pwd otp]", claims.AMR)
+	}
+}
+
+func TestJWTSessionVersionRoundTrip(t *testing.T) {
+	cfg, err := NewTokenConfig(validSecret)
+	if err != nil {
+		t.Fatalf("NewTokenConfig: %v", err)
+	}
+
+	for _, sv := range []int{0, 3} {
+		token, err := cfg.CreateToken(Claims{
+			UserID: 1, Role: "customer", SessionVersion: sv,
+		})
+		if err != nil {
+			t.Fatalf("CreateToken: %v", err)
+		}
+		claims, err := cfg.ParseToken(token)
+		if err != nil {
+			t.Fatalf("ParseToken: %v", err)
+		}
+		if claims.SessionVersion != sv {
+			t.Errorf("SessionVersion = %d, want %d",
+				claims.SessionVersion, sv)
+		}
+	}
+}
+
+func TestJWTStoreRoundTrip(t *testing.T) {
+	cfg, err := NewTokenConfig(validSecret)
+	if err != nil {
+		t.Fatalf("NewTokenConfig: %v", err)
+	}
+
+	storeID := int64(7)
+	for _, want := range []*int64{nil, &storeID} {
+		token, err := cfg.CreateToken(Claims{
+			UserID: 1, Role: "admin", StoreID: want,
+		})
+		if err != nil {
+			t.Fatalf("CreateToken: %v", err)
+		}
+		claims, err := cfg.ParseToken(token)
+		if err != nil {
+			t.Fatalf("ParseToken: %v", err)
+		}
+		if !sameStore(claims.StoreID, want) {
+			t.Errorf("StoreID = %v, want %v", claims.StoreID, want)
+		}
+	}
+}
+
+func TestJWTMFAToken(t *testing.T) {
+	cfg, err := NewTokenConfig(validSecret)
+	if err != nil {
+		t.Fatalf("NewTokenConfig: %v", err)
+	}
+
+	mfa, err := cfg.CreateMFAToken(42)
+	if err != nil {
+		t.Fatalf("CreateMFAToken: %v", err)
+	}
+	id, err := cfg.ParseMFAToken(mfa)
+	if err != nil {
+		t.Fatalf("ParseMFAToken: %v", err)
+	}
+	if id != 42 {
+		t.Errorf("user ID = %d, want 42", id)
+	}
+
+	if _, err := cfg.ParseToken(mfa); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("ParseToken(mfa token) err = %v, want ErrInvalidToken",
+			err)
+	}
+
+	access, err := cfg.CreateToken(Claims{UserID: 42, Role: "admin"})
+	if err != nil {
+		t.Fatalf("CreateToken: %v", err)
+	}
+	if _, err := cfg.ParseMFAToken(access); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("ParseMFAToken(access token) err = %v, want ErrInvalidToken",
+			err)
+	}
+
+	cfg.timeNow = func() time.Time { return time.Now().Add(mfaTokenTTL + time.Minute) }
+	if _, err := cfg.ParseMFAToken(mfa); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expired mfa token err = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestJWTAsymmetricRoundTrip(t *testing.T) {
+	edPriv, _ := ed25519PEM(t)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey: %v", err)
+	}
+	rsaPriv, _ := marshalPEM(t, rsaKey, &rsaKey.PublicKey)
+
+	for _, tt := range []struct {
+		name string
+		pem  []byte
+		alg  string
+	}{
+		{name: "EdDSA", pem: edPriv, alg: "EdDSA"},
+		{name: "RS256", pem: rsaPriv, alg: "RS256"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			k := mustParsePEMKey(t, tt.pem)
+			cfg, err := NewKeyTokenConfig(k)
+			if err != nil {
+				t.Fatalf("NewKeyTokenConfig: %v", err)
+			}
+			token, err := cfg.CreateToken(Claims{UserID: 7, Role: "admin"})
+			if err != nil {
+				t.Fatalf("CreateToken: %v", err)
+			}
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+			if err != nil {
+				t.Fatalf("ParseUnverified: %v", err)
+			}
+			if parsed.Header["alg"] != tt.alg || parsed.Header["kid"] != k.ID() {
+				t.Errorf("header = %v, want alg %s kid %s",
+					parsed.Header, tt.alg, k.ID())
+			}
+
+			claims, err := cfg.ParseToken(token)
+			if err != nil {
+				t.Fatalf("ParseToken: %v", err)
+			}
+			if claims.UserID != 7 {
+				t.Errorf("UserID = %d, want 7", claims.UserID)
+			}
+			if keys := cfg.PublicKeys(); len(keys) != 1 || keys[0].Kid != k.ID() {
+				t.Errorf("PublicKeys() = %+v", keys)
+			}
+		})
+	}
+}
+
+func TestJWTKeyRotation(t *testing.T) {
+	oldPEM, oldPub := ed25519PEM(t)
+	newPEM, _ := ed25519PEM(t)
+	oldKey := mustParsePEMKey(t, oldPEM)
+	newKey := mustParsePEMKey(t, newPEM)
+
+	oldCfg, err := NewKeyTokenConfig(oldKey)
+	if err != nil {
+		t.Fatalf("NewKeyTokenConfig: %v", err)
+	}
+	oldToken, err := oldCfg.CreateToken(Claims{UserID: 1, Role: "user"})
+	if err != nil {
+		t.Fatalf("CreateToken: %v", err)
+	}
+
+	// During the rotation window the retired public key
+	// still verifies.
+	rotating, err := NewKeyTokenConfig(newKey, mustParsePEMKey(t, oldPub))
+	if err != nil {
+		t.Fatalf("NewKeyTokenConfig: %v", err)
+	}
+	if _, err := rotating.ParseToken(oldToken); err != nil {
+		t.Errorf("old token during rotation: %v", err)
+	}
+	keys := rotating.PublicKeys()
+	if len(keys) != 2 || keys[0].Kid != newKey.ID() || keys[1].Kid != oldKey.ID() {
+		t.Errorf("PublicKeys() = %+v, want new then old", keys)
+	}
+
+	// Once it is dropped, its tokens are rejected.
+	rotated, err := NewKeyTokenConfig(newKey)
+	if err != nil {
+		t.Fatalf("NewKeyTokenConfig: %v", err)
+	}
+	if _, err := rotated.ParseToken(oldToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("old token after rotation err = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestJWTLegacyTokenWithoutKid(t *testing.T) {
+	hmacKey, err := NewHMACKey(validSecret)
+	if err != nil {
+		t.Fatalf("NewHMACKey: %v", err)
+	}
+	edPriv, _ := ed25519PEM(t)
+	cfg, err := NewKeyTokenConfig(mustParsePEMKey(t, edPriv), hmacKey)
+	if err != nil {
+		t.Fatalf("NewKeyTokenConfig: %v", err)
+	}
+
+	legacy, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":  "3",
+		"role": "user",
+		"exp":  jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).SignedString(validSecret)
+	if err != nil {
+		t.Fatalf("signing legacy token: %v", err)
+	}
+	claims, err := cfg.ParseToken(legacy)
+	if err != nil {
+		t.Fatalf("ParseToken(legacy): %v", err)
+	}
+	if claims.UserID != 3 {
+		t.Errorf("UserID = %d, want 3", claims.UserID)
+	}
+	if keys := cfg.PublicKeys(); len(keys) != 1 {
+		t.Errorf("PublicKeys() has %d keys, want HMAC excluded", len(keys))
+	}
+}
+
+func TestJWTLegacyTokenDuringSecretRotation(t *testing.T) {
+	current := []byte("the-current-secret-long-enough-32b")
+	previous := []byte("the-previous-secret-long-enough-32")
+	other := []byte("some-other-secret-that-is-long-32b")
+	curKey, err := NewHMACKey(current)
+	if err != nil {
+		t.Fatalf("NewHMACKey: %v", err)
+	}
+	prevKey, err := NewHMACKey(previous)
+	if err != nil {
+		t.Fatalf("NewHMACKey: %v", err)
+	}
+	edPriv, _ := ed25519PEM(t)
+	edKey := mustParsePEMKey(t, edPriv)
+
+	// Tokens without a kid must verify against any HMAC key,
+	// whatever order the keys were configured in.
+	tests := []struct {
+		name   string
+		keys   []Key
+		accept [][]byte
+	}{
+		{
+			name:   "hmac signing",
+			keys:   []Key{curKey, prevKey},
+			accept: [][]byte{current, previous},
+		},
+		{
+			name:   "asymmetric, current first",
+			keys:   []Key{edKey, curKey, prevKey},
+			accept: [][]byte{current, previous},
+		},
+		{
+			name:   "asymmetric, previous first",
+			keys:   []Key{edKey, prevKey, curKey},
+			accept: [][]byte{current, previous},
+		},
+	}
+
+	legacy := func(t *testing.T, secret []byte) string {
+		t.Helper()
+		tok, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"sub":  "3",
+			"role": "user",
+			"exp":  jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		}).SignedString(secret)
+		if err != nil {
+			t.Fatalf("signing legacy token: %v", err)
+		}
+		return tok
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := NewKeyTokenConfig(tt.keys[0], tt.keys[1:]...)
+			if err != nil {
+				t.Fatalf("NewKeyTokenConfig: %v", err)
+			}
+			for _, secret := range tt.accept {
+				if _, err := cfg.ParseToken(legacy(t, secret)); err != nil {
+					t.Errorf("token signed with %q rejected: %v", secret, err)
+				}
+			}
+			_, err = cfg.ParseToken(legacy(t, other))
+			if !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("unknown secret err = %v, want ErrInvalidToken", err)
+			}
+		})
+	}
+}
+
+func TestJWTAlgorithmConfusion(t *testing.T) {
+	edPriv, edPub := ed25519PEM(t)
+	k := mustParsePEMKey(t, edPriv)
+	cfg, err := NewKeyTokenConfig(k)
+	if err != nil {
+		t.Fatalf("NewKeyTokenConfig: %v", err)
+	}
+
+	// An HS256 token "signed" with the published public key
+	// and claiming its kid must not verify.
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":  "1",
+		"role": "admin",
+		"exp":  jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	})
+	token.Header["kid"] = k.ID()
+	forged, err := token.SignedString(edPub)
+	if err != nil {
+		t.Fatalf("signing forged token: %v", err)
+	}
+	if _, err := cfg.ParseToken(forged); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("err = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestNewKeyTokenConfigRequiresPrivateKey(t *testing.T) {
+	_, edPub := ed25519PEM(t)
+	if _, err := NewKeyTokenConfig(mustParsePEMKey(t, edPub)); err == nil {
+		t.Fatal("expected error for public signing key")
+	}
+}
@@ -0,0 +1,171 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minRSABits is the smallest RSA modulus accepted for RS256.
+const minRSABits = 2048
+
+// Key is a JWT signing or verification key. Keys loaded from
+// a public PEM block can only verify.
+type Key struct {
+	id     string
+	method jwt.SigningMethod
+	// private signs tokens; nil for verification-only keys.
+	private any
+	// public verifies tokens. For HMAC keys it is the
+	// shared secret.
+	public any
+}
+
+// ID returns the key's kid: the RFC 7638 thumbprint for
+// asymmetric keys, or a hash prefix for HMAC secrets.
+func (k Key) ID() string { return k.id }
+
+// CanSign reports whether the key holds private material.
+func (k Key) CanSign() bool { return k.private != nil }
+
+// NewHMACKey returns an HS256 key for the given shared
+// secret, which must be at least 32 bytes.
+func NewHMACKey(secret []byte) (Key, error) {
+	if len(secret) < 32 {
+		return Key{}, fmt.Errorf(
+			"jwt secret must be at least 32 bytes, got %d",
+			len(secret),
+		)
+	}
+	sum := sha256.Sum256(secret)
+	return Key{
+		id:      "hs256-" + hex.EncodeToString(sum[:8]),
+		method:  jwt.SigningMethodHS256,
+		private: secret,
+		public:  secret,
+	}, nil
+}
+
+// ParsePEMKey parses an Ed25519 or RSA key from PEM. Private
+// keys (PKCS #8, or PKCS #1 for RSA) can sign and verify;
+// public keys (PKIX, or PKCS #1 for RSA) can only verify.
+// Ed25519 keys sign with EdDSA and RSA keys with RS256.
+func ParsePEMKey(data []byte) (Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return Key{}, errors.New("no PEM block found")
+	}
+
+	var (
+		parsed any
+		err    error
+	)
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return Key{}, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return Key{}, fmt.Errorf("parsing %s: %w", block.Type, err)
+	}
+
+	var k Key
+	switch key := parsed.(type) {
+	case ed25519.PrivateKey:
+		k = Key{private: key, public: key.Public()}
+	case ed25519.PublicKey:
+		k = Key{public: key}
+	case *rsa.PrivateKey:
+		k = Key{private: key, public: &key.PublicKey}
+	case *rsa.PublicKey:
+		k = Key{public: key}
+	default:
+		return Key{}, fmt.Errorf("unsupported key type %T", parsed)
+	}
+
+	switch pub := k.public.(type) {
+	case ed25519.PublicKey:
+		k.method = jwt.SigningMethodEdDSA
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < minRSABits {
+			return Key{}, fmt.Errorf(
+				"rsa key must be at least %d bits, got %d",
+				minRSABits, pub.N.BitLen(),
+			)
+		}
+		k.method = jwt.SigningMethodRS256
+	}
+	k.id = k.JWK().thumbprint()
+	return k, nil
+}
+
+// JWK is a public key in JSON Web Key form (RFC 7517). Only
+// the members used by EdDSA and RS256 keys are modeled.
+type JWK struct {
+	Kty string
+	Kid string
+	Alg string
+	// Crv and X are set for OKP (Ed25519) keys.
+	Crv string
+	X   string
+	// N and E are set for RSA keys.
+	N string
+	E string
+}
+
+// JWK returns the public half of k. HMAC keys have no public
+// form and return a zero JWK.
+func (k Key) JWK() JWK {
+	enc := base64.RawURLEncoding
+	switch pub := k.public.(type) {
+	case ed25519.PublicKey:
+		return JWK{
+			Kty: "OKP", Kid: k.id, Alg: k.method.Alg(),
+			Crv: "Ed25519", X: enc.EncodeToString(pub),
+		}
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA", Kid: k.id, Alg: k.method.Alg(),
+			N: enc.EncodeToString(pub.N.Bytes()),
+			E: enc.EncodeToString(
+				big.NewInt(int64(pub.E)).Bytes(),
+			),
+		}
+	}
+	return JWK{}
+}
+
+// thumbprint returns the RFC 7638 SHA-256 thumbprint of the
+// key: a hash over its required members in lexicographic
+// order with no whitespace.
+func (j JWK) thumbprint() string {
+	var canonical string
+	switch j.Kty {
+	case "OKP":
+		canonical = fmt.Sprintf(
+			`{"crv":%q,"kty":"OKP","x":%q}`, j.Crv, j.X,
+		)
+	case "RSA":
+		canonical = fmt.Sprintf(
+			`{"e":%q,"kty":"RSA","n":%q}`, j.E, j.N,
+		)
+	}
+	sum := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ed25519PEM returns a freshly generated Ed25519 key as
+// PKCS #8 and PKIX PEM.
+func ed25519PEM(t *testing.T) (private, public []byte) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	return marshalPEM(t, priv, pub)
+}
+
+// marshalPEM encodes a private key as PKCS #8 PEM and its
+// public key as PKIX PEM.
+func marshalPEM(t *testing.T, priv, pub any) (private, public []byte) {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey: %v", err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})
+}
+
+// mustParsePEMKey is ParsePEMKey for known-good input.
+func mustParsePEMKey(t *testing.T, data []byte) Key {
+	t.Helper()
+	k, err := ParsePEMKey(data)
+	if err != nil {
+		t.Fatalf("ParsePEMKey: %v", err)
+	}
+	return k
+}
+
+func TestParsePEMKey(t *testing.T) {
+	edPriv, edPub := ed25519PEM(t)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey: %v", err)
+	}
+	rsaPriv, rsaPub := marshalPEM(t, rsaKey, &rsaKey.PublicKey)
+	rsaPKCS1 := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(rsaKey),
+	})
+
+	smallRSA, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey: %v", err)
+	}
+	smallPriv, _ := marshalPEM(t, smallRSA, &smallRSA.PublicKey)
+
+	tests := []struct {
+		name     string
+		pem      []byte
+		wantAlg  string
+		wantSign bool
+		wantErr  bool
+	}{
+		{name: "ed25519 private", pem: edPriv, wantAlg: "EdDSA", wantSign: true},
+		{name: "ed25519 public", pem: edPub, wantAlg: "EdDSA"},
+		{name: "rsa pkcs8 private", pem: rsaPriv, wantAlg: "RS256", wantSign: true},
+		{name: "rsa pkcs1 private", pem: rsaPKCS1, wantAlg: "RS256", wantSign: true},
+		{name: "rsa public", pem: rsaPub, wantAlg: "RS256"},
+		{name: "rsa too small", pem: smallPriv, wantErr: true},
+		{name: "not pem", pem: []byte("secret"), wantErr: true},
+		{
+			name: "unsupported block",
+			pem: pem.EncodeToMemory(&pem.Block{
+				Type: "CERTIFICATE", Bytes: []byte{1},
+			}),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := ParsePEMKey(tt.pem)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if k.method.Alg() != tt.wantAlg {
+				t.Errorf("alg = %s, want %s", k.method.Alg(), tt.wantAlg)
+			}
+			if k.CanSign() != tt.wantSign {
+				t.Errorf("CanSign = %v, want %v", k.CanSign(), tt.wantSign)
+			}
+			if k.ID() == "" || k.JWK().Kid != k.ID() {
+				t.Errorf("kid = %q, JWK kid = %q", k.ID(), k.JWK().Kid)
+			}
+		})
+	}
+
+	// The private and public halves of a key share a kid.
+	if a, b := mustParsePEMKey(t, edPriv), mustParsePEMKey(t, edPub); a.ID() != b.ID() {
+		t.Errorf("private kid %q != public kid %q", a.ID(), b.ID())
+	}
+}
+
+func TestJWKThumbprint(t *testing.T) {
+	// RFC 8037 Appendix A.1 and A.3.
+	seed, err := base64.RawURLEncoding.DecodeString(
+		"nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv := ed25519.NewKeyFromSeed(seed)
+	privPEM, _ := marshalPEM(t, priv, priv.Public())
+
+	k := mustParsePEMKey(t, privPEM)
+	if got, want := k.JWK().X, "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"; got != want {
+		t.Errorf("x = %s, want %s", got, want)
+	}
+	if got, want := k.ID(), "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k"; got != want {
+		t.Errorf("kid = %s, want %s", got, want)
+	}
+}
+
+func TestHMACKeyHasNoJWK(t *testing.T) {
+	k, err := NewHMACKey(validSecret)
+	if err != nil {
+		t.Fatalf("NewHMACKey: %v", err)
+	}
+	if k.method != jwt.SigningMethodHS256 || !k.CanSign() {
+		t.Errorf("got method %v, CanSign %v", k.method, k.CanSign())
+	}
+	if j := k.JWK(); j.Kty != "" {
+		t.Errorf("JWK() = %+v, want zero", j)
+	}
+}
@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// lockoutThreshold is the number of consecutive failed
+	// logins after which an account is locked.
+	lockoutThreshold = 5
+
+	// lockoutBase is the lock applied at the threshold. Each
+	// further failure doubles it, up to lockoutMax.
+	lockoutBase = time.Minute
+
+	// lockoutMax caps a single lock period.
+	lockoutMax = time.Hour
+)
+
+// UnlockUser clears the failed login counter and any lock
+// on the given account. Returns db.ErrNotFound if the user
+// does not exist or is outside the caller's store.
+func (s *Service) UnlockUser(ctx context.Context, id int64) error {
+	return s.repo.UnlockUser(ctx, id, StoreScope(ctx))
+}
+
+// isLocked reports whether user is inside a lock period.
+func (s *Service) isLocked(user User) bool {
+	return user.LockedUntil != nil &&
+		s.timeNow().Before(*user.LockedUntil)
+}
+
+// recordLoginFailure counts a failed login and locks the
+// account once the count reaches lockoutThreshold.
+// Attempts made while locked are rejected before reaching
+// here, so a lock cannot be extended by hammering it.
+func (s *Service) recordLoginFailure(
+	ctx context.Context, userID int64,
+) error {
+	attempts, err := s.repo.RecordLoginFailure(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if attempts < lockoutThreshold {
+		return nil
+	}
+	return s.repo.LockUser(
+		ctx, userID, s.timeNow().Add(lockDuration(attempts)),
+	)
+}
+
+// lockDuration returns the lock period after the given
+// number of consecutive failures: lockoutBase at the
+// threshold, doubling per further failure, capped at
+// lockoutMax.
+func lockDuration(attempts int) time.Duration {
+	d := lockoutBase
+	for i := lockoutThreshold; i < attempts && d < lockoutMax; i++ {
+		d *= 2
+	}
+	return min(d, lockoutMax)
+}
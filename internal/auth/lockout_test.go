@@ -0,0 +1,157 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+func TestLoginLockout(t *testing.T) {
+	hash, _ := bcrypt.GenerateFromPassword(
+		[]byte("s3cret"), bcrypt.MinCost,
+	)
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Minute)
+
+	tests := []struct {
+		name        string
+		attempts    int
+		lockedUntil *time.Time
+		password    string
+		newCount    int
+		wantErr     error
+		wantLockFor time.Duration
+		wantUnlock  bool
+	}{
+		{
+			name:     "failure below threshold",
+			password: "wrong",
+			newCount: 4,
+			wantErr:  auth.ErrInvalidCredentials,
+		},
+		{
+			name:        "failure at threshold locks",
+			attempts:    4,
+			password:    "wrong",
+			newCount:    5,
+			wantErr:     auth.ErrInvalidCredentials,
+			wantLockFor: time.Minute,
+		},
+		{
+			name:        "lock doubles per failure",
+			attempts:    6,
+			lockedUntil: &past,
+			password:    "wrong",
+			newCount:    7,
+			wantErr:     auth.ErrInvalidCredentials,
+			wantLockFor: 4 * time.Minute,
+		},
+		{
+			name:        "lock is capped",
+			attempts:    30,
+			lockedUntil: &past,
+			password:    "wrong",
+			newCount:    31,
+			wantErr:     auth.ErrInvalidCredentials,
+			wantLockFor: time.Hour,
+		},
+		{
+			name:        "locked rejects correct password",
+			attempts:    5,
+			lockedUntil: &future,
+			password:    "s3cret",
+			wantErr:     auth.ErrInvalidCredentials,
+		},
+		{
+			name:        "locked does not count failures",
+			attempts:    5,
+			lockedUntil: &future,
+			password:    "wrong",
+			wantErr:     auth.ErrInvalidCredentials,
+		},
+		{
+			name:        "expired lock allows login",
+			attempts:    5,
+			lockedUntil: &past,
+			password:    "s3cret",
+			wantUnlock:  true,
+		},
+		{
+			name:       "success resets counter",
+			attempts:   2,
+			password:   "s3cret",
+			wantUnlock: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lockedUntil time.Time
+			unlocked := false
+			repo := &mockRepo{
+				findByEmailFn: func(
+					context.Context, string,
+				) (auth.User, error) {
+					return auth.User{
+						ID:                  1,
+						Email:               "alice@example.com",
+						PasswordHash:        string(hash),
+						Role:                "customer",
+						FailedLoginAttempts: tt.attempts,
+						LockedUntil:         tt.lockedUntil,
+					}, nil
+				},
+				lockUserFn: func(
+					_ context.Context, _ int64, until time.Time,
+				) error {
+					lockedUntil = until
+					return nil
+				},
+				unlockUserFn: func(context.Context, int64, *int64) error {
+					unlocked = true
+					return nil
+				},
+				updatePasswordHashFn: func(
+					context.Context, int64, string, string,
+				) error {
+					return nil
+				},
+			}
+			if tt.newCount > 0 {
+				repo.recordLoginFailureFn = func(
+					context.Context, int64,
+				) (int, error) {
+					return tt.newCount, nil
+				}
+			}
+			svc := newTestService(t, repo)
+
+			_, err := svc.Login(
+				context.Background(),
+				"alice@example.com", tt.password,
+			)
+			if !errorIs(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantLockFor == 0 {
+				if !lockedUntil.IsZero() {
+					t.Errorf("locked until %v, want no lock",
+						lockedUntil)
+				}
+			} else if d := time.Until(lockedUntil); d <= 0 ||
+				d > tt.wantLockFor ||
+				d < tt.wantLockFor-time.Second {
+				t.Errorf("lock for %v, want %v", d, tt.wantLockFor)
+			}
+			if unlocked != tt.wantUnlock {
+				t.Errorf("unlocked = %v, want %v",
+					unlocked, tt.wantUnlock)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hhubris/petstore/internal/db"
+)
+
+// recoveryCodeCount is how many single-use recovery codes
+// are issued when TOTP is enabled.
+const recoveryCodeCount = 10
+
+var (
+	// ErrInvalidMFACode is returned when a TOTP or recovery
+	// code is wrong, already used, or the account is
+	// locked.
+	ErrInvalidMFACode = errors.New("invalid authentication code")
+
+	// ErrMFAAlreadyEnabled is returned when enrollment is
+	// started or confirmed for a user who already has TOTP.
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication already enabled")
+
+	// ErrMFANotEnrolled is returned when enrollment is
+	// confirmed before it was started.
+	ErrMFANotEnrolled = errors.New("two-factor enrollment not started")
+
+	// ErrMFARequired is returned when an operation requires
+	// a token obtained with a second factor.
+	ErrMFARequired = errors.New("two-factor authentication required")
+)
+
+// MFAEnrollment is a pending TOTP secret and its
+// provisioning URI for an authenticator app.
+type MFAEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// EnrollMFA generates a new TOTP secret for the user and
+// stores it as pending. It replaces any earlier pending
+// secret. Returns ErrMFAAlreadyEnabled if TOTP is already
+// on.
+func (s *Service) EnrollMFA(
+	ctx context.Context,
+	userID int64,
+) (MFAEnrollment, error) {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return MFAEnrollment{}, err
+	}
+	if user.TOTPEnabledAt != nil {
+		return MFAEnrollment{}, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := newTOTPSecret()
+	if err != nil {
+		return MFAEnrollment{}, err
+	}
+	if err := s.repo.SetTOTPSecret(ctx, user.ID, secret); err != nil {
+		if errors.Is(err, db.ErrConflict) {
+			return MFAEnrollment{}, ErrMFAAlreadyEnabled
+		}
+		return MFAEnrollment{}, err
+	}
+	return MFAEnrollment{
+		Secret: secret,
+		URI:    totpURI(secret, user.Email),
+	}, nil
+}
+
+// ConfirmMFA checks code against the user's pending secret
+// and, if it matches, enables TOTP and returns a fresh set
+// of recovery codes. The codes are shown once; only their
+// hashes are stored.
+func (s *Service) ConfirmMFA(
+	ctx context.Context,
+	userID int64,
+	code string,
+) ([]string, error) {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabledAt != nil {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, ErrMFANotEnrolled
+	}
+	step, ok := validateTOTP(user.TOTPSecret, code, s.timeNow())
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+	if err := s.repo.UseTOTPStep(ctx, user.ID, step); err != nil {
+		if errors.Is(err, db.ErrConflict) {
+			return nil, ErrInvalidMFACode
+		}
+		return nil, err
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		c, err := newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = c
+		hashes[i] = hashToken(normalizeRecoveryCode(c))
+	}
+	if err := s.repo.EnableTOTP(ctx, user.ID, hashes); err != nil {
+		if errors.Is(err, db.ErrConflict) {
+			return nil, ErrMFAAlreadyEnabled
+		}
+		return nil, err
+	}
+	return codes, nil
+}
+
+// VerifyMFA completes a two-step login. It checks the
+// challenge token from Login and a TOTP or recovery code,
+// and returns an access token whose amr claim includes
+// "otp". Wrong codes count towards account lockout.
+func (s *Service) VerifyMFA(
+	ctx context.Context,
+	mfaToken, code string,
+) (string, User, error) {
+	userID, err := s.token.ParseMFAToken(mfaToken)
+	if err != nil {
+		return "", User{}, err
+	}
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return "", User{}, ErrInvalidToken
+		}
+		return "", User{}, err
+	}
+	if user.TOTPEnabledAt == nil {
+		return "", User{}, ErrInvalidToken
+	}
+	if s.isLocked(user) {
+		return "", User{}, ErrInvalidMFACode
+	}
+
+	ok, err := s.checkMFACode(ctx, user, code)
+	if err != nil {
+		return "", User{}, err
+	}
+	if !ok {
+		if err := s.recordLoginFailure(ctx, user.ID); err != nil {
+			return "", User{}, err
+		}
+		return "", User{}, ErrInvalidMFACode
+	}
+	return s.completeLogin(ctx, user, "pwd", "otp")
+}
+
+// checkMFACode reports whether code is a valid, unused TOTP
+// code or recovery code for user, consuming it if so.
+func (s *Service) checkMFACode(
+	ctx context.Context, user User, code string,
+) (bool, error) {
+	code = strings.TrimSpace(code)
+	if step, ok := validateTOTP(
+		user.TOTPSecret, code, s.timeNow(),
+	); ok {
+		err := s.repo.UseTOTPStep(ctx, user.ID, step)
+		if errors.Is(err, db.ErrConflict) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	err := s.repo.UseRecoveryCode(
+		ctx, user.ID, hashToken(normalizeRecoveryCode(code)),
+	)
+	if errors.Is(err, db.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// newRecoveryCode returns a random 80-bit code formatted as
+// four dash-separated groups of lowercase base32.
+func newRecoveryCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating recovery code: %w", err)
+	}
+	s := strings.ToLower(totpEncoding.EncodeToString(b))
+	return s[0:4] + "-" + s[4:8] + "-" + s[8:12] + "-" + s[12:16], nil
+}
+
+// normalizeRecoveryCode strips separators and case so codes
+// can be typed loosely.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer(
+		"-", "", " ", "",
+	).Replace(code))
+}
@@ -0,0 +1,364 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/db"
+)
+
+// testSecretB32 is a fixed base32 TOTP secret.
+const testSecretB32 = "JBSWY3DPEHPK3PXP"
+
+// totpAt computes the six-digit RFC 6238 code for secret at
+// t, independently of the package implementation.
+func totpAt(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).
+		DecodeString(secret)
+	if err != nil {
+		t.Fatalf("decoding secret: %v", err)
+	}
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(at.Unix()/30))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	off := sum[len(sum)-1] & 0x0f
+	v := binary.BigEndian.Uint32(sum[off:off+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", v%1_000_000)
+}
+
+func TestLoginWithMFAReturnsChallenge(t *testing.T) {
+	hash, _ := bcrypt.GenerateFromPassword(
+		[]byte("s3cret"), bcrypt.MinCost,
+	)
+	enabled := time.Now()
+	repo := &mockRepo{
+		findByEmailFn: func(context.Context, string) (auth.User, error) {
+			return auth.User{
+				ID: 1, PasswordHash: string(hash), Role: "admin",
+				FailedLoginAttempts: 2,
+				TOTPSecret:          testSecretB32,
+				TOTPEnabledAt:       &enabled,
+			}, nil
+		},
+		updatePasswordHashFn: func(
+			context.Context, int64, string, string,
+		) error {
+			return nil
+		},
+	}
+	svc := newTestService(t, repo)
+
+	res, err := svc.Login(context.Background(), "a@b.com", "s3cret")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.AccessToken != "" {
+		t.Error("access token issued before second factor")
+	}
+	if res.MFAToken == "" {
+		t.Error("expected MFA challenge token")
+	}
+}
+
+func TestEnrollMFA(t *testing.T) {
+	enabled := time.Now()
+
+	tests := []struct {
+		name    string
+		user    auth.User
+		setErr  error
+		wantErr error
+	}{
+		{
+			name: "success",
+			user: auth.User{ID: 1, Email: "admin@example.com"},
+		},
+		{
+			name:    "already enabled",
+			user:    auth.User{ID: 1, TOTPEnabledAt: &enabled},
+			wantErr: auth.ErrMFAAlreadyEnabled,
+		},
+		{
+			name:    "enabled concurrently",
+			user:    auth.User{ID: 1},
+			setErr:  db.ErrConflict,
+			wantErr: auth.ErrMFAAlreadyEnabled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stored string
+			repo := &mockRepo{
+				findByIDFn: func(context.Context, int64) (auth.User, error) {
+					return tt.user, nil
+				},
+				setTOTPSecretFn: func(
+					_ context.Context, _ int64, secret string,
+				) error {
+					stored = secret
+					return tt.setErr
+				},
+			}
+			svc := newTestService(t, repo)
+
+			e, err := svc.EnrollMFA(context.Background(), 1)
+			if !errorIs(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if e.Secret == "" || e.Secret != stored {
+				t.Errorf("secret %q, stored %q", e.Secret, stored)
+			}
+			if !strings.HasPrefix(e.URI, "otpauth://totp/") ||
+				!strings.Contains(e.URI, "secret="+e.Secret) {
+				t.Errorf("bad provisioning URI %q", e.URI)
+			}
+		})
+	}
+}
+
+func TestConfirmMFA(t *testing.T) {
+	enabled := time.Now()
+	pending := auth.User{ID: 1, TOTPSecret: testSecretB32}
+
+	tests := []struct {
+		name    string
+		user    auth.User
+		code    string
+		stepErr error
+		wantErr error
+	}{
+		{
+			name: "success",
+			user: pending,
+			code: totpAt(t, testSecretB32, time.Now()),
+		},
+		{
+			name:    "wrong code",
+			user:    pending,
+			code:    "000000",
+			wantErr: auth.ErrInvalidMFACode,
+		},
+		{
+			name:    "replayed code",
+			user:    pending,
+			code:    totpAt(t, testSecretB32, time.Now()),
+			stepErr: db.ErrConflict,
+			wantErr: auth.ErrInvalidMFACode,
+		},
+		{
+			name:    "not started",
+			user:    auth.User{ID: 1},
+			code:    "123456",
+			wantErr: auth.ErrMFANotEnrolled,
+		},
+		{
+			name: "already enabled",
+			user: auth.User{
+				ID: 1, TOTPSecret: testSecretB32,
+				TOTPEnabledAt: &enabled,
+			},
+			code:    "123456",
+			wantErr: auth.ErrMFAAlreadyEnabled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var storedHashes []string
+			repo := &mockRepo{
+				findByIDFn: func(context.Context, int64) (auth.User, error) {
+					return tt.user, nil
+				},
+				useTOTPStepFn: func(context.Context, int64, int64) error {
+					return tt.stepErr
+				},
+				enableTOTPFn: func(
+					_ context.Context, _ int64, hashes []string,
+				) error {
+					storedHashes = hashes
+					return nil
+				},
+			}
+			svc := newTestService(t, repo)
+
+			codes, err := svc.ConfirmMFA(
+				context.Background(), 1, tt.code,
+			)
+			if !errorIs(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if len(codes) != 10 || len(storedHashes) != 10 {
+				t.Fatalf("got %d codes, %d hashes, want 10",
+					len(codes), len(storedHashes))
+			}
+			plain := strings.ReplaceAll(codes[0], "-", "")
+			sum := sha256.Sum256([]byte(plain))
+			if storedHashes[0] != hex.EncodeToString(sum[:]) {
+				t.Error("stored hash does not match recovery code")
+			}
+		})
+	}
+}
+
+func TestVerifyMFA(t *testing.T) {
+	enabled := time.Now()
+	enrolled := auth.User{
+		ID: 1, Role: "admin",
+		TOTPSecret:    testSecretB32,
+		TOTPEnabledAt: &enabled,
+	}
+
+	tests := []struct {
+		name        string
+		user        auth.User
+		code        string
+		stepErr     error
+		recoveryErr error
+		wantErr     error
+		wantFailure bool
+	}{
+		{
+			name: "totp code",
+			user: enrolled,
+			code: totpAt(t, testSecretB32, time.Now()),
+		},
+		{
+			name: "recovery code",
+			user: enrolled,
+			code: "ABCD-efgh-ijkl-mnop",
+		},
+		{
+			name:        "replayed totp code",
+			user:        enrolled,
+			code:        totpAt(t, testSecretB32, time.Now()),
+			stepErr:     db.ErrConflict,
+			wantErr:     auth.ErrInvalidMFACode,
+			wantFailure: true,
+		},
+		{
+			name:        "unknown recovery code",
+			user:        enrolled,
+			code:        "nope",
+			recoveryErr: db.ErrNotFound,
+			wantErr:     auth.ErrInvalidMFACode,
+			wantFailure: true,
+		},
+		{
+			name:    "mfa not enabled",
+			user:    auth.User{ID: 1},
+			code:    "123456",
+			wantErr: auth.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failed := false
+			repo := &mockRepo{
+				findByIDFn: func(context.Context, int64) (auth.User, error) {
+					return tt.user, nil
+				},
+				useTOTPStepFn: func(context.Context, int64, int64) error {
+					return tt.stepErr
+				},
+				useRecoveryCodeFn: func(
+					_ context.Context, _ int64, hash string,
+				) error {
+					sum := sha256.Sum256([]byte(
+						strings.ToLower(strings.ReplaceAll(tt.code, "-", "")),
+					))
+					if hash != hex.EncodeToString(sum[:]) {
+						t.Errorf("recovery code not normalized")
+					}
+					return tt.recoveryErr
+				},
+				recordLoginFailureFn: func(context.Context, int64) (int, error) {
+					failed = true
+					return 1, nil
+				},
+			}
+			svc := newTestService(t, repo)
+			mfaToken := mfaTokenFor(t, 1)
+
+			token, _, err := svc.VerifyMFA(
+				context.Background(), mfaToken, tt.code,
+			)
+			if !errorIs(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if failed != tt.wantFailure {
+				t.Errorf("failure recorded = %v, want %v",
+					failed, tt.wantFailure)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			claims, err := testTokenConfig(t).ParseToken(token)
+			if err != nil {
+				t.Fatalf("ParseToken: %v", err)
+			}
+			if !claims.HasAMR("otp") {
+				t.Errorf("AMR = %v, want otp", claims.AMR)
+			}
+		})
+	}
+}
+
+func TestVerifyMFARejectsAccessToken(t *testing.T) {
+	svc := newTestService(t, &mockRepo{})
+	access, err := testTokenConfig(t).CreateToken(
+		auth.Claims{UserID: 1, Role: "admin"},
+	)
+	if err != nil {
+		t.Fatalf("CreateToken: %v", err)
+	}
+	_, _, err = svc.VerifyMFA(context.Background(), access, "123456")
+	if !errorIs(err, auth.ErrInvalidToken) {
+		t.Fatalf("err = %v, want ErrInvalidToken", err)
+	}
+}
+
+// testTokenConfig returns the TokenConfig newTestService
+// signs with.
+func testTokenConfig(t *testing.T) *auth.TokenConfig {
+	t.Helper()
+	tc, err := auth.NewTokenConfig(
+		[]byte("test-secret-that-is-at-least-32-bytes!"),
+	)
+	if err != nil {
+		t.Fatalf("NewTokenConfig: %v", err)
+	}
+	return tc
+}
+
+// mfaTokenFor returns an MFA challenge token for userID.
+func mfaTokenFor(t *testing.T, userID int64) string {
+	t.Helper()
+	tok, err := testTokenConfig(t).CreateMFAToken(userID)
+	if err != nil {
+		t.Fatalf("CreateMFAToken: %v", err)
+	}
+	return tok
+}
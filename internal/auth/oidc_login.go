@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/hhubris/petstore/internal/db"
+)
+
+var (
+	// ErrOIDCNotConfigured is returned by the OIDC login
+	// flow when no identity provider is configured.
+	ErrOIDCNotConfigured = errors.New("oidc login is not configured")
+
+	// ErrInvalidOIDCState is returned when the callback's
+	// state does not match the login attempt, or the attempt
+	// has expired.
+	ErrInvalidOIDCState = errors.New("invalid or expired oidc login state")
+
+	// ErrIdentityRejected is returned when the identity
+	// provider authenticates a user we cannot sign in: the
+	// email is unverified, or it matches a local account
+	// whose own email was never verified.
+	ErrIdentityRejected = errors.New("external identity rejected")
+
+	// ErrSSORequired is returned by Login for admins when
+	// WithRequireAdminSSO is set.
+	ErrSSORequired = errors.New("admins must sign in with sso")
+)
+
+// ExternalIdentity is a user authenticated by an external
+// identity provider, taken from a verified ID token.
+type ExternalIdentity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	// AMR lists the methods the provider reports having
+	// used, carried through to our access token.
+	AMR []string
+}
+
+// IdentityProvider runs the provider side of an OIDC
+// authorization code flow. It is implemented by
+// oidc.Provider; the interface keeps this package free of a
+// dependency on it.
+type IdentityProvider interface {
+	// AuthCodeURL returns the provider URL to send the
+	// browser to.
+	AuthCodeURL(state, nonce, codeChallenge string) string
+	// Exchange redeems code and returns the identity from
+	// the validated ID token, whose nonce must match.
+	Exchange(ctx context.Context,
+		code, codeVerifier, nonce string,
+	) (ExternalIdentity, error)
+}
+
+// WithIdentityProvider enables OIDC login through p.
+func WithIdentityProvider(p IdentityProvider) Option {
+	return func(s *Service) { s.idp = p }
+}
+
+// WithRequireAdminSSO makes password login fail with
+// ErrSSORequired for admins, so staff accounts can only sign
+// in through the identity provider. The password is still
+// checked first, so the error reveals nothing to a guesser.
+func WithRequireAdminSSO(require bool) Option {
+	return func(s *Service) { s.requireAdminSSO = require }
+}
+
+// OIDCRedirect starts an OIDC login. The browser is sent to
+// URL, and StateToken must come back with the callback,
+// normally in a cookie.
+type OIDCRedirect struct {
+	URL        string
+	StateToken string
+}
+
+// BeginOIDCLogin creates the state, nonce, and PKCE
+// verifier for a login attempt and returns the provider
+// URL along with a signed token holding them.
+func (s *Service) BeginOIDCLogin() (OIDCRedirect, error) {
+	if s.idp == nil {
+		return OIDCRedirect{}, ErrOIDCNotConfigured
+	}
+	var st oidcState
+	for _, v := range []*string{&st.State, &st.Nonce, &st.Verifier} {
+		token, _, err := newOpaqueToken()
+		if err != nil {
+			return OIDCRedirect{}, err
+		}
+		*v = token
+	}
+	stateToken, err := s.token.createOIDCStateToken(st)
+	if err != nil {
+		return OIDCRedirect{}, fmt.Errorf(
+			"creating oidc state token: %w", err,
+		)
+	}
+	return OIDCRedirect{
+		URL: s.idp.AuthCodeURL(
+			st.State, st.Nonce, pkceChallenge(st.Verifier),
+		),
+		StateToken: stateToken,
+	}, nil
+}
+
+// CompleteOIDCLogin handles the provider's callback. It
+// checks state against stateToken, redeems code, and signs
+// in the linked user, linking by verified email or creating
+// a customer on first login. It returns the access token,
+// the user, and the frontend URL to send the browser to.
+func (s *Service) CompleteOIDCLogin(
+	ctx context.Context,
+	code, state, stateToken string,
+) (LoginResult, string, error) {
+	if s.idp == nil {
+		return LoginResult{}, "", ErrOIDCNotConfigured
+	}
+	st, err := s.token.parseOIDCStateToken(stateToken)
+	if err != nil {
+		return LoginResult{}, "", ErrInvalidOIDCState
+	}
+	if subtle.ConstantTimeCompare([]byte(st.State), []byte(state)) != 1 {
+		return LoginResult{}, "", ErrInvalidOIDCState
+	}
+
+	ident, err := s.idp.Exchange(ctx, code, st.Verifier, st.Nonce)
+	if err != nil {
+		return LoginResult{}, "", err
+	}
+	user, err := s.userForIdentity(ctx, ident)
+	if err != nil {
+		return LoginResult{}, "", err
+	}
+
+	token, user, err := s.completeLogin(ctx, user, ident.AMR...)
+	if err != nil {
+		return LoginResult{}, "", err
+	}
+	return LoginResult{User: user, AccessToken: token}, s.appURL + "/", nil
+}
+
+// userForIdentity returns the user linked to ident, linking
+// or creating one on first login.
+func (s *Service) userForIdentity(
+	ctx context.Context, ident ExternalIdentity,
+) (User, error) {
+	user, err := s.repo.FindByIdentity(ctx, ident.Issuer, ident.Subject)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, db.ErrNotFound) {
+		return User{}, err
+	}
+
+	if !ident.EmailVerified || ident.Email == "" {
+		return User{}, fmt.Errorf(
+			"%w: email not verified by provider", ErrIdentityRejected,
+		)
+	}
+
+	user, err = s.repo.FindByEmail(ctx, ident.Email)
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		name := ident.Name
+		if name == "" {
+			name = ident.Email
+		}
+		return s.repo.CreateExternalUser(
+			ctx, name, ident.Email, "customer",
+			ident.Issuer, ident.Subject,
+		)
+	case err != nil:
+		return User{}, err
+	}
+
+	// Linking to an account whose owner never proved the
+	// address would hand it to whoever registered it first.
+	if user.EmailVerifiedAt == nil {
+		return User{}, fmt.Errorf(
+			"%w: local account email not verified", ErrIdentityRejected,
+		)
+	}
+	if err := s.repo.LinkIdentity(
+		ctx, user.ID, ident.Issuer, ident.Subject,
+	); err != nil {
+		return User{}, err
+	}
+	return user, nil
+}
+
+// pkceChallenge returns the RFC 7636 S256 code challenge for
+// verifier.
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
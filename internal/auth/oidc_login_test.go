@@ -0,0 +1,298 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/oidc"
+	"github.com/hhubris/petstore/internal/oidc/oidctest"
+)
+
+// newOIDCService starts a fake provider asserting user and
+// returns it with a Service that logs in through it.
+func newOIDCService(
+	t *testing.T, repo *mockRepo, user oidctest.User,
+) (*auth.Service, *oidctest.Provider) {
+	t.Helper()
+	op := oidctest.New("petstore")
+	t.Cleanup(op.Close)
+	op.SetUser(user)
+
+	idp, err := oidc.Discover(context.Background(), oidc.Config{
+		Issuer:      op.Issuer(),
+		ClientID:    op.ClientID,
+		RedirectURL: "http://localhost:8080/auth/oidc/callback",
+	})
+	if err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+	svc := newTestService(t, repo,
+		auth.WithIdentityProvider(idp),
+		auth.WithAppURL("http://localhost:5173"),
+	)
+	return svc, op
+}
+
+// oidcLogin runs the whole redirect round trip.
+func oidcLogin(
+	t *testing.T, svc *auth.Service, op *oidctest.Provider,
+) (auth.LoginResult, string, error) {
+	t.Helper()
+	redirect, err := svc.BeginOIDCLogin()
+	if err != nil {
+		t.Fatalf("BeginOIDCLogin: %v", err)
+	}
+	code, state, err := op.Authorize(redirect.URL)
+	if err != nil {
+		t.Fatalf("Authorize: %v", err)
+	}
+	return svc.CompleteOIDCLogin(
+		context.Background(), code, state, redirect.StateToken,
+	)
+}
+
+func TestCompleteOIDCLogin(t *testing.T) {
+	verified := time.Now()
+	identity := oidctest.User{
+		Subject:       "sub-123",
+		Email:         "alice@example.com",
+		EmailVerified: true,
+		Name:          "Alice",
+		AMR:           []string{"pwd", "mfa"},
+	}
+
+	tests := []struct {
+		name     string
+		identity oidctest.User
+		repo     func(t *testing.T) *mockRepo
+		wantErr  error
+	}{
+		{
+			name:     "linked identity",
+			identity: identity,
+			repo: func(t *testing.T) *mockRepo {
+				return &mockRepo{
+					findByIdentityFn: func(
+						_ context.Context, _, subject string,
+					) (auth.User, error) {
+						if subject != "sub-123" {
+							t.Errorf("subject = %q", subject)
+						}
+						return auth.User{ID: 7, Role: "customer"}, nil
+					},
+				}
+			},
+		},
+		{
+			name:     "first login creates customer",
+			identity: identity,
+			repo: func(t *testing.T) *mockRepo {
+				return &mockRepo{
+					findByIdentityFn: func(
+						context.Context, string, string,
+					) (auth.User, error) {
+						return auth.User{}, db.ErrNotFound
+					},
+					findByEmailFn: func(
+						context.Context, string,
+					) (auth.User, error) {
+						return auth.User{}, db.ErrNotFound
+					},
+					createExternalUserFn: func(
+						_ context.Context,
+						name, email, role, _, subject string,
+					) (auth.User, error) {
+						if name != "Alice" || role != "customer" ||
+							subject != "sub-123" {
+							t.Errorf(
+								"created %q %q %q",
+								name, role, subject,
+							)
+						}
+						return auth.User{
+							ID: 7, Email: email, Role: role,
+						}, nil
+					},
+				}
+			},
+		},
+		{
+			name:     "links verified local account",
+			identity: identity,
+			repo: func(t *testing.T) *mockRepo {
+				return &mockRepo{
+					findByIdentityFn: func(
+						context.Context, string, string,
+					) (auth.User, error) {
+						return auth.User{}, db.ErrNotFound
+					},
+					findByEmailFn: func(
+						context.Context, string,
+					) (auth.User, error) {
+						return auth.User{
+							ID: 7, Role: "customer",
+							EmailVerifiedAt: &verified,
+						}, nil
+					},
+					linkIdentityFn: func(
+						_ context.Context, userID int64, _, _ string,
+					) error {
+						if userID != 7 {
+							t.Errorf("userID = %d, want 7", userID)
+						}
+						return nil
+					},
+				}
+			},
+		},
+		{
+			name:     "unverified local account",
+			identity: identity,
+			repo: func(*testing.T) *mockRepo {
+				return &mockRepo{
+					findByIdentityFn: func(
+						context.Context, string, string,
+					) (auth.User, error) {
+						return auth.User{}, db.ErrNotFound
+					},
+					findByEmailFn: func(
+						context.Context, string,
+					) (auth.User, error) {
+						return auth.User{ID: 7}, nil
+					},
+				}
+			},
+			wantErr: auth.ErrIdentityRejected,
+		},
+		{
+			name: "provider email unverified",
+			identity: oidctest.User{
+				Subject: "sub-123", Email: "alice@example.com",
+			},
+			repo: func(*testing.T) *mockRepo {
+				return &mockRepo{
+					findByIdentityFn: func(
+						context.Context, string, string,
+					) (auth.User, error) {
+						return auth.User{}, db.ErrNotFound
+					},
+				}
+			},
+			wantErr: auth.ErrIdentityRejected,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, op := newOIDCService(t, tt.repo(t), tt.identity)
+			res, next, err := oidcLogin(t, svc, op)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if next != "http://localhost:5173/" {
+				t.Errorf("redirect = %q", next)
+			}
+			if res.User.ID != 7 {
+				t.Errorf("user.ID = %d, want 7", res.User.ID)
+			}
+
+			tc, err := auth.NewTokenConfig(
+				[]byte("test-secret-that-is-at-least-32-bytes!"),
+			)
+			if err != nil {
+				t.Fatalf("NewTokenConfig: %v", err)
+			}
+			c, err := tc.ParseToken(res.AccessToken)
+			if err != nil {
+				t.Fatalf("ParseToken: %v", err)
+			}
+			if !c.HasAMR("mfa") {
+				t.Errorf("amr = %v, want provider's", c.AMR)
+			}
+		})
+	}
+}
+
+func TestCompleteOIDCLoginState(t *testing.T) {
+	svc, op := newOIDCService(t, &mockRepo{}, oidctest.User{
+		Subject: "sub-123",
+	})
+	redirect, err := svc.BeginOIDCLogin()
+	if err != nil {
+		t.Fatalf("BeginOIDCLogin: %v", err)
+	}
+	code, state, err := op.Authorize(redirect.URL)
+	if err != nil {
+		t.Fatalf("Authorize: %v", err)
+	}
+	other, err := svc.BeginOIDCLogin()
+	if err != nil {
+		t.Fatalf("BeginOIDCLogin: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		state      string
+		stateToken string
+	}{
+		{name: "missing cookie", state: state},
+		{name: "tampered token", state: state, stateToken: redirect.StateToken + "x"},
+		{name: "other attempt", state: state, stateToken: other.StateToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := svc.CompleteOIDCLogin(
+				context.Background(), code, tt.state, tt.stateToken,
+			)
+			if !errors.Is(err, auth.ErrInvalidOIDCState) {
+				t.Fatalf("err = %v, want ErrInvalidOIDCState", err)
+			}
+		})
+	}
+}
+
+func TestOIDCNotConfigured(t *testing.T) {
+	svc := newTestService(t, &mockRepo{})
+	if _, err := svc.BeginOIDCLogin(); !errors.Is(
+		err, auth.ErrOIDCNotConfigured,
+	) {
+		t.Errorf("BeginOIDCLogin err = %v", err)
+	}
+	if _, _, err := svc.CompleteOIDCLogin(
+		context.Background(), "code", "state", "token",
+	); !errors.Is(err, auth.ErrOIDCNotConfigured) {
+		t.Errorf("CompleteOIDCLogin err = %v", err)
+	}
+}
+
+func TestLoginRequireAdminSSO(t *testing.T) {
+	hash, _ := bcrypt.GenerateFromPassword(
+		[]byte("s3cret"), bcrypt.MinCost,
+	)
+	repo := &mockRepo{
+		findByEmailFn: func(
+			context.Context, string,
+		) (auth.User, error) {
+			return auth.User{
+				ID: 1, PasswordHash: string(hash), Role: "admin",
+			}, nil
+		},
+	}
+	svc := newTestService(t, repo, auth.WithRequireAdminSSO(true))
+	_, err := svc.Login(context.Background(), "a@example.com", "s3cret")
+	if !errors.Is(err, auth.ErrSSORequired) {
+		t.Fatalf("err = %v, want ErrSSORequired", err)
+	}
+}
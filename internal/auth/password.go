@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// argon2Params are the argon2id cost parameters recorded in
+// a PHC hash string. memory is in KiB.
+type argon2Params struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+	keyLen  uint32
+}
+
+// defaultArgon2Params is the OWASP baseline for argon2id:
+// 19 MiB, two passes, one lane. Hashes made with anything
+// else are upgraded on the next successful login.
+var defaultArgon2Params = argon2Params{
+	memory:  19 * 1024,
+	time:    2,
+	threads: 1,
+	keyLen:  32,
+}
+
+// argon2SaltLen is the length of a fresh salt in bytes.
+const argon2SaltLen = 16
+
+// errMalformedHash is returned when a stored argon2id hash
+// cannot be parsed.
+var errMalformedHash = errors.New("malformed password hash")
+
+// hashPassword returns the argon2id hash of password as a
+// PHC string, e.g. "$argon2id$v=19$m=19456,t=2,p=1$salt$key".
+func hashPassword(password string) (string, error) {
+	return hashArgon2(password, defaultArgon2Params)
+}
+
+// hashArgon2 hashes password with a fresh salt and p.
+func hashArgon2(password string, p argon2Params) (string, error) {
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("hashing password: %w", err)
+	}
+	key := argon2.IDKey(
+		[]byte(password), salt, p.time, p.memory, p.threads, p.keyLen,
+	)
+	b64 := base64.RawStdEncoding
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, p.memory, p.time, p.threads,
+		b64.EncodeToString(salt), b64.EncodeToString(key),
+	), nil
+}
+
+// checkPassword reports whether password matches hash, and
+// if so whether hash should be replaced with a fresh
+// hashPassword result: legacy bcrypt hashes and argon2id
+// hashes with outdated parameters both need it. Any other
+// hash, including the empty one of SSO-only accounts,
+// matches nothing.
+func checkPassword(hash, password string) (ok, rehash bool) {
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		p, salt, key, err := parseArgon2(hash)
+		if err != nil {
+			return false, false
+		}
+		got := argon2.IDKey(
+			[]byte(password), salt, p.time, p.memory, p.threads, p.keyLen,
+		)
+		if subtle.ConstantTimeCompare(got, key) != 1 {
+			return false, false
+		}
+		return true, p != defaultArgon2Params
+	case strings.HasPrefix(hash, "$2"):
+		err := bcrypt.CompareHashAndPassword(
+			[]byte(hash), []byte(password),
+		)
+		return err == nil, err == nil
+	default:
+		return false, false
+	}
+}
+
+// parseArgon2 splits a PHC argon2id string into its
+// parameters, salt, and key.
+func parseArgon2(hash string) (argon2Params, []byte, []byte, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return argon2Params{}, nil, nil, errMalformedHash
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil ||
+		version != argon2.Version {
+		return argon2Params{}, nil, nil, errMalformedHash
+	}
+	var p argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d",
+		&p.memory, &p.time, &p.threads,
+	); err != nil || p.time == 0 || p.threads == 0 {
+		return argon2Params{}, nil, nil, errMalformedHash
+	}
+	b64 := base64.RawStdEncoding
+	salt, err := b64.DecodeString(parts[4])
+	if err != nil {
+		return argon2Params{}, nil, nil, errMalformedHash
+	}
+	key, err := b64.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return argon2Params{}, nil, nil, errMalformedHash
+	}
+	p.keyLen = uint32(len(key))
+	return p, salt, key, nil
+}
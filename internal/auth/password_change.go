@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrIncorrectPassword is returned when a signed-in user
+// confirms an account change with the wrong password.
+var ErrIncorrectPassword = errors.New("current password is incorrect")
+
+// ChangePassword sets a new password for the user behind
+// claims after checking their current one. Every existing
+// session is signed out; the returned access token keeps
+// the caller's session going with the same amr. Wrong
+// passwords count towards the login lockout, and a locked
+// account gets ErrIncorrectPassword whatever the password.
+func (s *Service) ChangePassword(
+	ctx context.Context,
+	claims Claims,
+	currentPassword, newPassword string,
+) (string, error) {
+	user, err := s.repo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		return "", err
+	}
+	if err := s.confirmPassword(ctx, user, currentPassword); err != nil {
+		return "", err
+	}
+
+	hash, err := hashPassword(newPassword)
+	if err != nil {
+		return "", err
+	}
+	user, err = s.repo.UpdatePassword(ctx, user.ID, hash)
+	if err != nil {
+		return "", err
+	}
+	s.invalidate(user.ID)
+
+	c := claimsFor(user)
+	c.AMR = claims.AMR
+	token, err := s.token.CreateToken(c)
+	if err != nil {
+		return "", fmt.Errorf("creating token: %w", err)
+	}
+	return token, nil
+}
+
+// confirmPassword checks password against user's for an
+// account change, recording a failure on mismatch. Returns
+// ErrIncorrectPassword on mismatch or while locked.
+func (s *Service) confirmPassword(
+	ctx context.Context, user User, password string,
+) error {
+	ok, _ := checkPassword(user.PasswordHash, password)
+	if s.isLocked(user) {
+		return ErrIncorrectPassword
+	}
+	if !ok {
+		if err := s.recordLoginFailure(ctx, user.ID); err != nil {
+			return err
+		}
+		return ErrIncorrectPassword
+	}
+	return nil
+}
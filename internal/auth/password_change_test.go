@@ -0,0 +1,115 @@
+package auth_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+func TestChangePassword(t *testing.T) {
+	hash, _ := bcrypt.GenerateFromPassword(
+		[]byte("old-password"), bcrypt.MinCost,
+	)
+	future := time.Now().Add(time.Minute)
+
+	tests := []struct {
+		name        string
+		current     string
+		lockedUntil *time.Time
+		wantErr     error
+		wantFailure bool
+	}{
+		{name: "success", current: "old-password"},
+		{
+			name:        "wrong current password",
+			current:     "guess",
+			wantErr:     auth.ErrIncorrectPassword,
+			wantFailure: true,
+		},
+		{
+			name:        "locked",
+			current:     "old-password",
+			lockedUntil: &future,
+			wantErr:     auth.ErrIncorrectPassword,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failures := 0
+			repo := &mockRepo{
+				findByIDFn: func(
+					_ context.Context, id int64,
+				) (auth.User, error) {
+					return auth.User{
+						ID: id, Role: "customer",
+						PasswordHash:   string(hash),
+						LockedUntil:    tt.lockedUntil,
+						SessionVersion: 4,
+					}, nil
+				},
+				recordLoginFailureFn: func(
+					context.Context, int64,
+				) (int, error) {
+					failures++
+					return 1, nil
+				},
+				updatePasswordFn: func(
+					_ context.Context, id int64, pwHash string,
+				) (auth.User, error) {
+					if !strings.HasPrefix(pwHash, "$argon2id$") {
+						t.Errorf("password not hashed: %q", pwHash)
+					}
+					return auth.User{
+						ID: id, Role: "customer", SessionVersion: 5,
+					}, nil
+				},
+			}
+			svc := newTestService(t, repo)
+
+			token, err := svc.ChangePassword(
+				context.Background(),
+				auth.Claims{
+					UserID: 1, Role: "customer",
+					AMR: []string{"pwd", "otp"}, SessionVersion: 4,
+				},
+				tt.current, "n3w-password",
+			)
+			if (failures > 0) != tt.wantFailure {
+				t.Errorf("recorded %d failures, want failure %v",
+					failures, tt.wantFailure)
+			}
+			if tt.wantErr != nil {
+				if !errorIs(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			tc, err := auth.NewTokenConfig(
+				[]byte("test-secret-that-is-at-least-32-bytes!"),
+			)
+			if err != nil {
+				t.Fatalf("NewTokenConfig: %v", err)
+			}
+			c, err := tc.ParseToken(token)
+			if err != nil {
+				t.Fatalf("ParseToken: %v", err)
+			}
+			if c.SessionVersion != 5 {
+				t.Errorf("SessionVersion = %d, want 5", c.SessionVersion)
+			}
+			if !c.HasAMR("otp") {
+				t.Errorf("amr = %v, want caller's", c.AMR)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/mail"
+)
+
+// resetTokenTTL is how long a password reset link stays
+// valid.
+const resetTokenTTL = time.Hour
+
+// ErrInvalidResetToken is returned when a password reset
+// token is unknown, expired, or already used. The cases are
+// deliberately indistinguishable.
+var ErrInvalidResetToken = errors.New("invalid or expired reset token")
+
+// resetMailTimeout bounds the background work of one
+// password reset request: storing its token and sending the
+// email.
+const resetMailTimeout = 30 * time.Second
+
+// RequestPasswordReset emails a single-use reset link to
+// the account with the given email. It returns nil whether
+// or not the account exists, and also when the token cannot
+// be stored or the email sent, so callers cannot use it to
+// probe for registered addresses. Only the lookup runs
+// before it returns; the token and email are handled in the
+// background, so the response takes as long for an unknown
+// address as for a known one.
+func (s *Service) RequestPasswordReset(
+	ctx context.Context,
+	email string,
+) error {
+	user, err := s.repo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	s.background.Go(func() {
+		ctx, cancel := context.WithTimeout(ctx, resetMailTimeout)
+		defer cancel()
+		if err := s.sendPasswordReset(ctx, user); err != nil {
+			slog.ErrorContext(ctx, "sending password reset email",
+				"user_id", user.ID, "err", err,
+			)
+		}
+	})
+	return nil
+}
+
+// sendPasswordReset stores a new reset token for the user
+// and emails them the link.
+func (s *Service) sendPasswordReset(ctx context.Context, user User) error {
+	token, hash, err := newOpaqueToken()
+	if err != nil {
+		return err
+	}
+	if err := s.repo.CreateResetToken(
+		ctx, user.ID, hash, s.timeNow().Add(resetTokenTTL),
+	); err != nil {
+		return err
+	}
+
+	link := s.appURL + "/reset-password?token=" +
+		url.QueryEscape(token)
+	return s.mailer.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "Reset your Pet Store password",
+		Body: "Someone asked to reset the password for your " +
+			"Pet Store account.\n\n" +
+			"Use this link within one hour to choose a new " +
+			"password:\n\n" + link + "\n\n" +
+			"If you didn't ask for this, you can ignore this " +
+			"email.\n",
+	})
+}
+
+// ResetPassword sets a new password using a token from
+// RequestPasswordReset and drops the user's cached record,
+// so the session version bump signs out other sessions at
+// once. It returns the user's ID, or ErrInvalidResetToken
+// if the token is unknown, expired, or already used.
+func (s *Service) ResetPassword(
+	ctx context.Context,
+	token, newPassword string,
+) (int64, error) {
+	hash, err := hashPassword(newPassword)
+	if err != nil {
+		return 0, err
+	}
+	id, err := s.repo.ResetPassword(ctx, hashToken(token), hash)
+	if errors.Is(err, db.ErrNotFound) {
+		return 0, ErrInvalidResetToken
+	}
+	if err != nil {
+		return 0, err
+	}
+	s.invalidate(id)
+	return id, nil
+}
+
+// newOpaqueToken returns a random URL-safe token and the
+// hash to store in its place.
+func newOpaqueToken() (token, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("generating token: %w", err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, hashToken(token), nil
+}
+
+// hashToken returns the hex SHA-256 of token. Tokens carry
+// 256 bits of entropy, so an unsalted fast hash is enough to
+// keep a database leak from yielding usable tokens.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,292 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/mail"
+)
+
+// recordingMailer captures sent messages for assertions.
+type recordingMailer struct {
+	sent []mail.Message
+	err  error
+}
+
+func (m *recordingMailer) Send(
+	_ context.Context, msg mail.Message,
+) error {
+	m.sent = append(m.sent, msg)
+	return m.err
+}
+
+// tokenFromLink extracts the token query parameter from the
+// reset link in a message body.
+func tokenFromLink(t *testing.T, body string) string {
+	t.Helper()
+	for _, line := range strings.Split(body, "\n") {
+		if !strings.HasPrefix(line, "http") {
+			continue
+		}
+		u, err := url.Parse(line)
+		if err != nil {
+			t.Fatalf("parsing link: %v", err)
+		}
+		return u.Query().Get("token")
+	}
+	t.Fatalf("no link in body:\n%s", body)
+	return ""
+}
+
+func TestRequestPasswordReset(t *testing.T) {
+	alice := auth.User{ID: 7, Email: "alice@example.com"}
+
+	tests := []struct {
+		name     string
+		email    string
+		mailErr  error
+		findErr  error
+		storeErr error
+		wantMail bool
+		wantErr  bool
+	}{
+		{
+			name:     "known email",
+			email:    "alice@example.com",
+			wantMail: true,
+		},
+		{
+			name:    "unknown email",
+			email:   "nobody@example.com",
+			findErr: db.ErrNotFound,
+		},
+		{
+			name:     "mail failure is hidden",
+			email:    "alice@example.com",
+			mailErr:  errors.New("smtp down"),
+			wantMail: true,
+		},
+		{
+			name:    "repository error",
+			email:   "alice@example.com",
+			findErr: errors.New("connection reset"),
+			wantErr: true,
+		},
+		{
+			name:     "store error is hidden",
+			email:    "alice@example.com",
+			storeErr: errors.New("connection reset"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var storedHash string
+			var storedExpiry time.Time
+			repo := &mockRepo{
+				findByEmailFn: func(
+					context.Context, string,
+				) (auth.User, error) {
+					if tt.findErr != nil {
+						return auth.User{}, tt.findErr
+					}
+					return alice, nil
+				},
+				createResetTokenFn: func(
+					_ context.Context, userID int64,
+					hash string, exp time.Time,
+				) error {
+					if userID != alice.ID {
+						t.Errorf("userID = %d, want %d",
+							userID, alice.ID)
+					}
+					storedHash, storedExpiry = hash, exp
+					return tt.storeErr
+				},
+			}
+			mailer := &recordingMailer{err: tt.mailErr}
+			var background sync.WaitGroup
+			svc := newTestService(t, repo,
+				auth.WithMailer(mailer),
+				auth.WithAppURL("https://shop.test"),
+				auth.WithBackground(&background),
+			)
+
+			err := svc.RequestPasswordReset(
+				context.Background(), tt.email,
+			)
+			background.Wait()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !tt.wantMail {
+				if len(mailer.sent) != 0 {
+					t.Fatalf("sent %d messages, want 0",
+						len(mailer.sent))
+				}
+				return
+			}
+			if len(mailer.sent) != 1 {
+				t.Fatalf("sent %d messages, want 1",
+					len(mailer.sent))
+			}
+			msg := mailer.sent[0]
+			if msg.To != alice.Email {
+				t.Errorf("To = %q, want %q", msg.To, alice.Email)
+			}
+			if !strings.Contains(msg.Body,
+				"https://shop.test/reset-password?token=") {
+				t.Errorf("body lacks reset link:\n%s", msg.Body)
+			}
+			token := tokenFromLink(t, msg.Body)
+			sum := sha256.Sum256([]byte(token))
+			if storedHash != hex.EncodeToString(sum[:]) {
+				t.Error("stored hash does not match emailed token")
+			}
+			if storedHash == token {
+				t.Error("plaintext token was stored")
+			}
+			if d := time.Until(storedExpiry); d <= 0 ||
+				d > time.Hour {
+				t.Errorf("expiry %v not within the next hour",
+					storedExpiry)
+			}
+		})
+	}
+}
+
+// blockingMailer holds every Send until release is closed,
+// then records the error of the context it was given.
+type blockingMailer struct {
+	release chan struct{}
+	ctxErr  error
+}
+
+func (m *blockingMailer) Send(ctx context.Context, _ mail.Message) error {
+	<-m.release
+	m.ctxErr = ctx.Err()
+	return nil
+}
+
+func TestRequestPasswordResetDoesNotWaitForMail(t *testing.T) {
+	repo := &mockRepo{
+		findByEmailFn: func(context.Context, string) (auth.User, error) {
+			return auth.User{ID: 7, Email: "alice@example.com"}, nil
+		},
+		createResetTokenFn: func(context.Context, int64, string, time.Time) error {
+			return nil
+		},
+	}
+	mailer := &blockingMailer{release: make(chan struct{})}
+	var background sync.WaitGroup
+	svc := newTestService(t, repo,
+		auth.WithMailer(mailer), auth.WithBackground(&background))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- svc.RequestPasswordReset(ctx, "alice@example.com") }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("RequestPasswordReset waited for the email")
+	}
+	cancel()
+	close(mailer.release)
+	background.Wait()
+	if mailer.ctxErr != nil {
+		t.Errorf("send context ended with the request: %v", mailer.ctxErr)
+	}
+}
+
+func TestResetPassword(t *testing.T) {
+	errDB := errors.New("connection reset")
+
+	// wantLookups counts the user loads through the cache
+	// before and after the reset: a second load means the
+	// reset invalidated the cached record.
+	tests := []struct {
+		name        string
+		repoErr     error
+		wantErr     error
+		wantID      int64
+		wantLookups int
+	}{
+		{name: "success", wantID: 5, wantLookups: 2},
+		{
+			name:        "unknown or used token",
+			repoErr:     db.ErrNotFound,
+			wantErr:     auth.ErrInvalidResetToken,
+			wantLookups: 1,
+		},
+		{
+			name:        "repository error",
+			repoErr:     errDB,
+			wantErr:     errDB,
+			wantLookups: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookups := 0
+			repo := &mockRepo{
+				resetPasswordFn: func(
+					_ context.Context, tokenHash, pwHash string,
+				) (int64, error) {
+					sum := sha256.Sum256([]byte("tok"))
+					if tokenHash != hex.EncodeToString(sum[:]) {
+						t.Errorf("tokenHash = %q", tokenHash)
+					}
+					if !strings.HasPrefix(pwHash, "$argon2id$") ||
+						strings.Contains(pwHash, "n3w-password") {
+						t.Errorf("password not hashed: %q", pwHash)
+					}
+					return 5, tt.repoErr
+				},
+				findByIDFn: func(_ context.Context, id int64) (auth.User, error) {
+					lookups++
+					return auth.User{ID: id}, nil
+				},
+			}
+			cache := auth.NewUserCache(repo, time.Minute)
+			svc := newTestService(t, repo, auth.WithUserCache(cache))
+			ctx := context.Background()
+			if _, err := cache.FindByID(ctx, 5); err != nil {
+				t.Fatalf("FindByID: %v", err)
+			}
+
+			id, err := svc.ResetPassword(ctx, "tok", "n3w-password")
+			if !errorIs(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if id != tt.wantID {
+				t.Errorf("id = %d, want %d", id, tt.wantID)
+			}
+			if _, err := cache.FindByID(ctx, 5); err != nil {
+				t.Fatalf("FindByID: %v", err)
+			}
+			if lookups != tt.wantLookups {
+				t.Errorf("lookups = %d, want %d", lookups, tt.wantLookups)
+			}
+		})
+	}
+}
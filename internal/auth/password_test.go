@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestHashPasswordRoundTrip(t *testing.T) {
+	// Longer than bcrypt's 72-byte limit, and differing
+	// only after it.
+	long := strings.Repeat("x", 100)
+
+	hash, err := hashPassword(long + "a")
+	if err != nil {
+		t.Fatalf("hashPassword: %v", err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=19456,t=2,p=1$") {
+		t.Errorf("hash = %q", hash)
+	}
+	if ok, rehash := checkPassword(hash, long+"a"); !ok || rehash {
+		t.Errorf("checkPassword = %v, %v; want true, false", ok, rehash)
+	}
+	if ok, _ := checkPassword(hash, long+"b"); ok {
+		t.Error("wrong password matched")
+	}
+
+	again, err := hashPassword(long + "a")
+	if err != nil {
+		t.Fatalf("hashPassword: %v", err)
+	}
+	if again == hash {
+		t.Error("two hashes of one password are equal; salt not random")
+	}
+}
+
+func TestCheckPassword(t *testing.T) {
+	legacy, err := bcrypt.GenerateFromPassword(
+		[]byte("s3cret"), bcrypt.MinCost,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	weak, err := hashArgon2("s3cret", argon2Params{
+		memory: 8 * 1024, time: 1, threads: 1, keyLen: 32,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		hash       string
+		password   string
+		wantOK     bool
+		wantRehash bool
+	}{
+		{"bcrypt", string(legacy), "s3cret", true, true},
+		{"bcrypt wrong password", string(legacy), "wrong", false, false},
+		{"outdated params", weak, "s3cret", true, true},
+		{"outdated params wrong password", weak, "wrong", false, false},
+		{"empty hash", "", "", false, false},
+		{"unknown scheme", "$scrypt$ln=15$abc$def", "s3cret", false, false},
+		{"malformed argon2", "$argon2id$v=19$m=x$abc$def", "s3cret", false, false},
+		{"other argon2 version", strings.Replace(weak, "v=19", "v=16", 1), "s3cret", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, rehash := checkPassword(tt.hash, tt.password)
+			if ok != tt.wantOK || rehash != tt.wantRehash {
+				t.Errorf("checkPassword = %v, %v; want %v, %v",
+					ok, rehash, tt.wantOK, tt.wantRehash)
+			}
+		})
+	}
+}
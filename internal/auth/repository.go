@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 
 	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/store"
 )
 
 // dbtx is the database interface required by
@@ -17,12 +19,37 @@ import (
 type dbtx interface {
 	QueryRow(ctx context.Context, sql string,
 		args ...any) pgx.Row
+	Exec(ctx context.Context, sql string,
+		args ...any) (pgconn.CommandTag, error)
 }
 
 // UniqueViolation is the PostgreSQL error code for a
 // unique constraint violation.
 const uniqueViolation = "23505"
 
+// foreignKeyViolation is the PostgreSQL error code for a
+// foreign key constraint violation.
+const foreignKeyViolation = "23503"
+
+// userColumns is the column list scanned by scanUser.
+const userColumns = "id, name, email, password_hash, role, " +
+	"email_verified_at, failed_login_attempts, locked_until, " +
+	"COALESCE(totp_secret, ''), totp_enabled_at, " +
+	"session_version, disabled_at, store_id, created_at, updated_at"
+
+// scanUser scans a row selected with userColumns.
+func scanUser(row pgx.Row) (User, error) {
+	var u User
+	err := row.Scan(
+		&u.ID, &u.Name, &u.Email, &u.PasswordHash,
+		&u.Role, &u.EmailVerifiedAt, &u.FailedLoginAttempts,
+		&u.LockedUntil, &u.TOTPSecret, &u.TOTPEnabledAt,
+		&u.SessionVersion, &u.DisabledAt, &u.StoreID,
+		&u.CreatedAt, &u.UpdatedAt,
+	)
+	return u, err
+}
+
 // UserRepository provides database access for users.
 type UserRepository struct {
 	db dbtx
@@ -41,17 +68,12 @@ func (r *UserRepository) Create(
 	ctx context.Context,
 	name, email, passwordHash, role string,
 ) (User, error) {
-	var u User
-	err := r.db.QueryRow(ctx,
+	u, err := scanUser(r.db.QueryRow(ctx,
 		"INSERT INTO users (name, email, password_hash, role) "+
 			"VALUES ($1, $2, $3, $4) "+
-			"RETURNING id, name, email, password_hash, role, "+
-			"created_at, updated_at",
+			"RETURNING "+userColumns,
 		name, email, passwordHash, role,
-	).Scan(
-		&u.ID, &u.Name, &u.Email, &u.PasswordHash,
-		&u.Role, &u.CreatedAt, &u.UpdatedAt,
-	)
+	))
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) &&
@@ -69,16 +91,10 @@ func (r *UserRepository) FindByEmail(
 	ctx context.Context,
 	email string,
 ) (User, error) {
-	var u User
-	err := r.db.QueryRow(ctx,
-		"SELECT id, name, email, password_hash, role, "+
-			"created_at, updated_at "+
-			"FROM users WHERE email = $1",
+	u, err := scanUser(r.db.QueryRow(ctx,
+		"SELECT "+userColumns+" FROM users WHERE email = $1",
 		email,
-	).Scan(
-		&u.ID, &u.Name, &u.Email, &u.PasswordHash,
-		&u.Role, &u.CreatedAt, &u.UpdatedAt,
-	)
+	))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return User{}, db.ErrNotFound
@@ -94,16 +110,10 @@ func (r *UserRepository) FindByID(
 	ctx context.Context,
 	id int64,
 ) (User, error) {
-	var u User
-	err := r.db.QueryRow(ctx,
-		"SELECT id, name, email, password_hash, role, "+
-			"created_at, updated_at "+
-			"FROM users WHERE id = $1",
+	u, err := scanUser(r.db.QueryRow(ctx,
+		"SELECT "+userColumns+" FROM users WHERE id = $1",
 		id,
-	).Scan(
-		&u.ID, &u.Name, &u.Email, &u.PasswordHash,
-		&u.Role, &u.CreatedAt, &u.UpdatedAt,
-	)
+	))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return User{}, db.ErrNotFound
@@ -112,3 +122,536 @@ func (r *UserRepository) FindByID(
 	}
 	return u, nil
 }
+
+// CreateResetToken stores the hash of a password reset
+// token for the given user. The plaintext token is never
+// persisted.
+func (r *UserRepository) CreateResetToken(
+	ctx context.Context,
+	userID int64,
+	tokenHash string,
+	expiresAt time.Time,
+) error {
+	_, err := r.db.Exec(ctx,
+		"INSERT INTO password_reset_tokens "+
+			"(user_id, token_hash, expires_at) "+
+			"VALUES ($1, $2, $3)",
+		userID, tokenHash, expiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("create reset token: %w", err)
+	}
+	return nil
+}
+
+// ResetPassword consumes the reset token with the given
+// hash and sets the owning user's password hash in a single
+// statement, so a token can never be used twice. Any login
+// lockout is cleared along with the old password, and the
+// session version is bumped to sign out every session. Returns
+// the user's ID, or db.ErrNotFound if the token is unknown,
+// expired, or already used.
+func (r *UserRepository) ResetPassword(
+	ctx context.Context,
+	tokenHash, passwordHash string,
+) (int64, error) {
+	var id int64
+	err := r.db.QueryRow(ctx,
+		"WITH t AS ("+
+			"UPDATE password_reset_tokens SET used_at = now() "+
+			"WHERE token_hash = $1 AND used_at IS NULL "+
+			"AND expires_at > now() "+
+			"RETURNING user_id) "+
+			"UPDATE users SET password_hash = $2, "+
+			"failed_login_attempts = 0, locked_until = NULL, "+
+			"session_version = session_version + 1, "+
+			"updated_at = now() "+
+			"FROM t WHERE users.id = t.user_id RETURNING users.id",
+		tokenHash, passwordHash,
+	).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, db.ErrNotFound
+		}
+		return 0, fmt.Errorf("reset password: %w", err)
+	}
+	return id, nil
+}
+
+// UpdatePassword sets the user's password hash and bumps
+// their session version, signing out every existing session.
+// Returns the updated user, or db.ErrNotFound if the user
+// does not exist.
+func (r *UserRepository) UpdatePassword(
+	ctx context.Context,
+	userID int64,
+	passwordHash string,
+) (User, error) {
+	u, err := scanUser(r.db.QueryRow(ctx,
+		"UPDATE users SET password_hash = $2, "+
+			"session_version = session_version + 1, "+
+			"updated_at = now() "+
+			"WHERE id = $1 RETURNING "+userColumns,
+		userID, passwordHash,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return User{}, db.ErrNotFound
+		}
+		return User{}, fmt.Errorf("update password: %w", err)
+	}
+	return u, nil
+}
+
+// CreateEmailChangeToken stores the hash of a token that
+// changes the user's email to newEmail once confirmed.
+func (r *UserRepository) CreateEmailChangeToken(
+	ctx context.Context,
+	userID int64,
+	newEmail, tokenHash string,
+	expiresAt time.Time,
+) error {
+	_, err := r.db.Exec(ctx,
+		"INSERT INTO email_change_tokens "+
+			"(user_id, new_email, token_hash, expires_at) "+
+			"VALUES ($1, $2, $3, $4)",
+		userID, newEmail, tokenHash, expiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("create email change token: %w", err)
+	}
+	return nil
+}
+
+// UpdateEmail consumes the email change token with the
+// given hash and sets the owning user's email to the
+// address it was issued for, marking it verified, in a
+// single statement. Returns db.ErrNotFound if the token is
+// unknown, expired, or already used, and db.ErrConflict if
+// the address now belongs to another user; the token is
+// left unused in that case.
+func (r *UserRepository) UpdateEmail(
+	ctx context.Context,
+	tokenHash string,
+) error {
+	tag, err := r.db.Exec(ctx,
+		"WITH t AS ("+
+			"UPDATE email_change_tokens SET used_at = now() "+
+			"WHERE token_hash = $1 AND used_at IS NULL "+
+			"AND expires_at > now() "+
+			"RETURNING user_id, new_email) "+
+			"UPDATE users SET email = t.new_email, "+
+			"email_verified_at = now(), updated_at = now() "+
+			"FROM t WHERE users.id = t.user_id",
+		tokenHash,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) &&
+			pgErr.Code == uniqueViolation {
+			return db.ErrConflict
+		}
+		return fmt.Errorf("update email: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+// UpdatePasswordHash swaps the user's password hash from
+// oldHash to newHash, re-encoding the same password. It does
+// nothing if the hash changed since it was read, so a
+// concurrent reset is never overwritten.
+func (r *UserRepository) UpdatePasswordHash(
+	ctx context.Context,
+	userID int64,
+	oldHash, newHash string,
+) error {
+	_, err := r.db.Exec(ctx,
+		"UPDATE users SET password_hash = $3, updated_at = now() "+
+			"WHERE id = $1 AND password_hash = $2",
+		userID, oldHash, newHash,
+	)
+	if err != nil {
+		return fmt.Errorf("update password hash: %w", err)
+	}
+	return nil
+}
+
+// CreateVerificationToken stores the hash of an email
+// verification token for the given user.
+func (r *UserRepository) CreateVerificationToken(
+	ctx context.Context,
+	userID int64,
+	tokenHash string,
+	expiresAt time.Time,
+) error {
+	_, err := r.db.Exec(ctx,
+		"INSERT INTO email_verification_tokens "+
+			"(user_id, token_hash, expires_at) "+
+			"VALUES ($1, $2, $3)",
+		userID, tokenHash, expiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("create verification token: %w", err)
+	}
+	return nil
+}
+
+// CountVerificationTokensSince returns how many
+// verification tokens were issued to the user after since.
+// Used to throttle resend requests.
+func (r *UserRepository) CountVerificationTokensSince(
+	ctx context.Context,
+	userID int64,
+	since time.Time,
+) (int, error) {
+	var n int
+	err := r.db.QueryRow(ctx,
+		"SELECT count(*) FROM email_verification_tokens "+
+			"WHERE user_id = $1 AND created_at > $2",
+		userID, since,
+	).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("count verification tokens: %w", err)
+	}
+	return n, nil
+}
+
+// VerifyEmail consumes the verification token with the
+// given hash and marks the owning user's email as verified
+// in a single statement. Returns db.ErrNotFound if the
+// token is unknown, expired, or already used.
+func (r *UserRepository) VerifyEmail(
+	ctx context.Context,
+	tokenHash string,
+) (int64, error) {
+	var id int64
+	err := r.db.QueryRow(ctx,
+		"WITH t AS ("+
+			"UPDATE email_verification_tokens SET used_at = now() "+
+			"WHERE token_hash = $1 AND used_at IS NULL "+
+			"AND expires_at > now() "+
+			"RETURNING user_id) "+
+			"UPDATE users SET email_verified_at = "+
+			"COALESCE(email_verified_at, now()), "+
+			"updated_at = now() "+
+			"FROM t WHERE users.id = t.user_id RETURNING users.id",
+		tokenHash,
+	).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, db.ErrNotFound
+		}
+		return 0, fmt.Errorf("verify email: %w", err)
+	}
+	return id, nil
+}
+
+// DeleteSpentTokens removes password reset, email
+// verification, and email change tokens that expired or
+// were used before the given time, and returns how many
+// were removed.
+func (r *UserRepository) DeleteSpentTokens(
+	ctx context.Context,
+	before time.Time,
+) (int64, error) {
+	var n int64
+	err := r.db.QueryRow(ctx,
+		"WITH r AS ("+
+			"DELETE FROM password_reset_tokens "+
+			"WHERE expires_at < $1 OR used_at < $1 RETURNING 1), "+
+			"v AS ("+
+			"DELETE FROM email_verification_tokens "+
+			"WHERE expires_at < $1 OR used_at < $1 RETURNING 1), "+
+			"c AS ("+
+			"DELETE FROM email_change_tokens "+
+			"WHERE expires_at < $1 OR used_at < $1 RETURNING 1) "+
+			"SELECT (SELECT count(*) FROM r) + (SELECT count(*) FROM v) + "+
+			"(SELECT count(*) FROM c)",
+		before,
+	).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("delete spent tokens: %w", err)
+	}
+	return n, nil
+}
+
+// RecordLoginFailure increments the user's failed login
+// counter and returns the new count. Returns db.ErrNotFound
+// if the user does not exist.
+func (r *UserRepository) RecordLoginFailure(
+	ctx context.Context,
+	userID int64,
+) (int, error) {
+	var n int
+	err := r.db.QueryRow(ctx,
+		"UPDATE users SET failed_login_attempts = "+
+			"failed_login_attempts + 1, updated_at = now() "+
+			"WHERE id = $1 RETURNING failed_login_attempts",
+		userID,
+	).Scan(&n)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, db.ErrNotFound
+		}
+		return 0, fmt.Errorf("record login failure: %w", err)
+	}
+	return n, nil
+}
+
+// LockUser blocks logins for the user until the given
+// time.
+func (r *UserRepository) LockUser(
+	ctx context.Context,
+	userID int64,
+	until time.Time,
+) error {
+	_, err := r.db.Exec(ctx,
+		"UPDATE users SET locked_until = $2, updated_at = now() "+
+			"WHERE id = $1",
+		userID, until,
+	)
+	if err != nil {
+		return fmt.Errorf("lock user: %w", err)
+	}
+	return nil
+}
+
+// UnlockUser clears the user's failed login counter and
+// lock. A non-nil scope confines it to that store's users.
+// Returns db.ErrNotFound if the user does not exist or is
+// outside scope.
+func (r *UserRepository) UnlockUser(
+	ctx context.Context,
+	userID int64,
+	scope *int64,
+) error {
+	tag, err := r.db.Exec(ctx,
+		"UPDATE users SET failed_login_attempts = 0, "+
+			"locked_until = NULL, updated_at = now() WHERE id = $1 "+
+			"AND ($2::bigint IS NULL OR store_id = $2)",
+		userID, scope,
+	)
+	if err != nil {
+		return fmt.Errorf("unlock user: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+// UpdateAccess applies c to the user, leaving nil fields
+// unchanged. Disabling an account that is already disabled
+// keeps its original disabled_at. A non-nil scope confines
+// it to that store's users. Returns db.ErrNotFound if the
+// user does not exist or is outside scope, and
+// store.ErrUnknownStore if c names a store that does not
+// exist.
+func (r *UserRepository) UpdateAccess(
+	ctx context.Context,
+	userID int64,
+	c AccessChange,
+	scope *int64,
+) (User, error) {
+	u, err := scanUser(r.db.QueryRow(ctx,
+		"UPDATE users SET role = COALESCE($2, role), "+
+			"disabled_at = CASE WHEN $3::boolean IS NULL "+
+			"THEN disabled_at WHEN $3 "+
+			"THEN COALESCE(disabled_at, now()) ELSE NULL END, "+
+			"store_id = CASE WHEN $4 THEN NULL "+
+			"ELSE COALESCE($5, store_id) END, "+
+			"updated_at = now() "+
+			"WHERE id = $1 AND ($6::bigint IS NULL OR store_id = $6) "+
+			"RETURNING "+userColumns,
+		userID, c.Role, c.Disabled, c.Global, c.StoreID, scope,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return User{}, db.ErrNotFound
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) &&
+			pgErr.Code == foreignKeyViolation {
+			return User{}, store.ErrUnknownStore
+		}
+		return User{}, fmt.Errorf("update access: %w", err)
+	}
+	return u, nil
+}
+
+// SetTOTPSecret stores a pending TOTP secret for the user.
+// Returns db.ErrConflict if TOTP is already enabled or the
+// user does not exist.
+func (r *UserRepository) SetTOTPSecret(
+	ctx context.Context,
+	userID int64,
+	secret string,
+) error {
+	tag, err := r.db.Exec(ctx,
+		"UPDATE users SET totp_secret = $2, totp_last_step = NULL, "+
+			"updated_at = now() "+
+			"WHERE id = $1 AND totp_enabled_at IS NULL",
+		userID, secret,
+	)
+	if err != nil {
+		return fmt.Errorf("set totp secret: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return db.ErrConflict
+	}
+	return nil
+}
+
+// EnableTOTP marks the user's pending TOTP secret as
+// enabled and stores the hashes of their recovery codes in
+// a single statement. Returns db.ErrConflict if TOTP is
+// already enabled or no secret is pending.
+func (r *UserRepository) EnableTOTP(
+	ctx context.Context,
+	userID int64,
+	codeHashes []string,
+) error {
+	tag, err := r.db.Exec(ctx,
+		"WITH u AS ("+
+			"UPDATE users SET totp_enabled_at = now(), "+
+			"updated_at = now() "+
+			"WHERE id = $1 AND totp_secret IS NOT NULL "+
+			"AND totp_enabled_at IS NULL RETURNING id) "+
+			"INSERT INTO mfa_recovery_codes (user_id, code_hash) "+
+			"SELECT u.id, h FROM u, unnest($2::text[]) AS h",
+		userID, codeHashes,
+	)
+	if err != nil {
+		return fmt.Errorf("enable totp: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return db.ErrConflict
+	}
+	return nil
+}
+
+// UseTOTPStep records step as the user's last accepted TOTP
+// time step. Returns db.ErrConflict if step is not newer
+// than the last one, so a code cannot be replayed.
+func (r *UserRepository) UseTOTPStep(
+	ctx context.Context,
+	userID int64,
+	step int64,
+) error {
+	tag, err := r.db.Exec(ctx,
+		"UPDATE users SET totp_last_step = $2, updated_at = now() "+
+			"WHERE id = $1 "+
+			"AND (totp_last_step IS NULL OR totp_last_step < $2)",
+		userID, step,
+	)
+	if err != nil {
+		return fmt.Errorf("use totp step: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return db.ErrConflict
+	}
+	return nil
+}
+
+// UseRecoveryCode consumes the user's recovery code with
+// the given hash. Returns db.ErrNotFound if the code is
+// unknown or already used.
+func (r *UserRepository) UseRecoveryCode(
+	ctx context.Context,
+	userID int64,
+	codeHash string,
+) error {
+	tag, err := r.db.Exec(ctx,
+		"UPDATE mfa_recovery_codes SET used_at = now() "+
+			"WHERE user_id = $1 AND code_hash = $2 "+
+			"AND used_at IS NULL",
+		userID, codeHash,
+	)
+	if err != nil {
+		return fmt.Errorf("use recovery code: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+// FindByIdentity returns the user linked to the external
+// identity (issuer, subject). Returns db.ErrNotFound if none
+// is linked.
+func (r *UserRepository) FindByIdentity(
+	ctx context.Context,
+	issuer, subject string,
+) (User, error) {
+	u, err := scanUser(r.db.QueryRow(ctx,
+		"SELECT "+userColumns+" FROM users WHERE id = ("+
+			"SELECT user_id FROM user_identities "+
+			"WHERE issuer = $1 AND subject = $2)",
+		issuer, subject,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return User{}, db.ErrNotFound
+		}
+		return User{}, fmt.Errorf("find user by identity: %w", err)
+	}
+	return u, nil
+}
+
+// LinkIdentity links the external identity (issuer,
+// subject) to an existing user. Returns db.ErrConflict if
+// the identity is already linked.
+func (r *UserRepository) LinkIdentity(
+	ctx context.Context,
+	userID int64,
+	issuer, subject string,
+) error {
+	_, err := r.db.Exec(ctx,
+		"INSERT INTO user_identities (user_id, issuer, subject) "+
+			"VALUES ($1, $2, $3)",
+		userID, issuer, subject,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) &&
+			pgErr.Code == uniqueViolation {
+			return db.ErrConflict
+		}
+		return fmt.Errorf("link identity: %w", err)
+	}
+	return nil
+}
+
+// CreateExternalUser inserts a user with a verified email
+// and no password, linked to the external identity (issuer,
+// subject), in one statement. Returns db.ErrConflict if the
+// email or identity already exists.
+func (r *UserRepository) CreateExternalUser(
+	ctx context.Context,
+	name, email, role, issuer, subject string,
+) (User, error) {
+	u, err := scanUser(r.db.QueryRow(ctx,
+		"WITH u AS ("+
+			"INSERT INTO users "+
+			"(name, email, password_hash, role, email_verified_at) "+
+			"VALUES ($1, $2, '', $3, now()) "+
+			"RETURNING "+userColumns+"), "+
+			"i AS (INSERT INTO user_identities "+
+			"(user_id, issuer, subject) "+
+			"SELECT id, $4, $5 FROM u) "+
+			"SELECT * FROM u",
+		name, email, role, issuer, subject,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) &&
+			pgErr.Code == uniqueViolation {
+			return User{}, db.ErrConflict
+		}
+		return User{}, fmt.Errorf("create external user: %w", err)
+	}
+	return u, nil
+}
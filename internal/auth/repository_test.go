@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pashagolub/pgxmock/v4"
 
@@ -33,11 +34,18 @@ func TestUserCreate(t *testing.T) {
 						pgxmock.NewRows([]string{
 							"id", "name", "email",
 							"password_hash", "role",
-							"created_at", "updated_at",
+							"email_verified_at",
+							"failed_login_attempts", "locked_until",
+							"totp_secret", "totp_enabled_at",
+							"session_version", "disabled_at",
+							"store_id", "created_at", "updated_at",
 						}).AddRow(
 							int64(1), "Alice",
 							"alice@example.com", "hashed",
-							"customer", now, now,
+							"customer", (*time.Time)(nil),
+							0, (*time.Time)(nil),
+							"", (*time.Time)(nil), 0, (*time.Time)(nil),
+							(*int64)(nil), now, now,
 						),
 					)
 			},
@@ -121,11 +129,18 @@ func TestUserFindByEmail(t *testing.T) {
 						pgxmock.NewRows([]string{
 							"id", "name", "email",
 							"password_hash", "role",
-							"created_at", "updated_at",
+							"email_verified_at",
+							"failed_login_attempts", "locked_until",
+							"totp_secret", "totp_enabled_at",
+							"session_version", "disabled_at",
+							"store_id", "created_at", "updated_at",
 						}).AddRow(
 							int64(1), "Alice",
 							"alice@example.com", "hashed",
-							"customer", now, now,
+							"customer", (*time.Time)(nil),
+							0, (*time.Time)(nil),
+							"", (*time.Time)(nil), 0, (*time.Time)(nil),
+							(*int64)(nil), now, now,
 						),
 					)
 			},
@@ -149,7 +164,11 @@ func TestUserFindByEmail(t *testing.T) {
 						pgxmock.NewRows([]string{
 							"id", "name", "email",
 							"password_hash", "role",
-							"created_at", "updated_at",
+							"email_verified_at",
+							"failed_login_attempts", "locked_until",
+							"totp_secret", "totp_enabled_at",
+							"session_version", "disabled_at",
+							"store_id", "created_at", "updated_at",
 						}),
 					)
 			},
@@ -211,11 +230,18 @@ func TestUserFindByID(t *testing.T) {
 						pgxmock.NewRows([]string{
 							"id", "name", "email",
 							"password_hash", "role",
-							"created_at", "updated_at",
+							"email_verified_at",
+							"failed_login_attempts", "locked_until",
+							"totp_secret", "totp_enabled_at",
+							"session_version", "disabled_at",
+							"store_id", "created_at", "updated_at",
 						}).AddRow(
 							int64(1), "Alice",
 							"alice@example.com", "hashed",
-							"customer", now, now,
+							"customer", (*time.Time)(nil),
+							0, (*time.Time)(nil),
+							"", (*time.Time)(nil), 0, (*time.Time)(nil),
+							(*int64)(nil), now, now,
 						),
 					)
 			},
@@ -239,7 +265,11 @@ func TestUserFindByID(t *testing.T) {
 						pgxmock.NewRows([]string{
 							"id", "name", "email",
 							"password_hash", "role",
-							"created_at", "updated_at",
+							"email_verified_at",
+							"failed_login_attempts", "locked_until",
+							"totp_secret", "totp_enabled_at",
+							"session_version", "disabled_at",
+							"store_id", "created_at", "updated_at",
 						}),
 					)
 			},
@@ -279,3 +309,1031 @@ func TestUserFindByID(t *testing.T) {
 		})
 	}
 }
+
+func TestUserCreateResetToken(t *testing.T) {
+	ctx := context.Background()
+	expires := time.Now().Add(time.Hour).Truncate(time.Microsecond)
+
+	tests := []struct {
+		name    string
+		mock    func(m pgxmock.PgxPoolIface)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec("INSERT INTO password_reset_tokens").
+					WithArgs(int64(1), "hash", expires).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+			},
+		},
+		{
+			name: "db error",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec("INSERT INTO password_reset_tokens").
+					WithArgs(int64(1), "hash", expires).
+					WillReturnError(errors.New("db down"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			tt.mock(mock)
+
+			repo := auth.NewUserRepository(mock)
+			err = repo.CreateResetToken(ctx, 1, "hash", expires)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestUserResetPassword(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		mock    func(m pgxmock.PgxPoolIface)
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("UPDATE password_reset_tokens .+ UPDATE users .+ RETURNING users.id").
+					WithArgs("hash", "new-hash").
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(5)))
+			},
+		},
+		{
+			name: "unknown, expired, or used token",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("UPDATE password_reset_tokens .+ UPDATE users").
+					WithArgs("hash", "new-hash").
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantErr: db.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			tt.mock(mock)
+
+			repo := auth.NewUserRepository(mock)
+			id, err := repo.ResetPassword(ctx, "hash", "new-hash")
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v",
+						err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if id != 5 {
+				t.Errorf("id = %d, want 5", id)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestUserCreateVerificationToken(t *testing.T) {
+	ctx := context.Background()
+	expires := time.Now().Add(24 * time.Hour).Truncate(time.Microsecond)
+
+	tests := []struct {
+		name    string
+		mock    func(m pgxmock.PgxPoolIface)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec("INSERT INTO email_verification_tokens").
+					WithArgs(int64(1), "hash", expires).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+			},
+		},
+		{
+			name: "db error",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec("INSERT INTO email_verification_tokens").
+					WithArgs(int64(1), "hash", expires).
+					WillReturnError(errors.New("db down"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			tt.mock(mock)
+
+			repo := auth.NewUserRepository(mock)
+			err = repo.CreateVerificationToken(ctx, 1, "hash", expires)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestUserCountVerificationTokensSince(t *testing.T) {
+	ctx := context.Background()
+	since := time.Now().Add(-time.Hour).Truncate(time.Microsecond)
+
+	tests := []struct {
+		name    string
+		mock    func(m pgxmock.PgxPoolIface)
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "success",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("SELECT count").
+					WithArgs(int64(1), since).
+					WillReturnRows(
+						pgxmock.NewRows([]string{"count"}).
+							AddRow(3),
+					)
+			},
+			want: 3,
+		},
+		{
+			name: "db error",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("SELECT count").
+					WithArgs(int64(1), since).
+					WillReturnError(errors.New("db down"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			tt.mock(mock)
+
+			repo := auth.NewUserRepository(mock)
+			got, err := repo.CountVerificationTokensSince(ctx, 1, since)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestUserVerifyEmail(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		mock    func(m pgxmock.PgxPoolIface)
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("UPDATE email_verification_tokens .+ UPDATE users .+ RETURNING users.id").
+					WithArgs("hash").
+					WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(5)))
+			},
+		},
+		{
+			name: "unknown, expired, or used token",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("UPDATE email_verification_tokens .+ UPDATE users").
+					WithArgs("hash").
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantErr: db.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			tt.mock(mock)
+
+			repo := auth.NewUserRepository(mock)
+			id, err := repo.VerifyEmail(ctx, "hash")
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v",
+						err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if id != 5 {
+				t.Errorf("id = %d, want 5", id)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestUserRecordLoginFailure(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		mock    func(m pgxmock.PgxPoolIface)
+		want    int
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("UPDATE users SET failed_login_attempts = failed_login_attempts \\+ 1, updated_at = now\\(\\)").
+					WithArgs(int64(1)).
+					WillReturnRows(
+						pgxmock.NewRows([]string{
+							"failed_login_attempts",
+						}).AddRow(3),
+					)
+			},
+			want: 3,
+		},
+		{
+			name: "not found",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("UPDATE users SET failed_login_attempts = failed_login_attempts \\+ 1, updated_at = now\\(\\)").
+					WithArgs(int64(1)).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantErr: db.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			tt.mock(mock)
+
+			repo := auth.NewUserRepository(mock)
+			got, err := repo.RecordLoginFailure(ctx, 1)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v",
+						err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestUserLockUser(t *testing.T) {
+	ctx := context.Background()
+	until := time.Now().Add(time.Minute).Truncate(time.Microsecond)
+
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	mock.ExpectExec("UPDATE users SET locked_until = \\$2, updated_at = now\\(\\)").
+		WithArgs(int64(1), until).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	repo := auth.NewUserRepository(mock)
+	if err := repo.LockUser(ctx, 1, until); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestUserUnlockUser(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		mock    func(m pgxmock.PgxPoolIface)
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec("UPDATE users SET failed_login_attempts = 0, locked_until = NULL, updated_at = now\\(\\)").
+					WithArgs(int64(1), (*int64)(nil)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+		},
+		{
+			name: "not found",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec("UPDATE users SET failed_login_attempts = 0, locked_until = NULL, updated_at = now\\(\\)").
+					WithArgs(int64(1), (*int64)(nil)).
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+			},
+			wantErr: db.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			tt.mock(mock)
+
+			repo := auth.NewUserRepository(mock)
+			err = repo.UnlockUser(ctx, 1, nil)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v",
+						err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestUserSetTOTPSecret(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		rows    int64
+		wantErr error
+	}{
+		{name: "success", rows: 1},
+		{name: "already enabled", rows: 0, wantErr: db.ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			mock.ExpectExec("UPDATE users SET totp_secret = \\$2, totp_last_step = NULL, updated_at = now\\(\\)").
+				WithArgs(int64(1), "SECRET").
+				WillReturnResult(pgxmock.NewResult("UPDATE", tt.rows))
+
+			repo := auth.NewUserRepository(mock)
+			err = repo.SetTOTPSecret(ctx, 1, "SECRET")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestUserEnableTOTP(t *testing.T) {
+	ctx := context.Background()
+	hashes := []string{"h1", "h2"}
+
+	tests := []struct {
+		name    string
+		rows    int64
+		wantErr error
+	}{
+		{name: "success", rows: 2},
+		{name: "not pending", rows: 0, wantErr: db.ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			mock.ExpectExec("UPDATE users SET totp_enabled_at .+ INSERT INTO mfa_recovery_codes").
+				WithArgs(int64(1), hashes).
+				WillReturnResult(pgxmock.NewResult("INSERT", tt.rows))
+
+			repo := auth.NewUserRepository(mock)
+			err = repo.EnableTOTP(ctx, 1, hashes)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestUserUseTOTPStep(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		rows    int64
+		wantErr error
+	}{
+		{name: "newer step", rows: 1},
+		{name: "replayed step", rows: 0, wantErr: db.ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			mock.ExpectExec("UPDATE users SET totp_last_step = \\$2, updated_at = now\\(\\)").
+				WithArgs(int64(1), int64(1000)).
+				WillReturnResult(pgxmock.NewResult("UPDATE", tt.rows))
+
+			repo := auth.NewUserRepository(mock)
+			err = repo.UseTOTPStep(ctx, 1, 1000)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestUserUseRecoveryCode(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		rows    int64
+		wantErr error
+	}{
+		{name: "success", rows: 1},
+		{name: "unknown or used", rows: 0, wantErr: db.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			mock.ExpectExec("UPDATE mfa_recovery_codes SET used_at").
+				WithArgs(int64(1), "hash").
+				WillReturnResult(pgxmock.NewResult("UPDATE", tt.rows))
+
+			repo := auth.NewUserRepository(mock)
+			err = repo.UseRecoveryCode(ctx, 1, "hash")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+// userRows returns the users columns with one row for Alice.
+func userRows(now time.Time, verifiedAt *time.Time) *pgxmock.Rows {
+	return pgxmock.NewRows([]string{
+		"id", "name", "email",
+		"password_hash", "role",
+		"email_verified_at",
+		"failed_login_attempts", "locked_until",
+		"totp_secret", "totp_enabled_at",
+		"session_version", "disabled_at",
+		"store_id", "created_at", "updated_at",
+	}).AddRow(
+		int64(1), "Alice",
+		"alice@example.com", "",
+		"customer", verifiedAt,
+		0, (*time.Time)(nil),
+		"", (*time.Time)(nil), 0, (*time.Time)(nil),
+		(*int64)(nil), now, now,
+	)
+}
+
+func TestUserFindByIdentity(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Microsecond)
+
+	tests := []struct {
+		name    string
+		mock    func(m pgxmock.PgxPoolIface)
+		wantErr error
+	}{
+		{
+			name: "found",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("SELECT .+ FROM users WHERE id = \\(SELECT user_id FROM user_identities").
+					WithArgs("https://idp", "sub-1").
+					WillReturnRows(userRows(now, &now))
+			},
+		},
+		{
+			name: "not linked",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("SELECT .+ FROM user_identities").
+					WithArgs("https://idp", "sub-1").
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantErr: db.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			tt.mock(mock)
+
+			repo := auth.NewUserRepository(mock)
+			got, err := repo.FindByIdentity(ctx, "https://idp", "sub-1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got.ID != 1 {
+				t.Errorf("got ID %d, want 1", got.ID)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestUserLinkIdentity(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{name: "success"},
+		{
+			name:    "already linked",
+			err:     &pgconn.PgError{Code: "23505"},
+			wantErr: db.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			exp := mock.ExpectExec("INSERT INTO user_identities").
+				WithArgs(int64(1), "https://idp", "sub-1")
+			if tt.err != nil {
+				exp.WillReturnError(tt.err)
+			} else {
+				exp.WillReturnResult(pgxmock.NewResult("INSERT", 1))
+			}
+
+			repo := auth.NewUserRepository(mock)
+			err = repo.LinkIdentity(ctx, 1, "https://idp", "sub-1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestUserCreateExternalUser(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Microsecond)
+
+	tests := []struct {
+		name    string
+		mock    func(m pgxmock.PgxPoolIface)
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("INSERT INTO users .+INSERT INTO user_identities").
+					WithArgs("Alice", "alice@example.com",
+						"customer", "https://idp", "sub-1").
+					WillReturnRows(userRows(now, &now))
+			},
+		},
+		{
+			name: "email taken",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("INSERT INTO users").
+					WithArgs("Alice", "alice@example.com",
+						"customer", "https://idp", "sub-1").
+					WillReturnError(&pgconn.PgError{Code: "23505"})
+			},
+			wantErr: db.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			tt.mock(mock)
+
+			repo := auth.NewUserRepository(mock)
+			got, err := repo.CreateExternalUser(ctx,
+				"Alice", "alice@example.com", "customer",
+				"https://idp", "sub-1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got.EmailVerifiedAt == nil {
+				t.Error("expected verified email")
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestUserUpdatePasswordHash(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	// A hash changed since it was read matches no row,
+	// which is not an error.
+	mock.ExpectExec("UPDATE users SET password_hash = \\$3, updated_at = now\\(\\) WHERE id = \\$1 AND password_hash = \\$2").
+		WithArgs(int64(1), "old", "new").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	repo := auth.NewUserRepository(mock)
+	if err := repo.UpdatePasswordHash(
+		context.Background(), 1, "old", "new",
+	); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestUserUpdatePassword(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Microsecond)
+
+	tests := []struct {
+		name    string
+		mock    func(m pgxmock.PgxPoolIface)
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("UPDATE users SET password_hash .+ session_version = session_version \\+ 1").
+					WithArgs(int64(1), "new-hash").
+					WillReturnRows(userRows(now, nil))
+			},
+		},
+		{
+			name: "not found",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("UPDATE users SET password_hash").
+					WithArgs(int64(1), "new-hash").
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantErr: db.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			tt.mock(mock)
+
+			repo := auth.NewUserRepository(mock)
+			u, err := repo.UpdatePassword(ctx, 1, "new-hash")
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v",
+						err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if u.ID != 1 {
+				t.Errorf("ID = %d, want 1", u.ID)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestUserCreateEmailChangeToken(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	expires := time.Now().Add(24 * time.Hour).Truncate(time.Microsecond)
+	mock.ExpectExec("INSERT INTO email_change_tokens").
+		WithArgs(int64(1), "new@example.com", "hash", expires).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	repo := auth.NewUserRepository(mock)
+	if err := repo.CreateEmailChangeToken(
+		context.Background(), 1, "new@example.com", "hash", expires,
+	); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestUserUpdateEmail(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		mock    func(m pgxmock.PgxPoolIface)
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec("UPDATE email_change_tokens .+ UPDATE users").
+					WithArgs("hash").
+					WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+			},
+		},
+		{
+			name: "unknown, expired, or used token",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec("UPDATE email_change_tokens .+ UPDATE users").
+					WithArgs("hash").
+					WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+			},
+			wantErr: db.ErrNotFound,
+		},
+		{
+			name: "address taken",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec("UPDATE email_change_tokens .+ UPDATE users").
+					WithArgs("hash").
+					WillReturnError(&pgconn.PgError{Code: "23505"})
+			},
+			wantErr: db.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			tt.mock(mock)
+
+			repo := auth.NewUserRepository(mock)
+			err = repo.UpdateEmail(ctx, "hash")
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v",
+						err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestUserUpdateAccess(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Microsecond)
+	role := "staff"
+	disabled := true
+
+	tests := []struct {
+		name    string
+		mock    func(m pgxmock.PgxPoolIface)
+		wantErr error
+	}{
+		{
+			name: "success",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("UPDATE users SET role = COALESCE\\(\\$2, role\\), disabled_at = CASE").
+					WithArgs(int64(1), &role, &disabled, false,
+						(*int64)(nil), (*int64)(nil)).
+					WillReturnRows(userRows(now, nil))
+			},
+		},
+		{
+			name: "not found",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("UPDATE users SET role").
+					WithArgs(int64(1), &role, &disabled, false,
+						(*int64)(nil), (*int64)(nil)).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantErr: db.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			tt.mock(mock)
+
+			repo := auth.NewUserRepository(mock)
+			_, err = repo.UpdateAccess(ctx, 1,
+				auth.AccessChange{Role: &role, Disabled: &disabled}, nil)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v",
+						err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestUserDeleteSpentTokens(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	before := time.Now()
+	mock.ExpectQuery("DELETE FROM password_reset_tokens.+" +
+		"DELETE FROM email_verification_tokens.+" +
+		"DELETE FROM email_change_tokens").
+		WithArgs(before).
+		WillReturnRows(pgxmock.NewRows([]string{"n"}).AddRow(int64(6)))
+
+	repo := auth.NewUserRepository(mock)
+	n, err := repo.DeleteSpentTokens(context.Background(), before)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 6 {
+		t.Errorf("deleted %d, want 6", n)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
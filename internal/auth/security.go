@@ -5,38 +5,122 @@ import (
 	"errors"
 
 	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/db"
 )
 
-// ErrForbidden is returned when a user lacks the required
-// role for an operation.
-var ErrForbidden = errors.New("forbidden: admin required")
+// ErrForbidden is returned when a user's role is not among
+// those an operation admits.
+var ErrForbidden = errors.New("forbidden: role not permitted")
 
-// adminOperations lists operations that require the admin
-// role. ogen does not populate CookieAuth.Roles from the
-// x-required-role vendor extension, so we maintain this
-// map ourselves.
-var adminOperations = map[api.OperationName]bool{
-	api.AddPetOperation:    true,
-	api.DeletePetOperation: true,
+// ErrInsufficientScope is returned when an API key lacks the
+// scope an operation requires.
+var ErrInsufficientScope = errors.New("forbidden: insufficient scope")
+
+// ErrStoreScoped is returned when an account or API key
+// confined to a store calls an operation that concerns
+// every store.
+var ErrStoreScoped = errors.New("forbidden: operation requires a global account")
+
+// APIKeyAuthenticator resolves a bearer API key to Claims.
+// It is implemented by apikey.Service; the interface keeps
+// this package free of a dependency on it.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (Claims, error)
+}
+
+// UserFinder looks up the user behind a session. It is
+// implemented by UserRepository.
+type UserFinder interface {
+	FindByID(ctx context.Context, id int64) (User, error)
+}
+
+// verifiedOperations lists operations that require a
+// verified email address when SecurityHandler is built with
+// WithRequireVerifiedEmail: the customer actions that
+// commit the store to something, so far holding a pet.
+// Placing orders and adoption applications belong here once
+// those operations exist. Cancelling a hold stays open so a
+// user whose verification lapsed can still release one.
+var verifiedOperations = map[api.OperationName]bool{
+	api.ReservePetOperation: true,
 }
 
 // SecurityHandler implements api.SecurityHandler by
 // validating JWTs from cookies and enforcing role
 // requirements.
 type SecurityHandler struct {
-	token *TokenConfig
+	token           *TokenConfig
+	requireVerified bool
+	requireAdminMFA bool
+	apiKeys         APIKeyAuthenticator
+	users           UserFinder
+	checkRole       bool
+}
+
+// SecurityOption configures optional SecurityHandler
+// policies.
+type SecurityOption func(*SecurityHandler)
+
+// WithRequireVerifiedEmail makes operations listed in
+// verifiedOperations reject users whose email address is
+// not verified. Unverified users can still log in. With
+// WithSessionCheck the user record decides, so verifying
+// takes effect without logging in again; otherwise the
+// token's email_verified claim does.
+func WithRequireVerifiedEmail(require bool) SecurityOption {
+	return func(sh *SecurityHandler) { sh.requireVerified = require }
+}
+
+// WithRequireAdminMFA makes admins' calls to role-restricted
+// operations reject tokens whose amr claim lacks "otp".
+// Admins can still use their password-only session for
+// operations open to any role, including TOTP enrollment.
+func WithRequireAdminMFA(require bool) SecurityOption {
+	return func(sh *SecurityHandler) { sh.requireAdminMFA = require }
+}
+
+// WithAPIKeys enables bearer API key authentication. Without
+// it every bearer credential is rejected.
+func WithAPIKeys(a APIKeyAuthenticator) SecurityOption {
+	return func(sh *SecurityHandler) { sh.apiKeys = a }
+}
+
+// WithSessionCheck makes every cookie-authenticated request
+// confirm that the user still exists and that the token's
+// session version is current, so a password change signs
+// out other sessions, and refreshes the claims' email
+// verification from the record. It costs one user lookup
+// per request.
+func WithSessionCheck(users UserFinder) SecurityOption {
+	return func(sh *SecurityHandler) { sh.users = users }
+}
+
+// WithRoleCheck makes the session check also reject tokens
+// whose user has been disabled or no longer has the role or
+// store the token claims, instead of honouring the claim
+// until the token expires. It needs WithSessionCheck, ideally backed
+// by a UserCache that the Service invalidates.
+func WithRoleCheck(require bool) SecurityOption {
+	return func(sh *SecurityHandler) { sh.checkRole = require }
 }
 
 // NewSecurityHandler returns a SecurityHandler that uses
 // the given TokenConfig for JWT validation.
 func NewSecurityHandler(
-	token *TokenConfig,
+	token *TokenConfig, opts ...SecurityOption,
 ) *SecurityHandler {
-	return &SecurityHandler{token: token}
+	sh := &SecurityHandler{token: token}
+	for _, opt := range opts {
+		opt(sh)
+	}
+	return sh
 }
 
 // HandleCookieAuth validates the JWT from the cookie,
-// checks role requirements, and stores Claims in ctx.
+// checks the operation's x-required-role policy, and stores
+// Claims in ctx. ogen only calls it for secured operations,
+// and api.CheckPolicies ensures each of those has a policy;
+// an operation without one is refused all the same.
 func (sh *SecurityHandler) HandleCookieAuth(
 	ctx context.Context,
 	operationName api.OperationName,
@@ -46,11 +130,95 @@ func (sh *SecurityHandler) HandleCookieAuth(
 	if err != nil {
 		return ctx, ErrInvalidToken
 	}
+	claims, err = sh.checkSession(ctx, claims)
+	if err != nil {
+		return ctx, err
+	}
 
-	if adminOperations[operationName] &&
-		claims.Role != "admin" {
+	policy, _ := api.OperationPolicy(operationName)
+	if !policy.Allows(claims.Role) {
 		return ctx, ErrForbidden
 	}
+	if sh.requireAdminMFA && claims.Role == "admin" &&
+		policy.Restricted() && !claims.HasAMR("otp") {
+		return ctx, ErrMFARequired
+	}
+
+	if sh.requireVerified && verifiedOperations[operationName] &&
+		!claims.EmailVerified {
+		return ctx, ErrEmailNotVerified
+	}
+	if policy.Global && claims.StoreID != nil {
+		return ctx, ErrStoreScoped
+	}
+
+	return ContextWithClaims(ctx, claims), nil
+}
+
+// checkSession rejects claims whose user is gone or whose
+// session version is stale and, with WithRoleCheck, whose
+// user is disabled or has changed role or store. It returns
+// claims with EmailVerified taken from the user record, so
+// a verification or email change made since login counts.
+// It does nothing without WithSessionCheck.
+func (sh *SecurityHandler) checkSession(
+	ctx context.Context, claims Claims,
+) (Claims, error) {
+	if sh.users == nil {
+		return claims, nil
+	}
+	user, err := sh.users.FindByID(ctx, claims.UserID)
+	if errors.Is(err, db.ErrNotFound) {
+		return claims, ErrInvalidToken
+	}
+	if err != nil {
+		return claims, err
+	}
+	if user.SessionVersion != claims.SessionVersion {
+		return claims, ErrInvalidToken
+	}
+	if sh.checkRole && (user.DisabledAt != nil ||
+		user.Role != claims.Role || !sameStore(user.StoreID, claims.StoreID)) {
+		return claims, ErrInvalidToken
+	}
+	claims.EmailVerified = user.EmailVerifiedAt != nil
+	return claims, nil
+}
+
+// sameStore reports whether a and b name the same store, or
+// are both global.
+func sameStore(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// HandleBearerAuth validates an API key from the
+// Authorization header, checks that it carries the scope the
+// operation requires, and stores its Claims in ctx. Keys are
+// gated by scope alone; role and MFA policies apply to user
+// sessions only.
+func (sh *SecurityHandler) HandleBearerAuth(
+	ctx context.Context,
+	operationName api.OperationName,
+	t api.BearerAuth,
+) (context.Context, error) {
+	if sh.apiKeys == nil {
+		return ctx, ErrInvalidToken
+	}
+	claims, err := sh.apiKeys.AuthenticateAPIKey(ctx, t.Token)
+	if err != nil {
+		return ctx, err
+	}
+
+	policy, _ := api.OperationPolicy(operationName)
+	if policy.Scope == "" || !claims.HasScope(policy.Scope) {
+		return ctx, ErrInsufficientScope
+	}
+	if policy.Global && claims.StoreID != nil {
+		return ctx, ErrStoreScoped
+	}
 
 	return ContextWithClaims(ctx, claims), nil
 }
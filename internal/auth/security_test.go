@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/hhubris/petstore/internal/api"
 	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/db"
 )
 
 // testSecret is a 32-byte key used across security tests.
@@ -22,12 +24,21 @@ func TestSecurityHandlerHandleCookieAuth(t *testing.T) {
 	// Helper to create a valid JWT for the given role.
 	makeToken := func(t *testing.T, role string) string {
 		t.Helper()
-		tok, err := cfg.CreateToken(1, role)
+		tok, err := cfg.CreateToken(auth.Claims{
+			UserID: 1, Role: role,
+		})
 		if err != nil {
 			t.Fatalf("CreateToken: %v", err)
 		}
 		return tok
 	}
+	storeID := int64(2)
+	scoped, err := cfg.CreateToken(auth.Claims{
+		UserID: 1, Role: "admin", StoreID: &storeID,
+	})
+	if err != nil {
+		t.Fatalf("CreateToken: %v", err)
+	}
 
 	tests := []struct {
 		name      string
@@ -59,6 +70,54 @@ func TestSecurityHandlerHandleCookieAuth(t *testing.T) {
 			token:     makeToken(t, "customer"),
 			wantErr:   auth.ErrForbidden,
 		},
+		{
+			name:      "valid token, unlock op as customer",
+			operation: api.UnlockUserOperation,
+			token:     makeToken(t, "customer"),
+			wantErr:   auth.ErrForbidden,
+		},
+		{
+			name:      "valid token, add op as staff",
+			operation: api.AddPetOperation,
+			token:     makeToken(t, "staff"),
+			wantErr:   nil,
+		},
+		{
+			name:      "valid token, delete op as staff",
+			operation: api.DeletePetOperation,
+			token:     makeToken(t, "staff"),
+			wantErr:   auth.ErrForbidden,
+		},
+		{
+			name:      "valid token, any-role op as unknown role",
+			operation: api.GetCurrentUserOperation,
+			token:     makeToken(t, "auditor"),
+			wantErr:   nil,
+		},
+		{
+			name:      "valid token, operation without policy",
+			operation: api.FindPetsOperation,
+			token:     makeToken(t, "admin"),
+			wantErr:   auth.ErrForbidden,
+		},
+		{
+			name:      "valid token, global op as global admin",
+			operation: api.ListJobsOperation,
+			token:     makeToken(t, "admin"),
+			wantErr:   nil,
+		},
+		{
+			name:      "valid token, global op as store admin",
+			operation: api.ListJobsOperation,
+			token:     scoped,
+			wantErr:   auth.ErrStoreScoped,
+		},
+		{
+			name:      "valid token, store op as store admin",
+			operation: api.DeletePetOperation,
+			token:     scoped,
+			wantErr:   nil,
+		},
 		{
 			name:      "invalid token",
 			operation: api.LogoutUserOperation,
@@ -110,3 +169,389 @@ func TestSecurityHandlerHandleCookieAuth(t *testing.T) {
 		})
 	}
 }
+
+func TestSecurityHandlerRequireAdminMFA(t *testing.T) {
+	cfg, err := auth.NewTokenConfig(testSecret)
+	if err != nil {
+		t.Fatalf("NewTokenConfig: %v", err)
+	}
+	sh := auth.NewSecurityHandler(cfg, auth.WithRequireAdminMFA(true))
+
+	makeRoleToken := func(t *testing.T, role string, amr ...string) string {
+		t.Helper()
+		tok, err := cfg.CreateToken(auth.Claims{
+			UserID: 1, Role: role, AMR: amr,
+		})
+		if err != nil {
+			t.Fatalf("CreateToken: %v", err)
+		}
+		return tok
+	}
+	makeToken := func(t *testing.T, amr ...string) string {
+		t.Helper()
+		return makeRoleToken(t, "admin", amr...)
+	}
+
+	tests := []struct {
+		name      string
+		operation api.OperationName
+		token     string
+		wantErr   error
+	}{
+		{
+			name:      "admin op with otp",
+			operation: api.AddPetOperation,
+			token:     makeToken(t, "pwd", "otp"),
+		},
+		{
+			name:      "admin op with password only",
+			operation: api.DeletePetOperation,
+			token:     makeToken(t, "pwd"),
+			wantErr:   auth.ErrMFARequired,
+		},
+		{
+			name:      "enrollment with password only",
+			operation: api.EnrollMFAOperation,
+			token:     makeToken(t, "pwd"),
+		},
+		{
+			name:      "staff with password only",
+			operation: api.AddPetOperation,
+			token:     makeRoleToken(t, "staff", "pwd"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := sh.HandleCookieAuth(
+				context.Background(), tt.operation,
+				api.CookieAuth{APIKey: tt.token},
+			)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSecurityHandlerRequireVerifiedEmail(t *testing.T) {
+	cfg, err := auth.NewTokenConfig(testSecret)
+	if err != nil {
+		t.Fatalf("NewTokenConfig: %v", err)
+	}
+	makeToken := func(t *testing.T, verified bool) string {
+		t.Helper()
+		tok, err := cfg.CreateToken(auth.Claims{
+			UserID: 1, Role: "customer", EmailVerified: verified,
+		})
+		if err != nil {
+			t.Fatalf("CreateToken: %v", err)
+		}
+		return tok
+	}
+
+	now := time.Now()
+
+	// record, when set, is the user the session check loads;
+	// it overrides the token's claim.
+	tests := []struct {
+		name      string
+		require   bool
+		operation api.OperationName
+		verified  bool
+		record    *auth.User
+		wantErr   error
+	}{
+		{
+			name:      "reserve verified",
+			require:   true,
+			operation: api.ReservePetOperation,
+			verified:  true,
+		},
+		{
+			name:      "reserve verified since login",
+			require:   true,
+			operation: api.ReservePetOperation,
+			record:    &auth.User{ID: 1, Role: "customer", EmailVerifiedAt: &now},
+		},
+		{
+			name:      "reserve after email change",
+			require:   true,
+			operation: api.ReservePetOperation,
+			verified:  true,
+			record:    &auth.User{ID: 1, Role: "customer"},
+			wantErr:   auth.ErrEmailNotVerified,
+		},
+		{
+			name:      "reserve unverified",
+			require:   true,
+			operation: api.ReservePetOperation,
+			wantErr:   auth.ErrEmailNotVerified,
+		},
+		{
+			name:      "reserve unverified without the flag",
+			operation: api.ReservePetOperation,
+		},
+		{
+			name:      "cancel unverified",
+			require:   true,
+			operation: api.CancelReservationOperation,
+		},
+		{
+			name:      "profile unverified",
+			require:   true,
+			operation: api.GetCurrentUserOperation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []auth.SecurityOption{
+				auth.WithRequireVerifiedEmail(tt.require),
+			}
+			if tt.record != nil {
+				opts = append(opts, auth.WithSessionCheck(&mockRepo{
+					findByIDFn: func(context.Context, int64) (auth.User, error) {
+						return *tt.record, nil
+					},
+				}))
+			}
+			sh := auth.NewSecurityHandler(cfg, opts...)
+			_, err := sh.HandleCookieAuth(
+				context.Background(), tt.operation,
+				api.CookieAuth{APIKey: makeToken(t, tt.verified)},
+			)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// stubAPIKeys is a fixed-response auth.APIKeyAuthenticator.
+type stubAPIKeys map[string]auth.Claims
+
+func (s stubAPIKeys) AuthenticateAPIKey(
+	_ context.Context, key string,
+) (auth.Claims, error) {
+	c, ok := s[key]
+	if !ok {
+		return auth.Claims{}, auth.ErrInvalidToken
+	}
+	return c, nil
+}
+
+func TestSecurityHandlerHandleBearerAuth(t *testing.T) {
+	cfg, err := auth.NewTokenConfig(testSecret)
+	if err != nil {
+		t.Fatalf("NewTokenConfig: %v", err)
+	}
+	keys := stubAPIKeys{
+		"psk_writer": {APIKeyID: 3, Scopes: []string{"pets:write"}},
+		"psk_none":   {APIKeyID: 4},
+	}
+
+	tests := []struct {
+		name      string
+		sh        *auth.SecurityHandler
+		operation api.OperationName
+		token     string
+		wantErr   error
+	}{
+		{
+			name:      "scoped key",
+			sh:        auth.NewSecurityHandler(cfg, auth.WithAPIKeys(keys)),
+			operation: api.AddPetOperation,
+			token:     "psk_writer",
+		},
+		{
+			name:      "key without scope",
+			sh:        auth.NewSecurityHandler(cfg, auth.WithAPIKeys(keys)),
+			operation: api.DeletePetOperation,
+			token:     "psk_none",
+			wantErr:   auth.ErrInsufficientScope,
+		},
+		{
+			name:      "operation without scope",
+			sh:        auth.NewSecurityHandler(cfg, auth.WithAPIKeys(keys)),
+			operation: api.UnlockUserOperation,
+			token:     "psk_writer",
+			wantErr:   auth.ErrInsufficientScope,
+		},
+		{
+			name:      "unknown key",
+			sh:        auth.NewSecurityHandler(cfg, auth.WithAPIKeys(keys)),
+			operation: api.AddPetOperation,
+			token:     "psk_unknown",
+			wantErr:   auth.ErrInvalidToken,
+		},
+		{
+			name:      "api keys disabled",
+			sh:        auth.NewSecurityHandler(cfg),
+			operation: api.AddPetOperation,
+			token:     "psk_writer",
+			wantErr:   auth.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.sh.HandleBearerAuth(
+				context.Background(), tt.operation,
+				api.BearerAuth{Token: tt.token},
+			)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			claims, ok := auth.ClaimsFromContext(got)
+			if !ok {
+				t.Fatal("expected claims in context")
+			}
+			if claims.APIKeyID != 3 {
+				t.Errorf("APIKeyID = %d, want 3", claims.APIKeyID)
+			}
+		})
+	}
+}
+
+func TestSecurityHandlerSessionCheck(t *testing.T) {
+	cfg, err := auth.NewTokenConfig(testSecret)
+	if err != nil {
+		t.Fatalf("NewTokenConfig: %v", err)
+	}
+	token, err := cfg.CreateToken(auth.Claims{
+		UserID: 1, Role: "customer", SessionVersion: 2,
+	})
+	if err != nil {
+		t.Fatalf("CreateToken: %v", err)
+	}
+	errDB := errors.New("connection reset")
+
+	tests := []struct {
+		name    string
+		user    auth.User
+		findErr error
+		wantErr error
+	}{
+		{
+			name: "current session",
+			user: auth.User{ID: 1, SessionVersion: 2},
+		},
+		{
+			name:    "password changed since",
+			user:    auth.User{ID: 1, SessionVersion: 3},
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "user deleted",
+			findErr: db.ErrNotFound,
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "lookup fails",
+			findErr: errDB,
+			wantErr: errDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &mockRepo{
+				findByIDFn: func(
+					_ context.Context, id int64,
+				) (auth.User, error) {
+					if id != 1 {
+						t.Errorf("id = %d, want 1", id)
+					}
+					return tt.user, tt.findErr
+				},
+			}
+			sh := auth.NewSecurityHandler(cfg,
+				auth.WithSessionCheck(users),
+			)
+			_, err := sh.HandleCookieAuth(
+				context.Background(),
+				api.GetCurrentUserOperation,
+				api.CookieAuth{APIKey: token},
+			)
+			if !errorIs(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSecurityHandlerRoleCheck(t *testing.T) {
+	cfg, err := auth.NewTokenConfig(testSecret)
+	if err != nil {
+		t.Fatalf("NewTokenConfig: %v", err)
+	}
+	token, err := cfg.CreateToken(auth.Claims{UserID: 1, Role: "admin"})
+	if err != nil {
+		t.Fatalf("CreateToken: %v", err)
+	}
+	disabledAt := time.Now()
+	storeID := int64(2)
+
+	tests := []struct {
+		name      string
+		checkRole bool
+		user      auth.User
+		wantErr   error
+	}{
+		{
+			name:      "role unchanged",
+			checkRole: true,
+			user:      auth.User{ID: 1, Role: "admin"},
+		},
+		{
+			name:      "demoted",
+			checkRole: true,
+			user:      auth.User{ID: 1, Role: "customer"},
+			wantErr:   auth.ErrInvalidToken,
+		},
+		{
+			name:      "disabled",
+			checkRole: true,
+			user: auth.User{
+				ID: 1, Role: "admin", DisabledAt: &disabledAt,
+			},
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:      "moved to a store",
+			checkRole: true,
+			user:      auth.User{ID: 1, Role: "admin", StoreID: &storeID},
+			wantErr:   auth.ErrInvalidToken,
+		},
+		{
+			name: "demoted, check off",
+			user: auth.User{ID: 1, Role: "customer"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &mockRepo{
+				findByIDFn: func(context.Context, int64) (auth.User, error) {
+					return tt.user, nil
+				},
+			}
+			sh := auth.NewSecurityHandler(cfg,
+				auth.WithSessionCheck(users),
+				auth.WithRoleCheck(tt.checkRole),
+			)
+			_, err := sh.HandleCookieAuth(
+				context.Background(),
+				api.UnlockUserOperation,
+				api.CookieAuth{APIKey: token},
+			)
+			if !errorIs(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
-
-	"golang.org/x/crypto/bcrypt"
+	"log/slog"
+	"sync"
+	"time"
 
 	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/mail"
 )
 
 // ErrInvalidCredentials is returned when login fails due to
@@ -28,63 +30,239 @@ type Repository interface {
 	FindByID(ctx context.Context,
 		id int64,
 	) (User, error)
+	CreateResetToken(ctx context.Context,
+		userID int64, tokenHash string, expiresAt time.Time,
+	) error
+	ResetPassword(ctx context.Context,
+		tokenHash, passwordHash string,
+	) (int64, error)
+	UpdatePasswordHash(ctx context.Context,
+		userID int64, oldHash, newHash string,
+	) error
+	UpdatePassword(ctx context.Context,
+		userID int64, passwordHash string,
+	) (User, error)
+	CreateEmailChangeToken(ctx context.Context,
+		userID int64, newEmail, tokenHash string, expiresAt time.Time,
+	) error
+	UpdateEmail(ctx context.Context,
+		tokenHash string,
+	) error
+	CreateVerificationToken(ctx context.Context,
+		userID int64, tokenHash string, expiresAt time.Time,
+	) error
+	CountVerificationTokensSince(ctx context.Context,
+		userID int64, since time.Time,
+	) (int, error)
+	VerifyEmail(ctx context.Context,
+		tokenHash string,
+	) (int64, error)
+	RecordLoginFailure(ctx context.Context,
+		userID int64,
+	) (int, error)
+	LockUser(ctx context.Context,
+		userID int64, until time.Time,
+	) error
+	UnlockUser(ctx context.Context,
+		userID int64, scope *int64,
+	) error
+	UpdateAccess(ctx context.Context,
+		userID int64, c AccessChange, scope *int64,
+	) (User, error)
+	SetTOTPSecret(ctx context.Context,
+		userID int64, secret string,
+	) error
+	EnableTOTP(ctx context.Context,
+		userID int64, codeHashes []string,
+	) error
+	UseTOTPStep(ctx context.Context,
+		userID int64, step int64,
+	) error
+	UseRecoveryCode(ctx context.Context,
+		userID int64, codeHash string,
+	) error
+	FindByIdentity(ctx context.Context,
+		issuer, subject string,
+	) (User, error)
+	LinkIdentity(ctx context.Context,
+		userID int64, issuer, subject string,
+	) error
+	CreateExternalUser(ctx context.Context,
+		name, email, role, issuer, subject string,
+	) (User, error)
+}
+
+// LoginResult is the outcome of a successful password
+// check. Exactly one of AccessToken and MFAToken is set:
+// users with TOTP enabled get an MFAToken to exchange via
+// VerifyMFA.
+type LoginResult struct {
+	User        User
+	AccessToken string
+	MFAToken    string
 }
 
 // Service implements authentication business logic on top
 // of a Repository and TokenConfig.
 type Service struct {
-	repo  Repository
-	token *TokenConfig
+	repo   Repository
+	token  *TokenConfig
+	mailer mail.Mailer
+	appURL string
+	idp    IdentityProvider
+	cache  *UserCache
+	// background tracks work that outlives the request, such
+	// as password reset emails.
+	background *sync.WaitGroup
+
+	requireAdminSSO bool
+	// timeNow is used for testing; defaults to time.Now.
+	timeNow func() time.Time
+}
+
+// Option configures optional Service dependencies.
+type Option func(*Service)
+
+// WithMailer sets the Mailer used for account emails. The
+// default, mail.Discard, drops every message.
+func WithMailer(m mail.Mailer) Option {
+	return func(s *Service) { s.mailer = m }
+}
+
+// WithBackground runs work that outlives its request, such
+// as sending password reset emails, on wg, so the caller can
+// wait for it before closing the database. Without it the
+// Service uses a WaitGroup of its own that no one waits on.
+func WithBackground(wg *sync.WaitGroup) Option {
+	return func(s *Service) { s.background = wg }
+}
+
+// WithAppURL sets the frontend base URL used to build links
+// in account emails (e.g. "https://shop.example.com").
+func WithAppURL(u string) Option {
+	return func(s *Service) { s.appURL = u }
 }
 
 // NewService returns a Service wired to the given repository and
 // token configuration.
-func NewService(repo Repository, token *TokenConfig) *Service {
-	return &Service{repo: repo, token: token}
+func NewService(
+	repo Repository, token *TokenConfig, opts ...Option,
+) *Service {
+	s := &Service{
+		repo:    repo,
+		token:   token,
+		mailer:  mail.Discard,
+		appURL:  "http://localhost:5173",
+		timeNow: time.Now,
+
+		background: &sync.WaitGroup{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-// Register creates a new customer account. The plaintext
-// password is hashed before storage. Returns db.ErrConflict
-// if the email is already taken.
+// Register creates a new customer account and emails a
+// verification link. The plaintext password is hashed
+// before storage. Returns db.ErrConflict if the email is
+// already taken.
 func (s *Service) Register(
 	ctx context.Context,
 	name, email, password string,
 ) (User, error) {
-	hash, err := bcrypt.GenerateFromPassword(
-		[]byte(password), bcrypt.DefaultCost,
-	)
+	hash, err := hashPassword(password)
 	if err != nil {
-		return User{}, fmt.Errorf("hashing password: %w", err)
+		return User{}, err
 	}
 	user, err := s.repo.Create(
-		ctx, name, email, string(hash), "customer",
+		ctx, name, email, hash, "customer",
 	)
 	if err != nil {
 		return User{}, err
 	}
+	s.sendVerificationOnRegister(ctx, user)
 	return user, nil
 }
 
 // Login authenticates by email and password. On success it
-// returns a signed JWT and the User. Both unknown-email and
-// wrong-password cases return ErrInvalidCredentials.
+// returns either a signed access JWT or, for users with
+// TOTP enabled, an MFA challenge token. Unknown-email,
+// wrong-password, and locked-account cases all return
+// ErrInvalidCredentials.
 func (s *Service) Login(
 	ctx context.Context,
 	email, password string,
-) (string, User, error) {
+) (LoginResult, error) {
 	user, err := s.repo.FindByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, db.ErrNotFound) {
-			return "", User{}, ErrInvalidCredentials
+			return LoginResult{}, ErrInvalidCredentials
+		}
+		return LoginResult{}, err
+	}
+	// Compare even when locked so a locked account takes as
+	// long to reject as a wrong password.
+	ok, rehash := checkPassword(user.PasswordHash, password)
+	if s.isLocked(user) {
+		return LoginResult{}, ErrInvalidCredentials
+	}
+	if !ok {
+		if err := s.recordLoginFailure(ctx, user.ID); err != nil {
+			return LoginResult{}, err
+		}
+		return LoginResult{}, ErrInvalidCredentials
+	}
+	if s.requireAdminSSO && user.Role == "admin" {
+		return LoginResult{}, ErrSSORequired
+	}
+	if user.DisabledAt != nil {
+		return LoginResult{}, ErrAccountDisabled
+	}
+	if rehash {
+		s.upgradePasswordHash(ctx, user, password)
+	}
+
+	// The failure counter is left alone until the second
+	// factor succeeds, so a known password cannot be used
+	// to reset it between code guesses.
+	if user.TOTPEnabledAt != nil {
+		mfaToken, err := s.token.CreateMFAToken(user.ID)
+		if err != nil {
+			return LoginResult{}, fmt.Errorf(
+				"creating mfa token: %w", err,
+			)
 		}
-		return "", User{}, err
+		return LoginResult{User: user, MFAToken: mfaToken}, nil
 	}
-	if err := bcrypt.CompareHashAndPassword(
-		[]byte(user.PasswordHash), []byte(password),
-	); err != nil {
-		return "", User{}, ErrInvalidCredentials
+
+	token, user, err := s.completeLogin(ctx, user, "pwd")
+	if err != nil {
+		return LoginResult{}, err
+	}
+	return LoginResult{User: user, AccessToken: token}, nil
+}
+
+// completeLogin clears any failed login count and issues an
+// access token recording the given authentication methods.
+// It refuses disabled accounts, whichever way they logged
+// in.
+func (s *Service) completeLogin(
+	ctx context.Context, user User, amr ...string,
+) (string, User, error) {
+	if user.DisabledAt != nil {
+		return "", User{}, ErrAccountDisabled
+	}
+	if user.FailedLoginAttempts > 0 {
+		if err := s.repo.UnlockUser(ctx, user.ID, nil); err != nil {
+			return "", User{}, err
+		}
+		user.FailedLoginAttempts = 0
+		user.LockedUntil = nil
 	}
-	token, err := s.token.CreateToken(user.ID, user.Role)
+	c := claimsFor(user)
+	c.AMR = amr
+	token, err := s.token.CreateToken(c)
 	if err != nil {
 		return "", User{}, fmt.Errorf(
 			"creating token: %w", err,
@@ -101,3 +279,39 @@ func (s *Service) GetUser(
 ) (User, error) {
 	return s.repo.FindByID(ctx, id)
 }
+
+// PublicKeys returns the public keys that verify access
+// tokens, for publishing as a JWKS.
+func (s *Service) PublicKeys() []JWK {
+	return s.token.PublicKeys()
+}
+
+// upgradePasswordHash replaces user's legacy or outdated
+// password hash with a current one. It is best-effort: a
+// failure is logged and the login goes ahead.
+func (s *Service) upgradePasswordHash(
+	ctx context.Context, user User, password string,
+) {
+	hash, err := hashPassword(password)
+	if err == nil {
+		err = s.repo.UpdatePasswordHash(
+			ctx, user.ID, user.PasswordHash, hash,
+		)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "upgrading password hash",
+			"user_id", user.ID, "err", err,
+		)
+	}
+}
+
+// claimsFor returns the JWT claims describing user.
+func claimsFor(u User) Claims {
+	return Claims{
+		UserID:         u.ID,
+		Role:           u.Role,
+		EmailVerified:  u.EmailVerifiedAt != nil,
+		SessionVersion: u.SessionVersion,
+		StoreID:        u.StoreID,
+	}
+}
@@ -3,7 +3,9 @@ package auth_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 
@@ -16,6 +18,32 @@ type mockRepo struct {
 	createFn      func(ctx context.Context, name, email, passwordHash, role string) (auth.User, error)
 	findByEmailFn func(ctx context.Context, email string) (auth.User, error)
 	findByIDFn    func(ctx context.Context, id int64) (auth.User, error)
+
+	createResetTokenFn func(ctx context.Context, userID int64, tokenHash string, expiresAt time.Time) error
+	resetPasswordFn    func(ctx context.Context, tokenHash, passwordHash string) (int64, error)
+
+	updatePasswordHashFn     func(ctx context.Context, userID int64, oldHash, newHash string) error
+	updatePasswordFn         func(ctx context.Context, userID int64, passwordHash string) (auth.User, error)
+	createEmailChangeTokenFn func(ctx context.Context, userID int64, newEmail, tokenHash string, expiresAt time.Time) error
+	updateEmailFn            func(ctx context.Context, tokenHash string) error
+
+	createVerificationTokenFn      func(ctx context.Context, userID int64, tokenHash string, expiresAt time.Time) error
+	countVerificationTokensSinceFn func(ctx context.Context, userID int64, since time.Time) (int, error)
+	verifyEmailFn                  func(ctx context.Context, tokenHash string) (int64, error)
+
+	recordLoginFailureFn func(ctx context.Context, userID int64) (int, error)
+	lockUserFn           func(ctx context.Context, userID int64, until time.Time) error
+	unlockUserFn         func(ctx context.Context, userID int64, scope *int64) error
+	updateAccessFn       func(ctx context.Context, userID int64, c auth.AccessChange, scope *int64) (auth.User, error)
+
+	setTOTPSecretFn   func(ctx context.Context, userID int64, secret string) error
+	enableTOTPFn      func(ctx context.Context, userID int64, codeHashes []string) error
+	useTOTPStepFn     func(ctx context.Context, userID int64, step int64) error
+	useRecoveryCodeFn func(ctx context.Context, userID int64, codeHash string) error
+
+	findByIdentityFn     func(ctx context.Context, issuer, subject string) (auth.User, error)
+	linkIdentityFn       func(ctx context.Context, userID int64, issuer, subject string) error
+	createExternalUserFn func(ctx context.Context, name, email, role, issuer, subject string) (auth.User, error)
 }
 
 func (m *mockRepo) Create(
@@ -39,10 +67,168 @@ func (m *mockRepo) FindByID(
 	return m.findByIDFn(ctx, id)
 }
 
+func (m *mockRepo) CreateResetToken(
+	ctx context.Context,
+	userID int64,
+	tokenHash string,
+	expiresAt time.Time,
+) error {
+	return m.createResetTokenFn(ctx, userID, tokenHash, expiresAt)
+}
+
+func (m *mockRepo) ResetPassword(
+	ctx context.Context,
+	tokenHash, passwordHash string,
+) (int64, error) {
+	return m.resetPasswordFn(ctx, tokenHash, passwordHash)
+}
+
+func (m *mockRepo) UpdatePasswordHash(
+	ctx context.Context,
+	userID int64,
+	oldHash, newHash string,
+) error {
+	return m.updatePasswordHashFn(ctx, userID, oldHash, newHash)
+}
+
+func (m *mockRepo) UpdatePassword(
+	ctx context.Context,
+	userID int64,
+	passwordHash string,
+) (auth.User, error) {
+	return m.updatePasswordFn(ctx, userID, passwordHash)
+}
+
+func (m *mockRepo) CreateEmailChangeToken(
+	ctx context.Context,
+	userID int64,
+	newEmail, tokenHash string,
+	expiresAt time.Time,
+) error {
+	return m.createEmailChangeTokenFn(ctx, userID, newEmail, tokenHash, expiresAt)
+}
+
+func (m *mockRepo) UpdateEmail(
+	ctx context.Context,
+	tokenHash string,
+) error {
+	return m.updateEmailFn(ctx, tokenHash)
+}
+
+func (m *mockRepo) CreateVerificationToken(
+	ctx context.Context,
+	userID int64,
+	tokenHash string,
+	expiresAt time.Time,
+) error {
+	return m.createVerificationTokenFn(ctx, userID, tokenHash, expiresAt)
+}
+
+func (m *mockRepo) CountVerificationTokensSince(
+	ctx context.Context,
+	userID int64,
+	since time.Time,
+) (int, error) {
+	return m.countVerificationTokensSinceFn(ctx, userID, since)
+}
+
+func (m *mockRepo) VerifyEmail(
+	ctx context.Context,
+	tokenHash string,
+) (int64, error) {
+	return m.verifyEmailFn(ctx, tokenHash)
+}
+
+func (m *mockRepo) RecordLoginFailure(
+	ctx context.Context,
+	userID int64,
+) (int, error) {
+	return m.recordLoginFailureFn(ctx, userID)
+}
+
+func (m *mockRepo) LockUser(
+	ctx context.Context,
+	userID int64,
+	until time.Time,
+) error {
+	return m.lockUserFn(ctx, userID, until)
+}
+
+func (m *mockRepo) UnlockUser(
+	ctx context.Context,
+	userID int64,
+	scope *int64,
+) error {
+	return m.unlockUserFn(ctx, userID, scope)
+}
+
+func (m *mockRepo) UpdateAccess(
+	ctx context.Context,
+	userID int64,
+	c auth.AccessChange,
+	scope *int64,
+) (auth.User, error) {
+	return m.updateAccessFn(ctx, userID, c, scope)
+}
+
+func (m *mockRepo) SetTOTPSecret(
+	ctx context.Context,
+	userID int64,
+	secret string,
+) error {
+	return m.setTOTPSecretFn(ctx, userID, secret)
+}
+
+func (m *mockRepo) EnableTOTP(
+	ctx context.Context,
+	userID int64,
+	codeHashes []string,
+) error {
+	return m.enableTOTPFn(ctx, userID, codeHashes)
+}
+
+func (m *mockRepo) UseTOTPStep(
+	ctx context.Context,
+	userID int64,
+	step int64,
+) error {
+	return m.useTOTPStepFn(ctx, userID, step)
+}
+
+func (m *mockRepo) UseRecoveryCode(
+	ctx context.Context,
+	userID int64,
+	codeHash string,
+) error {
+	return m.useRecoveryCodeFn(ctx, userID, codeHash)
+}
+
+func (m *mockRepo) FindByIdentity(
+	ctx context.Context,
+	issuer, subject string,
+) (auth.User, error) {
+	return m.findByIdentityFn(ctx, issuer, subject)
+}
+
+func (m *mockRepo) LinkIdentity(
+	ctx context.Context,
+	userID int64,
+	issuer, subject string,
+) error {
+	return m.linkIdentityFn(ctx, userID, issuer, subject)
+}
+
+func (m *mockRepo) CreateExternalUser(
+	ctx context.Context,
+	name, email, role, issuer, subject string,
+) (auth.User, error) {
+	return m.createExternalUserFn(ctx, name, email, role, issuer, subject)
+}
+
 // newTestService returns a Service wired to the given mock
 // and a valid TokenConfig.
 func newTestService(
-	t *testing.T, repo *mockRepo,
+	t *testing.T, repo *mockRepo, opts ...auth.Option,
 ) *auth.Service {
 	t.Helper()
 	secret := []byte("test-secret-that-is-at-least-32-bytes!")
@@ -50,7 +236,7 @@ func newTestService(
 	if err != nil {
 		t.Fatalf("NewTokenConfig: %v", err)
 	}
-	return auth.NewService(repo, tc)
+	return auth.NewService(repo, tc, opts...)
 }
 
 func TestRegister(t *testing.T) {
@@ -67,12 +253,11 @@ func TestRegister(t *testing.T) {
 					name, email, hash, role string,
 				) (auth.User, error) {
 					// Verify the password was hashed.
-					if err := bcrypt.CompareHashAndPassword(
-						[]byte(hash), []byte("s3cret"),
-					); err != nil {
+					if !strings.HasPrefix(hash, "$argon2id$") ||
+						strings.Contains(hash, "s3cret") {
 						t.Errorf(
-							"password not properly hashed: %v",
-							err,
+							"password not properly hashed: %q",
+							hash,
 						)
 					}
 					if role != "customer" {
@@ -88,6 +273,11 @@ func TestRegister(t *testing.T) {
 						Role:  role,
 					}, nil
 				},
+				createVerificationTokenFn: func(
+					context.Context, int64, string, time.Time,
+				) error {
+					return nil
+				},
 			},
 			wantErr: nil,
 		},
@@ -163,6 +353,11 @@ func TestLogin(t *testing.T) {
 				) (auth.User, error) {
 					return stored, nil
 				},
+				updatePasswordHashFn: func(
+					context.Context, int64, string, string,
+				) error {
+					return nil
+				},
 			},
 			wantErr: nil,
 		},
@@ -189,6 +384,11 @@ func TestLogin(t *testing.T) {
 				) (auth.User, error) {
 					return stored, nil
 				},
+				recordLoginFailureFn: func(
+					context.Context, int64,
+				) (int, error) {
+					return 1, nil
+				},
 			},
 			wantErr: auth.ErrInvalidCredentials,
 		},
@@ -197,7 +397,7 @@ func TestLogin(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			svc := newTestService(t, tt.repo)
-			token, user, err := svc.Login(
+			res, err := svc.Login(
 				context.Background(),
 				tt.email, tt.password,
 			)
@@ -213,19 +413,64 @@ func TestLogin(t *testing.T) {
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
-			if token == "" {
+			if res.AccessToken == "" {
 				t.Error("expected non-empty token")
 			}
-			if user.ID != stored.ID {
+			if res.MFAToken != "" {
+				t.Error("unexpected MFA challenge")
+			}
+			if res.User.ID != stored.ID {
 				t.Errorf(
 					"user.ID = %d, want %d",
-					user.ID, stored.ID,
+					res.User.ID, stored.ID,
 				)
 			}
 		})
 	}
 }
 
+func TestLoginUpgradesPasswordHash(t *testing.T) {
+	legacy, _ := bcrypt.GenerateFromPassword(
+		[]byte("s3cret"), bcrypt.MinCost,
+	)
+	user := auth.User{
+		ID: 1, PasswordHash: string(legacy), Role: "customer",
+	}
+	upgrades := 0
+	repo := &mockRepo{
+		findByEmailFn: func(context.Context, string) (auth.User, error) {
+			return user, nil
+		},
+		updatePasswordHashFn: func(
+			_ context.Context, _ int64, oldHash, newHash string,
+		) error {
+			upgrades++
+			if oldHash != user.PasswordHash {
+				t.Errorf("oldHash = %q, want stored hash", oldHash)
+			}
+			if !strings.HasPrefix(newHash, "$argon2id$") {
+				t.Errorf("newHash = %q, want argon2id", newHash)
+			}
+			user.PasswordHash = newHash
+			return nil
+		},
+	}
+	svc := newTestService(t, repo)
+
+	// The first login replaces the bcrypt hash; the second
+	// verifies against the new one and leaves it alone.
+	for range 2 {
+		if _, err := svc.Login(
+			context.Background(), "alice@example.com", "s3cret",
+		); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if upgrades != 1 {
+		t.Errorf("hash upgraded %d times, want 1", upgrades)
+	}
+}
+
 func TestGetUser(t *testing.T) {
 	tests := []struct {
 		name    string
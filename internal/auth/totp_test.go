@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTOTPCodeRFC6238Vectors(t *testing.T) {
+	// SHA-1 vectors from RFC 6238 Appendix B.
+	key := []byte("12345678901234567890")
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+
+	for _, tt := range tests {
+		step := totpStep(time.Unix(tt.unix, 0))
+		if got := totpCode(key, step, 8); got != tt.want {
+			t.Errorf("T=%d: got %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret, err := newTOTPSecret()
+	if err != nil {
+		t.Fatalf("newTOTPSecret: %v", err)
+	}
+	key, _ := totpEncoding.DecodeString(secret)
+	now := time.Unix(1_700_000_000, 0)
+	cur := totpStep(now)
+
+	tests := []struct {
+		name   string
+		code   string
+		wantOK bool
+	}{
+		{"current step", totpCode(key, cur, 6), true},
+		{"previous step", totpCode(key, cur-1, 6), true},
+		{"next step", totpCode(key, cur+1, 6), true},
+		{"two steps old", totpCode(key, cur-2, 6), false},
+		{"wrong length", "12345", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ok := validateTOTP(secret, tt.code, now)
+			if ok != tt.wantOK {
+				t.Errorf("ok = %v, want %v", ok, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestTOTPURI(t *testing.T) {
+	got := totpURI("JBSWY3DPEHPK3PXP", "alice@example.com")
+	for _, want := range []string{
+		"otpauth://totp/Pet%20Store:alice@example.com?",
+		"secret=JBSWY3DPEHPK3PXP",
+		"issuer=Pet+Store",
+		"digits=6",
+		"period=30",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("URI %q lacks %q", got, want)
+		}
+	}
+}
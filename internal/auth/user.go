@@ -11,6 +11,31 @@ type User struct {
 	Email        string
 	PasswordHash string
 	Role         string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	// EmailVerifiedAt is nil until the user confirms their
+	// address via the emailed verification link.
+	EmailVerifiedAt *time.Time
+	// FailedLoginAttempts counts consecutive failed logins
+	// since the last success or unlock.
+	FailedLoginAttempts int
+	// LockedUntil is set once FailedLoginAttempts reaches
+	// the lockout threshold; logins fail until it passes.
+	LockedUntil *time.Time
+	// TOTPSecret is the base32 TOTP secret, empty until the
+	// user starts enrollment.
+	TOTPSecret string
+	// TOTPEnabledAt is set once enrollment is confirmed;
+	// from then on login requires a second factor.
+	TOTPEnabledAt *time.Time
+	// SessionVersion is bumped to sign out every session,
+	// e.g. on a password change. Access tokens carry the
+	// version they were issued under.
+	SessionVersion int
+	// DisabledAt is set while an admin has disabled the
+	// account; a disabled user cannot log in.
+	DisabledAt *time.Time
+	// StoreID is the store the user is confined to, or nil
+	// for a global user.
+	StoreID   *int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// userCacheSweepSize is the number of entries above which
+// storing a user first drops expired entries.
+const userCacheSweepSize = 10000
+
+// UserCache is a UserFinder that remembers users for a short
+// TTL, so the per-request session and role checks do not hit
+// the database on every call. Only successful lookups are
+// cached. Invalidation is local to the process; other
+// instances see a change once their entry expires.
+type UserCache struct {
+	users UserFinder
+	ttl   time.Duration
+
+	mu      sync.Mutex
+	entries map[int64]cachedUser
+	// gen counts invalidations. A lookup that overlaps one
+	// does not store its result, which may predate it.
+	gen uint64
+
+	// now is used for testing; defaults to time.Now.
+	now func() time.Time
+}
+
+// cachedUser is a UserCache entry.
+type cachedUser struct {
+	user    User
+	expires time.Time
+}
+
+// NewUserCache returns a UserCache in front of users that
+// keeps each user for ttl.
+func NewUserCache(users UserFinder, ttl time.Duration) *UserCache {
+	return &UserCache{
+		users:   users,
+		ttl:     ttl,
+		entries: make(map[int64]cachedUser),
+		now:     time.Now,
+	}
+}
+
+// FindByID returns the cached user, loading it from the
+// underlying UserFinder when absent or expired.
+func (c *UserCache) FindByID(ctx context.Context, id int64) (User, error) {
+	now := c.now()
+	c.mu.Lock()
+	e, ok := c.entries[id]
+	gen := c.gen
+	c.mu.Unlock()
+	if ok && now.Before(e.expires) {
+		return e.user, nil
+	}
+
+	user, err := c.users.FindByID(ctx, id)
+	if err != nil {
+		return User{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.gen != gen {
+		return user, nil
+	}
+	if len(c.entries) >= userCacheSweepSize {
+		for k, v := range c.entries {
+			if !now.Before(v.expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	c.entries[id] = cachedUser{user: user, expires: now.Add(c.ttl)}
+	return user, nil
+}
+
+// Invalidate drops the cached entry for id, so the next
+// FindByID loads it afresh.
+func (c *UserCache) Invalidate(id int64) {
+	c.mu.Lock()
+	delete(c.entries, id)
+	c.gen++
+	c.mu.Unlock()
+}
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// countingFinder is a UserFinder that counts lookups and
+// runs an optional hook during each one.
+type countingFinder struct {
+	calls  int
+	role   string
+	err    error
+	during func()
+}
+
+func (f *countingFinder) FindByID(
+	_ context.Context, id int64,
+) (User, error) {
+	f.calls++
+	if f.during != nil {
+		f.during()
+	}
+	if f.err != nil {
+		return User{}, f.err
+	}
+	return User{ID: id, Role: f.role}, nil
+}
+
+func TestUserCache(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	finder := &countingFinder{role: "admin"}
+	c := NewUserCache(finder, 30*time.Second)
+	c.now = func() time.Time { return now }
+
+	find := func(wantCalls int, wantRole string) {
+		t.Helper()
+		u, err := c.FindByID(ctx, 1)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if u.Role != wantRole {
+			t.Errorf("role = %q, want %q", u.Role, wantRole)
+		}
+		if finder.calls != wantCalls {
+			t.Errorf("lookups = %d, want %d", finder.calls, wantCalls)
+		}
+	}
+
+	find(1, "admin")
+	find(1, "admin") // cached
+
+	finder.role = "staff"
+	now = now.Add(29 * time.Second)
+	find(1, "admin") // still fresh
+
+	now = now.Add(time.Second)
+	find(2, "staff") // expired
+
+	finder.role = "customer"
+	c.Invalidate(1)
+	find(3, "customer")
+}
+
+func TestUserCacheErrorsNotCached(t *testing.T) {
+	ctx := context.Background()
+	finder := &countingFinder{err: errors.New("db down")}
+	c := NewUserCache(finder, time.Minute)
+
+	for range 2 {
+		if _, err := c.FindByID(ctx, 1); err == nil {
+			t.Fatal("expected error")
+		}
+	}
+	if finder.calls != 2 {
+		t.Errorf("lookups = %d, want 2", finder.calls)
+	}
+}
+
+func TestUserCacheInvalidateDuringLookup(t *testing.T) {
+	ctx := context.Background()
+	finder := &countingFinder{role: "admin"}
+	c := NewUserCache(finder, time.Minute)
+	// The role changes while the old row is being read.
+	finder.during = func() {
+		finder.during = nil
+		c.Invalidate(1)
+	}
+
+	if _, err := c.FindByID(ctx, 1); err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	finder.role = "customer"
+	u, err := c.FindByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if u.Role != "customer" {
+		t.Errorf("role = %q, want the value read after invalidation",
+			u.Role)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -105,6 +106,113 @@ func (d *DB) Exec(
 	return d.pool.Exec(ctx, sql, args...)
 }
 
+// Begin starts a transaction on a pooled connection. The
+// caller must commit or roll it back.
+func (d *DB) Begin(ctx context.Context) (pgx.Tx, error) {
+	return d.pool.Begin(ctx)
+}
+
+// Listen subscribes to a PostgreSQL NOTIFY channel on a
+// connection taken out of the pool for the purpose, and
+// calls fn with the payload of each notification. fn is
+// also called once with an empty payload as soon as LISTEN
+// takes effect, so the caller can catch up on anything it
+// missed before then. Listen blocks until ctx is cancelled
+// or the connection fails, and returns the error.
+func (d *DB) Listen(
+	ctx context.Context, channel string, fn func(payload string),
+) error {
+	pc, err := d.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquiring listen connection: %w", err)
+	}
+	// A LISTENing connection must not return to the pool.
+	conn := pc.Hijack()
+	defer conn.Close(context.WithoutCancel(ctx))
+
+	_, err = conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize())
+	if err != nil {
+		return fmt.Errorf("listen %s: %w", channel, err)
+	}
+	fn("")
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("wait for notification: %w", err)
+		}
+		fn(n.Payload)
+	}
+}
+
+// lockCheckInterval is how often WithAdvisoryLock checks
+// that the connection holding the lock is still alive.
+const lockCheckInterval = 15 * time.Second
+
+// WithAdvisoryLock tries to take the session-level advisory
+// lock key on a connection taken out of the pool for the
+// purpose, and calls fn while holding it. It returns false
+// without calling fn if another session holds the lock. The
+// context passed to fn is cancelled when ctx is, or when
+// the connection is lost and with it the lock; fn should
+// return promptly then. The lock is released when fn
+// returns.
+func (d *DB) WithAdvisoryLock(
+	ctx context.Context, key int64, fn func(ctx context.Context),
+) (bool, error) {
+	conn, err := d.pool.Acquire(ctx)
+	if err != nil {
+		return false, fmt.Errorf("acquiring lock connection: %w", err)
+	}
+	defer conn.Release()
+
+	var held bool
+	err = conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", key).
+		Scan(&held)
+	if err != nil {
+		return false, fmt.Errorf("advisory lock %d: %w", key, err)
+	}
+	if !held {
+		return false, nil
+	}
+
+	fnCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	var lost error
+	go func() {
+		defer close(done)
+		t := time.NewTicker(lockCheckInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-fnCtx.Done():
+				return
+			case <-t.C:
+				if err := conn.Ping(fnCtx); err != nil && fnCtx.Err() == nil {
+					lost = err
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+	fn(fnCtx)
+	cancel()
+	<-done
+
+	if lost != nil {
+		// The lock went with the session; the pool discards
+		// the broken connection.
+		return true, fmt.Errorf("advisory lock %d lost: %w", key, lost)
+	}
+	_, err = conn.Exec(context.WithoutCancel(ctx),
+		"SELECT pg_advisory_unlock($1)", key)
+	if err != nil {
+		conn.Conn().Close(context.WithoutCancel(ctx))
+		return true, fmt.Errorf("advisory unlock %d: %w", key, err)
+	}
+	return true, nil
+}
+
 // Close releases all database resources.
 func (d *DB) Close() {
 	d.pool.Close()
@@ -4,21 +4,23 @@ import (
 	"context"
 
 	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
 )
 
 // AddPet handles POST /pets.
 func (h *Handler) AddPet(
 	ctx context.Context, req *api.NewPet,
-) (*api.Pet, error) {
-	var tag *string
-	if v, ok := req.Tag.Get(); ok {
-		tag = &v
-	}
-
-	p, err := h.pets.CreatePet(ctx, req.Name, tag)
+	_ api.AddPetParams,
+) (api.AddPetRes, error) {
+	p, err := h.pets.CreatePet(ctx, newPetFromAPI(*req))
 	if err != nil {
+		h.record(ctx, audit.Event{Action: audit.ActionPetCreate}, err)
 		return nil, err
 	}
+	h.record(ctx, audit.Event{
+		Action: audit.ActionPetCreate,
+		Target: auditTarget("pet", p.ID),
+	}, nil)
 	ap := petToAPI(p)
 	return &ap, nil
 }
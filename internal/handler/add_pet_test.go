@@ -2,31 +2,36 @@ package handler_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/hhubris/petstore/internal/api"
 	"github.com/hhubris/petstore/internal/db"
 	"github.com/hhubris/petstore/internal/pet"
+	"github.com/hhubris/petstore/internal/store"
 )
 
 func TestAddPet(t *testing.T) {
 	tag := "dog"
 	tests := []struct {
-		name     string
-		req      *api.NewPet
-		pets     *mockPetService
-		wantName string
-		wantErr  error
+		name      string
+		req       *api.NewPet
+		pets      *mockPetService
+		wantName  string
+		wantPrice api.Money
+		wantErr   error
 	}{
 		{
 			name: "success without tag",
 			req:  &api.NewPet{Name: "Fido"},
 			pets: &mockPetService{
-				createPetFn: func(_ context.Context, name string, tag *string) (pet.Pet, error) {
-					if tag != nil {
+				createPetFn: func(_ context.Context, p pet.Pet) (pet.Pet, error) {
+					if p.Tag != nil {
 						t.Error("expected nil tag")
 					}
-					return pet.Pet{ID: 1, Name: name}, nil
+					p.ID = 1
+					return p, nil
 				},
 			},
 			wantName: "Fido",
@@ -38,20 +43,74 @@ func TestAddPet(t *testing.T) {
 				Tag:  api.NewOptString("dog"),
 			},
 			pets: &mockPetService{
-				createPetFn: func(_ context.Context, name string, tg *string) (pet.Pet, error) {
-					if tg == nil || *tg != "dog" {
+				createPetFn: func(_ context.Context, p pet.Pet) (pet.Pet, error) {
+					if p.Tag == nil || *p.Tag != "dog" {
 						t.Error("expected tag=dog")
 					}
-					return pet.Pet{ID: 2, Name: name, Tag: &tag}, nil
+					return pet.Pet{ID: 2, Name: p.Name, Tag: &tag}, nil
 				},
 			},
 			wantName: "Buddy",
 		},
+		{
+			name: "success with profile",
+			req: &api.NewPet{
+				Name:      "Rex",
+				Species:   api.NewOptString("dog"),
+				Breed:     api.NewOptString("beagle"),
+				BirthDate: api.NewOptDate(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)),
+				Sex:       api.NewOptPetSex(api.PetSexMale),
+				Price:     api.NewOptMoney(api.Money{Amount: 12500, Currency: "EUR"}),
+				StoreId:   api.NewOptInt64(2),
+			},
+			pets: &mockPetService{
+				createPetFn: func(_ context.Context, p pet.Pet) (pet.Pet, error) {
+					if p.Species == nil || *p.Species != "dog" ||
+						p.Breed == nil || *p.Breed != "beagle" ||
+						p.BirthDate == nil || p.BirthDate.Format(time.DateOnly) != "2024-03-01" ||
+						p.Sex == nil || *p.Sex != pet.SexMale ||
+						p.Price == nil || *p.Price != (pet.Money{Amount: 12500, Currency: "EUR"}) ||
+						p.StoreID != 2 {
+						t.Errorf("got pet %+v", p)
+					}
+					if p.Description != nil {
+						t.Error("expected nil description")
+					}
+					p.ID = 3
+					return p, nil
+				},
+			},
+			wantName:  "Rex",
+			wantPrice: api.Money{Amount: 12500, Currency: "EUR"},
+		},
+		{
+			name: "rule error",
+			req: &api.NewPet{
+				Name:  "Rex",
+				Breed: api.NewOptString("beagle"),
+			},
+			pets: &mockPetService{
+				createPetFn: func(context.Context, pet.Pet) (pet.Pet, error) {
+					return pet.Pet{}, pet.ErrBreedWithoutSpecies
+				},
+			},
+			wantErr: pet.ErrBreedWithoutSpecies,
+		},
+		{
+			name: "store error",
+			req:  &api.NewPet{Name: "Fido"},
+			pets: &mockPetService{
+				createPetFn: func(context.Context, pet.Pet) (pet.Pet, error) {
+					return pet.Pet{}, store.ErrOtherStore
+				},
+			},
+			wantErr: store.ErrOtherStore,
+		},
 		{
 			name: "conflict error",
 			req:  &api.NewPet{Name: "Fido"},
 			pets: &mockPetService{
-				createPetFn: func(context.Context, string, *string) (pet.Pet, error) {
+				createPetFn: func(context.Context, pet.Pet) (pet.Pet, error) {
 					return pet.Pet{}, db.ErrConflict
 				},
 			},
@@ -62,20 +121,28 @@ func TestAddPet(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			h := newHandler(t, tt.pets, nil)
-			got, err := h.AddPet(context.Background(), tt.req)
+			res, err := h.AddPet(context.Background(), tt.req, api.AddPetParams{})
 			if tt.wantErr != nil {
-				if err == nil {
-					t.Fatal("expected error")
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v", err, tt.wantErr)
 				}
 				return
 			}
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
+			got, ok := res.(*api.Pet)
+			if !ok {
+				t.Fatalf("got %T, want *api.Pet", res)
+			}
 			if got.Name != tt.wantName {
 				t.Errorf("got name %q, want %q",
 					got.Name, tt.wantName)
 			}
+			if got.Price.Or(api.Money{}) != tt.wantPrice {
+				t.Errorf("got price %+v, want %+v",
+					got.Price, tt.wantPrice)
+			}
 		})
 	}
 }
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+// CancelReservation handles DELETE
+// /auth/me/reservations/{id}.
+func (h *Handler) CancelReservation(
+	ctx context.Context, params api.CancelReservationParams,
+) (api.CancelReservationRes, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, auth.ErrUnauthorized
+	}
+	r, err := h.holds.Cancel(ctx, claims.UserID, params.ID)
+	e := audit.Event{
+		Action: audit.ActionReservationCancel,
+		Target: auditTarget("reservation", params.ID),
+	}
+	if err != nil {
+		h.record(ctx, e, err)
+		return nil, err
+	}
+	e.Details = map[string]string{"pet_id": strconv.FormatInt(r.PetID, 10)}
+	h.record(ctx, e, nil)
+	return &api.CancelReservationNoContent{}, nil
+}
@@ -0,0 +1,85 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/reservation"
+)
+
+func TestCancelReservation(t *testing.T) {
+	tests := []struct {
+		name        string
+		holds       *mockReservationService
+		wantCode    int
+		wantOutcome audit.Outcome
+	}{
+		{
+			name: "success",
+			holds: &mockReservationService{
+				cancelFn: func(_ context.Context, userID, id int64) (reservation.Reservation, error) {
+					if userID != 7 || id != 11 {
+						t.Errorf("got user %d reservation %d", userID, id)
+					}
+					now := time.Now()
+					return reservation.Reservation{
+						ID: id, PetID: 3, UserID: userID,
+						Status: reservation.StatusCancelled, CancelledAt: &now,
+					}, nil
+				},
+			},
+			wantOutcome: audit.OutcomeSuccess,
+		},
+		{
+			name: "not the caller's or not active",
+			holds: &mockReservationService{
+				cancelFn: func(context.Context, int64, int64) (reservation.Reservation, error) {
+					return reservation.Reservation{}, db.ErrNotFound
+				},
+			},
+			wantCode:    http.StatusNotFound,
+			wantOutcome: audit.OutcomeFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := auth.ContextWithClaims(context.Background(),
+				auth.Claims{UserID: 7, Role: "customer"})
+			events := &mockAuditService{}
+			h := newReservationHandler(t, tt.holds, events)
+			got, err := h.CancelReservation(ctx, api.CancelReservationParams{ID: 11})
+
+			if len(events.events) != 1 ||
+				events.events[0].Action != audit.ActionReservationCancel ||
+				events.events[0].Outcome != tt.wantOutcome ||
+				events.events[0].Target != "reservation:11" {
+				t.Errorf("events = %+v", events.events)
+			}
+			if tt.wantCode != 0 {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if code := h.NewError(ctx, err).StatusCode; code != tt.wantCode {
+					t.Errorf("got status %d, want %d", code, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, ok := got.(*api.CancelReservationNoContent); !ok {
+				t.Errorf("got %T, want *api.CancelReservationNoContent", got)
+			}
+			if d := events.events[0].Details["pet_id"]; d != "3" {
+				t.Errorf("pet_id detail = %q, want 3", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+// ChangePassword handles POST /auth/me/password. Other
+// sessions are signed out; the caller's cookie is replaced
+// with a token for the new session version.
+func (h *Handler) ChangePassword(
+	ctx context.Context, req *api.ChangePasswordRequest,
+	_ api.ChangePasswordParams,
+) (api.ChangePasswordRes, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, auth.ErrUnauthorized
+	}
+	token, err := h.auth.ChangePassword(
+		ctx, claims, req.CurrentPassword, req.NewPassword,
+	)
+	h.record(ctx, audit.Event{Action: audit.ActionPasswordChange}, err)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.setAccessToken(ctx, token); err != nil {
+		return nil, err
+	}
+	return &api.ChangePasswordNoContent{}, nil
+}
@@ -0,0 +1,90 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+func TestChangePassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		claims   *auth.Claims
+		auths    *mockAuthService
+		wantCode int
+	}{
+		{
+			name:   "success",
+			claims: &auth.Claims{UserID: 7, Role: "customer"},
+			auths: &mockAuthService{
+				changePasswordFn: func(_ context.Context, c auth.Claims, current, next string) (string, error) {
+					if c.UserID != 7 || current != "old" || next != "n3w-password" {
+						t.Errorf("got user %d, %q -> %q",
+							c.UserID, current, next)
+					}
+					return "jwt-token", nil
+				},
+			},
+		},
+		{
+			name:   "wrong current password",
+			claims: &auth.Claims{UserID: 7, Role: "customer"},
+			auths: &mockAuthService{
+				changePasswordFn: func(context.Context, auth.Claims, string, string) (string, error) {
+					return "", auth.ErrIncorrectPassword
+				},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "no claims",
+			auths:    &mockAuthService{},
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			ctx := ctxWithResponseWriter(rec)
+			if tt.claims != nil {
+				ctx = auth.ContextWithClaims(ctx, *tt.claims)
+			}
+			h := newHandler(t, nil, tt.auths)
+			got, err := h.ChangePassword(ctx, &api.ChangePasswordRequest{
+				CurrentPassword: "old",
+				NewPassword:     "n3w-password",
+			}, api.ChangePasswordParams{})
+			if tt.wantCode != 0 {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if code := h.NewError(ctx, err).StatusCode; code != tt.wantCode {
+					t.Errorf("got status %d, want %d",
+						code, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, ok := got.(*api.ChangePasswordNoContent); !ok {
+				t.Errorf("got %T, want *api.ChangePasswordNoContent",
+					got)
+			}
+			found := false
+			for _, c := range rec.Result().Cookies() {
+				if c.Name == "access_token" && c.Value == "jwt-token" {
+					found = true
+				}
+			}
+			if !found {
+				t.Error("access_token cookie not replaced")
+			}
+		})
+	}
+}
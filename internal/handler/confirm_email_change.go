@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+)
+
+// ConfirmEmailChange handles POST /auth/email/confirm.
+func (h *Handler) ConfirmEmailChange(
+	ctx context.Context, req *api.VerifyEmailRequest,
+	_ api.ConfirmEmailChangeParams,
+) (api.ConfirmEmailChangeRes, error) {
+	err := h.auth.ConfirmEmailChange(ctx, req.Token)
+	h.record(ctx, audit.Event{Action: audit.ActionEmailChange}, err)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ConfirmEmailChangeNoContent{}, nil
+}
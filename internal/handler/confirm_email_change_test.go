@@ -0,0 +1,76 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/db"
+)
+
+func TestConfirmEmailChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		auths    *mockAuthService
+		wantCode int
+	}{
+		{
+			name: "success",
+			auths: &mockAuthService{
+				confirmEmailChangeFn: func(_ context.Context, token string) error {
+					if token != "tok" {
+						t.Errorf("got token %q", token)
+					}
+					return nil
+				},
+			},
+		},
+		{
+			name: "invalid token",
+			auths: &mockAuthService{
+				confirmEmailChangeFn: func(context.Context, string) error {
+					return auth.ErrInvalidEmailChangeToken
+				},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "address taken meanwhile",
+			auths: &mockAuthService{
+				confirmEmailChangeFn: func(context.Context, string) error {
+					return db.ErrConflict
+				},
+			},
+			wantCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHandler(t, nil, tt.auths)
+			got, err := h.ConfirmEmailChange(context.Background(),
+				&api.VerifyEmailRequest{Token: "tok"}, api.ConfirmEmailChangeParams{})
+			if tt.wantCode != 0 {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if code := h.NewError(
+					context.Background(), err,
+				).StatusCode; code != tt.wantCode {
+					t.Errorf("got status %d, want %d",
+						code, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, ok := got.(*api.ConfirmEmailChangeNoContent); !ok {
+				t.Errorf("got %T, want *api.ConfirmEmailChangeNoContent",
+					got)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+// ConfirmMFAEnrollment handles POST /auth/mfa/enroll/confirm.
+func (h *Handler) ConfirmMFAEnrollment(
+	ctx context.Context, req *api.MFACodeRequest,
+	_ api.ConfirmMFAEnrollmentParams,
+) (api.ConfirmMFAEnrollmentRes, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, auth.ErrUnauthorized
+	}
+	codes, err := h.auth.ConfirmMFA(ctx, claims.UserID, req.Code)
+	h.record(ctx, audit.Event{Action: audit.ActionMFAEnable}, err)
+	if err != nil {
+		return nil, err
+	}
+	noStore(ctx)
+	return &api.MFARecoveryCodes{RecoveryCodes: codes}, nil
+}
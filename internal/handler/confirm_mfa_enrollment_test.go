@@ -0,0 +1,75 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+func TestConfirmMFAEnrollment(t *testing.T) {
+	tests := []struct {
+		name     string
+		auths    *mockAuthService
+		wantCode int
+	}{
+		{
+			name: "success",
+			auths: &mockAuthService{
+				confirmMFAFn: func(_ context.Context, id int64, code string) ([]string, error) {
+					if id != 1 || code != "123456" {
+						t.Errorf("got user %d, code %q", id, code)
+					}
+					return []string{"aaaa-bbbb-cccc-dddd"}, nil
+				},
+			},
+		},
+		{
+			name: "wrong code",
+			auths: &mockAuthService{
+				confirmMFAFn: func(context.Context, int64, string) ([]string, error) {
+					return nil, auth.ErrInvalidMFACode
+				},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "not started",
+			auths: &mockAuthService{
+				confirmMFAFn: func(context.Context, int64, string) ([]string, error) {
+					return nil, auth.ErrMFANotEnrolled
+				},
+			},
+			wantCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := auth.ContextWithClaims(context.Background(),
+				auth.Claims{UserID: 1, Role: "admin"})
+			h := newHandler(t, nil, tt.auths)
+			got, err := h.ConfirmMFAEnrollment(ctx,
+				&api.MFACodeRequest{Code: "123456"}, api.ConfirmMFAEnrollmentParams{})
+			if tt.wantCode != 0 {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if code := h.NewError(ctx, err).StatusCode; code != tt.wantCode {
+					t.Errorf("got status %d, want %d",
+						code, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			rc, ok := got.(*api.MFARecoveryCodes)
+			if !ok || len(rc.RecoveryCodes) != 1 {
+				t.Errorf("got %+v, want one recovery code", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+// CreateAPIKey handles POST /admin/api-keys.
+func (h *Handler) CreateAPIKey(
+	ctx context.Context, req *api.NewAPIKey,
+	_ api.CreateAPIKeyParams,
+) (api.CreateAPIKeyRes, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, auth.ErrUnauthorized
+	}
+
+	scopes := make([]string, len(req.Scopes))
+	for i, s := range req.Scopes {
+		scopes[i] = string(s)
+	}
+	var expiresAt *time.Time
+	if v, ok := req.ExpiresAt.Get(); ok {
+		expiresAt = &v
+	}
+	var storeID *int64
+	if v, ok := req.StoreId.Get(); ok {
+		storeID = &v
+	}
+
+	k, secret, err := h.keys.Create(
+		ctx, claims.UserID, req.Name, scopes, expiresAt, storeID,
+	)
+	if err != nil {
+		h.record(ctx, audit.Event{Action: audit.ActionAPIKeyCreate}, err)
+		return nil, err
+	}
+	details := map[string]string{"scopes": strings.Join(scopes, " ")}
+	if storeID != nil {
+		details["store_id"] = strconv.FormatInt(*storeID, 10)
+	}
+	h.record(ctx, audit.Event{
+		Action:  audit.ActionAPIKeyCreate,
+		Target:  auditTarget("api_key", k.ID),
+		Details: details,
+	}, nil)
+	noStore(ctx)
+	ak := apiKeyToAPI(k)
+	return &api.CreatedAPIKey{
+		ID:         ak.ID,
+		Name:       ak.Name,
+		Prefix:     ak.Prefix,
+		Scopes:     ak.Scopes,
+		CreatedAt:  ak.CreatedAt,
+		ExpiresAt:  ak.ExpiresAt,
+		LastUsedAt: ak.LastUsedAt,
+		RevokedAt:  ak.RevokedAt,
+		StoreId:    ak.StoreId,
+		Key:        secret,
+	}, nil
+}
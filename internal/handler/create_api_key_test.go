@@ -0,0 +1,116 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/apikey"
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/handler"
+	"github.com/hhubris/petstore/internal/store"
+)
+
+func TestCreateAPIKey(t *testing.T) {
+	req := &api.NewAPIKey{
+		Name:    "ci",
+		Scopes:  []api.APIKeyScope{api.APIKeyScopePetsWrite},
+		StoreId: api.NewOptInt64(2),
+	}
+
+	tests := []struct {
+		name     string
+		claims   *auth.Claims
+		keys     *mockAPIKeyService
+		wantCode int
+	}{
+		{
+			name:   "success",
+			claims: &auth.Claims{UserID: 1, Role: "admin"},
+			keys: &mockAPIKeyService{
+				createFn: func(
+					_ context.Context, by int64, name string,
+					scopes []string, _ *time.Time, storeID *int64,
+				) (apikey.APIKey, string, error) {
+					if by != 1 || name != "ci" || len(scopes) != 1 ||
+						storeID == nil || *storeID != 2 {
+						t.Errorf("got by=%d name=%q scopes=%v store=%v",
+							by, name, scopes, storeID)
+					}
+					return apikey.APIKey{
+						ID: 3, Name: name, Prefix: "psk_abcdefgh",
+						Scopes: scopes, StoreID: storeID,
+					}, "psk_abcdefghsecret", nil
+				},
+			},
+		},
+		{
+			name:   "expiry in the past",
+			claims: &auth.Claims{UserID: 1, Role: "admin"},
+			keys: &mockAPIKeyService{
+				createFn: func(
+					context.Context, int64, string, []string, *time.Time, *int64,
+				) (apikey.APIKey, string, error) {
+					return apikey.APIKey{}, "", apikey.ErrInvalidExpiry
+				},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:   "unknown store",
+			claims: &auth.Claims{UserID: 1, Role: "admin"},
+			keys: &mockAPIKeyService{
+				createFn: func(
+					context.Context, int64, string, []string, *time.Time, *int64,
+				) (apikey.APIKey, string, error) {
+					return apikey.APIKey{}, "", store.ErrUnknownStore
+				},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "no claims",
+			keys:     &mockAPIKeyService{},
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx := handler.WithResponseWriter(context.Background(), w)
+			if tt.claims != nil {
+				ctx = auth.ContextWithClaims(ctx, *tt.claims)
+			}
+			h := newKeyHandler(t, tt.keys)
+			got, err := h.CreateAPIKey(ctx, req, api.CreateAPIKeyParams{})
+			if tt.wantCode != 0 {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if code := h.NewError(ctx, err).StatusCode; code != tt.wantCode {
+					t.Errorf("got status %d, want %d",
+						code, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			k, ok := got.(*api.CreatedAPIKey)
+			if !ok {
+				t.Fatalf("got %T, want *api.CreatedAPIKey", got)
+			}
+			if k.ID != 3 || k.Key != "psk_abcdefghsecret" ||
+				k.StoreId != api.NewOptInt64(2) {
+				t.Errorf("got %+v", k)
+			}
+			if cc := w.Header().Get("Cache-Control"); cc != "no-store" {
+				t.Errorf("Cache-Control = %q, want no-store", cc)
+			}
+		})
+	}
+}
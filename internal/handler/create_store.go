@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+	"github.com/hhubris/petstore/internal/store"
+)
+
+// CreateStore handles POST /admin/stores.
+func (h *Handler) CreateStore(
+	ctx context.Context, req *api.NewStore,
+	_ api.CreateStoreParams,
+) (api.CreateStoreRes, error) {
+	var loc *store.Location
+	if v, ok := req.Location.Get(); ok {
+		loc = &store.Location{Latitude: v.Latitude, Longitude: v.Longitude}
+	}
+	s, err := h.stores.CreateStore(ctx, req.Name, loc)
+	if err != nil {
+		h.record(ctx, audit.Event{Action: audit.ActionStoreCreate}, err)
+		return nil, err
+	}
+	h.record(ctx, audit.Event{
+		Action:  audit.ActionStoreCreate,
+		Target:  auditTarget("store", s.ID),
+		Details: map[string]string{"name": s.Name},
+	}, nil)
+	as := storeToAPI(s)
+	return &as, nil
+}
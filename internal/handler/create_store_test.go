@@ -0,0 +1,105 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/store"
+)
+
+func TestCreateStore(t *testing.T) {
+	now := time.Now()
+
+	created := func(_ context.Context, name string, loc *store.Location) (store.Store, error) {
+		if name != "Harbour Road" {
+			t.Errorf("got name %q", name)
+		}
+		return store.Store{ID: 2, Name: name, Location: loc, CreatedAt: now}, nil
+	}
+	harbour := api.Location{Latitude: 53.35, Longitude: -6.26}
+
+	tests := []struct {
+		name        string
+		location    api.OptLocation
+		stores      *mockStoreService
+		wantCode    int
+		wantOutcome audit.Outcome
+	}{
+		{
+			name:        "success",
+			stores:      &mockStoreService{createStoreFn: created},
+			wantOutcome: audit.OutcomeSuccess,
+		},
+		{
+			name:        "with location",
+			location:    api.NewOptLocation(harbour),
+			stores:      &mockStoreService{createStoreFn: created},
+			wantOutcome: audit.OutcomeSuccess,
+		},
+		{
+			name: "name taken",
+			stores: &mockStoreService{
+				createStoreFn: func(context.Context, string, *store.Location) (store.Store, error) {
+					return store.Store{}, db.ErrConflict
+				},
+			},
+			wantCode:    http.StatusConflict,
+			wantOutcome: audit.OutcomeFailure,
+		},
+		{
+			name:     "location off the globe",
+			location: api.NewOptLocation(api.Location{Latitude: 91}),
+			stores: &mockStoreService{
+				createStoreFn: func(context.Context, string, *store.Location) (store.Store, error) {
+					return store.Store{}, store.ErrInvalidLocation
+				},
+			},
+			wantCode:    http.StatusBadRequest,
+			wantOutcome: audit.OutcomeFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			events := &mockAuditService{}
+			h := newStoreHandler(t, tt.stores, events)
+			got, err := h.CreateStore(ctx, &api.NewStore{
+				Name: "Harbour Road", Location: tt.location,
+			}, api.CreateStoreParams{})
+			if len(events.events) != 1 ||
+				events.events[0].Action != audit.ActionStoreCreate ||
+				events.events[0].Outcome != tt.wantOutcome {
+				t.Errorf("got audit events %+v", events.events)
+			}
+			if tt.wantCode != 0 {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if code := h.NewError(ctx, err).StatusCode; code != tt.wantCode {
+					t.Errorf("got status %d, want %d",
+						code, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			want := api.Store{
+				ID: 2, Name: "Harbour Road", Location: tt.location, CreatedAt: now,
+			}
+			if got, ok := got.(*api.Store); !ok || *got != want {
+				t.Errorf("got %+v, want %+v", got, want)
+			}
+			if events.events[0].Target != "store:2" {
+				t.Errorf("audit target = %q, want store:2",
+					events.events[0].Target)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+// CreateWebhook handles POST /admin/webhooks.
+func (h *Handler) CreateWebhook(
+	ctx context.Context, req *api.NewWebhook,
+	_ api.CreateWebhookParams,
+) (api.CreateWebhookRes, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, auth.ErrUnauthorized
+	}
+
+	eventTypes := make([]string, len(req.EventTypes))
+	for i, t := range req.EventTypes {
+		eventTypes[i] = string(t)
+	}
+
+	w, err := h.webhooks.Create(ctx, claims.UserID, req.URL, eventTypes)
+	if err != nil {
+		h.record(ctx, audit.Event{Action: audit.ActionWebhookCreate}, err)
+		return nil, err
+	}
+	h.record(ctx, audit.Event{
+		Action: audit.ActionWebhookCreate,
+		Target: auditTarget("webhook", w.ID),
+		Details: map[string]string{
+			"url":         w.URL,
+			"event_types": strings.Join(eventTypes, " "),
+		},
+	}, nil)
+	noStore(ctx)
+	aw := webhookToAPI(w)
+	return &api.CreatedWebhook{
+		ID:         aw.ID,
+		URL:        aw.URL,
+		EventTypes: aw.EventTypes,
+		CreatedBy:  aw.CreatedBy,
+		CreatedAt:  aw.CreatedAt,
+		Secret:     w.Secret,
+	}, nil
+}
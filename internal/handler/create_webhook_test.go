@@ -0,0 +1,115 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/handler"
+	"github.com/hhubris/petstore/internal/webhook"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	req := &api.NewWebhook{
+		URL: "https://partner.example/hook",
+		EventTypes: []api.WebhookEventType{
+			api.WebhookEventTypePetCreated,
+			api.WebhookEventTypePetDeleted,
+		},
+	}
+
+	tests := []struct {
+		name        string
+		claims      *auth.Claims
+		hooks       *mockWebhookService
+		wantCode    int
+		wantOutcome audit.Outcome
+	}{
+		{
+			name:   "success",
+			claims: &auth.Claims{UserID: 1, Role: "admin"},
+			hooks: &mockWebhookService{
+				createFn: func(
+					_ context.Context, by int64, url string, types []string,
+				) (webhook.Webhook, error) {
+					if by != 1 || url != req.URL || len(types) != 2 ||
+						types[1] != webhook.EventPetDeleted {
+						t.Errorf("got by=%d url=%q types=%v", by, url, types)
+					}
+					return webhook.Webhook{
+						ID: 3, URL: url, EventTypes: types,
+						Secret: "whsec_secret", CreatedBy: by,
+					}, nil
+				},
+			},
+			wantOutcome: audit.OutcomeSuccess,
+		},
+		{
+			name:   "invalid url",
+			claims: &auth.Claims{UserID: 1, Role: "admin"},
+			hooks: &mockWebhookService{
+				createFn: func(
+					context.Context, int64, string, []string,
+				) (webhook.Webhook, error) {
+					return webhook.Webhook{}, webhook.ErrInvalidURL
+				},
+			},
+			wantCode:    http.StatusBadRequest,
+			wantOutcome: audit.OutcomeFailure,
+		},
+		{
+			name:     "no claims",
+			hooks:    &mockWebhookService{},
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ctx := handler.WithResponseWriter(context.Background(), w)
+			if tt.claims != nil {
+				ctx = auth.ContextWithClaims(ctx, *tt.claims)
+			}
+			events := &mockAuditService{}
+			h := newWebhookHandler(t, tt.hooks, events)
+			got, err := h.CreateWebhook(ctx, req, api.CreateWebhookParams{})
+
+			if tt.wantOutcome != "" && (len(events.events) != 1 ||
+				events.events[0].Action != audit.ActionWebhookCreate ||
+				events.events[0].Outcome != tt.wantOutcome) {
+				t.Errorf("events = %+v", events.events)
+			}
+			if tt.wantCode != 0 {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if code := h.NewError(ctx, err).StatusCode; code != tt.wantCode {
+					t.Errorf("got status %d, want %d",
+						code, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			c, ok := got.(*api.CreatedWebhook)
+			if !ok {
+				t.Fatalf("got %T, want *api.CreatedWebhook", got)
+			}
+			if c.ID != 3 || c.Secret != "whsec_secret" || len(c.EventTypes) != 2 {
+				t.Errorf("got %+v", c)
+			}
+			if events.events[0].Target != "webhook:3" {
+				t.Errorf("target = %q", events.events[0].Target)
+			}
+			if cc := w.Header().Get("Cache-Control"); cc != "no-store" {
+				t.Errorf("Cache-Control = %q, want no-store", cc)
+			}
+		})
+	}
+}
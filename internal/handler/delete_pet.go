@@ -4,11 +4,17 @@ import (
 	"context"
 
 	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
 )
 
 // DeletePet handles DELETE /pets/{id}.
 func (h *Handler) DeletePet(
 	ctx context.Context, params api.DeletePetParams,
 ) error {
-	return h.pets.DeletePet(ctx, params.ID)
+	err := h.pets.DeletePet(ctx, params.ID)
+	h.record(ctx, audit.Event{
+		Action: audit.ActionPetDelete,
+		Target: auditTarget("pet", params.ID),
+	}, err)
+	return err
 }
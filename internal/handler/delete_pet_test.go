@@ -2,9 +2,13 @@ package handler_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+	"github.com/hhubris/petstore/internal/auth"
 	"github.com/hhubris/petstore/internal/db"
 )
 
@@ -52,3 +56,59 @@ func TestDeletePet(t *testing.T) {
 		})
 	}
 }
+
+func TestDeletePetAudit(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantOutcome audit.Outcome
+		wantError   string
+		wantStatus  string
+	}{
+		{name: "success", wantOutcome: audit.OutcomeSuccess},
+		{
+			name:        "failure",
+			err:         fmt.Errorf("delete pet: %w", db.ErrNotFound),
+			wantOutcome: audit.OutcomeFailure,
+			wantError:   "not-found",
+			wantStatus:  "404",
+		},
+		{
+			name:        "unexpected failure",
+			err:         errors.New("dial tcp 10.0.0.5:5432: connection refused"),
+			wantOutcome: audit.OutcomeFailure,
+			wantError:   "internal",
+			wantStatus:  "500",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := &mockAuditService{}
+			pets := &mockPetService{
+				deletePetFn: func(context.Context, int64) error {
+					return tt.err
+				},
+			}
+			h := newAuditHandler(t, pets, nil, events)
+			ctx := auth.ContextWithClaims(context.Background(),
+				auth.Claims{UserID: 1, Role: "admin"})
+
+			_ = h.DeletePet(ctx, api.DeletePetParams{ID: 7})
+
+			if len(events.events) != 1 {
+				t.Fatalf("recorded %d events, want 1", len(events.events))
+			}
+			e := events.events[0]
+			if e.Action != audit.ActionPetDelete || e.Target != "pet:7" ||
+				e.Outcome != tt.wantOutcome {
+				t.Errorf("event = %+v", e)
+			}
+			if e.Details["error"] != tt.wantError ||
+				e.Details["status"] != tt.wantStatus {
+				t.Errorf("details = %v, want error %q status %q",
+					e.Details, tt.wantError, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+)
+
+// DeleteWebhook handles DELETE /admin/webhooks/{id}.
+func (h *Handler) DeleteWebhook(
+	ctx context.Context, params api.DeleteWebhookParams,
+) error {
+	err := h.webhooks.Delete(ctx, params.ID)
+	h.record(ctx, audit.Event{
+		Action: audit.ActionWebhookDelete,
+		Target: auditTarget("webhook", params.ID),
+	}, err)
+	return err
+}
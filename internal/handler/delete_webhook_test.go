@@ -0,0 +1,52 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+	"github.com/hhubris/petstore/internal/db"
+)
+
+func TestDeleteWebhook(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantOutcome audit.Outcome
+	}{
+		{name: "success", wantOutcome: audit.OutcomeSuccess},
+		{
+			name:        "not found",
+			err:         db.ErrNotFound,
+			wantOutcome: audit.OutcomeFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hooks := &mockWebhookService{
+				deleteFn: func(_ context.Context, id int64) error {
+					if id != 3 {
+						t.Errorf("got id %d, want 3", id)
+					}
+					return tt.err
+				},
+			}
+			events := &mockAuditService{}
+			h := newWebhookHandler(t, hooks, events)
+			err := h.DeleteWebhook(context.Background(),
+				api.DeleteWebhookParams{ID: 3})
+			if !errors.Is(err, tt.err) {
+				t.Errorf("err = %v, want %v", err, tt.err)
+			}
+			if len(events.events) != 1 ||
+				events.events[0].Action != audit.ActionWebhookDelete ||
+				events.events[0].Target != "webhook:3" ||
+				events.events[0].Outcome != tt.wantOutcome {
+				t.Errorf("events = %+v", events.events)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+// EnrollMFA handles POST /auth/mfa/enroll.
+func (h *Handler) EnrollMFA(
+	ctx context.Context, _ api.EnrollMFAParams,
+) (api.EnrollMFARes, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, auth.ErrUnauthorized
+	}
+	e, err := h.auth.EnrollMFA(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	noStore(ctx)
+	return &api.MFAEnrollment{
+		Secret:          e.Secret,
+		ProvisioningUri: e.URI,
+	}, nil
+}
@@ -0,0 +1,81 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+func TestEnrollMFA(t *testing.T) {
+	tests := []struct {
+		name     string
+		claims   *auth.Claims
+		auths    *mockAuthService
+		wantCode int
+	}{
+		{
+			name:   "success",
+			claims: &auth.Claims{UserID: 1, Role: "admin"},
+			auths: &mockAuthService{
+				enrollMFAFn: func(_ context.Context, id int64) (auth.MFAEnrollment, error) {
+					if id != 1 {
+						t.Errorf("got user %d, want 1", id)
+					}
+					return auth.MFAEnrollment{
+						Secret: "SECRET",
+						URI:    "otpauth://totp/x?secret=SECRET",
+					}, nil
+				},
+			},
+		},
+		{
+			name:   "already enabled",
+			claims: &auth.Claims{UserID: 1, Role: "admin"},
+			auths: &mockAuthService{
+				enrollMFAFn: func(context.Context, int64) (auth.MFAEnrollment, error) {
+					return auth.MFAEnrollment{}, auth.ErrMFAAlreadyEnabled
+				},
+			},
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:     "no claims",
+			auths:    &mockAuthService{},
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.claims != nil {
+				ctx = auth.ContextWithClaims(ctx, *tt.claims)
+			}
+			h := newHandler(t, nil, tt.auths)
+			got, err := h.EnrollMFA(ctx, api.EnrollMFAParams{})
+			if tt.wantCode != 0 {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if code := h.NewError(ctx, err).StatusCode; code != tt.wantCode {
+					t.Errorf("got status %d, want %d",
+						code, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			e, ok := got.(*api.MFAEnrollment)
+			if !ok {
+				t.Fatalf("got %T, want *api.MFAEnrollment", got)
+			}
+			if e.Secret != "SECRET" || e.ProvisioningUri == "" {
+				t.Errorf("got %+v", e)
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-faster/jx"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/pet"
+	"github.com/hhubris/petstore/internal/store"
+)
+
+// exportFormat is a format the pet export can be written in.
+type exportFormat int
+
+const (
+	exportJSON exportFormat = iota
+	exportNDJSON
+	exportCSV
+)
+
+// exportMediaTypes lists the media types that select each
+// export format. Formats are listed in order of preference
+// for clients that accept several equally.
+var exportMediaTypes = []struct {
+	format exportFormat
+	types  []string
+}{
+	{exportJSON, []string{"application/vnd.petstore.pets+json", "application/json"}},
+	{exportNDJSON, []string{"application/x-ndjson"}},
+	{exportCSV, []string{"text/csv"}},
+}
+
+var (
+	errNotAcceptable = errors.New(
+		"export is available as text/csv, application/x-ndjson, or application/json")
+
+	// errExportInterrupted wraps a failure after an export
+	// response has started. ErrorHandler aborts the response
+	// rather than appending a problem to it.
+	errExportInterrupted = errors.New("export interrupted")
+)
+
+// ExportPets handles GET /pets/export. Pets are encoded as
+// the repository reads them and piped to the response, so
+// the export is never held in memory.
+func (h *Handler) ExportPets(
+	ctx context.Context, params api.ExportPetsParams,
+) (api.ExportPetsRes, error) {
+	format, err := negotiateExport(params.Accept.Or(""))
+	if err != nil {
+		return nil, err
+	}
+	f := petFilter(api.FindPetsParams{
+		StoreId:    params.StoreId,
+		Tags:       params.Tags,
+		Species:    params.Species,
+		Breed:      params.Breed,
+		Sex:        params.Sex,
+		BornAfter:  params.BornAfter,
+		BornBefore: params.BornBefore,
+		Currency:   params.Currency,
+		MinPrice:   params.MinPrice,
+		MaxPrice:   params.MaxPrice,
+		Near:       params.Near,
+		RadiusKm:   params.RadiusKm,
+		Limit:      params.Limit,
+	})
+	// Once the pipe is returned the status is sent, so reject
+	// a bad filter, or another store's, first.
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := store.Confine(auth.StoreScope(ctx), f.Store); err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		enc := newPetEncoder(format, pw)
+		err := h.pets.ExportPets(ctx, f, enc.Encode)
+		if err == nil {
+			err = enc.Close()
+		}
+		if err != nil {
+			err = fmt.Errorf("%w: %w", errExportInterrupted, err)
+		}
+		pw.CloseWithError(err)
+	}()
+
+	switch format {
+	case exportNDJSON:
+		return &api.ExportPetsOKApplicationXNdjson{Data: pr}, nil
+	case exportCSV:
+		return &api.ExportPetsOKTextCsv{Data: pr}, nil
+	default:
+		return &api.ExportPetsOKApplicationVndPetstorePetsJSON{Data: pr}, nil
+	}
+}
+
+// negotiateExport picks the export format for an Accept
+// header: the one with the highest quality, JSON when the
+// header is empty. It returns errNotAcceptable when the
+// header accepts none of them.
+func negotiateExport(accept string) (exportFormat, error) {
+	if strings.TrimSpace(accept) == "" {
+		return exportJSON, nil
+	}
+	var (
+		best  exportFormat
+		bestQ float64
+	)
+	for _, m := range exportMediaTypes {
+		if q := acceptQuality(accept, m.types); q > bestQ {
+			best, bestQ = m.format, q
+		}
+	}
+	if bestQ == 0 {
+		return 0, errNotAcceptable
+	}
+	return best, nil
+}
+
+// acceptQuality returns the quality an Accept header gives
+// the best of types, taking each from the most specific
+// media range that matches it. Unparsable ranges are
+// ignored.
+func acceptQuality(accept string, types []string) float64 {
+	var best float64
+	for _, t := range types {
+		q, specificity := 0.0, -1
+		for r := range strings.SplitSeq(accept, ",") {
+			mt, params, err := mime.ParseMediaType(r)
+			if err != nil {
+				continue
+			}
+			s := rangeSpecificity(mt, t)
+			if s <= specificity {
+				continue
+			}
+			rq := 1.0
+			if v, ok := params["q"]; ok {
+				if rq, err = strconv.ParseFloat(v, 64); err != nil {
+					continue
+				}
+			}
+			q, specificity = rq, s
+		}
+		best = max(best, q)
+	}
+	return best
+}
+
+// rangeSpecificity reports how specifically the media range
+// mr matches the media type t: 2 for an exact match, 1 for
+// type/*, 0 for */*, and -1 for no match.
+func rangeSpecificity(mr, t string) int {
+	typ, _, _ := strings.Cut(t, "/")
+	switch mr {
+	case t:
+		return 2
+	case typ + "/*":
+		return 1
+	case "*/*":
+		return 0
+	}
+	return -1
+}
+
+// petEncoder writes exported pets in one format. Close
+// writes anything that follows the last pet and flushes.
+type petEncoder interface {
+	Encode(p pet.Pet) error
+	Close() error
+}
+
+// newPetEncoder returns a petEncoder writing format to w.
+func newPetEncoder(format exportFormat, w io.Writer) petEncoder {
+	if format == exportCSV {
+		return &csvPetEncoder{w: csv.NewWriter(w)}
+	}
+	return &jsonPetEncoder{
+		w:     bufio.NewWriter(w),
+		array: format == exportJSON,
+	}
+}
+
+// csvPetEncoder writes pets as CSV under a header of id
+// and csvPetColumns, which importPets reads back, with an
+// empty cell for an absent value.
+type csvPetEncoder struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (e *csvPetEncoder) Encode(p pet.Pet) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	str := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	var born, amount, currency string
+	if p.BirthDate != nil {
+		born = p.BirthDate.Format(time.DateOnly)
+	}
+	if p.Price != nil {
+		amount = strconv.FormatInt(p.Price.Amount, 10)
+		currency = p.Price.Currency
+	}
+	return e.w.Write([]string{
+		strconv.FormatInt(p.ID, 10), p.Name, str(p.Tag),
+		str(p.Species), str(p.Breed), born, str(p.Sex),
+		str(p.Description), amount, currency,
+		strconv.FormatInt(p.StoreID, 10),
+	})
+}
+
+func (e *csvPetEncoder) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvPetEncoder) writeHeader() error {
+	if e.header {
+		return nil
+	}
+	e.header = true
+	return e.w.Write(append([]string{"id"}, csvPetColumns...))
+}
+
+// jsonPetEncoder writes pets as findPets does, either as
+// one JSON array or one object per line.
+type jsonPetEncoder struct {
+	w     *bufio.Writer
+	enc   jx.Encoder
+	array bool
+	n     int
+}
+
+func (e *jsonPetEncoder) Encode(p pet.Pet) error {
+	e.enc.Reset()
+	ap := petToAPI(p)
+	ap.Encode(&e.enc)
+	switch {
+	case !e.array:
+		e.enc.RawStr("\n")
+	case e.n == 0:
+		e.w.WriteByte('[')
+	default:
+		e.w.WriteByte(',')
+	}
+	e.n++
+	_, err := e.w.Write(e.enc.Bytes())
+	return err
+}
+
+func (e *jsonPetEncoder) Close() error {
+	if e.array {
+		if e.n == 0 {
+			e.w.WriteByte('[')
+		}
+		e.w.WriteByte(']')
+	}
+	return e.w.Flush()
+}
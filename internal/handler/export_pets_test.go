@@ -0,0 +1,220 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/handler"
+	"github.com/hhubris/petstore/internal/pet"
+)
+
+// exportBody returns the body of an export response and the
+// error, if any, that cut it short.
+func exportBody(t *testing.T, res api.ExportPetsRes) (ct, body string, err error) {
+	t.Helper()
+	var r io.Reader
+	switch res := res.(type) {
+	case *api.ExportPetsOKApplicationVndPetstorePetsJSON:
+		ct, r = "json", res.Data
+	case *api.ExportPetsOKApplicationXNdjson:
+		ct, r = "ndjson", res.Data
+	case *api.ExportPetsOKTextCsv:
+		ct, r = "csv", res.Data
+	default:
+		t.Fatalf("unexpected response %T", res)
+	}
+	b, err := io.ReadAll(r)
+	return ct, string(b), err
+}
+
+func TestExportPets(t *testing.T) {
+	dog, beagle, male := "dog", "beagle", pet.SexMale
+	born := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	catalog := []pet.Pet{
+		{
+			ID: 1, Name: "Fido", Tag: &dog, Species: &dog, Breed: &beagle,
+			BirthDate: &born, Sex: &male,
+			Price:   &pet.Money{Amount: 12500, Currency: "EUR"},
+			StoreID: 1,
+		},
+		{ID: 2, Name: "Luna, Jr.", StoreID: 2},
+	}
+	const fido = `"species":"dog","breed":"beagle","birthDate":"2024-03-01",` +
+		`"sex":"male","price":{"amount":12500,"currency":"EUR"},"storeId":1,`
+	tests := []struct {
+		name     string
+		accept   string
+		pets     []pet.Pet
+		wantCT   string
+		wantBody string
+		wantSlug string
+	}{
+		{
+			name:     "json by default",
+			pets:     catalog,
+			wantCT:   "json",
+			wantBody: `[{"name":"Fido","tag":"dog",` + fido + `"id":1},{"name":"Luna, Jr.","storeId":2,"id":2}]`,
+		},
+		{
+			name:     "empty json",
+			accept:   "application/json",
+			wantCT:   "json",
+			wantBody: `[]`,
+		},
+		{
+			name:     "ndjson",
+			accept:   "application/x-ndjson",
+			pets:     catalog,
+			wantCT:   "ndjson",
+			wantBody: `{"name":"Fido","tag":"dog",` + fido + "\"id\":1}\n{\"name\":\"Luna, Jr.\",\"storeId\":2,\"id\":2}\n",
+		},
+		{
+			name:   "csv",
+			accept: "text/csv",
+			pets:   catalog,
+			wantCT: "csv",
+			wantBody: "id,name,tag,species,breed,birth_date,sex,description,price_amount,price_currency,store_id\n1,Fido,dog,dog,beagle,2024-03-01,male,,12500,EUR,1\n" +
+				"2,\"Luna, Jr.\",,,,,,,,,2\n",
+		},
+		{
+			name:     "empty csv keeps its header",
+			accept:   "text/*",
+			wantCT:   "csv",
+			wantBody: "id,name,tag,species,breed,birth_date,sex,description,price_amount,price_currency,store_id\n",
+		},
+		{
+			name:   "highest quality wins",
+			accept: "application/json;q=0.5, text/csv;q=0.9, */*;q=0.1",
+			wantCT: "csv", wantBody: "id,name,tag,species,breed,birth_date,sex,description,price_amount,price_currency,store_id\n",
+		},
+		{
+			name:   "json preferred among equals",
+			accept: "text/csv, application/x-ndjson, application/*",
+			wantCT: "json", wantBody: "[]",
+		},
+		{
+			name:   "specific range overrides a wildcard",
+			accept: "*/*, application/vnd.petstore.pets+json;q=0, application/json;q=0",
+			wantCT: "ndjson",
+		},
+		{
+			name:     "not acceptable",
+			accept:   "application/xml",
+			wantSlug: "not-acceptable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit := int32(10)
+			pets := &mockPetService{
+				exportPetsFn: func(
+					_ context.Context, f pet.Filter, fn func(pet.Pet) error,
+				) error {
+					if len(f.Tags) != 1 || f.Tags[0] != "dog" ||
+						f.Sex != pet.SexMale || f.Limit == nil || *f.Limit != limit {
+						t.Errorf("got filter %+v", f)
+					}
+					for _, p := range tt.pets {
+						if err := fn(p); err != nil {
+							return err
+						}
+					}
+					return nil
+				},
+			}
+			params := api.ExportPetsParams{
+				Tags:  []string{"dog"},
+				Sex:   api.NewOptPetSex(api.PetSexMale),
+				Limit: api.NewOptInt32(limit),
+			}
+			if tt.accept != "" {
+				params.Accept = api.NewOptString(tt.accept)
+			}
+			h := newHandler(t, pets, nil)
+			res, err := h.ExportPets(context.Background(), params)
+			if tt.wantSlug != "" {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				p := h.NewError(context.Background(), err).Response
+				if p.Type != "urn:petstore:problem:"+tt.wantSlug {
+					t.Fatalf("got problem type %q, want %s", p.Type, tt.wantSlug)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			ct, body, err := exportBody(t, res)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ct != tt.wantCT {
+				t.Errorf("got %s export, want %s", ct, tt.wantCT)
+			}
+			if body != tt.wantBody {
+				t.Errorf("got body %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestExportPetsOtherStore(t *testing.T) {
+	own := int64(2)
+	ctx := auth.ContextWithClaims(context.Background(),
+		auth.Claims{UserID: 1, Role: "admin", StoreID: &own})
+	h := newHandler(t, &mockPetService{}, nil)
+
+	// The store is checked before the response starts, so
+	// the caller gets a problem rather than a cut-off body.
+	_, err := h.ExportPets(ctx, api.ExportPetsParams{StoreId: api.NewOptInt64(3)})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	p := h.NewError(ctx, err).Response
+	if p.Type != "urn:petstore:problem:other-store" {
+		t.Errorf("got problem type %q, want other-store", p.Type)
+	}
+}
+
+func TestExportPetsInterrupted(t *testing.T) {
+	pets := &mockPetService{
+		exportPetsFn: func(
+			_ context.Context, _ pet.Filter, fn func(pet.Pet) error,
+		) error {
+			if err := fn(pet.Pet{ID: 1, Name: "Fido"}); err != nil {
+				return err
+			}
+			return errors.New("connection reset")
+		},
+	}
+	h := newHandler(t, pets, nil)
+	res, err := h.ExportPets(context.Background(), api.ExportPetsParams{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, body, err := exportBody(t, res)
+	if err == nil {
+		t.Fatalf("export of %q ended cleanly", body)
+	}
+
+	// The response has started, so the error handler aborts
+	// it instead of writing a problem.
+	defer func() {
+		if v := recover(); v != http.ErrAbortHandler {
+			t.Errorf("recovered %v, want http.ErrAbortHandler", v)
+		}
+	}()
+	handler.ErrorHandler(context.Background(), httptest.NewRecorder(),
+		httptest.NewRequest(http.MethodGet, "/pets/export", nil), err)
+	t.Error("ErrorHandler returned normally")
+}
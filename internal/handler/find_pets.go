@@ -10,12 +10,7 @@ import (
 func (h *Handler) FindPets(
 	ctx context.Context, params api.FindPetsParams,
 ) ([]api.Pet, error) {
-	var limit *int32
-	if v, ok := params.Limit.Get(); ok {
-		limit = &v
-	}
-
-	pets, err := h.pets.ListPets(ctx, params.Tags, limit)
+	pets, err := h.pets.ListPets(ctx, petFilter(params))
 	if err != nil {
 		return nil, err
 	}
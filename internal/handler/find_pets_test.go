@@ -3,7 +3,9 @@ package handler_test
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/hhubris/petstore/internal/api"
 	"github.com/hhubris/petstore/internal/pet"
@@ -22,7 +24,7 @@ func TestFindPets(t *testing.T) {
 			name:   "no filters",
 			params: api.FindPetsParams{},
 			pets: &mockPetService{
-				listPetsFn: func(_ context.Context, tags []string, limit *int32) ([]pet.Pet, error) {
+				listPetsFn: func(context.Context, pet.Filter) ([]pet.Pet, error) {
 					return []pet.Pet{
 						{ID: 1, Name: "Fido", Tag: &tag},
 						{ID: 2, Name: "Rex"},
@@ -37,8 +39,8 @@ func TestFindPets(t *testing.T) {
 				Limit: api.NewOptInt32(1),
 			},
 			pets: &mockPetService{
-				listPetsFn: func(_ context.Context, _ []string, limit *int32) ([]pet.Pet, error) {
-					if limit == nil || *limit != 1 {
+				listPetsFn: func(_ context.Context, f pet.Filter) ([]pet.Pet, error) {
+					if f.Limit == nil || *f.Limit != 1 {
 						t.Error("expected limit=1")
 					}
 					return []pet.Pet{{ID: 1, Name: "Fido"}}, nil
@@ -46,11 +48,54 @@ func TestFindPets(t *testing.T) {
 			},
 			want: 1,
 		},
+		{
+			name: "with profile filters",
+			params: api.FindPetsParams{
+				StoreId:   api.NewOptInt64(2),
+				Species:   []string{"dog", "cat"},
+				Breed:     []string{"beagle"},
+				Sex:       api.NewOptPetSex(api.PetSexFemale),
+				BornAfter: api.NewOptDate(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
+				Currency:  api.NewOptString("EUR"),
+				MaxPrice:  api.NewOptInt64(50000),
+			},
+			pets: &mockPetService{
+				listPetsFn: func(_ context.Context, f pet.Filter) ([]pet.Pet, error) {
+					if f.Store == nil || *f.Store != 2 ||
+						len(f.Species) != 2 || len(f.Breeds) != 1 ||
+						f.Sex != pet.SexFemale || f.BornAfter == nil ||
+						f.BornBefore != nil || f.Currency != "EUR" ||
+						f.MinPrice != nil || f.MaxPrice == nil || *f.MaxPrice != 50000 {
+						t.Errorf("got filter %+v", f)
+					}
+					return nil, nil
+				},
+			},
+			want: 0,
+		},
+		{
+			name: "near",
+			params: api.FindPetsParams{
+				Near:     []float64{53.35, -6.26},
+				RadiusKm: api.NewOptFloat64(25),
+			},
+			pets: &mockPetService{
+				listPetsFn: func(_ context.Context, f pet.Filter) ([]pet.Pet, error) {
+					if f.Near == nil || f.Near.Latitude != 53.35 ||
+						f.Near.Longitude != -6.26 ||
+						f.RadiusKm == nil || *f.RadiusKm != 25 {
+						t.Errorf("got filter %+v", f)
+					}
+					return []pet.Pet{{ID: 1, Name: "Fido"}}, nil
+				},
+			},
+			want: 1,
+		},
 		{
 			name:   "service error",
 			params: api.FindPetsParams{},
 			pets: &mockPetService{
-				listPetsFn: func(context.Context, []string, *int32) ([]pet.Pet, error) {
+				listPetsFn: func(context.Context, pet.Filter) ([]pet.Pet, error) {
 					return nil, errors.New("db down")
 				},
 			},
@@ -77,3 +122,31 @@ func TestFindPets(t *testing.T) {
 		})
 	}
 }
+
+func TestFindPetsNear(t *testing.T) {
+	ctx := context.Background()
+	distance := 1.5
+	h := newHandler(t, &mockPetService{
+		listPetsFn: func(_ context.Context, f pet.Filter) ([]pet.Pet, error) {
+			if f.Near == nil {
+				return nil, pet.ErrRadiusWithoutNear
+			}
+			return []pet.Pet{{ID: 1, Name: "Fido", DistanceKm: &distance}}, nil
+		},
+	}, nil)
+
+	got, err := h.FindPets(ctx, api.FindPetsParams{Near: []float64{53.35, -6.26}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].DistanceKm.Or(0) != distance {
+		t.Errorf("got %+v", got)
+	}
+
+	_, err = h.FindPets(ctx, api.FindPetsParams{RadiusKm: api.NewOptFloat64(5)})
+	p := h.NewError(ctx, err)
+	if p.StatusCode != http.StatusBadRequest ||
+		p.Response.Type != "urn:petstore:problem:radius-without-near" {
+		t.Errorf("got %d %q", p.StatusCode, p.Response.Type)
+	}
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+)
+
+// ForgotPassword handles POST /auth/password/forgot. The
+// audit event names the email only by hash and has no
+// target, so, like the response, it does not reveal
+// whether the account exists.
+func (h *Handler) ForgotPassword(
+	ctx context.Context, req *api.ForgotPasswordRequest,
+	_ api.ForgotPasswordParams,
+) (api.ForgotPasswordRes, error) {
+	err := h.auth.RequestPasswordReset(ctx, req.Email)
+	h.record(ctx, audit.Event{
+		Action:  audit.ActionPasswordForgot,
+		Details: auditEmail(req.Email),
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ForgotPasswordAccepted{}, nil
+}
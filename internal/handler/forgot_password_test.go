@@ -0,0 +1,77 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+)
+
+func TestForgotPassword(t *testing.T) {
+	tests := []struct {
+		name        string
+		auths       *mockAuthService
+		wantErr     bool
+		wantOutcome audit.Outcome
+	}{
+		{
+			name: "success",
+			auths: &mockAuthService{
+				requestPasswordResetFn: func(_ context.Context, email string) error {
+					if email != "alice@example.com" {
+						t.Errorf("got email %q", email)
+					}
+					return nil
+				},
+			},
+			wantOutcome: audit.OutcomeSuccess,
+		},
+		{
+			name: "service error",
+			auths: &mockAuthService{
+				requestPasswordResetFn: func(context.Context, string) error {
+					return errors.New("db down")
+				},
+			},
+			wantErr:     true,
+			wantOutcome: audit.OutcomeFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := &mockAuditService{}
+			h := newAuditHandler(t, nil, tt.auths, events)
+			got, err := h.ForgotPassword(context.Background(),
+				&api.ForgotPasswordRequest{
+					Email: "alice@example.com",
+				}, api.ForgotPasswordParams{})
+			if len(events.events) != 1 {
+				t.Fatalf("recorded %d events, want 1", len(events.events))
+			}
+			e := events.events[0]
+			if e.Action != audit.ActionPasswordForgot ||
+				e.Outcome != tt.wantOutcome || e.Target != "" ||
+				e.ActorUserID != nil {
+				t.Errorf("event = %+v", e)
+			}
+			if e.Details["email_sha256"] != aliceEmailHash {
+				t.Errorf("email_sha256 = %q", e.Details["email_sha256"])
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, ok := got.(*api.ForgotPasswordAccepted); !ok {
+				t.Errorf("got %T, want *api.ForgotPasswordAccepted", got)
+			}
+		})
+	}
+}
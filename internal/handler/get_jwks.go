@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+)
+
+// GetJWKS handles GET /.well-known/jwks.json.
+func (h *Handler) GetJWKS(_ context.Context) (*api.JWKS, error) {
+	keys := h.auth.PublicKeys()
+	out := &api.JWKS{Keys: make([]api.JWK, len(keys))}
+	for i, k := range keys {
+		out.Keys[i] = jwkToAPI(k)
+	}
+	return out, nil
+}
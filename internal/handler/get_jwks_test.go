@@ -0,0 +1,51 @@
+package handler_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+func TestGetJWKS(t *testing.T) {
+	auths := &mockAuthService{
+		publicKeysFn: func() []auth.JWK {
+			return []auth.JWK{
+				{Kty: "OKP", Kid: "new", Alg: "EdDSA", Crv: "Ed25519", X: "eA"},
+				{Kty: "RSA", Kid: "old", Alg: "RS256", N: "bg", E: "AQAB"},
+			}
+		},
+	}
+	h := newHandler(t, nil, auths)
+
+	got, err := h.GetJWKS(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Keys) != 2 {
+		t.Fatalf("got %d keys, want 2", len(got.Keys))
+	}
+	ed, rsa := got.Keys[0], got.Keys[1]
+	if ed.Kid != "new" || ed.Use != api.JWKUseSig ||
+		ed.X.Or("") != "eA" || ed.N.IsSet() {
+		t.Errorf("ed25519 key = %+v", ed)
+	}
+	if rsa.Alg != api.JWKAlgRS256 || rsa.E.Or("") != "AQAB" ||
+		rsa.Crv.IsSet() {
+		t.Errorf("rsa key = %+v", rsa)
+	}
+}
+
+func TestGetJWKSEmpty(t *testing.T) {
+	h := newHandler(t, nil, &mockAuthService{
+		publicKeysFn: func() []auth.JWK { return []auth.JWK{} },
+	})
+	got, err := h.GetJWKS(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Keys == nil || len(got.Keys) != 0 {
+		t.Errorf("Keys = %#v, want empty non-nil slice", got.Keys)
+	}
+}
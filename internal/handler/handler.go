@@ -2,77 +2,363 @@ package handler
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ogen-go/ogen/ogenerrors"
+	"github.com/ogen-go/ogen/validate"
 
 	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/apikey"
+	"github.com/hhubris/petstore/internal/audit"
 	"github.com/hhubris/petstore/internal/auth"
 	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/jobs"
+	"github.com/hhubris/petstore/internal/middleware"
 	"github.com/hhubris/petstore/internal/pet"
+	"github.com/hhubris/petstore/internal/reservation"
+	"github.com/hhubris/petstore/internal/store"
+	"github.com/hhubris/petstore/internal/webhook"
 )
 
 // PetService defines the pet operations the handler depends on.
 type PetService interface {
-	CreatePet(ctx context.Context, name string, tag *string) (pet.Pet, error)
+	CreatePet(ctx context.Context, p pet.Pet) (pet.Pet, error)
+	ImportPets(ctx context.Context, pets []pet.Pet) (int, error)
+	ExportPets(ctx context.Context, f pet.Filter, fn func(pet.Pet) error) error
 	GetPet(ctx context.Context, id int64) (pet.Pet, error)
-	ListPets(ctx context.Context, tags []string, limit *int32) ([]pet.Pet, error)
+	ListPets(ctx context.Context, f pet.Filter) ([]pet.Pet, error)
+	ListSpecies(ctx context.Context) ([]pet.Species, error)
 	DeletePet(ctx context.Context, id int64) error
+	RestorePet(ctx context.Context, id int64) (pet.Pet, error)
+	PetHistory(ctx context.Context, id int64) ([]pet.Revision, error)
 }
 
 // AuthService defines the auth operations the handler depends on.
 type AuthService interface {
 	Register(ctx context.Context, name, email, password string) (auth.User, error)
-	Login(ctx context.Context, email, password string) (string, auth.User, error)
+	Login(ctx context.Context, email, password string) (auth.LoginResult, error)
 	GetUser(ctx context.Context, id int64) (auth.User, error)
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) (int64, error)
+	VerifyEmail(ctx context.Context, token string) (int64, error)
+	ResendVerification(ctx context.Context, userID int64) error
+	UnlockUser(ctx context.Context, id int64) error
+	UpdateUserAccess(ctx context.Context, id int64, c auth.AccessChange) (auth.User, error)
+	VerifyMFA(ctx context.Context, mfaToken, code string) (string, auth.User, error)
+	EnrollMFA(ctx context.Context, userID int64) (auth.MFAEnrollment, error)
+	ConfirmMFA(ctx context.Context, userID int64, code string) ([]string, error)
+	PublicKeys() []auth.JWK
+	BeginOIDCLogin() (auth.OIDCRedirect, error)
+	CompleteOIDCLogin(ctx context.Context, code, state, stateToken string) (auth.LoginResult, string, error)
+	ChangePassword(ctx context.Context, claims auth.Claims, currentPassword, newPassword string) (string, error)
+	RequestEmailChange(ctx context.Context, userID int64, email, password string) error
+	ConfirmEmailChange(ctx context.Context, token string) error
+}
+
+// APIKeyService defines the API key operations the handler
+// depends on.
+type APIKeyService interface {
+	Create(ctx context.Context, createdBy int64, name string, scopes []string, expiresAt *time.Time, storeID *int64) (apikey.APIKey, string, error)
+	List(ctx context.Context) ([]apikey.APIKey, error)
+	Revoke(ctx context.Context, id int64) error
+}
+
+// AuditService defines the audit log operations the
+// handler depends on.
+type AuditService interface {
+	Record(ctx context.Context, e audit.Event)
+	List(ctx context.Context, f audit.Filter) ([]audit.Event, error)
+}
+
+// WebhookService defines the webhook operations the
+// handler depends on.
+type WebhookService interface {
+	Create(ctx context.Context, createdBy int64, url string, eventTypes []string) (webhook.Webhook, error)
+	List(ctx context.Context) ([]webhook.Webhook, error)
+	Delete(ctx context.Context, id int64) error
+	Deliveries(ctx context.Context, webhookID int64, f webhook.DeliveryFilter) ([]webhook.Delivery, error)
+	Redeliver(ctx context.Context, webhookID, deliveryID int64) (webhook.Delivery, error)
+}
+
+// StoreService defines the store operations the handler
+// depends on.
+type StoreService interface {
+	CreateStore(ctx context.Context, name string, loc *store.Location) (store.Store, error)
+	ListStores(ctx context.Context) ([]store.Store, error)
+}
+
+// ReservationService defines the reservation operations
+// the handler depends on.
+type ReservationService interface {
+	Reserve(ctx context.Context, userID, petID int64) (reservation.Reservation, error)
+	List(ctx context.Context, userID int64) ([]reservation.Reservation, error)
+	Cancel(ctx context.Context, userID, id int64) (reservation.Reservation, error)
+}
+
+// JobScheduler defines the background job status the
+// handler reports.
+type JobScheduler interface {
+	Leader() bool
+	Status() []jobs.Status
+}
+
+// PetEventStreamer opens the pet event streams the handler
+// serves; after is the client's Last-Event-ID, if any.
+type PetEventStreamer interface {
+	Open(ctx context.Context, after *int64) (io.ReadCloser, error)
 }
 
 // Handler implements the ogen api.Handler interface.
 type Handler struct {
-	pets   PetService
-	auth   AuthService
-	secure bool
+	pets      PetService
+	auth      AuthService
+	keys      APIKeyService
+	events    AuditService
+	webhooks  WebhookService
+	stores    StoreService
+	holds     ReservationService
+	scheduler JobScheduler
+	stream    PetEventStreamer
+	secure    bool
 }
 
 // New creates a Handler. The secure flag controls the
-// Secure attribute on cookies (true in production).
+// Secure attribute on cookies (true in production). A nil
+// events service disables audit recording, and a nil
+// stream answers GET /pets/events with 501.
 func New(
 	pets PetService,
 	auth AuthService,
+	keys APIKeyService,
+	events AuditService,
+	webhooks WebhookService,
+	stores StoreService,
+	holds ReservationService,
+	scheduler JobScheduler,
+	stream PetEventStreamer,
 	secure bool,
 ) *Handler {
 	return &Handler{
-		pets:   pets,
-		auth:   auth,
-		secure: secure,
+		pets:      pets,
+		auth:      auth,
+		keys:      keys,
+		events:    events,
+		webhooks:  webhooks,
+		stores:    stores,
+		holds:     holds,
+		scheduler: scheduler,
+		stream:    stream,
+		secure:    secure,
 	}
 }
 
-// NewError maps service-layer errors to HTTP error responses.
+// errorKind maps a service-layer error to a status and a
+// problem type slug.
+type errorKind struct {
+	err    error
+	status int
+	slug   string
+}
+
+// errorKinds lists the errorKind of each service-layer
+// error. The first match wins, and the matched error's own
+// text becomes the problem detail, so context added by
+// wrapping never reaches the client.
+var errorKinds = []errorKind{
+	{db.ErrNotFound, http.StatusNotFound, "not-found"},
+	{db.ErrConflict, http.StatusConflict, "conflict"},
+	{auth.ErrInvalidCredentials, http.StatusUnauthorized, "invalid-credentials"},
+	{auth.ErrUnauthorized, http.StatusUnauthorized, "unauthorized"},
+	{
+		ogenerrors.ErrSecurityRequirementIsNotSatisfied,
+		http.StatusUnauthorized, "unauthorized",
+	},
+	{auth.ErrForbidden, http.StatusForbidden, "forbidden"},
+	{auth.ErrInsufficientScope, http.StatusForbidden, "insufficient-scope"},
+	{auth.ErrStoreScoped, http.StatusForbidden, "store-scoped"},
+	{store.ErrOtherStore, http.StatusForbidden, "other-store"},
+	{store.ErrUnknownStore, http.StatusBadRequest, "unknown-store"},
+	{store.ErrInvalidLocation, http.StatusBadRequest, "invalid-location"},
+	{pet.ErrRadiusWithoutNear, http.StatusBadRequest, "radius-without-near"},
+	{reservation.ErrPetReserved, http.StatusConflict, "pet-reserved"},
+	{reservation.ErrTooManyHolds, http.StatusConflict, "too-many-holds"},
+	{reservation.ErrHoldCooldown, http.StatusTooManyRequests, "hold-cooldown"},
+	{auth.ErrInvalidToken, http.StatusUnauthorized, "invalid-token"},
+	{auth.ErrInvalidResetToken, http.StatusBadRequest, "invalid-reset-token"},
+	{
+		auth.ErrInvalidVerificationToken,
+		http.StatusBadRequest, "invalid-verification-token",
+	},
+	{
+		auth.ErrInvalidEmailChangeToken,
+		http.StatusBadRequest, "invalid-email-change-token",
+	},
+	{auth.ErrEmailAlreadyVerified, http.StatusConflict, "email-already-verified"},
+	{auth.ErrEmailNotVerified, http.StatusForbidden, "email-not-verified"},
+	{auth.ErrTooManyRequests, http.StatusTooManyRequests, "too-many-requests"},
+	{auth.ErrInvalidMFACode, http.StatusBadRequest, "invalid-mfa-code"},
+	{auth.ErrMFAAlreadyEnabled, http.StatusConflict, "mfa-already-enabled"},
+	{auth.ErrMFANotEnrolled, http.StatusConflict, "mfa-not-enrolled"},
+	{auth.ErrMFARequired, http.StatusForbidden, "mfa-required"},
+	{apikey.ErrInvalidExpiry, http.StatusBadRequest, "invalid-expiry"},
+	{auth.ErrOIDCNotConfigured, http.StatusNotFound, "sso-not-configured"},
+	{auth.ErrInvalidOIDCState, http.StatusBadRequest, "invalid-sso-state"},
+	{auth.ErrIdentityRejected, http.StatusUnauthorized, "identity-rejected"},
+	{auth.ErrSSORequired, http.StatusForbidden, "sso-required"},
+	{auth.ErrIncorrectPassword, http.StatusBadRequest, "incorrect-password"},
+	{auth.ErrAccountDisabled, http.StatusForbidden, "account-disabled"},
+	{webhook.ErrInvalidURL, http.StatusBadRequest, "invalid-webhook-url"},
+	{webhook.ErrDeliveryPending, http.StatusConflict, "delivery-pending"},
+	{pet.ErrImportTooLarge, http.StatusRequestEntityTooLarge, "import-too-large"},
+	{pet.ErrUnknownBreed, http.StatusBadRequest, "unknown-breed"},
+	{pet.ErrBreedWithoutSpecies, http.StatusBadRequest, "breed-without-species"},
+	{pet.ErrFutureBirthDate, http.StatusBadRequest, "future-birth-date"},
+	{
+		pet.ErrPriceFilterWithoutCurrency,
+		http.StatusBadRequest, "price-filter-without-currency",
+	},
+	{errInvalidCSVHeader, http.StatusBadRequest, "invalid-import"},
+	{errImportLineTooLong, http.StatusBadRequest, "invalid-import"},
+	{errNotAcceptable, http.StatusNotAcceptable, "not-acceptable"},
+}
+
+// NewError maps service-layer errors to problem responses.
+// Unrecognised errors are logged and answered with a
+// generic 500 that does not reveal their text.
 func (h *Handler) NewError(
-	_ context.Context, err error,
-) *api.ErrorStatusCode {
-	code := http.StatusInternalServerError
+	ctx context.Context, err error,
+) *api.ProblemStatusCode {
+	if k, ok := kindOf(err); ok {
+		return &api.ProblemStatusCode{
+			StatusCode: k.status,
+			Response: middleware.Problem(
+				ctx, k.status, k.slug, k.err.Error(),
+			),
+		}
+	}
+	slog.ErrorContext(ctx, "request failed",
+		"error", err,
+		"correlation_id", middleware.GetCorrelationID(ctx),
+	)
+	return &api.ProblemStatusCode{
+		StatusCode: http.StatusInternalServerError,
+		Response: middleware.Problem(ctx,
+			http.StatusInternalServerError,
+			"internal", "internal server error"),
+	}
+}
+
+// ErrorHandler writes the errors ogen raises before a
+// handler runs — undecodable or invalid requests — as
+// problems, listing each invalid field. It is installed
+// with api.WithErrorHandler.
+func ErrorHandler(
+	ctx context.Context, w http.ResponseWriter, _ *http.Request, err error,
+) {
+	if errors.Is(err, errExportInterrupted) {
+		// The body has started, so abort it: a truncated
+		// chunked response tells the client the export is
+		// incomplete, which an appended problem would not.
+		slog.ErrorContext(ctx, "pet export failed",
+			"error", err,
+			"correlation_id", middleware.GetCorrelationID(ctx),
+		)
+		panic(http.ErrAbortHandler)
+	}
+	status := ogenerrors.ErrorCode(err)
+	var p api.Problem
 	switch {
-	case errors.Is(err, db.ErrNotFound):
-		code = http.StatusNotFound
-	case errors.Is(err, db.ErrConflict):
-		code = http.StatusConflict
-	case errors.Is(err, auth.ErrInvalidCredentials):
-		code = http.StatusUnauthorized
-	case errors.Is(err, auth.ErrUnauthorized):
-		code = http.StatusUnauthorized
-	case errors.Is(err, auth.ErrForbidden):
-		code = http.StatusForbidden
-	case errors.Is(err, auth.ErrInvalidToken):
-		code = http.StatusUnauthorized
-	}
-	return &api.ErrorStatusCode{
-		StatusCode: code,
-		Response: api.Error{
-			Code:    int32(code),
-			Message: err.Error(),
-		},
+	case status >= http.StatusInternalServerError:
+		slog.ErrorContext(ctx, "request failed",
+			"error", err,
+			"correlation_id", middleware.GetCorrelationID(ctx),
+		)
+		slug, detail := "internal", "internal server error"
+		if status == http.StatusNotImplemented {
+			slug, detail = "not-implemented", "not implemented"
+		}
+		p = middleware.Problem(ctx, status, slug, detail)
+	case status == http.StatusUnsupportedMediaType:
+		p = middleware.Problem(ctx, status,
+			"unsupported-media-type", requestErrorDetail(err))
+	default:
+		fields := problemFields("", err)
+		if len(fields) == 0 {
+			p = middleware.Problem(ctx, status,
+				"invalid-request", requestErrorDetail(err))
+			break
+		}
+		p = middleware.Problem(ctx, status,
+			"validation", "request validation failed")
+		p.Errors = fields
 	}
+	middleware.WriteProblem(w, p)
+}
+
+// requestErrorDetail returns the text of a request error
+// without ogen's "operation X: decode ..." prefix.
+func requestErrorDetail(err error) string {
+	var (
+		reqErr    *ogenerrors.DecodeRequestError
+		paramsErr *ogenerrors.DecodeParamsError
+	)
+	switch {
+	case errors.As(err, &reqErr):
+		return reqErr.Err.Error()
+	case errors.As(err, &paramsErr):
+		return paramsErr.Err.Error()
+	}
+	return err.Error()
+}
+
+// problemFields flattens the validation failures in err
+// into field paths under prefix: dotted for body fields,
+// "query.limit" style for parameters.
+func problemFields(prefix string, err error) []api.ProblemField {
+	var (
+		paramErr *ogenerrors.DecodeParamError
+		valErr   *validate.Error
+	)
+	switch {
+	case errors.As(err, &paramErr):
+		name := string(paramErr.In) + "." + paramErr.Name
+		if fields := problemFields(name, paramErr.Err); len(fields) > 0 {
+			return fields
+		}
+		return []api.ProblemField{{
+			Field: name, Message: paramErr.Err.Error(),
+		}}
+	case errors.As(err, &valErr):
+		var fields []api.ProblemField
+		for _, f := range valErr.Fields {
+			name := f.Name
+			switch {
+			case prefix == "":
+			case strings.HasPrefix(name, "["):
+				name = prefix + name
+			default:
+				name = prefix + "." + name
+			}
+			if nested := problemFields(name, f.Error); len(nested) > 0 {
+				fields = append(fields, nested...)
+				continue
+			}
+			fields = append(fields, api.ProblemField{
+				Field: name, Message: f.Error.Error(),
+			})
+		}
+		return fields
+	}
+	return nil
 }
 
 // responseWriterKey is the context key for the http.ResponseWriter.
@@ -120,24 +406,429 @@ func newCookie(
 	}
 }
 
+// setAccessToken sets the access_token cookie on the
+// response writer stored in ctx.
+func (h *Handler) setAccessToken(ctx context.Context, token string) error {
+	w, ok := responseWriterFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("response writer not in context")
+	}
+	http.SetCookie(w, newCookie(
+		"access_token", token, 86400, h.secure,
+	))
+	return nil
+}
+
+// kindOf returns the first errorKind matching err, and
+// false if there is none.
+func kindOf(err error) (errorKind, bool) {
+	for _, k := range errorKinds {
+		if errors.Is(err, k.err) {
+			return k, true
+		}
+	}
+	return errorKind{}, false
+}
+
+// noStore marks the response as carrying a secret, so it
+// is neither cached nor kept for Idempotency-Key replays.
+func noStore(ctx context.Context) {
+	if w, ok := responseWriterFromContext(ctx); ok {
+		w.Header().Set("Cache-Control", "no-store")
+	}
+}
+
+// record writes an audit event for the current request.
+// The event succeeded when err is nil; otherwise it is
+// marked failed and the problem slug and status the client
+// got are added to its details. The error text is left out:
+// wrapped errors can carry SQL, addresses, or other detail
+// the audit log should not keep.
+func (h *Handler) record(ctx context.Context, e audit.Event, err error) {
+	if h.events == nil {
+		return
+	}
+	e.Outcome = audit.OutcomeSuccess
+	if err != nil {
+		e.Outcome = audit.OutcomeFailure
+		if e.Details == nil {
+			e.Details = map[string]string{}
+		}
+		k, ok := kindOf(err)
+		if !ok {
+			k = errorKind{status: http.StatusInternalServerError, slug: "internal"}
+		}
+		e.Details["error"] = k.slug
+		e.Details["status"] = strconv.Itoa(k.status)
+	}
+	h.events.Record(ctx, e)
+}
+
+// auditTarget formats the target of an audit event as
+// "kind:id".
+func auditTarget(kind string, id int64) string {
+	return kind + ":" + strconv.FormatInt(id, 10)
+}
+
+// auditUser sets e's actor and target to the user with the
+// given ID, when known (id is 0 after most failures).
+func auditUser(e audit.Event, id int64) audit.Event {
+	if id != 0 {
+		e.ActorUserID = &id
+		e.Target = auditTarget("user", id)
+	}
+	return e
+}
+
+// auditEmail returns the audit details naming a submitted
+// email: its hex SHA-256, lower-cased first, so attempts
+// against one address can be found without the log holding
+// the address itself.
+func auditEmail(email string) map[string]string {
+	sum := sha256.Sum256([]byte(strings.ToLower(email)))
+	return map[string]string{"email_sha256": hex.EncodeToString(sum[:])}
+}
+
 // petToAPI converts a domain Pet to an API Pet.
 func petToAPI(p pet.Pet) api.Pet {
 	ap := api.Pet{
-		ID:   p.ID,
-		Name: p.Name,
+		ID:          p.ID,
+		Name:        p.Name,
+		Tag:         optString(p.Tag),
+		Species:     optString(p.Species),
+		Breed:       optString(p.Breed),
+		Description: optString(p.Description),
+		StoreId:     api.NewOptInt64(p.StoreID),
+	}
+	if p.BirthDate != nil {
+		ap.BirthDate = api.NewOptDate(*p.BirthDate)
 	}
-	if p.Tag != nil {
-		ap.Tag = api.NewOptString(*p.Tag)
+	if p.Sex != nil {
+		ap.Sex = api.NewOptPetSex(api.PetSex(*p.Sex))
+	}
+	if p.Price != nil {
+		ap.Price = api.NewOptMoney(api.Money{
+			Amount: p.Price.Amount, Currency: p.Price.Currency,
+		})
+	}
+	if p.ReservedUntil != nil {
+		ap.ReservedUntil = api.NewOptDateTime(*p.ReservedUntil)
+	}
+	if p.DistanceKm != nil {
+		ap.DistanceKm = api.NewOptFloat64(*p.DistanceKm)
 	}
 	return ap
 }
 
+// newPetFromAPI converts an API NewPet to a domain Pet.
+func newPetFromAPI(np api.NewPet) pet.Pet {
+	p := pet.Pet{
+		Name:        np.Name,
+		Tag:         stringPtr(np.Tag),
+		Species:     stringPtr(np.Species),
+		Breed:       stringPtr(np.Breed),
+		Description: stringPtr(np.Description),
+		StoreID:     np.StoreId.Or(0),
+	}
+	if v, ok := np.BirthDate.Get(); ok {
+		p.BirthDate = &v
+	}
+	if v, ok := np.Sex.Get(); ok {
+		sex := string(v)
+		p.Sex = &sex
+	}
+	if v, ok := np.Price.Get(); ok {
+		p.Price = &pet.Money{Amount: v.Amount, Currency: v.Currency}
+	}
+	return p
+}
+
+// speciesToAPI converts a domain Species to an API
+// Species.
+func speciesToAPI(s pet.Species) api.Species {
+	as := api.Species{
+		Code:   s.Code,
+		Name:   s.Name,
+		Breeds: make([]api.Breed, len(s.Breeds)),
+	}
+	for i, b := range s.Breeds {
+		as.Breeds[i] = api.Breed{Code: b.Code, Name: b.Name}
+	}
+	return as
+}
+
+// petFilter converts findPets parameters to a pet Filter.
+func petFilter(params api.FindPetsParams) pet.Filter {
+	f := pet.Filter{
+		Tags:     params.Tags,
+		Species:  params.Species,
+		Breeds:   params.Breed,
+		Sex:      string(params.Sex.Or("")),
+		Currency: params.Currency.Or(""),
+	}
+	if v, ok := params.StoreId.Get(); ok {
+		f.Store = &v
+	}
+	if v, ok := params.BornAfter.Get(); ok {
+		f.BornAfter = &v
+	}
+	if v, ok := params.BornBefore.Get(); ok {
+		f.BornBefore = &v
+	}
+	if v, ok := params.MinPrice.Get(); ok {
+		f.MinPrice = &v
+	}
+	if v, ok := params.MaxPrice.Get(); ok {
+		f.MaxPrice = &v
+	}
+	// ogen checks that near has exactly two items.
+	if len(params.Near) == 2 {
+		f.Near = &store.Location{
+			Latitude: params.Near[0], Longitude: params.Near[1],
+		}
+	}
+	if v, ok := params.RadiusKm.Get(); ok {
+		f.RadiusKm = &v
+	}
+	if v, ok := params.Limit.Get(); ok {
+		f.Limit = &v
+	}
+	return f
+}
+
+// storeToAPI converts a domain Store to an API Store.
+func storeToAPI(s store.Store) api.Store {
+	as := api.Store{ID: s.ID, Name: s.Name, CreatedAt: s.CreatedAt}
+	if s.Location != nil {
+		as.Location = api.NewOptLocation(api.Location{
+			Latitude: s.Location.Latitude, Longitude: s.Location.Longitude,
+		})
+	}
+	return as
+}
+
+// optInt64 converts a nullable int64 to an OptInt64.
+func optInt64(v *int64) api.OptInt64 {
+	if v == nil {
+		return api.OptInt64{}
+	}
+	return api.NewOptInt64(*v)
+}
+
+// optString converts a nullable string to an OptString.
+func optString(s *string) api.OptString {
+	if s == nil {
+		return api.OptString{}
+	}
+	return api.NewOptString(*s)
+}
+
+// stringPtr converts an OptString to a nullable string.
+func stringPtr(o api.OptString) *string {
+	if v, ok := o.Get(); ok {
+		return &v
+	}
+	return nil
+}
+
+// petRevisionToAPI converts a domain pet Revision to an
+// API PetRevision.
+func petRevisionToAPI(r pet.Revision) api.PetRevision {
+	ar := api.PetRevision{
+		ID:        r.ID,
+		PetId:     r.PetID,
+		Action:    api.PetRevisionAction(r.Action),
+		Name:      r.Name,
+		CreatedAt: r.CreatedAt,
+	}
+	if r.Tag != nil {
+		ar.Tag = api.NewOptString(*r.Tag)
+	}
+	if r.Actor.UserID != nil {
+		ar.ActorUserId = api.NewOptInt64(*r.Actor.UserID)
+	}
+	if r.Actor.APIKeyID != nil {
+		ar.ActorApiKeyId = api.NewOptInt64(*r.Actor.APIKeyID)
+	}
+	return ar
+}
+
 // userToAPI converts a domain User to an API AuthUser.
 func userToAPI(u auth.User) api.AuthUser {
 	return api.AuthUser{
 		ID:    u.ID,
 		Name:  u.Name,
 		Email: u.Email,
-		Role:  api.AuthUserRole(u.Role),
+		Role:  u.Role,
+
+		EmailVerified: u.EmailVerifiedAt != nil,
+		MfaEnabled:    u.TOTPEnabledAt != nil,
+		Disabled:      u.DisabledAt != nil,
+		StoreId:       optInt64(u.StoreID),
+	}
+}
+
+// apiKeyToAPI converts a domain APIKey to an API APIKey.
+func apiKeyToAPI(k apikey.APIKey) api.APIKey {
+	ak := api.APIKey{
+		ID:        k.ID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Scopes:    make([]api.APIKeyScope, len(k.Scopes)),
+		CreatedAt: k.CreatedAt,
+		StoreId:   optInt64(k.StoreID),
+	}
+	for i, s := range k.Scopes {
+		ak.Scopes[i] = api.APIKeyScope(s)
+	}
+	if k.ExpiresAt != nil {
+		ak.ExpiresAt = api.NewOptDateTime(*k.ExpiresAt)
+	}
+	if k.LastUsedAt != nil {
+		ak.LastUsedAt = api.NewOptDateTime(*k.LastUsedAt)
+	}
+	if k.RevokedAt != nil {
+		ak.RevokedAt = api.NewOptDateTime(*k.RevokedAt)
+	}
+	return ak
+}
+
+// reservationToAPI converts a domain Reservation to an API
+// Reservation.
+func reservationToAPI(r reservation.Reservation) api.Reservation {
+	ar := api.Reservation{
+		ID:        r.ID,
+		PetId:     r.PetID,
+		Status:    api.ReservationStatus(r.Status),
+		CreatedAt: r.CreatedAt,
+		ExpiresAt: r.ExpiresAt,
+	}
+	if r.CancelledAt != nil {
+		ar.CancelledAt = api.NewOptDateTime(*r.CancelledAt)
+	}
+	return ar
+}
+
+// auditEventToAPI converts a domain audit Event to an API
+// AuditEvent, omitting empty request metadata.
+func auditEventToAPI(e audit.Event) api.AuditEvent {
+	ae := api.AuditEvent{
+		ID:        e.ID,
+		Action:    e.Action,
+		Outcome:   api.AuditOutcome(e.Outcome),
+		CreatedAt: e.CreatedAt,
+	}
+	if e.ActorUserID != nil {
+		ae.ActorUserId = api.NewOptInt64(*e.ActorUserID)
+	}
+	if e.ActorAPIKeyID != nil {
+		ae.ActorApiKeyId = api.NewOptInt64(*e.ActorAPIKeyID)
+	}
+	if e.Target != "" {
+		ae.Target = api.NewOptString(e.Target)
+	}
+	if e.IP != "" {
+		ae.IP = api.NewOptString(e.IP)
+	}
+	if e.UserAgent != "" {
+		ae.UserAgent = api.NewOptString(e.UserAgent)
+	}
+	if e.CorrelationID != "" {
+		ae.CorrelationId = api.NewOptString(e.CorrelationID)
+	}
+	if len(e.Details) > 0 {
+		ae.Details = api.NewOptAuditEventDetails(e.Details)
+	}
+	return ae
+}
+
+// webhookToAPI converts a domain Webhook to an API
+// Webhook. The secret is left out.
+func webhookToAPI(w webhook.Webhook) api.Webhook {
+	aw := api.Webhook{
+		ID:         w.ID,
+		URL:        w.URL,
+		EventTypes: make([]api.WebhookEventType, len(w.EventTypes)),
+		CreatedBy:  w.CreatedBy,
+		CreatedAt:  w.CreatedAt,
+	}
+	for i, t := range w.EventTypes {
+		aw.EventTypes[i] = api.WebhookEventType(t)
+	}
+	return aw
+}
+
+// webhookDeliveryToAPI converts a domain webhook Delivery
+// to an API WebhookDelivery.
+func webhookDeliveryToAPI(d webhook.Delivery) api.WebhookDelivery {
+	ad := api.WebhookDelivery{
+		ID:            d.ID,
+		WebhookId:     d.WebhookID,
+		EventId:       d.EventID,
+		EventType:     api.WebhookEventType(d.EventType),
+		Status:        api.WebhookDeliveryStatus(d.Status),
+		Attempts:      int32(d.Attempts),
+		NextAttemptAt: d.NextAttemptAt,
+		CreatedAt:     d.CreatedAt,
+	}
+	if d.LastAttemptAt != nil {
+		ad.LastAttemptAt = api.NewOptDateTime(*d.LastAttemptAt)
+	}
+	if d.LastStatusCode != nil {
+		ad.LastStatusCode = api.NewOptInt32(int32(*d.LastStatusCode))
+	}
+	if d.LastError != nil {
+		ad.LastError = api.NewOptString(*d.LastError)
+	}
+	if d.DeliveredAt != nil {
+		ad.DeliveredAt = api.NewOptDateTime(*d.DeliveredAt)
+	}
+	return ad
+}
+
+// jwkToAPI converts a domain JWK to an API JWK, omitting
+// members that do not apply to the key type.
+func jwkToAPI(k auth.JWK) api.JWK {
+	aj := api.JWK{
+		Kty: api.JWKKty(k.Kty),
+		Kid: k.Kid,
+		Use: api.JWKUseSig,
+		Alg: api.JWKAlg(k.Alg),
+	}
+	if k.Crv != "" {
+		aj.Crv = api.NewOptString(k.Crv)
+		aj.X = api.NewOptString(k.X)
+	}
+	if k.N != "" {
+		aj.N = api.NewOptString(k.N)
+		aj.E = api.NewOptString(k.E)
+	}
+	return aj
+}
+
+// jobStatusToAPI converts a jobs.Status to its API form.
+func jobStatusToAPI(st jobs.Status) api.JobStatus {
+	aj := api.JobStatus{
+		Name:           st.Name,
+		Schedule:       st.Schedule,
+		TimeoutSeconds: int32(st.Timeout / time.Second),
+		Running:        st.Running,
+		Runs:           st.Runs,
+		Failures:       st.Failures,
+		Skipped:        st.Skipped,
+	}
+	if st.LastStartedAt != nil {
+		aj.LastStartedAt = api.NewOptDateTime(*st.LastStartedAt)
+		aj.LastDurationMs = api.NewOptInt64(st.LastDuration.Milliseconds())
+	}
+	if st.LastError != "" {
+		aj.LastError = api.NewOptString(st.LastError)
+	}
+	if st.LastSucceededAt != nil {
+		aj.LastSucceededAt = api.NewOptDateTime(*st.LastSucceededAt)
+	}
+	if st.NextRunAt != nil {
+		aj.NextRunAt = api.NewOptDateTime(*st.NextRunAt)
 	}
+	return aj
 }
@@ -2,50 +2,109 @@ package handler_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"slices"
 	"testing"
+	"time"
 
+	"github.com/ogen-go/ogen/ogenerrors"
+	"github.com/ogen-go/ogen/validate"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/apikey"
+	"github.com/hhubris/petstore/internal/audit"
 	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/db"
 	"github.com/hhubris/petstore/internal/handler"
 	"github.com/hhubris/petstore/internal/pet"
+	"github.com/hhubris/petstore/internal/reservation"
+	"github.com/hhubris/petstore/internal/store"
+	"github.com/hhubris/petstore/internal/webhook"
 )
 
 // mockPetService implements handler.PetService for testing.
 type mockPetService struct {
-	createPetFn func(ctx context.Context, name string, tag *string) (pet.Pet, error)
-	getPetFn    func(ctx context.Context, id int64) (pet.Pet, error)
-	listPetsFn  func(ctx context.Context, tags []string, limit *int32) ([]pet.Pet, error)
-	deletePetFn func(ctx context.Context, id int64) error
+	createPetFn   func(ctx context.Context, p pet.Pet) (pet.Pet, error)
+	importPetsFn  func(ctx context.Context, pets []pet.Pet) (int, error)
+	exportPetsFn  func(ctx context.Context, f pet.Filter, fn func(pet.Pet) error) error
+	getPetFn      func(ctx context.Context, id int64) (pet.Pet, error)
+	listPetsFn    func(ctx context.Context, f pet.Filter) ([]pet.Pet, error)
+	listSpeciesFn func(ctx context.Context) ([]pet.Species, error)
+	deletePetFn   func(ctx context.Context, id int64) error
+	restorePetFn  func(ctx context.Context, id int64) (pet.Pet, error)
+	petHistoryFn  func(ctx context.Context, id int64) ([]pet.Revision, error)
+}
+
+func (m *mockPetService) CreatePet(ctx context.Context, p pet.Pet) (pet.Pet, error) {
+	return m.createPetFn(ctx, p)
+}
+
+func (m *mockPetService) ImportPets(ctx context.Context, pets []pet.Pet) (int, error) {
+	return m.importPetsFn(ctx, pets)
 }
 
-func (m *mockPetService) CreatePet(ctx context.Context, name string, tag *string) (pet.Pet, error) {
-	return m.createPetFn(ctx, name, tag)
+func (m *mockPetService) ExportPets(ctx context.Context, f pet.Filter, fn func(pet.Pet) error) error {
+	return m.exportPetsFn(ctx, f, fn)
 }
 
 func (m *mockPetService) GetPet(ctx context.Context, id int64) (pet.Pet, error) {
 	return m.getPetFn(ctx, id)
 }
 
-func (m *mockPetService) ListPets(ctx context.Context, tags []string, limit *int32) ([]pet.Pet, error) {
-	return m.listPetsFn(ctx, tags, limit)
+func (m *mockPetService) ListPets(ctx context.Context, f pet.Filter) ([]pet.Pet, error) {
+	return m.listPetsFn(ctx, f)
+}
+
+func (m *mockPetService) ListSpecies(ctx context.Context) ([]pet.Species, error) {
+	return m.listSpeciesFn(ctx)
 }
 
 func (m *mockPetService) DeletePet(ctx context.Context, id int64) error {
 	return m.deletePetFn(ctx, id)
 }
 
+func (m *mockPetService) RestorePet(ctx context.Context, id int64) (pet.Pet, error) {
+	return m.restorePetFn(ctx, id)
+}
+
+func (m *mockPetService) PetHistory(ctx context.Context, id int64) ([]pet.Revision, error) {
+	return m.petHistoryFn(ctx, id)
+}
+
 // mockAuthService implements handler.AuthService for testing.
 type mockAuthService struct {
 	registerFn func(ctx context.Context, name, email, password string) (auth.User, error)
-	loginFn    func(ctx context.Context, email, password string) (string, auth.User, error)
+	loginFn    func(ctx context.Context, email, password string) (auth.LoginResult, error)
 	getUserFn  func(ctx context.Context, id int64) (auth.User, error)
+
+	requestPasswordResetFn func(ctx context.Context, email string) error
+	resetPasswordFn        func(ctx context.Context, token, newPassword string) (int64, error)
+	verifyEmailFn          func(ctx context.Context, token string) (int64, error)
+	resendVerificationFn   func(ctx context.Context, userID int64) error
+	unlockUserFn           func(ctx context.Context, id int64) error
+	updateUserAccessFn     func(ctx context.Context, id int64, c auth.AccessChange) (auth.User, error)
+
+	verifyMFAFn  func(ctx context.Context, mfaToken, code string) (string, auth.User, error)
+	enrollMFAFn  func(ctx context.Context, userID int64) (auth.MFAEnrollment, error)
+	confirmMFAFn func(ctx context.Context, userID int64, code string) ([]string, error)
+	publicKeysFn func() []auth.JWK
+
+	beginOIDCLoginFn    func() (auth.OIDCRedirect, error)
+	completeOIDCLoginFn func(ctx context.Context, code, state, stateToken string) (auth.LoginResult, string, error)
+
+	changePasswordFn     func(ctx context.Context, claims auth.Claims, currentPassword, newPassword string) (string, error)
+	requestEmailChangeFn func(ctx context.Context, userID int64, email, password string) error
+	confirmEmailChangeFn func(ctx context.Context, token string) error
 }
 
 func (m *mockAuthService) Register(ctx context.Context, name, email, password string) (auth.User, error) {
 	return m.registerFn(ctx, name, email, password)
 }
 
-func (m *mockAuthService) Login(ctx context.Context, email, password string) (string, auth.User, error) {
+func (m *mockAuthService) Login(ctx context.Context, email, password string) (auth.LoginResult, error) {
 	return m.loginFn(ctx, email, password)
 }
 
@@ -53,6 +112,172 @@ func (m *mockAuthService) GetUser(ctx context.Context, id int64) (auth.User, err
 	return m.getUserFn(ctx, id)
 }
 
+func (m *mockAuthService) RequestPasswordReset(ctx context.Context, email string) error {
+	return m.requestPasswordResetFn(ctx, email)
+}
+
+func (m *mockAuthService) ResetPassword(ctx context.Context, token, newPassword string) (int64, error) {
+	return m.resetPasswordFn(ctx, token, newPassword)
+}
+
+func (m *mockAuthService) VerifyEmail(ctx context.Context, token string) (int64, error) {
+	return m.verifyEmailFn(ctx, token)
+}
+
+func (m *mockAuthService) ResendVerification(ctx context.Context, userID int64) error {
+	return m.resendVerificationFn(ctx, userID)
+}
+
+func (m *mockAuthService) UnlockUser(ctx context.Context, id int64) error {
+	return m.unlockUserFn(ctx, id)
+}
+
+func (m *mockAuthService) UpdateUserAccess(ctx context.Context, id int64, c auth.AccessChange) (auth.User, error) {
+	return m.updateUserAccessFn(ctx, id, c)
+}
+
+func (m *mockAuthService) VerifyMFA(ctx context.Context, mfaToken, code string) (string, auth.User, error) {
+	return m.verifyMFAFn(ctx, mfaToken, code)
+}
+
+func (m *mockAuthService) EnrollMFA(ctx context.Context, userID int64) (auth.MFAEnrollment, error) {
+	return m.enrollMFAFn(ctx, userID)
+}
+
+func (m *mockAuthService) ConfirmMFA(ctx context.Context, userID int64, code string) ([]string, error) {
+	return m.confirmMFAFn(ctx, userID, code)
+}
+
+func (m *mockAuthService) PublicKeys() []auth.JWK {
+	return m.publicKeysFn()
+}
+
+func (m *mockAuthService) BeginOIDCLogin() (auth.OIDCRedirect, error) {
+	return m.beginOIDCLoginFn()
+}
+
+func (m *mockAuthService) CompleteOIDCLogin(ctx context.Context, code, state, stateToken string) (auth.LoginResult, string, error) {
+	return m.completeOIDCLoginFn(ctx, code, state, stateToken)
+}
+
+func (m *mockAuthService) ChangePassword(ctx context.Context, claims auth.Claims, currentPassword, newPassword string) (string, error) {
+	return m.changePasswordFn(ctx, claims, currentPassword, newPassword)
+}
+
+func (m *mockAuthService) RequestEmailChange(ctx context.Context, userID int64, email, password string) error {
+	return m.requestEmailChangeFn(ctx, userID, email, password)
+}
+
+func (m *mockAuthService) ConfirmEmailChange(ctx context.Context, token string) error {
+	return m.confirmEmailChangeFn(ctx, token)
+}
+
+// mockAPIKeyService implements handler.APIKeyService for
+// testing.
+type mockAPIKeyService struct {
+	createFn func(ctx context.Context, createdBy int64, name string, scopes []string, expiresAt *time.Time, storeID *int64) (apikey.APIKey, string, error)
+	listFn   func(ctx context.Context) ([]apikey.APIKey, error)
+	revokeFn func(ctx context.Context, id int64) error
+}
+
+func (m *mockAPIKeyService) Create(ctx context.Context, createdBy int64, name string, scopes []string, expiresAt *time.Time, storeID *int64) (apikey.APIKey, string, error) {
+	return m.createFn(ctx, createdBy, name, scopes, expiresAt, storeID)
+}
+
+func (m *mockAPIKeyService) List(ctx context.Context) ([]apikey.APIKey, error) {
+	return m.listFn(ctx)
+}
+
+func (m *mockAPIKeyService) Revoke(ctx context.Context, id int64) error {
+	return m.revokeFn(ctx, id)
+}
+
+// mockWebhookService implements handler.WebhookService
+// for testing.
+type mockWebhookService struct {
+	createFn     func(ctx context.Context, createdBy int64, url string, eventTypes []string) (webhook.Webhook, error)
+	listFn       func(ctx context.Context) ([]webhook.Webhook, error)
+	deleteFn     func(ctx context.Context, id int64) error
+	deliveriesFn func(ctx context.Context, webhookID int64, f webhook.DeliveryFilter) ([]webhook.Delivery, error)
+	redeliverFn  func(ctx context.Context, webhookID, deliveryID int64) (webhook.Delivery, error)
+}
+
+func (m *mockWebhookService) Create(ctx context.Context, createdBy int64, url string, eventTypes []string) (webhook.Webhook, error) {
+	return m.createFn(ctx, createdBy, url, eventTypes)
+}
+
+func (m *mockWebhookService) List(ctx context.Context) ([]webhook.Webhook, error) {
+	return m.listFn(ctx)
+}
+
+func (m *mockWebhookService) Delete(ctx context.Context, id int64) error {
+	return m.deleteFn(ctx, id)
+}
+
+func (m *mockWebhookService) Deliveries(ctx context.Context, webhookID int64, f webhook.DeliveryFilter) ([]webhook.Delivery, error) {
+	return m.deliveriesFn(ctx, webhookID, f)
+}
+
+func (m *mockWebhookService) Redeliver(ctx context.Context, webhookID, deliveryID int64) (webhook.Delivery, error) {
+	return m.redeliverFn(ctx, webhookID, deliveryID)
+}
+
+// mockStoreService implements handler.StoreService for
+// testing.
+type mockStoreService struct {
+	createStoreFn func(ctx context.Context, name string, loc *store.Location) (store.Store, error)
+	listStoresFn  func(ctx context.Context) ([]store.Store, error)
+}
+
+func (m *mockStoreService) CreateStore(ctx context.Context, name string, loc *store.Location) (store.Store, error) {
+	return m.createStoreFn(ctx, name, loc)
+}
+
+func (m *mockStoreService) ListStores(ctx context.Context) ([]store.Store, error) {
+	return m.listStoresFn(ctx)
+}
+
+// mockReservationService implements
+// handler.ReservationService for testing.
+type mockReservationService struct {
+	reserveFn func(ctx context.Context, userID, petID int64) (reservation.Reservation, error)
+	listFn    func(ctx context.Context, userID int64) ([]reservation.Reservation, error)
+	cancelFn  func(ctx context.Context, userID, id int64) (reservation.Reservation, error)
+}
+
+func (m *mockReservationService) Reserve(ctx context.Context, userID, petID int64) (reservation.Reservation, error) {
+	return m.reserveFn(ctx, userID, petID)
+}
+
+func (m *mockReservationService) List(ctx context.Context, userID int64) ([]reservation.Reservation, error) {
+	return m.listFn(ctx, userID)
+}
+
+func (m *mockReservationService) Cancel(ctx context.Context, userID, id int64) (reservation.Reservation, error) {
+	return m.cancelFn(ctx, userID, id)
+}
+
+// aliceEmailHash is the email_sha256 audit detail for
+// alice@example.com.
+const aliceEmailHash = "ff8d9819fc0e12bf0d24892e45987e249a28dce836a85cad60e28eaaa8c6d976"
+
+// mockAuditService implements handler.AuditService for
+// testing. Recorded events are kept in order.
+type mockAuditService struct {
+	events []audit.Event
+	listFn func(ctx context.Context, f audit.Filter) ([]audit.Event, error)
+}
+
+func (m *mockAuditService) Record(_ context.Context, e audit.Event) {
+	m.events = append(m.events, e)
+}
+
+func (m *mockAuditService) List(
+	ctx context.Context, f audit.Filter,
+) ([]audit.Event, error) {
+	return m.listFn(ctx, f)
+}
+
 // newHandler is a test helper that constructs a Handler with
 // the given mocks and secure=false.
 func newHandler(
@@ -61,7 +286,65 @@ func newHandler(
 	auths *mockAuthService,
 ) *handler.Handler {
 	t.Helper()
-	return handler.New(pets, auths, false)
+	return handler.New(pets, auths, nil, nil, nil, nil, nil, nil, nil, false)
+}
+
+// newKeyHandler is a test helper that constructs a Handler
+// with only an API key service.
+func newKeyHandler(
+	t *testing.T,
+	keys *mockAPIKeyService,
+) *handler.Handler {
+	t.Helper()
+	return handler.New(nil, nil, keys, nil, nil, nil, nil, nil, nil, false)
+}
+
+// newAuditHandler is a test helper that constructs a
+// Handler that records audit events to events.
+func newAuditHandler(
+	t *testing.T,
+	pets *mockPetService,
+	auths *mockAuthService,
+	events *mockAuditService,
+) *handler.Handler {
+	t.Helper()
+	return handler.New(pets, auths, nil, events, nil, nil, nil, nil, nil, false)
+}
+
+// newWebhookHandler is a test helper that constructs a
+// Handler with a webhook service that records audit events
+// to events.
+func newWebhookHandler(
+	t *testing.T,
+	hooks *mockWebhookService,
+	events *mockAuditService,
+) *handler.Handler {
+	t.Helper()
+	return handler.New(nil, nil, nil, events, hooks, nil, nil, nil, nil, false)
+}
+
+// newStoreHandler is a test helper that constructs a
+// Handler with a store service that records audit events
+// to events.
+func newStoreHandler(
+	t *testing.T,
+	stores *mockStoreService,
+	events *mockAuditService,
+) *handler.Handler {
+	t.Helper()
+	return handler.New(nil, nil, nil, events, nil, stores, nil, nil, nil, false)
+}
+
+// newReservationHandler is a test helper that constructs a
+// Handler with a reservation service that records audit
+// events to events.
+func newReservationHandler(
+	t *testing.T,
+	holds *mockReservationService,
+	events *mockAuditService,
+) *handler.Handler {
+	t.Helper()
+	return handler.New(nil, nil, nil, events, nil, nil, holds, nil, nil, false)
 }
 
 // ctxWithResponseWriter returns a context with an embedded
@@ -71,3 +354,154 @@ func ctxWithResponseWriter(
 ) context.Context {
 	return handler.WithResponseWriter(context.Background(), w)
 }
+
+func TestNewErrorProblem(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantType   string
+		wantDetail string
+	}{
+		{
+			name:       "wrapped sentinel hides wrapping",
+			err:        fmt.Errorf("find pet 7: %w", db.ErrNotFound),
+			wantStatus: http.StatusNotFound,
+			wantType:   "urn:petstore:problem:not-found",
+			wantDetail: "not found",
+		},
+		{
+			name: "missing credentials",
+			err: &ogenerrors.SecurityError{
+				Err: ogenerrors.ErrSecurityRequirementIsNotSatisfied,
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantType:   "urn:petstore:problem:unauthorized",
+			wantDetail: "security requirement is not satisfied",
+		},
+		{
+			name:       "specific type",
+			err:        auth.ErrMFARequired,
+			wantStatus: http.StatusForbidden,
+			wantType:   "urn:petstore:problem:mfa-required",
+			wantDetail: "two-factor authentication required",
+		},
+		{
+			name:       "another store",
+			err:        store.ErrOtherStore,
+			wantStatus: http.StatusForbidden,
+			wantType:   "urn:petstore:problem:other-store",
+			wantDetail: "forbidden: outside the account's store",
+		},
+		{
+			name:       "internal error text withheld",
+			err:        errors.New("find pets: connection refused"),
+			wantStatus: http.StatusInternalServerError,
+			wantType:   "urn:petstore:problem:internal",
+			wantDetail: "internal server error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHandler(t, &mockPetService{}, &mockAuthService{})
+			got := h.NewError(context.Background(), tt.err)
+			if got.StatusCode != tt.wantStatus ||
+				got.Response.Status != int32(tt.wantStatus) {
+				t.Errorf("status = %d/%d, want %d",
+					got.StatusCode, got.Response.Status, tt.wantStatus)
+			}
+			if got.Response.Type != tt.wantType {
+				t.Errorf("type = %q, want %q",
+					got.Response.Type, tt.wantType)
+			}
+			if got.Response.Detail != tt.wantDetail {
+				t.Errorf("detail = %q, want %q",
+					got.Response.Detail, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestErrorHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantType   string
+		wantFields []string
+	}{
+		{
+			name: "body validation",
+			err: &ogenerrors.DecodeRequestError{Err: &validate.Error{
+				Fields: []validate.FieldError{
+					{Name: "name", Error: validate.ErrFieldRequired},
+					{Name: "scopes", Error: &validate.Error{
+						Fields: []validate.FieldError{
+							{Name: "[1]", Error: errors.New("invalid value")},
+						},
+					}},
+				},
+			}},
+			wantStatus: http.StatusBadRequest,
+			wantType:   "urn:petstore:problem:validation",
+			wantFields: []string{"name", "scopes[1]"},
+		},
+		{
+			name: "parameter",
+			err: &ogenerrors.DecodeParamsError{
+				Err: &ogenerrors.DecodeParamError{
+					Name: "limit", In: "query",
+					Err: errors.New("int: invalid syntax"),
+				},
+			},
+			wantStatus: http.StatusBadRequest,
+			wantType:   "urn:petstore:problem:validation",
+			wantFields: []string{"query.limit"},
+		},
+		{
+			name: "content type",
+			err: &ogenerrors.DecodeRequestError{
+				Err: validate.InvalidContentType("text/plain"),
+			},
+			wantStatus: http.StatusUnsupportedMediaType,
+			wantType:   "urn:petstore:problem:unsupported-media-type",
+		},
+		{
+			name:       "internal",
+			err:        errors.New("encode response: broken pipe"),
+			wantStatus: http.StatusInternalServerError,
+			wantType:   "urn:petstore:problem:internal",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/pets", nil)
+			handler.ErrorHandler(req.Context(), rec, req, tt.err)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			var p api.Problem
+			if err := p.UnmarshalJSON(rec.Body.Bytes()); err != nil {
+				t.Fatal(err)
+			}
+			if p.Type != tt.wantType {
+				t.Errorf("type = %q, want %q", p.Type, tt.wantType)
+			}
+			if tt.wantStatus == http.StatusInternalServerError &&
+				p.Detail != "internal server error" {
+				t.Errorf("detail = %q leaks the error", p.Detail)
+			}
+			var fields []string
+			for _, f := range p.Errors {
+				fields = append(fields, f.Field)
+			}
+			if !slices.Equal(fields, tt.wantFields) {
+				t.Errorf("fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
@@ -0,0 +1,418 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-faster/jx"
+	"github.com/ogen-go/ogen/validate"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/pet"
+	"github.com/hhubris/petstore/internal/store"
+)
+
+// maxImportLineBytes bounds one NDJSON line of an import.
+const maxImportLineBytes = 64 << 10
+
+// csvPetColumns are the CSV columns of a pet, in the order
+// exports write them.
+var csvPetColumns = []string{
+	"name", "tag", "species", "breed", "birth_date", "sex",
+	"description", "price_amount", "price_currency", "store_id",
+}
+
+// petRuleFields names the NewPet field each pet rule
+// concerns.
+var petRuleFields = map[error]string{
+	pet.ErrBreedWithoutSpecies: "breed",
+	pet.ErrFutureBirthDate:     "birthDate",
+}
+
+var (
+	errInvalidCSVHeader = errors.New(
+		"csv header must name a name column and only known columns")
+	errImportLineTooLong = errors.New("import line exceeds 64 KiB")
+)
+
+// importRow is one row read from an import: a pet and the
+// line it starts on, or the reasons the row is invalid.
+type importRow struct {
+	line int32
+	pet  pet.Pet
+	errs []api.ImportRowError
+}
+
+// ImportPets handles POST /pets/import.
+func (h *Handler) ImportPets(
+	ctx context.Context, req api.ImportPetsReq, params api.ImportPetsParams,
+) (api.ImportPetsRes, error) {
+	var (
+		rows []importRow
+		err  error
+	)
+	switch req := req.(type) {
+	case *api.ImportPetsReqTextCsv:
+		rows, err = readCSVImport(req.Data)
+	case *api.ImportPetsReqApplicationXNdjson:
+		rows, err = readNDJSONImport(req.Data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := h.checkImportBreeds(ctx, rows); err != nil {
+		return nil, err
+	}
+	if v, ok := params.StoreId.Get(); ok {
+		if _, err := store.Resolve(auth.StoreScope(ctx), v); err != nil {
+			return nil, err
+		}
+		for i := range rows {
+			if rows[i].pet.StoreID == 0 {
+				rows[i].pet.StoreID = v
+			}
+		}
+	}
+	if err := h.checkImportStores(ctx, rows); err != nil {
+		return nil, err
+	}
+
+	report := api.ImportReport{
+		Mode:   params.Mode.Or(api.ImportModeAtomic),
+		DryRun: params.DryRun.Or(false),
+		Rows:   int32(len(rows)),
+		Errors: []api.ImportRowError{},
+	}
+	var pets []pet.Pet
+	for _, r := range rows {
+		if len(r.errs) > 0 {
+			report.Failed++
+			report.Errors = append(report.Errors, r.errs...)
+			continue
+		}
+		pets = append(pets, r.pet)
+	}
+	if report.Failed > 0 && report.Mode == api.ImportModeAtomic {
+		res := api.ImportPetsApplicationJSONUnprocessableEntity(report)
+		return &res, nil
+	}
+	if report.DryRun {
+		report.Imported = int32(len(pets))
+		res := api.ImportPetsOK(report)
+		return &res, nil
+	}
+
+	n, err := h.pets.ImportPets(ctx, pets)
+	h.record(ctx, audit.Event{
+		Action: audit.ActionPetImport,
+		Details: map[string]string{
+			"mode":     string(report.Mode),
+			"imported": strconv.Itoa(n),
+			"failed":   strconv.Itoa(int(report.Failed)),
+		},
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	report.Imported = int32(n)
+	res := api.ImportPetsOK(report)
+	return &res, nil
+}
+
+// readCSVImport reads pets from CSV with a header row. The
+// name column is required and the others in csvPetColumns
+// are optional; an id column is ignored. An empty cell
+// counts as an absent value.
+func readCSVImport(r io.Reader) ([]importRow, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		var perr *csv.ParseError
+		if errors.Is(err, io.EOF) || errors.As(err, &perr) {
+			return nil, errInvalidCSVHeader
+		}
+		return nil, err
+	}
+	cols := make(map[string]int)
+	for i, col := range header {
+		if i == 0 {
+			col = strings.TrimPrefix(col, "\ufeff")
+		}
+		col = strings.ToLower(strings.TrimSpace(col))
+		if _, dup := cols[col]; dup ||
+			(col != "id" && !slices.Contains(csvPetColumns, col)) {
+			return nil, errInvalidCSVHeader
+		}
+		cols[col] = i
+	}
+	if _, ok := cols["name"]; !ok {
+		return nil, errInvalidCSVHeader
+	}
+
+	var rows []importRow
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if len(rows) == pet.MaxImportRows {
+			return nil, pet.ErrImportTooLarge
+		}
+		var perr *csv.ParseError
+		if errors.As(err, &perr) {
+			rows = append(rows, importRow{errs: []api.ImportRowError{{
+				Line: int32(perr.StartLine), Message: perr.Err.Error(),
+			}}})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := cr.FieldPos(0)
+		np, errs := csvNewPet(int32(line), rec, cols)
+		if len(errs) > 0 {
+			rows = append(rows, importRow{errs: errs})
+			continue
+		}
+		rows = append(rows, newImportRow(int32(line), np))
+	}
+}
+
+// csvNewPet builds a NewPet from a CSV record, reporting
+// cells that do not parse under their NewPet field names.
+func csvNewPet(
+	line int32, rec []string, cols map[string]int,
+) (api.NewPet, []api.ImportRowError) {
+	cell := func(col string) (string, bool) {
+		i, ok := cols[col]
+		if !ok || rec[i] == "" {
+			return "", false
+		}
+		return rec[i], true
+	}
+	opt := func(col string) api.OptString {
+		if v, ok := cell(col); ok {
+			return api.NewOptString(v)
+		}
+		return api.OptString{}
+	}
+
+	var errs []api.ImportRowError
+	fail := func(field, msg string) {
+		errs = append(errs, api.ImportRowError{
+			Line: line, Field: api.NewOptString(field), Message: msg,
+		})
+	}
+	np := api.NewPet{
+		Tag:         opt("tag"),
+		Species:     opt("species"),
+		Breed:       opt("breed"),
+		Description: opt("description"),
+	}
+	if v, ok := cell("name"); ok {
+		np.Name = v
+	} else {
+		fail("name", validate.ErrFieldRequired.Error())
+	}
+	if v, ok := cell("birth_date"); ok {
+		d, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			fail("birthDate", "must be a date like 2024-03-01")
+		}
+		np.BirthDate = api.NewOptDate(d)
+	}
+	if v, ok := cell("sex"); ok {
+		np.Sex = api.NewOptPetSex(api.PetSex(v))
+	}
+	amount, hasAmount := cell("price_amount")
+	currency, hasCurrency := cell("price_currency")
+	switch {
+	case hasAmount && hasCurrency:
+		n, err := strconv.ParseInt(amount, 10, 64)
+		if err != nil {
+			fail("price.amount", "must be a whole number of minor units")
+		}
+		np.Price = api.NewOptMoney(api.Money{Amount: n, Currency: currency})
+	case hasAmount || hasCurrency:
+		fail("price", "price_amount and price_currency must be given together")
+	}
+	if v, ok := cell("store_id"); ok {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			fail("storeId", "must be a store ID")
+		}
+		np.StoreId = api.NewOptInt64(n)
+	}
+	return np, errs
+}
+
+// readNDJSONImport reads pets from NDJSON, one NewPet object
+// per line, decoded exactly as addPet decodes its body.
+// Blank lines are skipped.
+func readNDJSONImport(r io.Reader) ([]importRow, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, maxImportLineBytes)
+	var (
+		rows []importRow
+		line int32
+	)
+	for sc.Scan() {
+		line++
+		b := bytes.TrimSpace(sc.Bytes())
+		if len(b) == 0 {
+			continue
+		}
+		if len(rows) == pet.MaxImportRows {
+			return nil, pet.ErrImportTooLarge
+		}
+
+		var np api.NewPet
+		d := jx.DecodeBytes(b)
+		err := np.Decode(d)
+		if err == nil && d.Next() != jx.Invalid {
+			err = errors.New("unexpected data after the object")
+		}
+		if err != nil {
+			rows = append(rows, importRow{errs: importRowErrors(line, err)})
+			continue
+		}
+		rows = append(rows, newImportRow(line, np))
+	}
+	if err := sc.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, errImportLineTooLong
+		}
+		return nil, err
+	}
+	return rows, nil
+}
+
+// newImportRow checks np, read from line, with the rules
+// addPet applies before it reaches the database.
+func newImportRow(line int32, np api.NewPet) importRow {
+	if err := np.Validate(); err != nil {
+		return importRow{errs: importRowErrors(line, err)}
+	}
+	p := newPetFromAPI(np)
+	if err := p.Validate(); err != nil {
+		return importRow{errs: []api.ImportRowError{{
+			Line:    line,
+			Field:   api.NewOptString(petRuleFields[err]),
+			Message: err.Error(),
+		}}}
+	}
+	return importRow{line: line, pet: p}
+}
+
+// checkImportBreeds fails each valid row whose species or
+// breed is not in the reference tables, so that one such
+// row is reported rather than aborting the import.
+func (h *Handler) checkImportBreeds(ctx context.Context, rows []importRow) error {
+	if !slices.ContainsFunc(rows, func(r importRow) bool {
+		return r.pet.Species != nil
+	}) {
+		return nil
+	}
+	species, err := h.pets.ListSpecies(ctx)
+	if err != nil {
+		return err
+	}
+	breeds := make(map[string]map[string]bool, len(species))
+	for _, s := range species {
+		breeds[s.Code] = make(map[string]bool, len(s.Breeds))
+		for _, b := range s.Breeds {
+			breeds[s.Code][b.Code] = true
+		}
+	}
+
+	for i := range rows {
+		r := &rows[i]
+		if len(r.errs) > 0 || r.pet.Species == nil {
+			continue
+		}
+		field := ""
+		switch known, ok := breeds[*r.pet.Species]; {
+		case !ok:
+			field = "species"
+		case r.pet.Breed != nil && !known[*r.pet.Breed]:
+			field = "breed"
+		default:
+			continue
+		}
+		r.errs = []api.ImportRowError{{
+			Line:    r.line,
+			Field:   api.NewOptString(field),
+			Message: pet.ErrUnknownBreed.Error(),
+		}}
+	}
+	return nil
+}
+
+// checkImportStores marks rows naming a store that does
+// not exist, or one outside the caller's store, as invalid,
+// so that a partial import skips them instead of failing.
+// Rows naming none are left to the service.
+func (h *Handler) checkImportStores(ctx context.Context, rows []importRow) error {
+	if !slices.ContainsFunc(rows, func(r importRow) bool {
+		return r.pet.StoreID != 0
+	}) {
+		return nil
+	}
+	stores, err := h.stores.ListStores(ctx)
+	if err != nil {
+		return err
+	}
+	known := make(map[int64]bool, len(stores))
+	for _, s := range stores {
+		known[s.ID] = true
+	}
+
+	scope := auth.StoreScope(ctx)
+	for i := range rows {
+		r := &rows[i]
+		if len(r.errs) > 0 || r.pet.StoreID == 0 {
+			continue
+		}
+		_, err := store.Resolve(scope, r.pet.StoreID)
+		if err == nil && !known[r.pet.StoreID] {
+			err = store.ErrUnknownStore
+		}
+		if err != nil {
+			r.errs = []api.ImportRowError{{
+				Line:    r.line,
+				Field:   api.NewOptString("storeId"),
+				Message: err.Error(),
+			}}
+		}
+	}
+	return nil
+}
+
+// importRowErrors reports err, raised decoding the row on
+// line, once per invalid field when it names any.
+func importRowErrors(line int32, err error) []api.ImportRowError {
+	fields := problemFields("", err)
+	if len(fields) == 0 {
+		return []api.ImportRowError{{Line: line, Message: err.Error()}}
+	}
+	errs := make([]api.ImportRowError, len(fields))
+	for i, f := range fields {
+		errs[i] = api.ImportRowError{
+			Line:    line,
+			Field:   api.NewOptString(f.Field),
+			Message: f.Message,
+		}
+	}
+	return errs
+}
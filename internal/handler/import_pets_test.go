@@ -0,0 +1,321 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/handler"
+	"github.com/hhubris/petstore/internal/pet"
+	"github.com/hhubris/petstore/internal/store"
+)
+
+func csvImport(body string) api.ImportPetsReq {
+	return &api.ImportPetsReqTextCsv{Data: strings.NewReader(body)}
+}
+
+func ndjsonImport(body string) api.ImportPetsReq {
+	return &api.ImportPetsReqApplicationXNdjson{Data: strings.NewReader(body)}
+}
+
+func TestImportPets(t *testing.T) {
+	partial := api.ImportPetsParams{
+		Mode: api.NewOptImportMode(api.ImportModePartial),
+	}
+	tests := []struct {
+		name     string
+		req      api.ImportPetsReq
+		params   api.ImportPetsParams
+		wantPets []string
+		want     api.ImportReport
+		// wantRejected expects the 422 response.
+		wantRejected bool
+		wantSlug     string
+	}{
+		{
+			name:     "csv",
+			req:      csvImport("name,tag\nFido,dog\n\"Luna, Jr.\",\n"),
+			wantPets: []string{"Fido/dog", "Luna, Jr./"},
+			want:     api.ImportReport{Rows: 2, Imported: 2},
+		},
+		{
+			name:     "csv columns in any order, BOM",
+			req:      csvImport("\ufeffTag,Name\r\ncat,Tom\r\n"),
+			wantPets: []string{"Tom/cat"},
+			want:     api.ImportReport{Rows: 1, Imported: 1},
+		},
+		{
+			name: "csv profile columns",
+			req: csvImport("id,name,tag,species,breed,birth_date,sex,description,price_amount,price_currency\n" +
+				"7,Rex,,dog,beagle,2024-03-01,male,Friendly,12500,EUR\n" +
+				"8,Tom,,cat,,,,,,\n"),
+			wantPets: []string{"Rex/", "Tom/"},
+			want:     api.ImportReport{Rows: 2, Imported: 2},
+		},
+		{
+			name: "invalid profiles",
+			req: csvImport("name,species,breed,birth_date,sex,price_amount,price_currency\n" +
+				"A,,beagle,,,,\n" +
+				"B,dog,,2024-13-01,,,\n" +
+				"C,dog,,,other,,\n" +
+				"D,dog,,,,100,\n" +
+				"E,dog,,,,100,eur\n" +
+				"F,dragon,,,,,\n" +
+				"G,cat,beagle,,,,\n" +
+				"H,dog,,2999-01-01,,,\n"),
+			params: partial,
+			want: api.ImportReport{Rows: 8, Failed: 8, Errors: []api.ImportRowError{
+				{Line: 2, Field: api.NewOptString("breed"), Message: pet.ErrBreedWithoutSpecies.Error()},
+				{Line: 3, Field: api.NewOptString("birthDate"), Message: "must be a date like 2024-03-01"},
+				{Line: 4, Field: api.NewOptString("sex"), Message: "invalid value: other"},
+				{Line: 5, Field: api.NewOptString("price"), Message: "price_amount and price_currency must be given together"},
+				{Line: 6, Field: api.NewOptString("price.currency"), Message: "string: no regex match: ^[A-Z]{3}$"},
+				{Line: 7, Field: api.NewOptString("species"), Message: pet.ErrUnknownBreed.Error()},
+				{Line: 8, Field: api.NewOptString("breed"), Message: pet.ErrUnknownBreed.Error()},
+				{Line: 9, Field: api.NewOptString("birthDate"), Message: pet.ErrFutureBirthDate.Error()},
+			}},
+		},
+		{
+			name:     "ndjson",
+			req:      ndjsonImport("{\"name\":\"Fido\",\"tag\":\"dog\"}\n\n{\"name\":\"Luna\"}\n"),
+			wantPets: []string{"Fido/dog", "Luna/"},
+			want:     api.ImportReport{Rows: 2, Imported: 2},
+		},
+		{
+			name: "atomic with invalid rows",
+			req:  csvImport("name,tag\nFido,dog\n,cat\nRex\n"),
+			want: api.ImportReport{Rows: 3, Failed: 2, Errors: []api.ImportRowError{
+				{Line: 3, Field: api.NewOptString("name"), Message: "field required"},
+				{Line: 4, Message: "wrong number of fields"},
+			}},
+			wantRejected: true,
+		},
+		{
+			name:     "partial with invalid rows",
+			req:      ndjsonImport("{\"name\":\"Fido\"}\n{\"tag\":\"cat\"}\n{\"name\":\"Luna\"} {}\n"),
+			params:   partial,
+			wantPets: []string{"Fido/"},
+			want: api.ImportReport{Rows: 3, Imported: 1, Failed: 2, Errors: []api.ImportRowError{
+				{Line: 2, Field: api.NewOptString("name"), Message: "field required"},
+				{Line: 3, Message: "unexpected data after the object"},
+			}},
+		},
+		{
+			name:   "dry run",
+			req:    csvImport("name\nFido\nLuna\n"),
+			params: api.ImportPetsParams{DryRun: api.NewOptBool(true)},
+			want:   api.ImportReport{DryRun: true, Rows: 2, Imported: 2},
+		},
+		{
+			name:     "csv without a name column",
+			req:      csvImport("tag\ndog\n"),
+			wantSlug: "invalid-import",
+		},
+		{
+			name:     "csv with an unknown column",
+			req:      csvImport("name,color\nFido,brown\n"),
+			wantSlug: "invalid-import",
+		},
+		{
+			name:     "too many rows",
+			req:      csvImport("name\n" + strings.Repeat("Fido\n", pet.MaxImportRows+1)),
+			wantSlug: "import-too-large",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			pets := &mockPetService{
+				importPetsFn: func(_ context.Context, pets []pet.Pet) (int, error) {
+					for _, p := range pets {
+						tag := ""
+						if p.Tag != nil {
+							tag = *p.Tag
+						}
+						got = append(got, p.Name+"/"+tag)
+					}
+					return len(pets), nil
+				},
+				listSpeciesFn: func(context.Context) ([]pet.Species, error) {
+					return []pet.Species{
+						{Code: "cat", Name: "Cat", Breeds: []pet.Breed{}},
+						{Code: "dog", Name: "Dog", Breeds: []pet.Breed{{Code: "beagle", Name: "Beagle"}}},
+					}, nil
+				},
+			}
+			h := newHandler(t, pets, nil)
+			res, err := h.ImportPets(context.Background(), tt.req, tt.params)
+			if tt.wantSlug != "" {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				p := h.NewError(context.Background(), err).Response
+				if p.Type != "urn:petstore:problem:"+tt.wantSlug {
+					t.Fatalf("got problem type %q, want %s", p.Type, tt.wantSlug)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var report api.ImportReport
+			switch res := res.(type) {
+			case *api.ImportPetsOK:
+				if tt.wantRejected {
+					t.Fatal("got 200, want 422")
+				}
+				report = api.ImportReport(*res)
+			case *api.ImportPetsApplicationJSONUnprocessableEntity:
+				if !tt.wantRejected {
+					t.Fatal("got 422, want 200")
+				}
+				report = api.ImportReport(*res)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.wantPets, "|") {
+				t.Errorf("imported %q, want %q", got, tt.wantPets)
+			}
+			if report.Rows != tt.want.Rows || report.Imported != tt.want.Imported ||
+				report.Failed != tt.want.Failed || report.DryRun != tt.want.DryRun {
+				t.Errorf("got report %+v", report)
+			}
+			if len(report.Errors) != len(tt.want.Errors) {
+				t.Fatalf("got errors %+v, want %+v", report.Errors, tt.want.Errors)
+			}
+			for i, e := range report.Errors {
+				if e != tt.want.Errors[i] {
+					t.Errorf("error %d = %+v, want %+v", i, e, tt.want.Errors[i])
+				}
+			}
+		})
+	}
+}
+
+func TestImportPetsServiceError(t *testing.T) {
+	pets := &mockPetService{
+		importPetsFn: func(context.Context, []pet.Pet) (int, error) {
+			return 0, errors.New("connection reset")
+		},
+	}
+	h := newHandler(t, pets, nil)
+	_, err := h.ImportPets(context.Background(), csvImport("name\nFido\n"),
+		api.ImportPetsParams{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestImportPetsStores(t *testing.T) {
+	var got []int64
+	pets := &mockPetService{
+		importPetsFn: func(_ context.Context, pets []pet.Pet) (int, error) {
+			got = nil
+			for _, p := range pets {
+				got = append(got, p.StoreID)
+			}
+			return len(pets), nil
+		},
+	}
+	stores := &mockStoreService{
+		listStoresFn: func(context.Context) ([]store.Store, error) {
+			return []store.Store{{ID: 1}, {ID: 2}, {ID: 3}}, nil
+		},
+	}
+	h := handler.New(pets, nil, nil, nil, nil, stores, nil, nil, nil, false)
+	partial := api.ImportPetsParams{
+		Mode:    api.NewOptImportMode(api.ImportModePartial),
+		StoreId: api.NewOptInt64(2),
+	}
+	own := int64(3)
+	confined := auth.ContextWithClaims(context.Background(),
+		auth.Claims{UserID: 4, Role: "admin", StoreID: &own})
+	storeErr := func(line int32, msg string) api.ImportRowError {
+		return api.ImportRowError{
+			Line: line, Field: api.NewOptString("storeId"), Message: msg,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		body       string
+		params     api.ImportPetsParams
+		wantStores []int64
+		wantErrors []api.ImportRowError
+		wantErr    error
+	}{
+		{
+			// Rows that name a store keep it; the others go to
+			// the storeId parameter.
+			name:       "global",
+			ctx:        context.Background(),
+			body:       "name,store_id\nFido,3\nLuna,\nRex,main\nTom,9\n",
+			params:     partial,
+			wantStores: []int64{3, 2},
+			wantErrors: []api.ImportRowError{
+				storeErr(4, "must be a store ID"),
+				storeErr(5, store.ErrUnknownStore.Error()),
+			},
+		},
+		{
+			name: "global without storeId",
+			ctx:  context.Background(),
+			body: "name\nFido\n",
+			params: api.ImportPetsParams{
+				Mode: api.NewOptImportMode(api.ImportModePartial),
+			},
+			wantStores: []int64{0},
+		},
+		{
+			// A confined caller's rows naming another store are
+			// skipped, not the whole import.
+			name:       "confined",
+			ctx:        confined,
+			body:       "name,store_id\nFido,3\nLuna,1\nRex,\n",
+			params:     api.ImportPetsParams{Mode: api.NewOptImportMode(api.ImportModePartial)},
+			wantStores: []int64{3, 0},
+			wantErrors: []api.ImportRowError{
+				storeErr(3, store.ErrOtherStore.Error()),
+			},
+		},
+		{
+			name:    "confined storeId for another store",
+			ctx:     confined,
+			body:    "name\nFido\n",
+			params:  partial,
+			wantErr: store.ErrOtherStore,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = nil
+			res, err := h.ImportPets(tt.ctx, csvImport(tt.body), tt.params)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			ok, isOK := res.(*api.ImportPetsOK)
+			if !isOK {
+				t.Fatalf("got %T, want *api.ImportPetsOK", res)
+			}
+			if !slices.Equal(got, tt.wantStores) {
+				t.Errorf("imported into stores %v, want %v", got, tt.wantStores)
+			}
+			if int(ok.Failed) != len(tt.wantErrors) ||
+				!slices.Equal(ok.Errors, tt.wantErrors) {
+				t.Errorf("got report %+v, want errors %+v", *ok, tt.wantErrors)
+			}
+		})
+	}
+}
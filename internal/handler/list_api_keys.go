@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+)
+
+// ListAPIKeys handles GET /admin/api-keys.
+func (h *Handler) ListAPIKeys(
+	ctx context.Context,
+) ([]api.APIKey, error) {
+	keys, err := h.keys.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]api.APIKey, len(keys))
+	for i, k := range keys {
+		out[i] = apiKeyToAPI(k)
+	}
+	return out, nil
+}
@@ -0,0 +1,32 @@
+package handler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hhubris/petstore/internal/apikey"
+)
+
+func TestListAPIKeys(t *testing.T) {
+	revoked := time.Now()
+	h := newKeyHandler(t, &mockAPIKeyService{
+		listFn: func(context.Context) ([]apikey.APIKey, error) {
+			return []apikey.APIKey{
+				{ID: 2, Name: "b", Scopes: []string{"pets:write"}, RevokedAt: &revoked},
+				{ID: 1, Name: "a", Scopes: []string{"pets:write"}},
+			}, nil
+		},
+	})
+
+	got, err := h.ListAPIKeys(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d keys, want 2", len(got))
+	}
+	if !got[0].RevokedAt.IsSet() || got[1].RevokedAt.IsSet() {
+		t.Errorf("revokedAt not mapped: %+v", got)
+	}
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+)
+
+// ListAuditEvents handles GET /admin/audit-events.
+func (h *Handler) ListAuditEvents(
+	ctx context.Context, params api.ListAuditEventsParams,
+) ([]api.AuditEvent, error) {
+	f := audit.Filter{
+		Action:  params.Action.Or(""),
+		Outcome: audit.Outcome(params.Outcome.Or("")),
+		Limit:   int(params.Limit.Or(0)),
+	}
+	if v, ok := params.ActorId.Get(); ok {
+		f.ActorUserID = &v
+	}
+	if v, ok := params.Since.Get(); ok {
+		f.Since = &v
+	}
+	if v, ok := params.Until.Get(); ok {
+		f.Until = &v
+	}
+	if v, ok := params.Before.Get(); ok {
+		f.Before = &v
+	}
+
+	events, err := h.events.List(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]api.AuditEvent, len(events))
+	for i, e := range events {
+		out[i] = auditEventToAPI(e)
+	}
+	return out, nil
+}
@@ -0,0 +1,64 @@
+package handler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+)
+
+func TestListAuditEvents(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	actor := int64(3)
+	var got audit.Filter
+	events := &mockAuditService{
+		listFn: func(_ context.Context, f audit.Filter) ([]audit.Event, error) {
+			got = f
+			return []audit.Event{
+				{
+					ID: 2, Action: audit.ActionLogin,
+					Outcome: audit.OutcomeSuccess, ActorUserID: &actor,
+					IP: "203.0.113.7", CorrelationID: "cid",
+				},
+				{
+					ID: 1, Action: audit.ActionLogin,
+					Outcome: audit.OutcomeFailure,
+					Details: map[string]string{"email": "a@example.com"},
+				},
+			}, nil
+		},
+	}
+	h := newAuditHandler(t, nil, nil, events)
+
+	out, err := h.ListAuditEvents(context.Background(),
+		api.ListAuditEventsParams{
+			Action:  api.NewOptString(audit.ActionLogin),
+			ActorId: api.NewOptInt64(actor),
+			Outcome: api.NewOptAuditOutcome(api.AuditOutcomeFailure),
+			Since:   api.NewOptDateTime(since),
+			Limit:   api.NewOptInt32(10),
+		})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.Action != audit.ActionLogin || got.Outcome != audit.OutcomeFailure ||
+		got.ActorUserID == nil || *got.ActorUserID != actor ||
+		got.Since == nil || !got.Since.Equal(since) ||
+		got.Until != nil || got.Before != nil || got.Limit != 10 {
+		t.Errorf("filter = %+v", got)
+	}
+	if len(out) != 2 {
+		t.Fatalf("got %d events, want 2", len(out))
+	}
+	if out[0].ActorUserId.Or(0) != actor || out[0].IP.Or("") != "203.0.113.7" ||
+		out[0].UserAgent.IsSet() || out[0].Details.IsSet() {
+		t.Errorf("event 0 = %+v", out[0])
+	}
+	if out[1].ActorUserId.IsSet() ||
+		out[1].Details.Value["email"] != "a@example.com" {
+		t.Errorf("event 1 = %+v", out[1])
+	}
+}
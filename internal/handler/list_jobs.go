@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+)
+
+// ListJobs handles GET /admin/jobs.
+func (h *Handler) ListJobs(
+	ctx context.Context,
+) (*api.JobSchedulerStatus, error) {
+	statuses := h.scheduler.Status()
+	out := &api.JobSchedulerStatus{
+		Leader: h.scheduler.Leader(),
+		Jobs:   make([]api.JobStatus, len(statuses)),
+	}
+	for i, st := range statuses {
+		out.Jobs[i] = jobStatusToAPI(st)
+	}
+	return out, nil
+}
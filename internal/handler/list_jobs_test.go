@@ -0,0 +1,59 @@
+package handler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hhubris/petstore/internal/handler"
+	"github.com/hhubris/petstore/internal/jobs"
+)
+
+// mockJobScheduler is a hand-written mock of
+// handler.JobScheduler.
+type mockJobScheduler struct {
+	leader   bool
+	statuses []jobs.Status
+}
+
+func (m *mockJobScheduler) Leader() bool          { return m.leader }
+func (m *mockJobScheduler) Status() []jobs.Status { return m.statuses }
+
+func TestListJobs(t *testing.T) {
+	started := time.Now().Add(-time.Minute)
+	next := time.Now().Add(time.Hour)
+	sched := &mockJobScheduler{
+		leader: true,
+		statuses: []jobs.Status{
+			{
+				Name: "purge", Schedule: "0 * * * *", Timeout: time.Minute,
+				Runs: 3, Failures: 1, LastStartedAt: &started,
+				LastDuration: 1500 * time.Millisecond, LastError: "db down",
+				NextRunAt: &next,
+			},
+			{Name: "idle", Schedule: "@daily", Timeout: 5 * time.Minute},
+		},
+	}
+
+	h := handler.New(nil, nil, nil, nil, nil, nil, nil, sched, nil, false)
+	got, err := h.ListJobs(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.Leader || len(got.Jobs) != 2 {
+		t.Fatalf("got %+v", got)
+	}
+
+	j := got.Jobs[0]
+	if j.Name != "purge" || j.TimeoutSeconds != 60 || j.Runs != 3 ||
+		j.Failures != 1 || j.LastDurationMs.Value != 1500 ||
+		j.LastError.Value != "db down" || !j.NextRunAt.Value.Equal(next) ||
+		j.LastSucceededAt.Set {
+		t.Errorf("got %+v", j)
+	}
+	idle := got.Jobs[1]
+	if idle.LastStartedAt.Set || idle.LastDurationMs.Set ||
+		idle.LastError.Set || idle.NextRunAt.Set {
+		t.Errorf("got %+v, want no run fields", idle)
+	}
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+// ListMyReservations handles GET /auth/me/reservations.
+func (h *Handler) ListMyReservations(
+	ctx context.Context,
+) ([]api.Reservation, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, auth.ErrUnauthorized
+	}
+	holds, err := h.holds.List(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]api.Reservation, len(holds))
+	for i, r := range holds {
+		out[i] = reservationToAPI(r)
+	}
+	return out, nil
+}
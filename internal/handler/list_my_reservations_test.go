@@ -0,0 +1,42 @@
+package handler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/reservation"
+)
+
+func TestListMyReservations(t *testing.T) {
+	now := time.Now()
+	h := newReservationHandler(t, &mockReservationService{
+		listFn: func(_ context.Context, userID int64) ([]reservation.Reservation, error) {
+			if userID != 7 {
+				t.Errorf("got user %d, want 7", userID)
+			}
+			return []reservation.Reservation{
+				{ID: 12, PetID: 4, Status: reservation.StatusActive, ExpiresAt: now},
+				{ID: 11, PetID: 3, Status: reservation.StatusCancelled, CancelledAt: &now},
+			}, nil
+		},
+	}, nil)
+
+	ctx := auth.ContextWithClaims(context.Background(),
+		auth.Claims{UserID: 7, Role: "customer"})
+	got, err := h.ListMyReservations(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != 12 || got[0].CancelledAt.Set ||
+		got[1].Status != api.ReservationStatusCancelled ||
+		!got[1].CancelledAt.Value.Equal(now) {
+		t.Errorf("got %+v", got)
+	}
+
+	if _, err := h.ListMyReservations(context.Background()); err == nil {
+		t.Error("expected error without claims")
+	}
+}
@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+)
+
+// ListPetRevisions handles GET /admin/pets/{id}/history.
+func (h *Handler) ListPetRevisions(
+	ctx context.Context, params api.ListPetRevisionsParams,
+) ([]api.PetRevision, error) {
+	revs, err := h.pets.PetHistory(ctx, params.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]api.PetRevision, len(revs))
+	for i, r := range revs {
+		out[i] = petRevisionToAPI(r)
+	}
+	return out, nil
+}
@@ -0,0 +1,82 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/pet"
+)
+
+func TestListPetRevisions(t *testing.T) {
+	now := time.Now()
+	actor := int64(4)
+	tag := "dog"
+
+	tests := []struct {
+		name     string
+		pets     *mockPetService
+		wantLen  int
+		wantCode int
+	}{
+		{
+			name: "history",
+			pets: &mockPetService{
+				petHistoryFn: func(_ context.Context, id int64) ([]pet.Revision, error) {
+					return []pet.Revision{
+						{ID: 1, PetID: id, Action: pet.RevisionCreate,
+							Name: "Fido", Tag: &tag, CreatedAt: now},
+						{ID: 2, PetID: id, Action: pet.RevisionDelete,
+							Name: "Fido", Tag: &tag,
+							Actor: pet.Actor{UserID: &actor}, CreatedAt: now},
+					}, nil
+				},
+			},
+			wantLen: 2,
+		},
+		{
+			name: "unknown pet",
+			pets: &mockPetService{
+				petHistoryFn: func(context.Context, int64) ([]pet.Revision, error) {
+					return nil, db.ErrNotFound
+				},
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHandler(t, tt.pets, nil)
+			ctx := context.Background()
+			got, err := h.ListPetRevisions(ctx,
+				api.ListPetRevisionsParams{ID: 7})
+			if tt.wantCode != 0 {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if code := h.NewError(ctx, err).StatusCode; code != tt.wantCode {
+					t.Errorf("status = %d, want %d", code, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != tt.wantLen {
+				t.Fatalf("got %d revisions, want %d", len(got), tt.wantLen)
+			}
+			if got[0].Action != api.PetRevisionActionCreate ||
+				got[0].ActorUserId.IsSet() || got[0].Tag.Or("") != "dog" {
+				t.Errorf("revision 0 = %+v", got[0])
+			}
+			if got[1].Action != api.PetRevisionActionDelete ||
+				got[1].ActorUserId.Or(0) != actor || got[1].PetId != 7 {
+				t.Errorf("revision 1 = %+v", got[1])
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+)
+
+// ListSpecies handles GET /species.
+func (h *Handler) ListSpecies(ctx context.Context) ([]api.Species, error) {
+	species, err := h.pets.ListSpecies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]api.Species, len(species))
+	for i, s := range species {
+		out[i] = speciesToAPI(s)
+	}
+	return out, nil
+}
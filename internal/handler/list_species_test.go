@@ -0,0 +1,43 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/pet"
+)
+
+func TestListSpecies(t *testing.T) {
+	pets := &mockPetService{
+		listSpeciesFn: func(context.Context) ([]pet.Species, error) {
+			return []pet.Species{
+				{Code: "dog", Name: "Dog", Breeds: []pet.Breed{
+					{Code: "beagle", Name: "Beagle"},
+				}},
+				{Code: "fish", Name: "Fish", Breeds: []pet.Breed{}},
+			}, nil
+		},
+	}
+	h := newHandler(t, pets, nil)
+	got, err := h.ListSpecies(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].Code != "dog" || len(got[0].Breeds) != 1 ||
+		got[0].Breeds[0].Name != "Beagle" || got[1].Breeds == nil {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestListSpeciesError(t *testing.T) {
+	pets := &mockPetService{
+		listSpeciesFn: func(context.Context) ([]pet.Species, error) {
+			return nil, errors.New("db down")
+		},
+	}
+	h := newHandler(t, pets, nil)
+	if _, err := h.ListSpecies(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+}
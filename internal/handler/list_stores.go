@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+)
+
+// ListStores handles GET /stores.
+func (h *Handler) ListStores(ctx context.Context) ([]api.Store, error) {
+	stores, err := h.stores.ListStores(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]api.Store, len(stores))
+	for i, s := range stores {
+		out[i] = storeToAPI(s)
+	}
+	return out, nil
+}
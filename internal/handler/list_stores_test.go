@@ -0,0 +1,40 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/store"
+)
+
+func TestListStores(t *testing.T) {
+	stores := &mockStoreService{
+		listStoresFn: func(context.Context) ([]store.Store, error) {
+			return []store.Store{
+				{ID: 2, Name: "Harbour Road"},
+				{ID: 1, Name: "Main Store"},
+			}, nil
+		},
+	}
+	h := newStoreHandler(t, stores, nil)
+	got, err := h.ListStores(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != 2 || got[1].Name != "Main Store" {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestListStoresError(t *testing.T) {
+	stores := &mockStoreService{
+		listStoresFn: func(context.Context) ([]store.Store, error) {
+			return nil, errors.New("db down")
+		},
+	}
+	h := newStoreHandler(t, stores, nil)
+	if _, err := h.ListStores(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+}
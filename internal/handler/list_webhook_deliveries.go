@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/webhook"
+)
+
+// ListWebhookDeliveries handles GET
+// /admin/webhooks/{id}/deliveries.
+func (h *Handler) ListWebhookDeliveries(
+	ctx context.Context, params api.ListWebhookDeliveriesParams,
+) ([]api.WebhookDelivery, error) {
+	f := webhook.DeliveryFilter{
+		Status: string(params.Status.Or("")),
+		Limit:  int(params.Limit.Or(0)),
+	}
+	if v, ok := params.Before.Get(); ok {
+		f.Before = &v
+	}
+
+	deliveries, err := h.webhooks.Deliveries(ctx, params.ID, f)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]api.WebhookDelivery, len(deliveries))
+	for i, d := range deliveries {
+		out[i] = webhookDeliveryToAPI(d)
+	}
+	return out, nil
+}
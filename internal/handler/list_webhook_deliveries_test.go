@@ -0,0 +1,94 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/webhook"
+)
+
+func TestListWebhookDeliveries(t *testing.T) {
+	now := time.Now()
+	code := 503
+	reason := "unexpected status 503"
+
+	tests := []struct {
+		name       string
+		params     api.ListWebhookDeliveriesParams
+		wantFilter webhook.DeliveryFilter
+		err        error
+	}{
+		{
+			name:       "no filters",
+			params:     api.ListWebhookDeliveriesParams{ID: 3},
+			wantFilter: webhook.DeliveryFilter{},
+		},
+		{
+			name: "all filters",
+			params: api.ListWebhookDeliveriesParams{
+				ID:     3,
+				Status: api.NewOptWebhookDeliveryStatus(api.WebhookDeliveryStatusDead),
+				Before: api.NewOptInt64(50),
+				Limit:  api.NewOptInt32(10),
+			},
+			wantFilter: webhook.DeliveryFilter{Status: "dead", Limit: 10},
+		},
+		{
+			name:   "unknown webhook",
+			params: api.ListWebhookDeliveriesParams{ID: 3},
+			err:    db.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hooks := &mockWebhookService{
+				deliveriesFn: func(
+					_ context.Context, id int64, f webhook.DeliveryFilter,
+				) ([]webhook.Delivery, error) {
+					if id != 3 || f.Status != tt.wantFilter.Status ||
+						f.Limit != tt.wantFilter.Limit {
+						t.Errorf("got id=%d filter=%+v", id, f)
+					}
+					if v, ok := tt.params.Before.Get(); ok &&
+						(f.Before == nil || *f.Before != v) {
+						t.Errorf("before = %v, want %d", f.Before, v)
+					}
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return []webhook.Delivery{{
+						ID: 9, WebhookID: 3, EventID: 42,
+						EventType: webhook.EventPetCreated,
+						Status:    webhook.StatusDead, Attempts: 8,
+						NextAttemptAt: now, LastAttemptAt: &now,
+						LastStatusCode: &code, LastError: &reason,
+						CreatedAt: now,
+					}}, nil
+				},
+			}
+
+			h := newWebhookHandler(t, hooks, nil)
+			got, err := h.ListWebhookDeliveries(context.Background(), tt.params)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				return
+			}
+			if len(got) != 1 {
+				t.Fatalf("got %d deliveries, want 1", len(got))
+			}
+			d := got[0]
+			if d.ID != 9 || d.Status != api.WebhookDeliveryStatusDead ||
+				d.Attempts != 8 || d.LastStatusCode.Or(0) != 503 ||
+				d.LastError.Or("") != reason || d.DeliveredAt.Set {
+				t.Errorf("got %+v", d)
+			}
+		})
+	}
+}
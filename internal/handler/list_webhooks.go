@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+)
+
+// ListWebhooks handles GET /admin/webhooks.
+func (h *Handler) ListWebhooks(
+	ctx context.Context,
+) ([]api.Webhook, error) {
+	hooks, err := h.webhooks.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]api.Webhook, len(hooks))
+	for i, w := range hooks {
+		out[i] = webhookToAPI(w)
+	}
+	return out, nil
+}
@@ -0,0 +1,48 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/webhook"
+)
+
+func TestListWebhooks(t *testing.T) {
+	hooks := &mockWebhookService{
+		listFn: func(context.Context) ([]webhook.Webhook, error) {
+			return []webhook.Webhook{
+				{
+					ID: 2, URL: "https://b.example/hook",
+					EventTypes: []string{webhook.EventPetCreated},
+					Secret:     "whsec_b",
+				},
+				{ID: 1, URL: "https://a.example/hook", Secret: "whsec_a"},
+			}, nil
+		},
+	}
+
+	h := newWebhookHandler(t, hooks, nil)
+	got, err := h.ListWebhooks(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != 2 || got[0].URL != "https://b.example/hook" ||
+		len(got[0].EventTypes) != 1 || got[0].EventTypes[0] != "pet.created" {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestListWebhooksError(t *testing.T) {
+	boom := errors.New("db down")
+	hooks := &mockWebhookService{
+		listFn: func(context.Context) ([]webhook.Webhook, error) {
+			return nil, boom
+		},
+	}
+
+	h := newWebhookHandler(t, hooks, nil)
+	if _, err := h.ListWebhooks(context.Background()); !errors.Is(err, boom) {
+		t.Errorf("err = %v, want %v", err, boom)
+	}
+}
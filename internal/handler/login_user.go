@@ -2,33 +2,39 @@ package handler
 
 import (
 	"context"
-	"fmt"
-	"net/http"
 
 	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
 )
 
-// LoginUser handles POST /auth/login.
+// LoginUser handles POST /auth/login. Users with TOTP
+// enabled get a 202 MFAChallenge instead of a cookie.
 func (h *Handler) LoginUser(
 	ctx context.Context, req *api.LoginRequest,
+	_ api.LoginUserParams,
 ) (api.LoginUserRes, error) {
-	token, u, err := h.auth.Login(
+	event := audit.Event{
+		Action:  audit.ActionLogin,
+		Details: auditEmail(req.Email),
+	}
+	res, err := h.auth.Login(
 		ctx, req.Email, req.Password,
 	)
 	if err != nil {
+		h.record(ctx, event, err)
 		return nil, err
 	}
-
-	w, ok := responseWriterFromContext(ctx)
-	if !ok {
-		return nil, fmt.Errorf(
-			"response writer not in context",
-		)
+	event.ActorUserID = &res.User.ID
+	if res.MFAToken != "" {
+		event.Details["mfa"] = "pending"
+		h.record(ctx, event, nil)
+		return &api.MFAChallenge{MfaToken: res.MFAToken}, nil
 	}
-	http.SetCookie(w, newCookie(
-		"access_token", token, 86400, h.secure,
-	))
 
-	au := userToAPI(u)
+	if err := h.setAccessToken(ctx, res.AccessToken); err != nil {
+		return nil, err
+	}
+	h.record(ctx, event, nil)
+	au := userToAPI(res.User)
 	return &au, nil
 }
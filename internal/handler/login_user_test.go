@@ -3,20 +3,23 @@ package handler_test
 import (
 	"context"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
 	"github.com/hhubris/petstore/internal/auth"
 )
 
 func TestLoginUser(t *testing.T) {
 	tests := []struct {
-		name       string
-		req        *api.LoginRequest
-		auths      *mockAuthService
-		wantName   string
-		wantCookie bool
-		wantErr    error
+		name         string
+		req          *api.LoginRequest
+		auths        *mockAuthService
+		wantName     string
+		wantCookie   bool
+		wantMFAToken string
+		wantErr      error
 	}{
 		{
 			name: "success",
@@ -25,18 +28,37 @@ func TestLoginUser(t *testing.T) {
 				Password: "secret123",
 			},
 			auths: &mockAuthService{
-				loginFn: func(_ context.Context, email, _ string) (string, auth.User, error) {
-					return "jwt-token", auth.User{
-						ID:    1,
-						Name:  "Alice",
-						Email: email,
-						Role:  "customer",
+				loginFn: func(_ context.Context, email, _ string) (auth.LoginResult, error) {
+					return auth.LoginResult{
+						User: auth.User{
+							ID:    1,
+							Name:  "Alice",
+							Email: email,
+							Role:  "customer",
+						},
+						AccessToken: "jwt-token",
 					}, nil
 				},
 			},
 			wantName:   "Alice",
 			wantCookie: true,
 		},
+		{
+			name: "mfa required",
+			req: &api.LoginRequest{
+				Email:    "admin@example.com",
+				Password: "secret123",
+			},
+			auths: &mockAuthService{
+				loginFn: func(context.Context, string, string) (auth.LoginResult, error) {
+					return auth.LoginResult{
+						User:     auth.User{ID: 1, Name: "Admin"},
+						MFAToken: "mfa-token",
+					}, nil
+				},
+			},
+			wantMFAToken: "mfa-token",
+		},
 		{
 			name: "invalid credentials",
 			req: &api.LoginRequest{
@@ -44,8 +66,8 @@ func TestLoginUser(t *testing.T) {
 				Password: "wrong",
 			},
 			auths: &mockAuthService{
-				loginFn: func(context.Context, string, string) (string, auth.User, error) {
-					return "", auth.User{}, auth.ErrInvalidCredentials
+				loginFn: func(context.Context, string, string) (auth.LoginResult, error) {
+					return auth.LoginResult{}, auth.ErrInvalidCredentials
 				},
 			},
 			wantErr: auth.ErrInvalidCredentials,
@@ -58,7 +80,7 @@ func TestLoginUser(t *testing.T) {
 			rec := httptest.NewRecorder()
 			ctx := ctxWithResponseWriter(rec)
 
-			got, err := h.LoginUser(ctx, tt.req)
+			got, err := h.LoginUser(ctx, tt.req, api.LoginUserParams{})
 			if tt.wantErr != nil {
 				if err == nil {
 					t.Fatal("expected error")
@@ -69,6 +91,21 @@ func TestLoginUser(t *testing.T) {
 				t.Fatalf("unexpected error: %v", err)
 			}
 
+			if tt.wantMFAToken != "" {
+				ch, ok := got.(*api.MFAChallenge)
+				if !ok {
+					t.Fatalf("got %T, want *api.MFAChallenge", got)
+				}
+				if ch.MfaToken != tt.wantMFAToken {
+					t.Errorf("got token %q, want %q",
+						ch.MfaToken, tt.wantMFAToken)
+				}
+				if len(rec.Result().Cookies()) != 0 {
+					t.Error("cookie set before second factor")
+				}
+				return
+			}
+
 			au, ok := got.(*api.AuthUser)
 			if !ok {
 				t.Fatal("expected *api.AuthUser response")
@@ -103,15 +140,93 @@ func TestLoginUser(t *testing.T) {
 
 func TestLoginUser_NoResponseWriter(t *testing.T) {
 	h := newHandler(t, nil, &mockAuthService{
-		loginFn: func(context.Context, string, string) (string, auth.User, error) {
-			return "token", auth.User{ID: 1}, nil
+		loginFn: func(context.Context, string, string) (auth.LoginResult, error) {
+			return auth.LoginResult{
+				User: auth.User{ID: 1}, AccessToken: "token",
+			}, nil
 		},
 	})
 	_, err := h.LoginUser(context.Background(), &api.LoginRequest{
 		Email:    "a@b.com",
 		Password: "pass",
-	})
+	}, api.LoginUserParams{})
 	if err == nil {
 		t.Fatal("expected error when response writer missing")
 	}
 }
+
+func TestLoginUserAudit(t *testing.T) {
+	tests := []struct {
+		name        string
+		loginFn     func(context.Context, string, string) (auth.LoginResult, error)
+		wantOutcome audit.Outcome
+		wantActor   bool
+		wantMFA     bool
+	}{
+		{
+			name: "success",
+			loginFn: func(context.Context, string, string) (auth.LoginResult, error) {
+				return auth.LoginResult{
+					User: auth.User{ID: 1}, AccessToken: "jwt-token",
+				}, nil
+			},
+			wantOutcome: audit.OutcomeSuccess,
+			wantActor:   true,
+		},
+		{
+			name: "mfa pending",
+			loginFn: func(context.Context, string, string) (auth.LoginResult, error) {
+				return auth.LoginResult{
+					User: auth.User{ID: 1}, MFAToken: "mfa-token",
+				}, nil
+			},
+			wantOutcome: audit.OutcomeSuccess,
+			wantActor:   true,
+			wantMFA:     true,
+		},
+		{
+			name: "failure",
+			loginFn: func(context.Context, string, string) (auth.LoginResult, error) {
+				return auth.LoginResult{}, auth.ErrInvalidCredentials
+			},
+			wantOutcome: audit.OutcomeFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := &mockAuditService{}
+			h := newAuditHandler(t, nil,
+				&mockAuthService{loginFn: tt.loginFn}, events)
+			ctx := ctxWithResponseWriter(httptest.NewRecorder())
+
+			_, _ = h.LoginUser(ctx, &api.LoginRequest{
+				Email: "alice@example.com", Password: "secret123",
+			}, api.LoginUserParams{})
+
+			if len(events.events) != 1 {
+				t.Fatalf("recorded %d events, want 1", len(events.events))
+			}
+			e := events.events[0]
+			if e.Action != audit.ActionLogin || e.Outcome != tt.wantOutcome {
+				t.Errorf("event = %s/%s", e.Action, e.Outcome)
+			}
+			if tt.wantActor != (e.ActorUserID != nil) {
+				t.Errorf("actor = %v, want set %v", e.ActorUserID, tt.wantActor)
+			}
+			if e.Details["email_sha256"] != aliceEmailHash {
+				t.Errorf("email_sha256 = %q", e.Details["email_sha256"])
+			}
+			if tt.wantMFA != (e.Details["mfa"] == "pending") {
+				t.Errorf("mfa detail = %q", e.Details["mfa"])
+			}
+			for k, v := range e.Details {
+				if strings.Contains(v, "secret123") ||
+					strings.Contains(v, "token") ||
+					strings.Contains(v, "alice") {
+					t.Errorf("detail %s leaks a secret: %q", k, v)
+				}
+			}
+		})
+	}
+}
@@ -4,16 +4,22 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
 )
 
 // LogoutUser handles POST /auth/logout.
-func (h *Handler) LogoutUser(ctx context.Context) error {
+func (h *Handler) LogoutUser(
+	ctx context.Context, _ api.LogoutUserParams,
+) (api.LogoutUserRes, error) {
 	w, ok := responseWriterFromContext(ctx)
 	if !ok {
-		return fmt.Errorf("response writer not in context")
+		return nil, fmt.Errorf("response writer not in context")
 	}
 	http.SetCookie(w, newCookie(
 		"access_token", "", -1, h.secure,
 	))
-	return nil
+	h.record(ctx, audit.Event{Action: audit.ActionLogout}, nil)
+	return &api.LogoutUserNoContent{}, nil
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/http/httptest"
 	"testing"
+
+	"github.com/hhubris/petstore/internal/api"
 )
 
 func TestLogoutUser(t *testing.T) {
@@ -11,10 +13,13 @@ func TestLogoutUser(t *testing.T) {
 	rec := httptest.NewRecorder()
 	ctx := ctxWithResponseWriter(rec)
 
-	err := h.LogoutUser(ctx)
+	got, err := h.LogoutUser(ctx, api.LogoutUserParams{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+	if _, ok := got.(*api.LogoutUserNoContent); !ok {
+		t.Errorf("got %T, want *api.LogoutUserNoContent", got)
+	}
 
 	cookies := rec.Result().Cookies()
 	found := false
@@ -33,7 +38,7 @@ func TestLogoutUser(t *testing.T) {
 
 func TestLogoutUser_NoResponseWriter(t *testing.T) {
 	h := newHandler(t, nil, nil)
-	err := h.LogoutUser(context.Background())
+	_, err := h.LogoutUser(context.Background(), api.LogoutUserParams{})
 	if err == nil {
 		t.Fatal("expected error when response writer missing")
 	}
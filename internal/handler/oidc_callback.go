@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+)
+
+// OidcCallback handles GET /auth/oidc/callback.
+func (h *Handler) OidcCallback(
+	ctx context.Context, params api.OidcCallbackParams,
+) (api.OidcCallbackRes, error) {
+	w, ok := responseWriterFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("response writer not in context")
+	}
+	// The state is single-use whatever the outcome.
+	http.SetCookie(w, newCookie(oidcStateCookie, "", -1, h.secure))
+
+	res, next, err := h.auth.CompleteOIDCLogin(
+		ctx, params.Code, params.State, params.OidcState.Or(""),
+	)
+	if err != nil {
+		h.record(ctx, audit.Event{Action: audit.ActionOIDCLogin}, err)
+		return nil, err
+	}
+	loc, err := url.Parse(next)
+	if err != nil {
+		return nil, fmt.Errorf("parsing redirect url: %w", err)
+	}
+	if err := h.setAccessToken(ctx, res.AccessToken); err != nil {
+		return nil, err
+	}
+	h.record(ctx, audit.Event{
+		Action:      audit.ActionOIDCLogin,
+		ActorUserID: &res.User.ID,
+	}, nil)
+	return &api.OidcCallbackFound{Location: *loc}, nil
+}
@@ -0,0 +1,102 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+func TestOidcCallback(t *testing.T) {
+	tests := []struct {
+		name       string
+		complete   func(ctx context.Context, code, state, stateToken string) (auth.LoginResult, string, error)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			complete: func(
+				_ context.Context, code, state, stateToken string,
+			) (auth.LoginResult, string, error) {
+				if code != "c" || state != "s" || stateToken != "st" {
+					t.Errorf("got %q %q %q", code, state, stateToken)
+				}
+				return auth.LoginResult{
+					User: auth.User{ID: 1}, AccessToken: "jwt-token",
+				}, "http://localhost:5173/", nil
+			},
+		},
+		{
+			name: "state mismatch",
+			complete: func(
+				context.Context, string, string, string,
+			) (auth.LoginResult, string, error) {
+				return auth.LoginResult{}, "", auth.ErrInvalidOIDCState
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "identity rejected",
+			complete: func(
+				context.Context, string, string, string,
+			) (auth.LoginResult, string, error) {
+				return auth.LoginResult{}, "", auth.ErrIdentityRejected
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHandler(t, nil, &mockAuthService{
+				completeOIDCLoginFn: tt.complete,
+			})
+			rec := httptest.NewRecorder()
+
+			got, err := h.OidcCallback(ctxWithResponseWriter(rec),
+				api.OidcCallbackParams{
+					Code:      "c",
+					State:     "s",
+					OidcState: api.NewOptString("st"),
+				},
+			)
+
+			cookies := map[string]*http.Cookie{}
+			for _, c := range rec.Result().Cookies() {
+				cookies[c.Name] = c
+			}
+			if c := cookies["oidc_state"]; c == nil || c.MaxAge >= 0 {
+				t.Error("oidc_state cookie not cleared")
+			}
+
+			if tt.wantStatus != 0 {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if code := h.NewError(context.Background(), err).StatusCode; code != tt.wantStatus {
+					t.Errorf("status = %d, want %d", code, tt.wantStatus)
+				}
+				if cookies["access_token"] != nil {
+					t.Error("access_token set on failure")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			found, ok := got.(*api.OidcCallbackFound)
+			if !ok {
+				t.Fatalf("got %T, want *api.OidcCallbackFound", got)
+			}
+			if found.Location.String() != "http://localhost:5173/" {
+				t.Errorf("Location = %q", found.Location.String())
+			}
+			if c := cookies["access_token"]; c == nil || c.Value != "jwt-token" {
+				t.Error("access_token cookie not set")
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+)
+
+// RedeliverWebhookDelivery handles POST
+// /admin/webhooks/{id}/deliveries/{deliveryId}/redeliver.
+func (h *Handler) RedeliverWebhookDelivery(
+	ctx context.Context, params api.RedeliverWebhookDeliveryParams,
+) (api.RedeliverWebhookDeliveryRes, error) {
+	d, err := h.webhooks.Redeliver(ctx, params.ID, params.DeliveryId)
+	h.record(ctx, audit.Event{
+		Action: audit.ActionWebhookRedeliver,
+		Target: auditTarget("webhook_delivery", params.DeliveryId),
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	ad := webhookDeliveryToAPI(d)
+	return &ad, nil
+}
@@ -0,0 +1,86 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/webhook"
+)
+
+func TestRedeliverWebhookDelivery(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    int
+		wantOutcome audit.Outcome
+	}{
+		{name: "success", wantOutcome: audit.OutcomeSuccess},
+		{
+			name:        "still pending",
+			err:         webhook.ErrDeliveryPending,
+			wantCode:    http.StatusConflict,
+			wantOutcome: audit.OutcomeFailure,
+		},
+		{
+			name:        "not found",
+			err:         db.ErrNotFound,
+			wantCode:    http.StatusNotFound,
+			wantOutcome: audit.OutcomeFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hooks := &mockWebhookService{
+				redeliverFn: func(
+					_ context.Context, webhookID, deliveryID int64,
+				) (webhook.Delivery, error) {
+					if webhookID != 3 || deliveryID != 9 {
+						t.Errorf("got webhook %d delivery %d",
+							webhookID, deliveryID)
+					}
+					if tt.err != nil {
+						return webhook.Delivery{}, tt.err
+					}
+					return webhook.Delivery{
+						ID: 9, WebhookID: 3, Status: webhook.StatusPending,
+						EventType: webhook.EventPetCreated,
+					}, nil
+				},
+			}
+			events := &mockAuditService{}
+			h := newWebhookHandler(t, hooks, events)
+			ctx := context.Background()
+			got, err := h.RedeliverWebhookDelivery(ctx,
+				api.RedeliverWebhookDeliveryParams{ID: 3, DeliveryId: 9})
+
+			if len(events.events) != 1 ||
+				events.events[0].Action != audit.ActionWebhookRedeliver ||
+				events.events[0].Target != "webhook_delivery:9" ||
+				events.events[0].Outcome != tt.wantOutcome {
+				t.Errorf("events = %+v", events.events)
+			}
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("err = %v, want %v", err, tt.err)
+				}
+				if code := h.NewError(ctx, err).StatusCode; code != tt.wantCode {
+					t.Errorf("got status %d, want %d", code, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			d, ok := got.(*api.WebhookDelivery)
+			if !ok || d.ID != 9 || d.Status != api.WebhookDeliveryStatusPending {
+				t.Errorf("got %+v", got)
+			}
+		})
+	}
+}
@@ -4,18 +4,30 @@ import (
 	"context"
 
 	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
 )
 
 // RegisterUser handles POST /auth/register.
 func (h *Handler) RegisterUser(
 	ctx context.Context, req *api.RegisterRequest,
+	_ api.RegisterUserParams,
 ) (api.RegisterUserRes, error) {
 	u, err := h.auth.Register(
 		ctx, req.Name, req.Email, req.Password,
 	)
 	if err != nil {
+		h.record(ctx, audit.Event{
+			Action:  audit.ActionRegister,
+			Details: auditEmail(req.Email),
+		}, err)
 		return nil, err
 	}
+	h.record(ctx, audit.Event{
+		Action:      audit.ActionRegister,
+		ActorUserID: &u.ID,
+		Target:      auditTarget("user", u.ID),
+		Details:     auditEmail(req.Email),
+	}, nil)
 	au := userToAPI(u)
 	return &au, nil
 }
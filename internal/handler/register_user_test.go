@@ -57,6 +57,7 @@ func TestRegisterUser(t *testing.T) {
 			h := newHandler(t, nil, tt.auths)
 			got, err := h.RegisterUser(
 				context.Background(), tt.req,
+				api.RegisterUserParams{},
 			)
 			if tt.wantErr != nil {
 				if err == nil {
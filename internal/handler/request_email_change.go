@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+// RequestEmailChange handles POST /auth/me/email.
+func (h *Handler) RequestEmailChange(
+	ctx context.Context, req *api.ChangeEmailRequest,
+	_ api.RequestEmailChangeParams,
+) (api.RequestEmailChangeRes, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, auth.ErrUnauthorized
+	}
+	if err := h.auth.RequestEmailChange(
+		ctx, claims.UserID, req.Email, req.Password,
+	); err != nil {
+		return nil, err
+	}
+	return &api.RequestEmailChangeAccepted{}, nil
+}
@@ -0,0 +1,89 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/db"
+)
+
+func TestRequestEmailChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		claims   *auth.Claims
+		auths    *mockAuthService
+		wantCode int
+	}{
+		{
+			name:   "success",
+			claims: &auth.Claims{UserID: 7, Role: "customer"},
+			auths: &mockAuthService{
+				requestEmailChangeFn: func(_ context.Context, id int64, email, password string) error {
+					if id != 7 || email != "new@example.com" || password != "s3cret" {
+						t.Errorf("got %d %q %q", id, email, password)
+					}
+					return nil
+				},
+			},
+		},
+		{
+			name:   "wrong password",
+			claims: &auth.Claims{UserID: 7, Role: "customer"},
+			auths: &mockAuthService{
+				requestEmailChangeFn: func(context.Context, int64, string, string) error {
+					return auth.ErrIncorrectPassword
+				},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:   "email taken",
+			claims: &auth.Claims{UserID: 7, Role: "customer"},
+			auths: &mockAuthService{
+				requestEmailChangeFn: func(context.Context, int64, string, string) error {
+					return db.ErrConflict
+				},
+			},
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:     "no claims",
+			auths:    &mockAuthService{},
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.claims != nil {
+				ctx = auth.ContextWithClaims(ctx, *tt.claims)
+			}
+			h := newHandler(t, nil, tt.auths)
+			got, err := h.RequestEmailChange(ctx, &api.ChangeEmailRequest{
+				Email:    "new@example.com",
+				Password: "s3cret",
+			}, api.RequestEmailChangeParams{})
+			if tt.wantCode != 0 {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if code := h.NewError(ctx, err).StatusCode; code != tt.wantCode {
+					t.Errorf("got status %d, want %d",
+						code, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, ok := got.(*api.RequestEmailChangeAccepted); !ok {
+				t.Errorf("got %T, want *api.RequestEmailChangeAccepted",
+					got)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+// ResendVerificationEmail handles POST /auth/verify/resend.
+func (h *Handler) ResendVerificationEmail(
+	ctx context.Context, _ api.ResendVerificationEmailParams,
+) (api.ResendVerificationEmailRes, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, auth.ErrUnauthorized
+	}
+	if err := h.auth.ResendVerification(
+		ctx, claims.UserID,
+	); err != nil {
+		return nil, err
+	}
+	return &api.ResendVerificationEmailAccepted{}, nil
+}
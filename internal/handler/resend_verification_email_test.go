@@ -0,0 +1,85 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+func TestResendVerificationEmail(t *testing.T) {
+	tests := []struct {
+		name     string
+		claims   *auth.Claims
+		auths    *mockAuthService
+		wantCode int
+	}{
+		{
+			name:   "success",
+			claims: &auth.Claims{UserID: 7, Role: "customer"},
+			auths: &mockAuthService{
+				resendVerificationFn: func(_ context.Context, id int64) error {
+					if id != 7 {
+						t.Errorf("got user %d, want 7", id)
+					}
+					return nil
+				},
+			},
+		},
+		{
+			name:   "already verified",
+			claims: &auth.Claims{UserID: 7, Role: "customer"},
+			auths: &mockAuthService{
+				resendVerificationFn: func(context.Context, int64) error {
+					return auth.ErrEmailAlreadyVerified
+				},
+			},
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:   "throttled",
+			claims: &auth.Claims{UserID: 7, Role: "customer"},
+			auths: &mockAuthService{
+				resendVerificationFn: func(context.Context, int64) error {
+					return auth.ErrTooManyRequests
+				},
+			},
+			wantCode: http.StatusTooManyRequests,
+		},
+		{
+			name:     "no claims",
+			auths:    &mockAuthService{},
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.claims != nil {
+				ctx = auth.ContextWithClaims(ctx, *tt.claims)
+			}
+			h := newHandler(t, nil, tt.auths)
+			got, err := h.ResendVerificationEmail(ctx, api.ResendVerificationEmailParams{})
+			if tt.wantCode != 0 {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if code := h.NewError(ctx, err).StatusCode; code != tt.wantCode {
+					t.Errorf("got status %d, want %d",
+						code, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, ok := got.(*api.ResendVerificationEmailAccepted); !ok {
+				t.Errorf("got %T, want *api.ResendVerificationEmailAccepted",
+					got)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+// ReservePet handles POST /pets/{id}/reservations.
+func (h *Handler) ReservePet(
+	ctx context.Context, params api.ReservePetParams,
+) (api.ReservePetRes, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, auth.ErrUnauthorized
+	}
+	r, err := h.holds.Reserve(ctx, claims.UserID, params.ID)
+	if err != nil {
+		h.record(ctx, audit.Event{
+			Action: audit.ActionReservationCreate,
+			Target: auditTarget("pet", params.ID),
+		}, err)
+		return nil, err
+	}
+	h.record(ctx, audit.Event{
+		Action:  audit.ActionReservationCreate,
+		Target:  auditTarget("reservation", r.ID),
+		Details: map[string]string{"pet_id": strconv.FormatInt(r.PetID, 10)},
+	}, nil)
+	ar := reservationToAPI(r)
+	return &ar, nil
+}
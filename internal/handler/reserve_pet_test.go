@@ -0,0 +1,150 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/reservation"
+)
+
+func TestReservePet(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		claims      *auth.Claims
+		holds       *mockReservationService
+		wantCode    int
+		wantType    string
+		wantTarget  string
+		wantOutcome audit.Outcome
+	}{
+		{
+			name:   "success",
+			claims: &auth.Claims{UserID: 7, Role: "customer"},
+			holds: &mockReservationService{
+				reserveFn: func(_ context.Context, userID, petID int64) (reservation.Reservation, error) {
+					if userID != 7 || petID != 3 {
+						t.Errorf("got user %d pet %d", userID, petID)
+					}
+					return reservation.Reservation{
+						ID: 11, PetID: petID, UserID: userID,
+						Status:    reservation.StatusActive,
+						CreatedAt: now, ExpiresAt: now.Add(reservation.HoldDuration),
+					}, nil
+				},
+			},
+			wantTarget:  "reservation:11",
+			wantOutcome: audit.OutcomeSuccess,
+		},
+		{
+			name:   "already reserved",
+			claims: &auth.Claims{UserID: 7, Role: "customer"},
+			holds: &mockReservationService{
+				reserveFn: func(context.Context, int64, int64) (reservation.Reservation, error) {
+					return reservation.Reservation{}, reservation.ErrPetReserved
+				},
+			},
+			wantCode:    http.StatusConflict,
+			wantType:    "urn:petstore:problem:pet-reserved",
+			wantTarget:  "pet:3",
+			wantOutcome: audit.OutcomeFailure,
+		},
+		{
+			name:   "too many holds",
+			claims: &auth.Claims{UserID: 7, Role: "customer"},
+			holds: &mockReservationService{
+				reserveFn: func(context.Context, int64, int64) (reservation.Reservation, error) {
+					return reservation.Reservation{}, reservation.ErrTooManyHolds
+				},
+			},
+			wantCode:    http.StatusConflict,
+			wantType:    "urn:petstore:problem:too-many-holds",
+			wantTarget:  "pet:3",
+			wantOutcome: audit.OutcomeFailure,
+		},
+		{
+			name:   "cooldown",
+			claims: &auth.Claims{UserID: 7, Role: "customer"},
+			holds: &mockReservationService{
+				reserveFn: func(context.Context, int64, int64) (reservation.Reservation, error) {
+					return reservation.Reservation{}, reservation.ErrHoldCooldown
+				},
+			},
+			wantCode:    http.StatusTooManyRequests,
+			wantType:    "urn:petstore:problem:hold-cooldown",
+			wantTarget:  "pet:3",
+			wantOutcome: audit.OutcomeFailure,
+		},
+		{
+			name:   "no such pet",
+			claims: &auth.Claims{UserID: 7, Role: "customer"},
+			holds: &mockReservationService{
+				reserveFn: func(context.Context, int64, int64) (reservation.Reservation, error) {
+					return reservation.Reservation{}, db.ErrNotFound
+				},
+			},
+			wantCode:    http.StatusNotFound,
+			wantType:    "urn:petstore:problem:not-found",
+			wantTarget:  "pet:3",
+			wantOutcome: audit.OutcomeFailure,
+		},
+		{
+			name:     "no claims",
+			holds:    &mockReservationService{},
+			wantCode: http.StatusUnauthorized,
+			wantType: "urn:petstore:problem:unauthorized",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.claims != nil {
+				ctx = auth.ContextWithClaims(ctx, *tt.claims)
+			}
+			events := &mockAuditService{}
+			h := newReservationHandler(t, tt.holds, events)
+			got, err := h.ReservePet(ctx, api.ReservePetParams{ID: 3})
+
+			if tt.wantOutcome != "" && (len(events.events) != 1 ||
+				events.events[0].Action != audit.ActionReservationCreate ||
+				events.events[0].Outcome != tt.wantOutcome ||
+				events.events[0].Target != tt.wantTarget) {
+				t.Errorf("events = %+v", events.events)
+			}
+			if tt.wantCode != 0 {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				p := h.NewError(ctx, err)
+				if p.StatusCode != tt.wantCode || p.Response.Type != tt.wantType {
+					t.Errorf("got %d %q, want %d %q", p.StatusCode,
+						p.Response.Type, tt.wantCode, tt.wantType)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			r, ok := got.(*api.Reservation)
+			if !ok {
+				t.Fatalf("got %T, want *api.Reservation", got)
+			}
+			if r.ID != 11 || r.PetId != 3 || r.Status != api.ReservationStatusActive ||
+				!r.ExpiresAt.Equal(now.Add(reservation.HoldDuration)) ||
+				r.CancelledAt.Set {
+				t.Errorf("got %+v", r)
+			}
+			if d := events.events[0].Details["pet_id"]; d != "3" {
+				t.Errorf("pet_id detail = %q, want 3", d)
+			}
+		})
+	}
+}
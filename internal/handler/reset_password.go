@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+)
+
+// ResetPassword handles POST /auth/password/reset.
+func (h *Handler) ResetPassword(
+	ctx context.Context, req *api.ResetPasswordRequest,
+	_ api.ResetPasswordParams,
+) (api.ResetPasswordRes, error) {
+	id, err := h.auth.ResetPassword(ctx, req.Token, req.Password)
+	h.record(ctx, auditUser(
+		audit.Event{Action: audit.ActionPasswordReset}, id,
+	), err)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ResetPasswordNoContent{}, nil
+}
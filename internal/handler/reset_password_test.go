@@ -0,0 +1,82 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+func TestResetPassword(t *testing.T) {
+	tests := []struct {
+		name       string
+		auths      *mockAuthService
+		wantCode   int
+		wantTarget string
+	}{
+		{
+			name: "success",
+			auths: &mockAuthService{
+				resetPasswordFn: func(_ context.Context, token, pw string) (int64, error) {
+					if token != "tok" || pw != "n3w-password" {
+						t.Errorf("got token %q, password %q",
+							token, pw)
+					}
+					return 7, nil
+				},
+			},
+			wantTarget: "user:7",
+		},
+		{
+			name: "invalid token",
+			auths: &mockAuthService{
+				resetPasswordFn: func(context.Context, string, string) (int64, error) {
+					return 0, auth.ErrInvalidResetToken
+				},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := &mockAuditService{}
+			h := newAuditHandler(t, nil, tt.auths, events)
+			got, err := h.ResetPassword(context.Background(),
+				&api.ResetPasswordRequest{
+					Token:    "tok",
+					Password: "n3w-password",
+				}, api.ResetPasswordParams{})
+			if len(events.events) != 1 {
+				t.Fatalf("recorded %d events, want 1", len(events.events))
+			}
+			if e := events.events[0]; e.Action != audit.ActionPasswordReset ||
+				e.Target != tt.wantTarget ||
+				(e.ActorUserID != nil) != (tt.wantTarget != "") {
+				t.Errorf("event = %+v, want target %q", e, tt.wantTarget)
+			}
+			if tt.wantCode != 0 {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if code := h.NewError(
+					context.Background(), err,
+				).StatusCode; code != tt.wantCode {
+					t.Errorf("got status %d, want %d",
+						code, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, ok := got.(*api.ResetPasswordNoContent); !ok {
+				t.Errorf("got %T, want *api.ResetPasswordNoContent",
+					got)
+			}
+		})
+	}
+}
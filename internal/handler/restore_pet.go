@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+)
+
+// RestorePet handles POST /admin/pets/{id}/restore.
+func (h *Handler) RestorePet(
+	ctx context.Context, params api.RestorePetParams,
+) (api.RestorePetRes, error) {
+	p, err := h.pets.RestorePet(ctx, params.ID)
+	h.record(ctx, audit.Event{
+		Action: audit.ActionPetRestore,
+		Target: auditTarget("pet", params.ID),
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	ap := petToAPI(p)
+	return &ap, nil
+}
@@ -0,0 +1,69 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/pet"
+)
+
+func TestRestorePet(t *testing.T) {
+	tests := []struct {
+		name        string
+		pets        *mockPetService
+		wantName    string
+		wantErr     error
+		wantOutcome audit.Outcome
+	}{
+		{
+			name: "success",
+			pets: &mockPetService{
+				restorePetFn: func(_ context.Context, id int64) (pet.Pet, error) {
+					return pet.Pet{ID: id, Name: "Fido"}, nil
+				},
+			},
+			wantName:    "Fido",
+			wantOutcome: audit.OutcomeSuccess,
+		},
+		{
+			name: "not deleted",
+			pets: &mockPetService{
+				restorePetFn: func(context.Context, int64) (pet.Pet, error) {
+					return pet.Pet{}, db.ErrNotFound
+				},
+			},
+			wantErr:     db.ErrNotFound,
+			wantOutcome: audit.OutcomeFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := &mockAuditService{}
+			h := newAuditHandler(t, tt.pets, nil, events)
+			got, err := h.RestorePet(context.Background(),
+				api.RestorePetParams{ID: 3})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if got, ok := got.(*api.Pet); !ok ||
+				got.ID != 3 || got.Name != tt.wantName {
+				t.Errorf("got %+v", got)
+			}
+
+			if len(events.events) != 1 ||
+				events.events[0].Action != audit.ActionPetRestore ||
+				events.events[0].Target != "pet:3" ||
+				events.events[0].Outcome != tt.wantOutcome {
+				t.Errorf("events = %+v", events.events)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+)
+
+// RevokeAPIKey handles DELETE /admin/api-keys/{id}.
+func (h *Handler) RevokeAPIKey(
+	ctx context.Context, params api.RevokeAPIKeyParams,
+) error {
+	err := h.keys.Revoke(ctx, params.ID)
+	h.record(ctx, audit.Event{
+		Action: audit.ActionAPIKeyRevoke,
+		Target: auditTarget("api_key", params.ID),
+	}, err)
+	return err
+}
@@ -0,0 +1,50 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/db"
+)
+
+func TestRevokeAPIKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		keys    *mockAPIKeyService
+		wantErr error
+	}{
+		{
+			name: "success",
+			keys: &mockAPIKeyService{
+				revokeFn: func(_ context.Context, id int64) error {
+					if id != 4 {
+						t.Errorf("got id %d, want 4", id)
+					}
+					return nil
+				},
+			},
+		},
+		{
+			name: "not found",
+			keys: &mockAPIKeyService{
+				revokeFn: func(context.Context, int64) error {
+					return db.ErrNotFound
+				},
+			},
+			wantErr: db.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newKeyHandler(t, tt.keys)
+			err := h.RevokeAPIKey(context.Background(),
+				api.RevokeAPIKeyParams{ID: 4})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hhubris/petstore/internal/api"
+)
+
+// oidcStateCookie carries the signed login state across the
+// round trip to the identity provider.
+const oidcStateCookie = "oidc_state"
+
+// oidcStateMaxAge matches the state token's lifetime.
+const oidcStateMaxAge = 600
+
+// StartOIDCLogin handles GET /auth/oidc/login.
+func (h *Handler) StartOIDCLogin(
+	ctx context.Context,
+) (*api.StartOIDCLoginFound, error) {
+	redirect, err := h.auth.BeginOIDCLogin()
+	if err != nil {
+		return nil, err
+	}
+	loc, err := url.Parse(redirect.URL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing provider url: %w", err)
+	}
+
+	w, ok := responseWriterFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("response writer not in context")
+	}
+	http.SetCookie(w, newCookie(
+		oidcStateCookie, redirect.StateToken,
+		oidcStateMaxAge, h.secure,
+	))
+	return &api.StartOIDCLoginFound{Location: *loc}, nil
+}
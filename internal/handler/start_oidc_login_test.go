@@ -0,0 +1,61 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+func TestStartOIDCLogin(t *testing.T) {
+	auths := &mockAuthService{
+		beginOIDCLoginFn: func() (auth.OIDCRedirect, error) {
+			return auth.OIDCRedirect{
+				URL:        "https://idp.example/authorize?state=abc",
+				StateToken: "state-token",
+			}, nil
+		},
+	}
+	h := newHandler(t, nil, auths)
+	rec := httptest.NewRecorder()
+
+	got, err := h.StartOIDCLogin(ctxWithResponseWriter(rec))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Location.String() != "https://idp.example/authorize?state=abc" {
+		t.Errorf("Location = %q", got.Location.String())
+	}
+
+	var found bool
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == "oidc_state" {
+			found = true
+			if c.Value != "state-token" || !c.HttpOnly {
+				t.Errorf("cookie = %+v", c)
+			}
+		}
+	}
+	if !found {
+		t.Error("oidc_state cookie not set")
+	}
+}
+
+func TestStartOIDCLoginNotConfigured(t *testing.T) {
+	h := newHandler(t, nil, &mockAuthService{
+		beginOIDCLoginFn: func() (auth.OIDCRedirect, error) {
+			return auth.OIDCRedirect{}, auth.ErrOIDCNotConfigured
+		},
+	})
+	_, err := h.StartOIDCLogin(
+		ctxWithResponseWriter(httptest.NewRecorder()),
+	)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if code := h.NewError(context.Background(), err).StatusCode; code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	ht "github.com/ogen-go/ogen/http"
+
+	"github.com/hhubris/petstore/internal/api"
+)
+
+// StreamPetEvents handles GET /pets/events.
+func (h *Handler) StreamPetEvents(
+	ctx context.Context, params api.StreamPetEventsParams,
+) (api.StreamPetEventsRes, error) {
+	if h.stream == nil {
+		return nil, ht.ErrNotImplemented
+	}
+	var after *int64
+	if id, ok := params.LastEventID.Get(); ok {
+		after = &id
+	}
+	body, err := h.stream.Open(ctx, after)
+	if err != nil {
+		return nil, err
+	}
+	if w, ok := responseWriterFromContext(ctx); ok {
+		body = flushingReader{body, http.NewResponseController(w)}
+	}
+	return &api.StreamPetEventsOKHeaders{
+		CacheControl: "no-cache",
+		Response:     api.StreamPetEventsOK{Data: body},
+	}, nil
+}
+
+// flushingReader flushes the response before each read of
+// the stream. ogen copies the body to the response, so each
+// read follows the write of the previous event: flushing
+// then sends that event before waiting for the next one.
+type flushingReader struct {
+	io.ReadCloser
+	rc *http.ResponseController
+}
+
+func (r flushingReader) Read(p []byte) (int, error) {
+	if err := r.rc.Flush(); err != nil {
+		return 0, err
+	}
+	return r.ReadCloser.Read(p)
+}
@@ -0,0 +1,99 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	ht "github.com/ogen-go/ogen/http"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/handler"
+)
+
+// mockStreamer is a hand-written mock of
+// handler.PetEventStreamer.
+type mockStreamer struct {
+	after *int64
+	err   error
+}
+
+func (m *mockStreamer) Open(
+	_ context.Context, after *int64,
+) (io.ReadCloser, error) {
+	m.after = after
+	if m.err != nil {
+		return nil, m.err
+	}
+	return io.NopCloser(strings.NewReader("retry: 3000\n\n")), nil
+}
+
+func TestStreamPetEvents(t *testing.T) {
+	tests := []struct {
+		name      string
+		params    api.StreamPetEventsParams
+		wantAfter *int64
+	}{
+		{name: "live"},
+		{
+			name: "resume",
+			params: api.StreamPetEventsParams{
+				LastEventID: api.NewOptInt64(41),
+			},
+			wantAfter: new(int64(41)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := &mockStreamer{}
+			h := handler.New(nil, nil, nil, nil, nil, nil, nil, nil, stream, false)
+
+			res, err := h.StreamPetEvents(context.Background(), tt.params)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, ok := res.(*api.StreamPetEventsOKHeaders)
+			if !ok {
+				t.Fatalf("got %T", res)
+			}
+			if got.CacheControl != "no-cache" {
+				t.Errorf("Cache-Control = %q", got.CacheControl)
+			}
+			body, _ := io.ReadAll(got.Response.Data)
+			if string(body) != "retry: 3000\n\n" {
+				t.Errorf("body = %q", body)
+			}
+			switch {
+			case tt.wantAfter == nil && stream.after != nil:
+				t.Errorf("after = %d, want live", *stream.after)
+			case tt.wantAfter != nil &&
+				(stream.after == nil || *stream.after != *tt.wantAfter):
+				t.Errorf("after = %v, want %d", stream.after, *tt.wantAfter)
+			}
+		})
+	}
+}
+
+func TestStreamPetEventsOpenError(t *testing.T) {
+	wantErr := errors.New("db down")
+	h := handler.New(nil, nil, nil, nil, nil, nil, nil, nil,
+		&mockStreamer{err: wantErr}, false)
+
+	_, err := h.StreamPetEvents(context.Background(),
+		api.StreamPetEventsParams{})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("got %v, want %v", err, wantErr)
+	}
+}
+
+func TestStreamPetEventsNotRunning(t *testing.T) {
+	h := handler.New(nil, nil, nil, nil, nil, nil, nil, nil, nil, false)
+
+	_, err := h.StreamPetEvents(context.Background(),
+		api.StreamPetEventsParams{})
+	if !errors.Is(err, ht.ErrNotImplemented) {
+		t.Errorf("got %v, want ErrNotImplemented", err)
+	}
+}
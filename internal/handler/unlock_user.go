@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+)
+
+// UnlockUser handles POST /admin/users/{id}/unlock.
+func (h *Handler) UnlockUser(
+	ctx context.Context, params api.UnlockUserParams,
+) (api.UnlockUserRes, error) {
+	err := h.auth.UnlockUser(ctx, params.ID)
+	h.record(ctx, audit.Event{
+		Action: audit.ActionUserUnlock,
+		Target: auditTarget("user", params.ID),
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	return &api.UnlockUserNoContent{}, nil
+}
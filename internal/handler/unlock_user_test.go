@@ -0,0 +1,57 @@
+package handler_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/db"
+)
+
+func TestUnlockUser(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  api.UnlockUserParams
+		auths   *mockAuthService
+		wantErr error
+	}{
+		{
+			name:   "success",
+			params: api.UnlockUserParams{ID: 7},
+			auths: &mockAuthService{
+				unlockUserFn: func(_ context.Context, id int64) error {
+					if id != 7 {
+						t.Errorf("got id %d, want 7", id)
+					}
+					return nil
+				},
+			},
+		},
+		{
+			name:   "not found",
+			params: api.UnlockUserParams{ID: 99},
+			auths: &mockAuthService{
+				unlockUserFn: func(context.Context, int64) error {
+					return db.ErrNotFound
+				},
+			},
+			wantErr: db.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHandler(t, nil, tt.auths)
+			_, err := h.UnlockUser(context.Background(), tt.params)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
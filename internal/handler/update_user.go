@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+// UpdateUser handles PATCH /admin/users/{id}.
+func (h *Handler) UpdateUser(
+	ctx context.Context,
+	req *api.UpdateUserRequest,
+	params api.UpdateUserParams,
+) (*api.AuthUser, error) {
+	var c auth.AccessChange
+	if v, ok := req.Role.Get(); ok {
+		c.Role = &v
+	}
+	if v, ok := req.Disabled.Get(); ok {
+		c.Disabled = &v
+	}
+	if req.StoreId.IsNull() {
+		c.Global = true
+	} else if v, ok := req.StoreId.Get(); ok {
+		c.StoreID = &v
+	}
+	u, err := h.auth.UpdateUserAccess(ctx, params.ID, c)
+	event := audit.Event{
+		Action:  audit.ActionUserUpdate,
+		Target:  auditTarget("user", params.ID),
+		Details: map[string]string{},
+	}
+	if c.Role != nil {
+		event.Details["role"] = *c.Role
+	}
+	if c.Disabled != nil {
+		event.Details["disabled"] = strconv.FormatBool(*c.Disabled)
+	}
+	switch {
+	case c.Global:
+		event.Details["store_id"] = "global"
+	case c.StoreID != nil:
+		event.Details["store_id"] = strconv.FormatInt(*c.StoreID, 10)
+	}
+	h.record(ctx, event, err)
+	if err != nil {
+		return nil, err
+	}
+	au := userToAPI(u)
+	return &au, nil
+}
@@ -0,0 +1,135 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/store"
+)
+
+func TestUpdateUser(t *testing.T) {
+	disabledAt := time.Now()
+
+	tests := []struct {
+		name     string
+		req      api.UpdateUserRequest
+		auths    *mockAuthService
+		want     api.AuthUser
+		wantCode int
+	}{
+		{
+			name: "change role",
+			req:  api.UpdateUserRequest{Role: api.NewOptString("staff")},
+			auths: &mockAuthService{
+				updateUserAccessFn: func(_ context.Context, id int64, c auth.AccessChange) (auth.User, error) {
+					if id != 7 || c.Role == nil || *c.Role != "staff" || c.Disabled != nil {
+						t.Errorf("got id %d, change %+v", id, c)
+					}
+					return auth.User{ID: 7, Name: "Sam", Role: "staff"}, nil
+				},
+			},
+			want: api.AuthUser{ID: 7, Name: "Sam", Role: "staff"},
+		},
+		{
+			name: "disable",
+			req:  api.UpdateUserRequest{Disabled: api.NewOptBool(true)},
+			auths: &mockAuthService{
+				updateUserAccessFn: func(_ context.Context, _ int64, c auth.AccessChange) (auth.User, error) {
+					if c.Role != nil || c.Disabled == nil || !*c.Disabled {
+						t.Errorf("got change %+v", c)
+					}
+					return auth.User{
+						ID: 7, Name: "Sam", Role: "customer",
+						DisabledAt: &disabledAt,
+					}, nil
+				},
+			},
+			want: api.AuthUser{
+				ID: 7, Name: "Sam", Role: "customer", Disabled: true,
+			},
+		},
+		{
+			name: "move to a store",
+			req:  api.UpdateUserRequest{StoreId: api.NewOptNilInt64(2)},
+			auths: &mockAuthService{
+				updateUserAccessFn: func(_ context.Context, _ int64, c auth.AccessChange) (auth.User, error) {
+					if c.StoreID == nil || *c.StoreID != 2 || c.Global {
+						t.Errorf("got change %+v", c)
+					}
+					return auth.User{
+						ID: 7, Name: "Sam", Role: "staff", StoreID: c.StoreID,
+					}, nil
+				},
+			},
+			want: api.AuthUser{
+				ID: 7, Name: "Sam", Role: "staff",
+				StoreId: api.NewOptInt64(2),
+			},
+		},
+		{
+			name: "make global",
+			req: api.UpdateUserRequest{
+				StoreId: api.OptNilInt64{Set: true, Null: true},
+			},
+			auths: &mockAuthService{
+				updateUserAccessFn: func(_ context.Context, _ int64, c auth.AccessChange) (auth.User, error) {
+					if c.StoreID != nil || !c.Global {
+						t.Errorf("got change %+v", c)
+					}
+					return auth.User{ID: 7, Name: "Sam", Role: "admin"}, nil
+				},
+			},
+			want: api.AuthUser{ID: 7, Name: "Sam", Role: "admin"},
+		},
+		{
+			name: "unknown user",
+			req:  api.UpdateUserRequest{Disabled: api.NewOptBool(false)},
+			auths: &mockAuthService{
+				updateUserAccessFn: func(context.Context, int64, auth.AccessChange) (auth.User, error) {
+					return auth.User{}, db.ErrNotFound
+				},
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "another store",
+			req:  api.UpdateUserRequest{StoreId: api.NewOptNilInt64(3)},
+			auths: &mockAuthService{
+				updateUserAccessFn: func(context.Context, int64, auth.AccessChange) (auth.User, error) {
+					return auth.User{}, store.ErrOtherStore
+				},
+			},
+			wantCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			h := newHandler(t, nil, tt.auths)
+			got, err := h.UpdateUser(ctx, &tt.req,
+				api.UpdateUserParams{ID: 7})
+			if tt.wantCode != 0 {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if code := h.NewError(ctx, err).StatusCode; code != tt.wantCode {
+					t.Errorf("got status %d, want %d",
+						code, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("got %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+)
+
+// VerifyEmail handles POST /auth/verify.
+func (h *Handler) VerifyEmail(
+	ctx context.Context, req *api.VerifyEmailRequest,
+	_ api.VerifyEmailParams,
+) (api.VerifyEmailRes, error) {
+	id, err := h.auth.VerifyEmail(ctx, req.Token)
+	h.record(ctx, auditUser(
+		audit.Event{Action: audit.ActionEmailVerify}, id,
+	), err)
+	if err != nil {
+		return nil, err
+	}
+	return &api.VerifyEmailNoContent{}, nil
+}
@@ -0,0 +1,78 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+func TestVerifyEmail(t *testing.T) {
+	tests := []struct {
+		name       string
+		auths      *mockAuthService
+		wantCode   int
+		wantTarget string
+	}{
+		{
+			name: "success",
+			auths: &mockAuthService{
+				verifyEmailFn: func(_ context.Context, token string) (int64, error) {
+					if token != "tok" {
+						t.Errorf("got token %q", token)
+					}
+					return 7, nil
+				},
+			},
+			wantTarget: "user:7",
+		},
+		{
+			name: "invalid token",
+			auths: &mockAuthService{
+				verifyEmailFn: func(context.Context, string) (int64, error) {
+					return 0, auth.ErrInvalidVerificationToken
+				},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := &mockAuditService{}
+			h := newAuditHandler(t, nil, tt.auths, events)
+			got, err := h.VerifyEmail(context.Background(),
+				&api.VerifyEmailRequest{Token: "tok"}, api.VerifyEmailParams{})
+			if len(events.events) != 1 {
+				t.Fatalf("recorded %d events, want 1", len(events.events))
+			}
+			if e := events.events[0]; e.Action != audit.ActionEmailVerify ||
+				e.Target != tt.wantTarget ||
+				(e.ActorUserID != nil) != (tt.wantTarget != "") {
+				t.Errorf("event = %+v, want target %q", e, tt.wantTarget)
+			}
+			if tt.wantCode != 0 {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if code := h.NewError(
+					context.Background(), err,
+				).StatusCode; code != tt.wantCode {
+					t.Errorf("got status %d, want %d",
+						code, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, ok := got.(*api.VerifyEmailNoContent); !ok {
+				t.Errorf("got %T, want *api.VerifyEmailNoContent",
+					got)
+			}
+		})
+	}
+}
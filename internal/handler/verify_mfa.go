@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/audit"
+)
+
+// VerifyMFA handles POST /auth/mfa/verify.
+func (h *Handler) VerifyMFA(
+	ctx context.Context, req *api.MFAVerifyRequest,
+	_ api.VerifyMFAParams,
+) (api.VerifyMFARes, error) {
+	token, u, err := h.auth.VerifyMFA(ctx, req.MfaToken, req.Code)
+	if err != nil {
+		h.record(ctx, audit.Event{Action: audit.ActionMFAVerify}, err)
+		return nil, err
+	}
+	if err := h.setAccessToken(ctx, token); err != nil {
+		return nil, err
+	}
+	h.record(ctx, audit.Event{
+		Action:      audit.ActionMFAVerify,
+		ActorUserID: &u.ID,
+	}, nil)
+	au := userToAPI(u)
+	return &au, nil
+}
@@ -0,0 +1,87 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+func TestVerifyMFA(t *testing.T) {
+	tests := []struct {
+		name     string
+		auths    *mockAuthService
+		wantCode int
+	}{
+		{
+			name: "success",
+			auths: &mockAuthService{
+				verifyMFAFn: func(_ context.Context, tok, code string) (string, auth.User, error) {
+					if tok != "mfa-token" || code != "123456" {
+						t.Errorf("got token %q, code %q", tok, code)
+					}
+					return "jwt-token", auth.User{ID: 1, Name: "Admin", Role: "admin"}, nil
+				},
+			},
+		},
+		{
+			name: "wrong code",
+			auths: &mockAuthService{
+				verifyMFAFn: func(context.Context, string, string) (string, auth.User, error) {
+					return "", auth.User{}, auth.ErrInvalidMFACode
+				},
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "expired challenge",
+			auths: &mockAuthService{
+				verifyMFAFn: func(context.Context, string, string) (string, auth.User, error) {
+					return "", auth.User{}, auth.ErrInvalidToken
+				},
+			},
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHandler(t, nil, tt.auths)
+			rec := httptest.NewRecorder()
+			ctx := ctxWithResponseWriter(rec)
+
+			got, err := h.VerifyMFA(ctx, &api.MFAVerifyRequest{
+				MfaToken: "mfa-token",
+				Code:     "123456",
+			}, api.VerifyMFAParams{})
+			if tt.wantCode != 0 {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if code := h.NewError(ctx, err).StatusCode; code != tt.wantCode {
+					t.Errorf("got status %d, want %d",
+						code, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if au, ok := got.(*api.AuthUser); !ok || au.Name != "Admin" {
+				t.Errorf("got %+v, want AuthUser Admin", got)
+			}
+			found := false
+			for _, c := range rec.Result().Cookies() {
+				if c.Name == "access_token" && c.Value == "jwt-token" {
+					found = true
+				}
+			}
+			if !found {
+				t.Error("access_token cookie not set")
+			}
+		})
+	}
+}
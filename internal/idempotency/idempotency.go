@@ -0,0 +1,35 @@
+// Package idempotency makes retried POST requests safe.
+// A client sends an Idempotency-Key header; the first
+// request with a key runs and its response is stored, and
+// retries with the same key and body get that response
+// back instead of running again.
+package idempotency
+
+import "errors"
+
+// ErrKeyBusy is returned by Begin when the key's record was
+// removed between the claim attempt and the lookup, so the
+// request should be retried.
+var ErrKeyBusy = errors.New("idempotency key is busy")
+
+// ErrClaimLost is returned when a pending claim is no
+// longer held under the caller's token because another
+// request took it over as abandoned.
+var ErrClaimLost = errors.New("idempotency claim was taken over")
+
+// Response is a stored HTTP response.
+type Response struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// Record is the stored state of a key.
+type Record struct {
+	// Fingerprint identifies the request that claimed the
+	// key: a hash of its method, path, query, and body.
+	Fingerprint string
+	// Response is nil while the first request is still
+	// running.
+	Response *Response
+}
@@ -0,0 +1,301 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hhubris/petstore/internal/middleware"
+)
+
+// Header is the request header carrying the client's key.
+const Header = "Idempotency-Key"
+
+// ReplayedHeader is set to "true" on replayed responses.
+const ReplayedHeader = "Idempotent-Replayed"
+
+// maxKeyLength bounds the length of an Idempotency-Key.
+const maxKeyLength = 255
+
+// maxBodyBytes bounds the request body the middleware
+// reads to fingerprint a request. It comfortably fits a
+// full-size pet import (pet.MaxImportRows rows).
+const maxBodyBytes = 32 << 20
+
+// staleAfter is how long a pending claim may go without a
+// heartbeat before another request may take it over, so a
+// key whose first request died with its process does not
+// stay locked until it expires.
+const staleAfter = time.Minute
+
+// heartbeatInterval is how often a running request renews
+// its claim; well inside staleAfter, so a slow request such
+// as a large import is never taken over while it runs.
+const heartbeatInterval = staleAfter / 3
+
+// Store persists idempotency keys. Satisfied by
+// *KeyRepository.
+type Store interface {
+	Begin(ctx context.Context, scope, key, token, fingerprint string,
+		expiresAt, staleBefore time.Time) (Record, bool, error)
+	Touch(ctx context.Context, scope, key, token string) error
+	Complete(ctx context.Context, scope, key, token string,
+		resp Response) error
+	Release(ctx context.Context, scope, key, token string) error
+}
+
+// Middleware returns middleware that honors the
+// Idempotency-Key header on POST requests. The first
+// request with a key runs and its response is kept for ttl;
+// a retry with the same key and request gets the stored
+// response back with Idempotent-Replayed set. Reusing a key
+// for a different request fails with 422, and a retry while
+// the first request is still running fails with 409. A body
+// over 32 MiB fails with 413 before any key is claimed.
+//
+// Keys are scoped to the caller's credentials, so two
+// clients cannot see each other's responses. Responses that
+// set cookies, ask not to be stored (Cache-Control:
+// no-store), or fail with a 5xx are not kept; the key is
+// released and the request may be retried.
+func Middleware(store Store, ttl time.Duration) middleware.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(
+			w http.ResponseWriter, r *http.Request,
+		) {
+			key := r.Header.Get(Header)
+			if r.Method != http.MethodPost || key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxKeyLength {
+				writeProblem(w, r, http.StatusBadRequest,
+					"invalid-idempotency-key",
+					"Idempotency-Key must be at most 255 characters")
+				return
+			}
+
+			body, err := io.ReadAll(
+				http.MaxBytesReader(w, r.Body, maxBodyBytes))
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				writeProblem(w, r, http.StatusRequestEntityTooLarge,
+					"request-too-large",
+					"request body must be at most 32 MiB")
+				return
+			}
+			if err != nil {
+				writeProblem(w, r, http.StatusBadRequest,
+					"invalid-request", "reading request body")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := r.Context()
+			scope := scopeOf(r)
+			fp := fingerprint(r, body)
+			token := rand.Text()
+			now := time.Now()
+			rec, started, err := store.Begin(ctx,
+				scope, key, token, fp,
+				now.Add(ttl), now.Add(-staleAfter),
+			)
+			switch {
+			case errors.Is(err, ErrKeyBusy):
+				writeProblem(w, r, http.StatusConflict,
+					"idempotency-key-in-progress",
+					"a request with this Idempotency-Key is in progress")
+				return
+			case err != nil:
+				slog.ErrorContext(ctx, "idempotency begin failed",
+					"error", err)
+				writeProblem(w, r, http.StatusInternalServerError,
+					"internal", "internal server error")
+				return
+			case !started && rec.Fingerprint != fp:
+				writeProblem(w, r, http.StatusUnprocessableEntity,
+					"idempotency-key-reused",
+					"Idempotency-Key was used with a different request")
+				return
+			case !started && rec.Response == nil:
+				writeProblem(w, r, http.StatusConflict,
+					"idempotency-key-in-progress",
+					"a request with this Idempotency-Key is in progress")
+				return
+			case !started:
+				replay(w, *rec.Response)
+				return
+			}
+
+			rw := &recorder{ResponseWriter: w, status: http.StatusOK}
+			// The claim outlives the request context so a
+			// cancelled client does not leave it pending.
+			bg := context.WithoutCancel(ctx)
+			stop := heartbeat(bg, store, scope, key, token)
+			defer func() {
+				if v := recover(); v != nil {
+					stop()
+					release(bg, store, scope, key, token)
+					panic(v)
+				}
+			}()
+
+			next.ServeHTTP(rw, r)
+			stop()
+
+			if !storable(rw) {
+				release(bg, store, scope, key, token)
+				return
+			}
+			err = store.Complete(bg, scope, key, token, Response{
+				StatusCode:  rw.status,
+				ContentType: rw.Header().Get("Content-Type"),
+				Body:        rw.body.Bytes(),
+			})
+			switch {
+			case errors.Is(err, ErrClaimLost):
+				slog.WarnContext(ctx,
+					"idempotency claim taken over, response not stored")
+			case err != nil:
+				slog.ErrorContext(ctx, "idempotency complete failed",
+					"error", err)
+				release(bg, store, scope, key, token)
+			}
+		})
+	}
+}
+
+// recorder passes a response through while keeping a copy
+// of its status and body.
+type recorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rw *recorder) WriteHeader(code int) {
+	if !rw.wroteHeader {
+		rw.status = code
+		rw.wroteHeader = true
+	}
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *recorder) Write(b []byte) (int, error) {
+	rw.wroteHeader = true
+	rw.body.Write(b)
+	return rw.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the wrapped writer, so that
+// http.ResponseController can reach its Flush method.
+func (rw *recorder) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// storable reports whether a response may be kept for
+// replay.
+func storable(rw *recorder) bool {
+	h := rw.Header()
+	return rw.status < 500 &&
+		len(h.Values("Set-Cookie")) == 0 &&
+		!strings.Contains(h.Get("Cache-Control"), "no-store")
+}
+
+// heartbeat renews the claim every heartbeatInterval until
+// the returned function is called, which waits for it to
+// stop. It gives up once the claim is lost.
+func heartbeat(
+	ctx context.Context, store Store, scope, key, token string,
+) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		t := time.NewTicker(heartbeatInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-t.C:
+			}
+			err := store.Touch(ctx, scope, key, token)
+			if errors.Is(err, ErrClaimLost) {
+				slog.WarnContext(ctx, "idempotency claim taken over")
+				return
+			}
+			if err != nil {
+				slog.ErrorContext(ctx, "idempotency heartbeat failed",
+					"error", err)
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// release drops a pending claim, logging any failure; the
+// claim then goes stale on its own.
+func release(ctx context.Context, store Store, scope, key, token string) {
+	if err := store.Release(ctx, scope, key, token); err != nil {
+		slog.ErrorContext(ctx, "idempotency release failed",
+			"error", err)
+	}
+}
+
+// replay writes a stored response.
+func replay(w http.ResponseWriter, resp Response) {
+	if resp.ContentType != "" {
+		w.Header().Set("Content-Type", resp.ContentType)
+	}
+	w.Header().Set(ReplayedHeader, "true")
+	w.WriteHeader(resp.StatusCode)
+	_, _ = w.Write(resp.Body)
+}
+
+// fingerprint hashes the parts of a request that must match
+// for a retry to be replayed.
+func fingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "?" + r.URL.RawQuery + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// scopeOf hashes the caller's credentials: the API key in
+// the Authorization header, else the access_token cookie.
+// Anonymous callers share the empty scope.
+func scopeOf(r *http.Request) string {
+	cred := r.Header.Get("Authorization")
+	if cred == "" {
+		if c, err := r.Cookie("access_token"); err == nil {
+			cred = c.Value
+		}
+	}
+	if cred == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(cred))
+	return hex.EncodeToString(sum[:])
+}
+
+// writeProblem writes a problem+json error response.
+func writeProblem(
+	w http.ResponseWriter, r *http.Request,
+	status int, slug, detail string,
+) {
+	middleware.WriteProblem(w,
+		middleware.Problem(r.Context(), status, slug, detail))
+}
@@ -0,0 +1,337 @@
+package idempotency_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hhubris/petstore/internal/idempotency"
+)
+
+// memStore is an in-memory idempotency.Store.
+type memStore struct {
+	records  map[string]idempotency.Record
+	tokens   map[string]string
+	released []string
+	beginErr error
+}
+
+func newMemStore() *memStore {
+	return &memStore{
+		records: map[string]idempotency.Record{},
+		tokens:  map[string]string{},
+	}
+}
+
+func (s *memStore) Begin(
+	_ context.Context, scope, key, token, fp string, _, _ time.Time,
+) (idempotency.Record, bool, error) {
+	if s.beginErr != nil {
+		return idempotency.Record{}, false, s.beginErr
+	}
+	if rec, ok := s.records[scope+"/"+key]; ok {
+		return rec, false, nil
+	}
+	rec := idempotency.Record{Fingerprint: fp}
+	s.records[scope+"/"+key] = rec
+	s.tokens[scope+"/"+key] = token
+	return rec, true, nil
+}
+
+func (s *memStore) Touch(_ context.Context, scope, key, token string) error {
+	if s.tokens[scope+"/"+key] != token {
+		return idempotency.ErrClaimLost
+	}
+	return nil
+}
+
+func (s *memStore) Complete(
+	_ context.Context, scope, key, token string, resp idempotency.Response,
+) error {
+	if s.tokens[scope+"/"+key] != token {
+		return idempotency.ErrClaimLost
+	}
+	rec := s.records[scope+"/"+key]
+	rec.Response = &resp
+	s.records[scope+"/"+key] = rec
+	return nil
+}
+
+func (s *memStore) Release(_ context.Context, scope, key, token string) error {
+	if s.tokens[scope+"/"+key] == token {
+		delete(s.records, scope+"/"+key)
+		delete(s.tokens, scope+"/"+key)
+	}
+	s.released = append(s.released, key)
+	return nil
+}
+
+// counter is a handler that counts calls and answers 201
+// with the call number.
+type counter struct {
+	calls  int
+	header http.Header
+	status int
+}
+
+func (c *counter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.calls++
+	_, _ = io.ReadAll(r.Body)
+	for k, v := range c.header {
+		w.Header()[k] = v
+	}
+	w.Header().Set("Content-Type", "application/json")
+	status := c.status
+	if status == 0 {
+		status = http.StatusCreated
+	}
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, `{"call":`+strconv.Itoa(c.calls)+`}`)
+}
+
+func post(h http.Handler, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/pets",
+		strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(idempotency.Header, key)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMiddlewareReplaysResponse(t *testing.T) {
+	next := &counter{}
+	h := idempotency.Middleware(newMemStore(), time.Hour)(next)
+
+	first := post(h, "k1", `{"name":"Rex"}`)
+	second := post(h, "k1", `{"name":"Rex"}`)
+
+	if next.calls != 1 {
+		t.Fatalf("calls = %d, want 1", next.calls)
+	}
+	if second.Code != http.StatusCreated {
+		t.Errorf("status = %d, want 201", second.Code)
+	}
+	if second.Body.String() != first.Body.String() {
+		t.Errorf("body = %q, want %q", second.Body, first.Body)
+	}
+	if got := second.Header().Get(idempotency.ReplayedHeader); got != "true" {
+		t.Errorf("%s = %q, want true", idempotency.ReplayedHeader, got)
+	}
+	if first.Header().Get(idempotency.ReplayedHeader) != "" {
+		t.Error("first response marked as replayed")
+	}
+}
+
+func TestMiddlewareRejectsDifferentBody(t *testing.T) {
+	next := &counter{}
+	h := idempotency.Middleware(newMemStore(), time.Hour)(next)
+
+	post(h, "k1", `{"name":"Rex"}`)
+	rec := post(h, "k1", `{"name":"Fido"}`)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want 422", rec.Code)
+	}
+	if next.calls != 1 {
+		t.Errorf("calls = %d, want 1", next.calls)
+	}
+}
+
+func TestMiddlewarePendingKey(t *testing.T) {
+	store := newMemStore()
+	h := idempotency.Middleware(store, time.Hour)(&counter{})
+
+	// The inner request retries the key while the outer
+	// one still holds it.
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		req := httptest.NewRequest(http.MethodPost, "/pets",
+			strings.NewReader(string(body)))
+		req.Header.Set(idempotency.Header, "k1")
+		h.ServeHTTP(w, req)
+	})
+	outer := idempotency.Middleware(store, time.Hour)(inner)
+
+	rec := post(outer, "k1", `{}`)
+	if rec.Code != http.StatusConflict {
+		t.Errorf("status = %d, want 409", rec.Code)
+	}
+}
+
+func TestMiddlewareDoesNotStore(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		status int
+	}{
+		{
+			name:   "server error",
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:   "sets cookie",
+			header: http.Header{"Set-Cookie": {"access_token=x"}},
+		},
+		{
+			name:   "no-store",
+			header: http.Header{"Cache-Control": {"no-store"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemStore()
+			next := &counter{header: tt.header, status: tt.status}
+			h := idempotency.Middleware(store, time.Hour)(next)
+
+			post(h, "k1", `{}`)
+			post(h, "k1", `{}`)
+
+			if next.calls != 2 {
+				t.Errorf("calls = %d, want 2", next.calls)
+			}
+			if len(store.released) != 2 {
+				t.Errorf("released = %v, want two releases",
+					store.released)
+			}
+		})
+	}
+}
+
+func TestMiddlewareKeepsTakenOverClaim(t *testing.T) {
+	store := newMemStore()
+	// While the first request runs, its claim goes stale and
+	// a retry takes it over under another token.
+	h := idempotency.Middleware(store, time.Hour)(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			store.tokens["/k1"] = "retry"
+			w.WriteHeader(http.StatusCreated)
+		},
+	))
+
+	post(h, "k1", `{}`)
+
+	if rec := store.records["/k1"]; rec.Response != nil {
+		t.Errorf("response = %+v, want the retry's claim left pending",
+			rec.Response)
+	}
+}
+
+func TestMiddlewareReleasesOnPanic(t *testing.T) {
+	store := newMemStore()
+	h := idempotency.Middleware(store, time.Hour)(http.HandlerFunc(
+		func(http.ResponseWriter, *http.Request) { panic("boom") },
+	))
+
+	func() {
+		defer func() { _ = recover() }()
+		post(h, "k1", `{}`)
+	}()
+
+	if len(store.released) != 1 {
+		t.Errorf("released = %v, want one release", store.released)
+	}
+}
+
+func TestMiddlewarePassesThrough(t *testing.T) {
+	next := &counter{}
+	h := idempotency.Middleware(newMemStore(), time.Hour)(next)
+
+	post(h, "", `{}`)
+	post(h, "", `{}`)
+
+	req := httptest.NewRequest(http.MethodGet, "/pets", nil)
+	req.Header.Set(idempotency.Header, "k1")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	if next.calls != 4 {
+		t.Errorf("calls = %d, want 4", next.calls)
+	}
+}
+
+func TestMiddlewareErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		beginErr error
+		want     int
+	}{
+		{
+			name: "key too long",
+			key:  strings.Repeat("k", 256),
+			want: http.StatusBadRequest,
+		},
+		{
+			name:     "key busy",
+			key:      "k1",
+			beginErr: idempotency.ErrKeyBusy,
+			want:     http.StatusConflict,
+		},
+		{
+			name:     "store error",
+			key:      "k1",
+			beginErr: errors.New("connection refused"),
+			want:     http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemStore()
+			store.beginErr = tt.beginErr
+			next := &counter{}
+			h := idempotency.Middleware(store, time.Hour)(next)
+
+			rec := post(h, tt.key, `{}`)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if next.calls != 0 {
+				t.Errorf("calls = %d, want 0", next.calls)
+			}
+		})
+	}
+}
+
+func TestMiddlewareRejectsLargeBody(t *testing.T) {
+	store := newMemStore()
+	next := &counter{}
+	h := idempotency.Middleware(store, time.Hour)(next)
+
+	rec := post(h, "k1", strings.Repeat("x", 32<<20+1))
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want 413", rec.Code)
+	}
+	if next.calls != 0 {
+		t.Errorf("calls = %d, want 0", next.calls)
+	}
+	if len(store.records) != 0 {
+		t.Errorf("records = %v, want none claimed", store.records)
+	}
+}
+
+func TestMiddlewareScopesKeysByCredentials(t *testing.T) {
+	next := &counter{}
+	h := idempotency.Middleware(newMemStore(), time.Hour)(next)
+
+	for _, token := range []string{"psk_a", "psk_b"} {
+		req := httptest.NewRequest(http.MethodPost, "/pets",
+			strings.NewReader(`{}`))
+		req.Header.Set(idempotency.Header, "k1")
+		req.Header.Set("Authorization", "Bearer "+token)
+		h.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if next.calls != 2 {
+		t.Errorf("calls = %d, want 2", next.calls)
+	}
+}
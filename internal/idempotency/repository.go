@@ -0,0 +1,170 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// dbtx is the database interface required by
+// KeyRepository. Satisfied by *pgxpool.Pool, pgx.Tx, and
+// pgxmock.
+type dbtx interface {
+	QueryRow(ctx context.Context, sql string,
+		args ...any) pgx.Row
+	Exec(ctx context.Context, sql string,
+		args ...any) (pgconn.CommandTag, error)
+}
+
+// KeyRepository provides database access for idempotency
+// keys.
+type KeyRepository struct {
+	db dbtx
+}
+
+// NewKeyRepository returns a KeyRepository backed by the
+// given database connection.
+func NewKeyRepository(conn dbtx) *KeyRepository {
+	return &KeyRepository{db: conn}
+}
+
+// Begin claims key within scope for a request with the
+// given fingerprint, under token. It returns true if the
+// key was free, expired, or abandoned — a pending claim
+// whose last heartbeat was before staleBefore — and is now
+// held by the caller. Otherwise it returns the existing
+// record and false.
+func (r *KeyRepository) Begin(
+	ctx context.Context,
+	scope, key, token, fingerprint string,
+	expiresAt, staleBefore time.Time,
+) (Record, bool, error) {
+	var claimed string
+	err := r.db.QueryRow(ctx,
+		"INSERT INTO idempotency_keys "+
+			"(scope, key, claim_token, fingerprint, expires_at) "+
+			"VALUES ($1, $2, $3, $4, $5) "+
+			"ON CONFLICT (scope, key) DO UPDATE SET "+
+			"claim_token = EXCLUDED.claim_token, "+
+			"fingerprint = EXCLUDED.fingerprint, status_code = NULL, "+
+			"content_type = '', body = NULL, created_at = now(), "+
+			"heartbeat_at = now(), expires_at = EXCLUDED.expires_at "+
+			"WHERE idempotency_keys.expires_at <= now() "+
+			"OR (idempotency_keys.status_code IS NULL "+
+			"AND idempotency_keys.heartbeat_at < $6) "+
+			"RETURNING fingerprint",
+		scope, key, token, fingerprint, expiresAt, staleBefore,
+	).Scan(&claimed)
+	if err == nil {
+		return Record{Fingerprint: claimed}, true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return Record{}, false, fmt.Errorf("claim idempotency key: %w", err)
+	}
+
+	var (
+		rec    Record
+		status *int
+		ctype  string
+		body   []byte
+	)
+	err = r.db.QueryRow(ctx,
+		"SELECT fingerprint, status_code, content_type, body "+
+			"FROM idempotency_keys WHERE scope = $1 AND key = $2",
+		scope, key,
+	).Scan(&rec.Fingerprint, &status, &ctype, &body)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Record{}, false, ErrKeyBusy
+		}
+		return Record{}, false, fmt.Errorf("find idempotency key: %w", err)
+	}
+	if status != nil {
+		rec.Response = &Response{
+			StatusCode: *status, ContentType: ctype, Body: body,
+		}
+	}
+	return rec, false, nil
+}
+
+// Touch records a heartbeat for a pending claim held under
+// token, so it is not taken over as abandoned. Returns
+// ErrClaimLost if the claim is no longer the caller's.
+func (r *KeyRepository) Touch(
+	ctx context.Context,
+	scope, key, token string,
+) error {
+	tag, err := r.db.Exec(ctx,
+		"UPDATE idempotency_keys SET heartbeat_at = now() "+
+			"WHERE scope = $1 AND key = $2 AND claim_token = $3 "+
+			"AND status_code IS NULL",
+		scope, key, token,
+	)
+	if err != nil {
+		return fmt.Errorf("touch idempotency key: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrClaimLost
+	}
+	return nil
+}
+
+// Complete stores the response for a key claimed with
+// Begin under token. Returns ErrClaimLost if the claim was
+// taken over, leaving the other request's record alone.
+func (r *KeyRepository) Complete(
+	ctx context.Context,
+	scope, key, token string,
+	resp Response,
+) error {
+	tag, err := r.db.Exec(ctx,
+		"UPDATE idempotency_keys "+
+			"SET status_code = $4, content_type = $5, body = $6 "+
+			"WHERE scope = $1 AND key = $2 AND claim_token = $3 "+
+			"AND status_code IS NULL",
+		scope, key, token, resp.StatusCode, resp.ContentType, resp.Body,
+	)
+	if err != nil {
+		return fmt.Errorf("complete idempotency key: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrClaimLost
+	}
+	return nil
+}
+
+// Release drops a pending claim held under token so the key
+// can be used again, for requests whose response is not
+// stored. A claim taken over by another request is left
+// alone.
+func (r *KeyRepository) Release(
+	ctx context.Context,
+	scope, key, token string,
+) error {
+	_, err := r.db.Exec(ctx,
+		"DELETE FROM idempotency_keys "+
+			"WHERE scope = $1 AND key = $2 AND claim_token = $3 "+
+			"AND status_code IS NULL",
+		scope, key, token,
+	)
+	if err != nil {
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired removes expired keys and returns how many
+// were removed.
+func (r *KeyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	tag, err := r.db.Exec(ctx,
+		"DELETE FROM idempotency_keys WHERE expires_at <= now()",
+	)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired idempotency keys: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,258 @@
+package idempotency_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+
+	"github.com/hhubris/petstore/internal/idempotency"
+)
+
+func TestRepositoryBegin(t *testing.T) {
+	expires := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	stale := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	status := 201
+
+	tests := []struct {
+		name        string
+		setup       func(pgxmock.PgxPoolIface)
+		want        idempotency.Record
+		wantStarted bool
+		wantErr     error
+		anyErr      bool
+	}{
+		{
+			name: "claims free key",
+			setup: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("INSERT INTO idempotency_keys").
+					WithArgs("s", "k", "tok", "fp", expires, stale).
+					WillReturnRows(pgxmock.NewRows(
+						[]string{"fingerprint"}).AddRow("fp"))
+			},
+			want:        idempotency.Record{Fingerprint: "fp"},
+			wantStarted: true,
+		},
+		{
+			name: "returns completed record",
+			setup: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("INSERT INTO idempotency_keys").
+					WithArgs("s", "k", "tok", "fp", expires, stale).
+					WillReturnError(pgx.ErrNoRows)
+				m.ExpectQuery("SELECT fingerprint").
+					WithArgs("s", "k").
+					WillReturnRows(pgxmock.NewRows([]string{
+						"fingerprint", "status_code", "content_type", "body",
+					}).AddRow("fp", &status, "application/json",
+						[]byte(`{"id":1}`)))
+			},
+			want: idempotency.Record{
+				Fingerprint: "fp",
+				Response: &idempotency.Response{
+					StatusCode:  201,
+					ContentType: "application/json",
+					Body:        []byte(`{"id":1}`),
+				},
+			},
+		},
+		{
+			name: "returns pending record",
+			setup: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("INSERT INTO idempotency_keys").
+					WithArgs("s", "k", "tok", "fp", expires, stale).
+					WillReturnError(pgx.ErrNoRows)
+				m.ExpectQuery("SELECT fingerprint").
+					WithArgs("s", "k").
+					WillReturnRows(pgxmock.NewRows([]string{
+						"fingerprint", "status_code", "content_type", "body",
+					}).AddRow("other", (*int)(nil), "", []byte(nil)))
+			},
+			want: idempotency.Record{Fingerprint: "other"},
+		},
+		{
+			name: "record vanished",
+			setup: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("INSERT INTO idempotency_keys").
+					WithArgs("s", "k", "tok", "fp", expires, stale).
+					WillReturnError(pgx.ErrNoRows)
+				m.ExpectQuery("SELECT fingerprint").
+					WithArgs("s", "k").
+					WillReturnError(pgx.ErrNoRows)
+			},
+			wantErr: idempotency.ErrKeyBusy,
+		},
+		{
+			name: "database error",
+			setup: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("INSERT INTO idempotency_keys").
+					WithArgs("s", "k", "tok", "fp", expires, stale).
+					WillReturnError(errors.New("connection refused"))
+			},
+			anyErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+			tt.setup(mock)
+
+			repo := idempotency.NewKeyRepository(mock)
+			got, started, err := repo.Begin(context.Background(),
+				"s", "k", "tok", "fp", expires, stale)
+			switch {
+			case tt.anyErr:
+				if err == nil {
+					t.Fatal("expected error")
+				}
+			case !errors.Is(err, tt.wantErr):
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if started != tt.wantStarted {
+				t.Errorf("started = %v, want %v", started, tt.wantStarted)
+			}
+			if got.Fingerprint != tt.want.Fingerprint {
+				t.Errorf("fingerprint = %q, want %q",
+					got.Fingerprint, tt.want.Fingerprint)
+			}
+			if (got.Response == nil) != (tt.want.Response == nil) {
+				t.Fatalf("response = %v, want %v",
+					got.Response, tt.want.Response)
+			}
+			if got.Response != nil &&
+				(got.Response.StatusCode != tt.want.Response.StatusCode ||
+					got.Response.ContentType != tt.want.Response.ContentType ||
+					string(got.Response.Body) != string(tt.want.Response.Body)) {
+				t.Errorf("response = %+v, want %+v",
+					got.Response, tt.want.Response)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestRepositoryTouch(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    int64
+		wantErr error
+	}{
+		{name: "claim held", rows: 1},
+		{name: "claim taken over", wantErr: idempotency.ErrClaimLost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			mock.ExpectExec("UPDATE idempotency_keys SET heartbeat_at = now\\(\\) "+
+				"WHERE scope = \\$1 AND key = \\$2 AND claim_token = \\$3").
+				WithArgs("s", "k", "tok").
+				WillReturnResult(pgxmock.NewResult("UPDATE", tt.rows))
+
+			repo := idempotency.NewKeyRepository(mock)
+			err = repo.Touch(context.Background(), "s", "k", "tok")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestRepositoryComplete(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    int64
+		wantErr error
+	}{
+		{name: "claim held", rows: 1},
+		{name: "claim taken over", wantErr: idempotency.ErrClaimLost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			mock.ExpectExec("UPDATE idempotency_keys .* "+
+				"WHERE scope = \\$1 AND key = \\$2 AND claim_token = \\$3").
+				WithArgs("s", "k", "tok", 201, "application/json", []byte("{}")).
+				WillReturnResult(pgxmock.NewResult("UPDATE", tt.rows))
+
+			repo := idempotency.NewKeyRepository(mock)
+			err = repo.Complete(context.Background(), "s", "k", "tok",
+				idempotency.Response{
+					StatusCode: 201, ContentType: "application/json",
+					Body: []byte("{}"),
+				})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestRepositoryRelease(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	mock.ExpectExec("DELETE FROM idempotency_keys .*claim_token = \\$3").
+		WithArgs("s", "k", "tok").
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	repo := idempotency.NewKeyRepository(mock)
+	if err := repo.Release(context.Background(), "s", "k", "tok"); err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRepositoryDeleteExpired(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	mock.ExpectExec("DELETE FROM idempotency_keys WHERE expires_at").
+		WillReturnResult(pgxmock.NewResult("DELETE", 3))
+
+	repo := idempotency.NewKeyRepository(mock)
+	n, err := repo.DeleteExpired(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Errorf("n = %d, want 3", n)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,66 @@
+// Package jobs runs periodic background work inside the
+// server process. Each Job has a cron-like Schedule and a
+// timeout. Every replica runs a Scheduler, but only the one
+// holding a Postgres advisory lock, the leader, runs jobs;
+// the others wait to take over if the leader goes away.
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// defaultTimeout bounds a job run when the job sets no
+// Timeout.
+const defaultTimeout = 5 * time.Minute
+
+// Job is a unit of periodic work.
+type Job struct {
+	// Name identifies the job in logs and status. It must be
+	// unique within a Scheduler.
+	Name string
+	// Schedule is a ParseSchedule specification.
+	Schedule string
+	// Timeout bounds each run; zero means five minutes.
+	Timeout time.Duration
+	// Run does the work. Its context is cancelled at the
+	// timeout, at shutdown, and when leadership is lost.
+	Run func(ctx context.Context) error
+}
+
+// Status is a snapshot of a job's counters in this process.
+// Counters start at zero when the process starts; a replica
+// that is not the leader runs nothing.
+type Status struct {
+	Name     string
+	Schedule string
+	Timeout  time.Duration
+	// Runs counts completed runs, Failures those among them
+	// that returned an error, and Skipped the scheduled runs
+	// dropped because the previous run was still going.
+	Runs     int64
+	Failures int64
+	Skipped  int64
+	Running  bool
+	// The fields below are nil or zero until the first run.
+	LastStartedAt   *time.Time
+	LastDuration    time.Duration
+	LastError       string
+	LastSucceededAt *time.Time
+	// NextRunAt is nil unless this process is the leader.
+	NextRunAt *time.Time
+}
+
+// loggerKey is the context key for a running job's logger.
+type loggerKey struct{}
+
+// Logger returns the logger for the job running with ctx:
+// the default logger tagged with the job's name. Outside a
+// job it returns the default logger.
+func Logger(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return l
+	}
+	return slog.Default()
+}
@@ -0,0 +1,218 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSchedule is returned by ParseSchedule for a
+// specification it cannot parse or that never fires.
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// Schedule says when a job runs.
+type Schedule interface {
+	// Next returns the first run time strictly after t, or
+	// the zero time if there is none.
+	Next(t time.Time) time.Time
+}
+
+// descriptors are the named shorthands ParseSchedule
+// accepts for common cron expressions.
+var descriptors = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+}
+
+// ParseSchedule parses a cron-like specification:
+//
+//   - five fields, "minute hour day-of-month month
+//     day-of-week", each "*", a number, a range "a-b", or a
+//     list of these separated by commas, with an optional
+//     step "/n". Day-of-week runs 0 (Sunday) to 7 (Sunday
+//     again); names are not accepted. As in cron, when both
+//     day fields are restricted a day matching either one
+//     runs;
+//   - a shorthand: @hourly, @daily or @midnight, @weekly,
+//     @monthly, @yearly or @annually;
+//   - "@every d" with a positive time.ParseDuration, run at
+//     that interval after the scheduler starts.
+//
+// Cron schedules are evaluated in UTC.
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if d, ok := strings.CutPrefix(spec, "@every "); ok {
+		every, err := time.ParseDuration(strings.TrimSpace(d))
+		if err != nil || every <= 0 {
+			return nil, fmt.Errorf("%w %q: bad interval", ErrInvalidSchedule, spec)
+		}
+		return interval(every), nil
+	}
+	expr := spec
+	if e, ok := descriptors[spec]; ok {
+		expr = e
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w %q: want 5 fields", ErrInvalidSchedule, spec)
+	}
+	var c cron
+	sets := []*uint64{&c.minute, &c.hour, &c.dom, &c.month, &c.dow}
+	for i, f := range fields {
+		set, err := parseField(f, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %s: %v",
+				ErrInvalidSchedule, spec, cronFields[i].name, err)
+		}
+		*sets[i] = set
+	}
+	// 7 is another way to write Sunday.
+	if c.dow&(1<<7) != 0 {
+		c.dow = c.dow&^(1<<7) | 1
+	}
+	// As in cron, a field starting with "*" counts as
+	// unrestricted for the either-day rule.
+	c.anyDOM = strings.HasPrefix(fields[2], "*")
+	c.anyDOW = strings.HasPrefix(fields[4], "*")
+
+	if c.Next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("%w %q: never fires", ErrInvalidSchedule, spec)
+	}
+	return c, nil
+}
+
+// interval is an "@every" schedule.
+type interval time.Duration
+
+// Next returns t plus the interval.
+func (i interval) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(i))
+}
+
+// cronField describes one field of a cron expression.
+type cronField struct {
+	name     string
+	min, max int
+}
+
+// cronFields lists the fields in expression order.
+var cronFields = [5]cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// parseField returns the set of values, as bits, that the
+// field f admits.
+func parseField(f string, cf cronField) (uint64, error) {
+	var set uint64
+	for part := range strings.SplitSeq(f, ",") {
+		rng, stepText, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepText)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("bad step %q", stepText)
+			}
+			step = n
+		}
+
+		lo, hi := cf.min, cf.max
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			a, b, _ := strings.Cut(rng, "-")
+			var err error
+			if lo, err = parseValue(a, cf); err != nil {
+				return 0, err
+			}
+			if hi, err = parseValue(b, cf); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("bad range %q", rng)
+			}
+		default:
+			v, err := parseValue(rng, cf)
+			if err != nil {
+				return 0, err
+			}
+			lo = v
+			if !hasStep {
+				hi = v
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+// parseValue parses a single number within cf's bounds.
+func parseValue(s string, cf cronField) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < cf.min || v > cf.max {
+		return 0, fmt.Errorf("%q is not in %d-%d", s, cf.min, cf.max)
+	}
+	return v, nil
+}
+
+// cron is a parsed five-field schedule. Each set has bit v
+// set when value v matches.
+type cron struct {
+	minute, hour, dom, month, dow uint64
+	anyDOM, anyDOW                bool
+}
+
+// searchLimit bounds how far ahead Next looks; every valid
+// schedule fires within it, leap days included.
+const searchLimit = 5 * 366 * 24 * time.Hour
+
+// Next returns the first matching minute after t, in UTC.
+func (c cron) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(searchLimit)
+	for t.Before(limit) {
+		switch {
+		case !has(c.month, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !has(c.hour, t.Hour()):
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case !has(c.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches reports whether t's day matches the day of
+// month and day of week fields. When both are restricted,
+// a day matching either is enough.
+func (c cron) dayMatches(t time.Time) bool {
+	dom := has(c.dom, t.Day())
+	dow := has(c.dow, int(t.Weekday()))
+	if c.anyDOM || c.anyDOW {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// has reports whether bit v of set is set.
+func has(set uint64, v int) bool {
+	return set&(1<<v) != 0
+}
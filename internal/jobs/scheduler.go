@@ -0,0 +1,255 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// electionInterval is how often a replica that is not the
+// leader tries to become it.
+const electionInterval = 15 * time.Second
+
+// leaderLockKey is the advisory lock key that elects the
+// leader: the FNV-1a hash of "petstore/jobs".
+var leaderLockKey = lockKey("petstore/jobs")
+
+// lockKey returns an advisory lock key for name.
+func lockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return int64(h.Sum64())
+}
+
+// LockFunc takes the advisory lock key, if no other session
+// holds it, and calls fn while holding it, with a context
+// that is cancelled if the lock is lost. It reports whether
+// the lock was taken. db.DB.WithAdvisoryLock satisfies it.
+type LockFunc func(
+	ctx context.Context, key int64, fn func(ctx context.Context),
+) (bool, error)
+
+// entry is a job with its parsed schedule and status.
+type entry struct {
+	job      Job
+	schedule Schedule
+	timeout  time.Duration
+	next     time.Time
+	status   Status
+}
+
+// Scheduler runs jobs on their schedules while it is the
+// leader.
+type Scheduler struct {
+	entries []*entry
+
+	mu     sync.Mutex // guards the fields below and entries' next and status
+	leader bool
+}
+
+// NewScheduler returns a Scheduler for jobs. It fails if a
+// job has no name or Run function, a name is repeated, or a
+// schedule does not parse. Call Run to start it.
+func NewScheduler(jobs ...Job) (*Scheduler, error) {
+	s := &Scheduler{}
+	seen := make(map[string]bool)
+	for _, j := range jobs {
+		if j.Name == "" || j.Run == nil {
+			return nil, errors.New("job needs a name and a run function")
+		}
+		if seen[j.Name] {
+			return nil, fmt.Errorf("duplicate job %q", j.Name)
+		}
+		seen[j.Name] = true
+		sched, err := ParseSchedule(j.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("job %q: %w", j.Name, err)
+		}
+		timeout := j.Timeout
+		if timeout <= 0 {
+			timeout = defaultTimeout
+		}
+		s.entries = append(s.entries, &entry{
+			job:      j,
+			schedule: sched,
+			timeout:  timeout,
+			status: Status{
+				Name: j.Name, Schedule: j.Schedule, Timeout: timeout,
+			},
+		})
+	}
+	return s, nil
+}
+
+// Run competes for leadership through lock until ctx is
+// cancelled, running jobs whenever it leads. It returns once
+// every job it started has returned.
+func (s *Scheduler) Run(ctx context.Context, lock LockFunc) {
+	for {
+		held, err := lock(ctx, leaderLockKey, s.lead)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "job scheduler election failed",
+				"error", err)
+		} else if held {
+			slog.WarnContext(ctx, "job scheduler stopped leading")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(electionInterval):
+		}
+	}
+}
+
+// Leader reports whether this scheduler is currently the
+// leader.
+func (s *Scheduler) Leader() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.leader
+}
+
+// Status returns a snapshot of every job's status, in the
+// order the jobs were given.
+func (s *Scheduler) Status() []Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Status, len(s.entries))
+	for i, e := range s.entries {
+		out[i] = e.status
+		if s.leader && !e.next.IsZero() {
+			next := e.next
+			out[i].NextRunAt = &next
+		}
+	}
+	return out
+}
+
+// lead runs jobs as they fall due until ctx is cancelled,
+// then waits for running jobs to return.
+func (s *Scheduler) lead(ctx context.Context) {
+	slog.InfoContext(ctx, "job scheduler leading")
+	var wg sync.WaitGroup
+	s.mu.Lock()
+	s.leader = true
+	now := time.Now()
+	for _, e := range s.entries {
+		e.next = e.schedule.Next(now)
+	}
+	s.mu.Unlock()
+	defer func() {
+		wg.Wait()
+		s.mu.Lock()
+		s.leader = false
+		s.mu.Unlock()
+	}()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		timer.Reset(time.Until(s.nextDue()))
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		now := time.Now()
+		s.mu.Lock()
+		for _, e := range s.entries {
+			if e.next.IsZero() || e.next.After(now) {
+				continue
+			}
+			e.next = e.schedule.Next(now)
+			if e.status.Running {
+				e.status.Skipped++
+				slog.WarnContext(ctx, "job still running, skipping run",
+					"job", e.job.Name)
+				continue
+			}
+			e.status.Running = true
+			wg.Go(func() { s.run(ctx, e) })
+		}
+		s.mu.Unlock()
+	}
+}
+
+// nextDue returns the earliest next run time, or a time far
+// in the future when no job has one.
+func (s *Scheduler) nextDue() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var next time.Time
+	for _, e := range s.entries {
+		if !e.next.IsZero() && (next.IsZero() || e.next.Before(next)) {
+			next = e.next
+		}
+	}
+	if next.IsZero() {
+		return time.Now().Add(24 * time.Hour)
+	}
+	return next
+}
+
+// run makes one run of e's job and records the outcome.
+func (s *Scheduler) run(ctx context.Context, e *entry) {
+	log := slog.With("job", e.job.Name)
+	runCtx, cancel := context.WithTimeout(
+		context.WithValue(ctx, loggerKey{}, log), e.timeout)
+	defer cancel()
+
+	start := time.Now()
+	s.mu.Lock()
+	e.status.LastStartedAt = &start
+	s.mu.Unlock()
+	log.DebugContext(ctx, "job started")
+
+	err := call(runCtx, e.job.Run)
+	d := time.Since(start)
+
+	s.mu.Lock()
+	st := &e.status
+	st.Running = false
+	st.Runs++
+	st.LastDuration = d
+	st.LastError = ""
+	if err != nil {
+		st.Failures++
+		st.LastError = err.Error()
+	} else {
+		st.LastSucceededAt = &start
+	}
+	s.mu.Unlock()
+
+	switch {
+	case err == nil:
+		log.InfoContext(ctx, "job finished", "duration", d)
+	case ctx.Err() != nil:
+		log.WarnContext(ctx, "job interrupted", "duration", d,
+			"error", err)
+	case errors.Is(err, context.DeadlineExceeded):
+		log.ErrorContext(ctx, "job timed out", "duration", d,
+			"timeout", e.timeout, "error", err)
+	default:
+		log.ErrorContext(ctx, "job failed", "duration", d,
+			"error", err)
+	}
+}
+
+// call runs fn, turning a panic into an error so one job
+// cannot take down the server.
+func call(ctx context.Context, fn func(context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
@@ -0,0 +1,276 @@
+package jobs_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hhubris/petstore/internal/jobs"
+)
+
+// leading is a LockFunc that always wins the election.
+func leading(
+	ctx context.Context, _ int64, fn func(ctx context.Context),
+) (bool, error) {
+	fn(ctx)
+	return true, nil
+}
+
+// following is a LockFunc that never wins the election.
+func following(context.Context, int64, func(context.Context)) (bool, error) {
+	return false, nil
+}
+
+// startScheduler runs s with lock until the test ends and
+// returns a function that stops it and waits for Run to
+// return.
+func startScheduler(
+	t *testing.T, s *jobs.Scheduler, lock jobs.LockFunc,
+) (stop func()) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx, lock)
+		close(done)
+	}()
+	stop = func() {
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("scheduler did not stop")
+		}
+	}
+	t.Cleanup(stop)
+	return stop
+}
+
+// waitFor polls cond until it holds or the test times out.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestNewSchedulerErrors(t *testing.T) {
+	run := func(context.Context) error { return nil }
+	tests := []struct {
+		name string
+		jobs []jobs.Job
+	}{
+		{"no name", []jobs.Job{{Schedule: "@hourly", Run: run}}},
+		{"no run", []jobs.Job{{Name: "a", Schedule: "@hourly"}}},
+		{"bad schedule", []jobs.Job{{Name: "a", Schedule: "often", Run: run}}},
+		{"duplicate", []jobs.Job{
+			{Name: "a", Schedule: "@hourly", Run: run},
+			{Name: "a", Schedule: "@daily", Run: run},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := jobs.NewScheduler(tt.jobs...); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestSchedulerRunsJobsWhenLeading(t *testing.T) {
+	var ok, failing atomic.Int64
+	s, err := jobs.NewScheduler(
+		jobs.Job{Name: "ok", Schedule: "@every 10ms",
+			Run: func(context.Context) error {
+				ok.Add(1)
+				return nil
+			}},
+		jobs.Job{Name: "failing", Schedule: "@every 10ms",
+			Run: func(context.Context) error {
+				failing.Add(1)
+				return errors.New("boom")
+			}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stop := startScheduler(t, s, leading)
+	waitFor(t, "three runs of each job", func() bool {
+		return ok.Load() >= 3 && failing.Load() >= 3
+	})
+	if !s.Leader() {
+		t.Error("Leader() = false while leading")
+	}
+	st := s.Status()
+	if st[1].NextRunAt == nil {
+		t.Error("no next run time while leading")
+	}
+	stop()
+
+	st = s.Status()
+	if st[0].Name != "ok" || st[0].Runs < 3 || st[0].Failures != 0 ||
+		st[0].LastSucceededAt == nil || st[0].LastError != "" {
+		t.Errorf("got status %+v", st[0])
+	}
+	if st[1].Runs < 3 || st[1].Failures != st[1].Runs ||
+		st[1].LastError != "boom" || st[1].LastSucceededAt != nil {
+		t.Errorf("got status %+v", st[1])
+	}
+	if s.Leader() || st[1].NextRunAt != nil {
+		t.Error("still leading after stopping")
+	}
+}
+
+func TestSchedulerIdleWhenFollowing(t *testing.T) {
+	var runs atomic.Int64
+	s, err := jobs.NewScheduler(jobs.Job{
+		Name: "a", Schedule: "@every 5ms",
+		Run: func(context.Context) error {
+			runs.Add(1)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stop := startScheduler(t, s, following)
+	time.Sleep(50 * time.Millisecond)
+	stop()
+
+	if n := runs.Load(); n != 0 || s.Leader() {
+		t.Errorf("follower ran %d jobs", n)
+	}
+}
+
+func TestSchedulerTimeout(t *testing.T) {
+	s, err := jobs.NewScheduler(jobs.Job{
+		Name: "slow", Schedule: "@every 10ms", Timeout: 20 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	startScheduler(t, s, leading)
+	waitFor(t, "a timed-out run", func() bool {
+		return s.Status()[0].Runs > 0
+	})
+
+	st := s.Status()[0]
+	if st.Failures == 0 || st.LastError != context.DeadlineExceeded.Error() {
+		t.Errorf("got status %+v", st)
+	}
+	if st.Skipped == 0 {
+		t.Error("overlapping runs were not skipped")
+	}
+}
+
+func TestSchedulerRecoversPanics(t *testing.T) {
+	s, err := jobs.NewScheduler(jobs.Job{
+		Name: "panics", Schedule: "@every 10ms",
+		Run: func(context.Context) error { panic("oops") },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	startScheduler(t, s, leading)
+	waitFor(t, "a failed run", func() bool {
+		return s.Status()[0].Failures > 0
+	})
+	if got := s.Status()[0].LastError; got != "panic: oops" {
+		t.Errorf("got last error %q", got)
+	}
+}
+
+func TestSchedulerShutdownWaitsForJobs(t *testing.T) {
+	started := make(chan struct{})
+	var finished atomic.Bool
+	s, err := jobs.NewScheduler(jobs.Job{
+		Name: "a", Schedule: "@every 10ms",
+		Run: func(ctx context.Context) error {
+			select {
+			case started <- struct{}{}:
+			default:
+			}
+			<-ctx.Done()
+			time.Sleep(20 * time.Millisecond)
+			finished.Store(true)
+			return ctx.Err()
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stop := startScheduler(t, s, leading)
+	<-started
+	stop()
+
+	if !finished.Load() {
+		t.Error("Run returned before the running job")
+	}
+}
+
+func TestLogger(t *testing.T) {
+	if jobs.Logger(context.Background()) != slog.Default() {
+		t.Error("outside a job, Logger is not the default logger")
+	}
+
+	var buf syncBuffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+
+	logged := make(chan struct{})
+	s, err := jobs.NewScheduler(jobs.Job{
+		Name: "tagged", Schedule: "@every 10ms",
+		Run: func(ctx context.Context) error {
+			jobs.Logger(ctx).InfoContext(ctx, "hello")
+			select {
+			case logged <- struct{}{}:
+			default:
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stop := startScheduler(t, s, leading)
+	<-logged
+	stop()
+
+	if !strings.Contains(buf.String(), "msg=hello job=tagged") {
+		t.Errorf("log output %q lacks the job name", buf.String())
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
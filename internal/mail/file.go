@@ -0,0 +1,48 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// FileMailer writes each message to its own .eml file in a
+// directory instead of delivering it. Intended for local
+// development: the message body often contains one-time
+// tokens, so only the recipient, subject, and file path are
+// logged.
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+// NewFileMailer returns a FileMailer that writes messages
+// into dir, creating it on first use.
+func NewFileMailer(dir, from string) *FileMailer {
+	return &FileMailer{dir: dir, from: from}
+}
+
+// Send writes msg to a new file named after a ULID so files
+// sort in delivery order.
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	if err := os.MkdirAll(m.dir, 0o700); err != nil {
+		return fmt.Errorf("creating mail dir: %w", err)
+	}
+	path := filepath.Join(m.dir, ulid.Make().String()+".eml")
+	if err := os.WriteFile(
+		path, format(m.from, msg, time.Now()), 0o600,
+	); err != nil {
+		return fmt.Errorf("writing mail file: %w", err)
+	}
+	slog.InfoContext(ctx, "mail written",
+		"to", msg.To,
+		"subject", msg.Subject,
+		"path", path,
+	)
+	return nil
+}
@@ -0,0 +1,62 @@
+package mail_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/mail"
+)
+
+func TestFileMailerSend(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "outbox")
+	m := mail.NewFileMailer(dir, "noreply@petstore.test")
+
+	err := m.Send(context.Background(), mail.Message{
+		To:      "alice@example.com",
+		Subject: "Hello\r\nBcc: evil@example.com",
+		Body:    "line one\nline two",
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d files, want 1", len(entries))
+	}
+	if !strings.HasSuffix(entries[0].Name(), ".eml") {
+		t.Errorf("file %q lacks .eml suffix", entries[0].Name())
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	got := string(raw)
+
+	for _, want := range []string{
+		"From: noreply@petstore.test\r\n",
+		"To: alice@example.com\r\n",
+		"Subject: HelloBcc: evil@example.com\r\n",
+		"\r\n\r\nline one\r\nline two",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("message missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestDiscardSend(t *testing.T) {
+	err := mail.Discard.Send(
+		context.Background(), mail.Message{To: "a@b.com"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Message is a plain-text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends email. Implementations must be safe for
+// concurrent use.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// discard is a Mailer that drops every message.
+type discard struct{}
+
+func (discard) Send(context.Context, Message) error { return nil }
+
+// Discard is a Mailer on which all Send calls succeed
+// without doing anything.
+var Discard Mailer = discard{}
+
+// format renders msg as an RFC 5322 message with the given
+// sender and date. Header values are stripped of CR and LF
+// so user-supplied input cannot inject extra headers.
+func format(from string, msg Message, date time.Time) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", headerValue(from))
+	fmt.Fprintf(&b, "To: %s\r\n", headerValue(msg.To))
+	fmt.Fprintf(&b, "Subject: %s\r\n", headerValue(msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(
+		strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n",
+	))
+	return []byte(b.String())
+}
+
+// headerValue removes characters that would terminate a
+// header line.
+func headerValue(s string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+}
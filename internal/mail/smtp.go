@@ -0,0 +1,108 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+)
+
+// smtpTimeout bounds a single delivery when ctx has no
+// earlier deadline.
+const smtpTimeout = 30 * time.Second
+
+// SMTPMailer delivers messages to an SMTP server. It works
+// with a local catcher such as Mailpit or MailHog (no auth,
+// no TLS) as well as a real relay: STARTTLS is used when
+// the server offers it, and PLAIN auth when a username is
+// configured.
+type SMTPMailer struct {
+	host     string
+	port     string
+	from     string
+	username string
+	password string
+}
+
+// NewSMTPMailer returns an SMTPMailer for host:port. Leave
+// username empty to skip authentication.
+func NewSMTPMailer(
+	host, port, from, username, password string,
+) *SMTPMailer {
+	return &SMTPMailer{
+		host:     host,
+		port:     port,
+		from:     from,
+		username: username,
+		password: password,
+	}
+}
+
+// Send delivers msg in a single SMTP transaction. The
+// sender may include a display name ("Pet Store
+// <noreply@example.com>"): the envelope gets the bare
+// address and the From header keeps the display form.
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	sender, err := mail.ParseAddress(m.from)
+	if err != nil {
+		return fmt.Errorf("parsing sender: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, smtpTimeout)
+	defer cancel()
+
+	var d net.Dialer
+	conn, err := d.DialContext(
+		ctx, "tcp", net.JoinHostPort(m.host, m.port),
+	)
+	if err != nil {
+		return fmt.Errorf("dialing smtp server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("smtp handshake: %w", err)
+	}
+	defer func() { _ = c.Close() }()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{
+			ServerName: m.host,
+			MinVersion: tls.VersionTLS12,
+		}); err != nil {
+			return fmt.Errorf("smtp starttls: %w", err)
+		}
+	}
+	if m.username != "" {
+		if err := c.Auth(smtp.PlainAuth(
+			"", m.username, m.password, m.host,
+		)); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+
+	if err := c.Mail(sender.Address); err != nil {
+		return fmt.Errorf("smtp MAIL FROM: %w", err)
+	}
+	if err := c.Rcpt(headerValue(msg.To)); err != nil {
+		return fmt.Errorf("smtp RCPT TO: %w", err)
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("smtp DATA: %w", err)
+	}
+	if _, err := w.Write(format(m.from, msg, time.Now())); err != nil {
+		return fmt.Errorf("writing message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("finishing message: %w", err)
+	}
+	return c.Quit()
+}
@@ -0,0 +1,168 @@
+package mail_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/mail"
+)
+
+// fakeSMTP accepts a single connection on a random port,
+// speaks just enough SMTP for net/smtp, and sends the
+// envelope sender, envelope recipient and message data on
+// the returned channels.
+func fakeSMTP(
+	t *testing.T,
+) (host, port string, from, rcpt, data <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	fromCh := make(chan string, 1)
+	rcptCh := make(chan string, 1)
+	dataCh := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		r := bufio.NewReader(conn)
+		reply := func(s string) {
+			_, _ = conn.Write([]byte(s + "\r\n"))
+		}
+		reply("220 fake ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"):
+				reply("250-fake")
+				reply("250 8BITMIME")
+			case strings.HasPrefix(cmd, "MAIL FROM"):
+				fromCh <- strings.TrimSpace(line)
+				reply("250 OK")
+			case strings.HasPrefix(cmd, "RCPT TO"):
+				rcptCh <- strings.TrimSpace(line)
+				reply("250 OK")
+			case cmd == "DATA":
+				reply("354 go ahead")
+				var b strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					b.WriteString(l)
+				}
+				dataCh <- b.String()
+				reply("250 queued")
+			case cmd == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	host, port, err = net.SplitHostPort(ln.Addr().String())
+	if err != nil {
+		t.Fatalf("split addr: %v", err)
+	}
+	return host, port, fromCh, rcptCh, dataCh
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	host, port, from, rcpt, data := fakeSMTP(t)
+	m := mail.NewSMTPMailer(
+		host, port, "noreply@petstore.test", "", "",
+	)
+
+	err := m.Send(context.Background(), mail.Message{
+		To:      "alice@example.com",
+		Subject: "Reset your password",
+		Body:    "Follow the link.",
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	if got := <-from; !strings.HasPrefix(got,
+		"MAIL FROM:<noreply@petstore.test>") {
+		t.Errorf("got %q, want MAIL FROM:<noreply@petstore.test>", got)
+	}
+	if got := <-rcpt; got != "RCPT TO:<alice@example.com>" {
+		t.Errorf("got %q, want RCPT TO:<alice@example.com>", got)
+	}
+	body := <-data
+	for _, want := range []string{
+		"Subject: Reset your password\r\n",
+		"Follow the link.",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("message missing %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestSMTPMailerSendDisplayName(t *testing.T) {
+	host, port, from, _, data := fakeSMTP(t)
+	m := mail.NewSMTPMailer(
+		host, port, "Pet Store <noreply@petstore.test>", "", "",
+	)
+
+	err := m.Send(context.Background(), mail.Message{
+		To: "alice@example.com", Subject: "Hi", Body: "Hello.",
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	if got := <-from; !strings.HasPrefix(got,
+		"MAIL FROM:<noreply@petstore.test>") {
+		t.Errorf("got %q, want the bare address", got)
+	}
+	if body := <-data; !strings.Contains(body,
+		"From: Pet Store <noreply@petstore.test>\r\n") {
+		t.Errorf("message lost the display name:\n%s", body)
+	}
+}
+
+func TestSMTPMailerSendInvalidSender(t *testing.T) {
+	m := mail.NewSMTPMailer("127.0.0.1", "25", "not an address", "", "")
+	err := m.Send(context.Background(), mail.Message{To: "a@b.com"})
+	if err == nil {
+		t.Fatal("expected error for invalid sender")
+	}
+}
+
+func TestSMTPMailerSendUnreachable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	if err := ln.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	m := mail.NewSMTPMailer(host, port, "noreply@petstore.test", "", "")
+	err = m.Send(context.Background(), mail.Message{To: "a@b.com"})
+	if err == nil {
+		t.Fatal("expected error for unreachable server")
+	}
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+)
+
+// clientKey is the context key for the request's clientInfo.
+type clientKey struct{}
+
+// clientInfo describes the caller of a request.
+type clientInfo struct {
+	ip        string
+	userAgent string
+}
+
+// ClientInfo returns middleware that stores the caller's IP
+// address and User-Agent in the request context for
+// GetClientIP and GetUserAgent. The IP is taken from the
+// connection's remote address; forwarding headers are not
+// trusted.
+func ClientInfo() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(
+			w http.ResponseWriter, r *http.Request,
+		) {
+			ip, _, err := net.SplitHostPort(r.RemoteAddr)
+			if err != nil {
+				ip = r.RemoteAddr
+			}
+			ctx := context.WithValue(r.Context(), clientKey{}, clientInfo{
+				ip:        ip,
+				userAgent: r.UserAgent(),
+			})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetClientIP returns the caller's IP address from the
+// context, or an empty string if none is set.
+func GetClientIP(ctx context.Context) string {
+	c, _ := ctx.Value(clientKey{}).(clientInfo)
+	return c.ip
+}
+
+// GetUserAgent returns the caller's User-Agent from the
+// context, or an empty string if none is set.
+func GetUserAgent(ctx context.Context) string {
+	c, _ := ctx.Value(clientKey{}).(clientInfo)
+	return c.userAgent
+}
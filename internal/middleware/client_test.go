@@ -0,0 +1,75 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/middleware"
+)
+
+func TestClientInfo(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		userAgent  string
+		wantIP     string
+	}{
+		{
+			name:       "host and port",
+			remoteAddr: "203.0.113.7:51234",
+			userAgent:  "curl/8.0",
+			wantIP:     "203.0.113.7",
+		},
+		{
+			name:       "ipv6",
+			remoteAddr: "[2001:db8::1]:443",
+			wantIP:     "2001:db8::1",
+		},
+		{
+			name:       "no port",
+			remoteAddr: "unix",
+			wantIP:     "unix",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotIP, gotUA string
+			inner := http.HandlerFunc(
+				func(_ http.ResponseWriter, r *http.Request) {
+					gotIP = middleware.GetClientIP(r.Context())
+					gotUA = middleware.GetUserAgent(r.Context())
+				},
+			)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tc.remoteAddr
+			req.Header.Set("X-Forwarded-For", "198.51.100.1")
+			if tc.userAgent != "" {
+				req.Header.Set("User-Agent", tc.userAgent)
+			}
+			middleware.ClientInfo()(inner).ServeHTTP(
+				httptest.NewRecorder(), req,
+			)
+
+			if gotIP != tc.wantIP {
+				t.Errorf("ip = %q, want %q", gotIP, tc.wantIP)
+			}
+			if gotUA != tc.userAgent {
+				t.Errorf("user agent = %q, want %q", gotUA, tc.userAgent)
+			}
+		})
+	}
+}
+
+func TestClientInfoMissing(t *testing.T) {
+	ctx := context.Background()
+	if ip := middleware.GetClientIP(ctx); ip != "" {
+		t.Errorf("ip = %q, want empty", ip)
+	}
+	if ua := middleware.GetUserAgent(ctx); ua != "" {
+		t.Errorf("user agent = %q, want empty", ua)
+	}
+}
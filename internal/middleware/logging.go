@@ -30,6 +30,12 @@ func (rc *responseCapture) Write(b []byte) (int, error) {
 	return rc.ResponseWriter.Write(b)
 }
 
+// Unwrap returns the wrapped writer, so that
+// http.ResponseController can reach its Flush method.
+func (rc *responseCapture) Unwrap() http.ResponseWriter {
+	return rc.ResponseWriter
+}
+
 // Logging returns middleware that logs each request after
 // it completes. Requests resulting in status < 500 are
 // logged at Info; 5xx responses are logged at Error.
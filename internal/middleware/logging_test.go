@@ -109,3 +109,22 @@ func TestLogging(t *testing.T) {
 		})
 	}
 }
+
+func TestLoggingFlush(t *testing.T) {
+	slog.SetDefault(slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+
+	inner := http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			if err := http.NewResponseController(w).Flush(); err != nil {
+				t.Errorf("Flush: %v", err)
+			}
+		},
+	)
+	rec := httptest.NewRecorder()
+	middleware.Logging()(inner).ServeHTTP(
+		rec, httptest.NewRequest(http.MethodGet, "/", nil),
+	)
+	if !rec.Flushed {
+		t.Error("response was not flushed")
+	}
+}
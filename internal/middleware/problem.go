@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hhubris/petstore/internal/api"
+)
+
+// ProblemContentType is the media type of error responses.
+const ProblemContentType = "application/problem+json"
+
+// problemTypePrefix namespaces problem type URIs.
+const problemTypePrefix = "urn:petstore:problem:"
+
+// Problem returns an RFC 7807 problem of the kind named by
+// slug, titled with the status text and tagged with the
+// correlation ID from ctx.
+func Problem(
+	ctx context.Context, status int, slug, detail string,
+) api.Problem {
+	p := api.Problem{
+		Type:   problemTypePrefix + slug,
+		Title:  http.StatusText(status),
+		Status: int32(status),
+		Detail: detail,
+	}
+	if id := GetCorrelationID(ctx); id != "" {
+		p.CorrelationId = api.NewOptString(id)
+	}
+	return p
+}
+
+// WriteProblem writes p as an application/problem+json
+// response, for errors raised outside the ogen handlers.
+func WriteProblem(w http.ResponseWriter, p api.Problem) {
+	body, err := p.MarshalJSON()
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(int(p.Status))
+	_, _ = w.Write(body)
+}
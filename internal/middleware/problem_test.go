@@ -0,0 +1,57 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/middleware"
+)
+
+func TestProblem(t *testing.T) {
+	var ctx context.Context
+	h := middleware.CorrelationID()(http.HandlerFunc(
+		func(_ http.ResponseWriter, r *http.Request) { ctx = r.Context() },
+	))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Correlation-ID", "cid-1")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	rec := httptest.NewRecorder()
+	middleware.WriteProblem(rec, middleware.Problem(
+		ctx, http.StatusNotFound, "not-found", "not found",
+	))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	var body map[string]any
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"type":          "urn:petstore:problem:not-found",
+		"title":         "Not Found",
+		"status":        float64(404),
+		"detail":        "not found",
+		"correlationId": "cid-1",
+	}
+	for k, v := range want {
+		if body[k] != v {
+			t.Errorf("%s = %v, want %v", k, body[k], v)
+		}
+	}
+}
+
+func TestProblemWithoutCorrelationID(t *testing.T) {
+	p := middleware.Problem(context.Background(),
+		http.StatusBadRequest, "invalid-request", "bad")
+	if p.CorrelationId.Set {
+		t.Errorf("correlationId = %q, want unset", p.CorrelationId.Value)
+	}
+}
@@ -1,15 +1,15 @@
 package middleware
 
 import (
-	"encoding/json"
 	"log/slog"
 	"net/http"
 	"runtime/debug"
 )
 
 // Recovery returns middleware that recovers from panics,
-// logs the error with a stack trace, and writes a 500 JSON
-// response matching the ogen Error schema.
+// logs the error with a stack trace, and writes a 500
+// problem+json response. http.ErrAbortHandler is passed on
+// so the server aborts the response as it intends.
 func Recovery() Middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(
@@ -17,21 +17,16 @@ func Recovery() Middleware {
 		) {
 			defer func() {
 				if v := recover(); v != nil {
+					if v == http.ErrAbortHandler {
+						panic(v)
+					}
 					slog.Error("panic recovered",
 						"error", v,
 						"stack", string(debug.Stack()),
 					)
-					w.Header().Set(
-						"Content-Type",
-						"application/json",
-					)
-					w.WriteHeader(
+					WriteProblem(w, Problem(r.Context(),
 						http.StatusInternalServerError,
-					)
-					_ = json.NewEncoder(w).Encode(map[string]any{
-						"code":    500,
-						"message": "internal server error",
-					})
+						"internal", "internal server error"))
 				}
 			}()
 			next.ServeHTTP(w, r)
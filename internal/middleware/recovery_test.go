@@ -69,10 +69,10 @@ func TestRecovery(t *testing.T) {
 
 			if tc.wantJSON {
 				ct := rec.Header().Get("Content-Type")
-				if ct != "application/json" {
+				if ct != middleware.ProblemContentType {
 					t.Errorf(
-						"Content-Type = %q, want application/json",
-						ct,
+						"Content-Type = %q, want %s",
+						ct, middleware.ProblemContentType,
 					)
 				}
 
@@ -82,15 +82,18 @@ func TestRecovery(t *testing.T) {
 				).Decode(&body); err != nil {
 					t.Fatalf("invalid JSON body: %v", err)
 				}
-				if body["code"] != float64(500) {
+				if body["status"] != float64(500) {
 					t.Errorf(
-						"code = %v, want 500", body["code"],
+						"status = %v, want 500", body["status"],
 					)
 				}
-				if body["message"] != "internal server error" {
+				if body["type"] != "urn:petstore:problem:internal" {
+					t.Errorf("type = %v", body["type"])
+				}
+				if body["detail"] != "internal server error" {
 					t.Errorf(
-						"message = %v, want %q",
-						body["message"],
+						"detail = %v, want %q",
+						body["detail"],
 						"internal server error",
 					)
 				}
@@ -98,3 +101,19 @@ func TestRecovery(t *testing.T) {
 		})
 	}
 }
+
+func TestRecoveryPassesOnAbortHandler(t *testing.T) {
+	h := middleware.Recovery()(http.HandlerFunc(
+		func(http.ResponseWriter, *http.Request) {
+			panic(http.ErrAbortHandler)
+		},
+	))
+	defer func() {
+		if v := recover(); v != http.ErrAbortHandler {
+			t.Errorf("recovered %v, want http.ErrAbortHandler", v)
+		}
+	}()
+	h.ServeHTTP(httptest.NewRecorder(),
+		httptest.NewRequest(http.MethodGet, "/", nil))
+	t.Error("ServeHTTP returned normally")
+}
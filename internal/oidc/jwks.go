@@ -0,0 +1,141 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// minRefreshInterval stops a token with an unknown kid from
+// making us refetch the JWKS on every request.
+const minRefreshInterval = time.Minute
+
+// jwk is a JSON Web Key as published by a provider.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// publicKey is a verification key with the algorithm it is
+// used with.
+type publicKey struct {
+	alg string
+	key any
+}
+
+// keySet caches a provider's JWKS, refetching it when a
+// token names a kid it has not seen.
+type keySet struct {
+	client *http.Client
+	uri    string
+
+	mu        sync.Mutex
+	keys      map[string]publicKey
+	fetchedAt time.Time
+}
+
+// newKeySet returns an empty keySet for the JWKS at uri.
+func newKeySet(client *http.Client, uri string) *keySet {
+	return &keySet{client: client, uri: uri}
+}
+
+// key returns the key named kid, which must be usable with
+// alg.
+func (ks *keySet) key(ctx context.Context, kid, alg string) (any, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	k, ok := ks.keys[kid]
+	if !ok && time.Since(ks.fetchedAt) >= minRefreshInterval {
+		if err := ks.refresh(ctx); err != nil {
+			return nil, err
+		}
+		k, ok = ks.keys[kid]
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown kid %q", kid)
+	}
+	if k.alg != alg {
+		return nil, fmt.Errorf("key %q is for %s, not %s", kid, k.alg, alg)
+	}
+	return k.key, nil
+}
+
+// refresh refetches the JWKS. Keys that fail to parse are
+// skipped so one unsupported key does not break the rest.
+// Callers must hold ks.mu.
+func (ks *keySet) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ks.uri, nil)
+	if err != nil {
+		return fmt.Errorf("building jwks request: %w", err)
+	}
+	var doc struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := doJSON(ks.client, req, &doc); err != nil {
+		return fmt.Errorf("fetching jwks: %w", err)
+	}
+
+	keys := make(map[string]publicKey, len(doc.Keys))
+	for _, j := range doc.Keys {
+		if j.Use != "" && j.Use != "sig" {
+			continue
+		}
+		if k, err := j.publicKey(); err == nil {
+			keys[j.Kid] = k
+		}
+	}
+	ks.keys = keys
+	ks.fetchedAt = time.Now()
+	return nil
+}
+
+// publicKey decodes an RSA or Ed25519 JWK.
+func (j jwk) publicKey() (publicKey, error) {
+	dec := base64.RawURLEncoding
+	switch j.Kty {
+	case "RSA":
+		n, err := dec.DecodeString(j.N)
+		if err != nil {
+			return publicKey{}, fmt.Errorf("decoding n: %w", err)
+		}
+		e, err := dec.DecodeString(j.E)
+		if err != nil {
+			return publicKey{}, fmt.Errorf("decoding e: %w", err)
+		}
+		exp := new(big.Int).SetBytes(e)
+		if !exp.IsInt64() || exp.Int64() > 1<<31-1 {
+			return publicKey{}, errors.New("rsa exponent too large")
+		}
+		return publicKey{alg: "RS256", key: &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(exp.Int64()),
+		}}, nil
+	case "OKP":
+		if j.Crv != "Ed25519" {
+			return publicKey{}, fmt.Errorf("unsupported curve %q", j.Crv)
+		}
+		x, err := dec.DecodeString(j.X)
+		if err != nil {
+			return publicKey{}, fmt.Errorf("decoding x: %w", err)
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return publicKey{}, errors.New("bad ed25519 key size")
+		}
+		return publicKey{alg: "EdDSA", key: ed25519.PublicKey(x)}, nil
+	}
+	return publicKey{}, fmt.Errorf("unsupported key type %q", j.Kty)
+}
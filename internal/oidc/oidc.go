@@ -0,0 +1,258 @@
+// Package oidc is a minimal OpenID Connect relying party:
+// discovery, the authorization code flow with PKCE, and ID
+// token validation against the provider's JWKS.
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/hhubris/petstore/internal/auth"
+)
+
+// ErrInvalidIDToken is returned when the provider's ID
+// token fails validation. It wraps auth.ErrIdentityRejected.
+var ErrInvalidIDToken = fmt.Errorf(
+	"%w: invalid id token", auth.ErrIdentityRejected,
+)
+
+// httpTimeout bounds each request to the provider.
+const httpTimeout = 10 * time.Second
+
+// maxResponseBytes caps how much of a provider response is
+// read.
+const maxResponseBytes = 1 << 20
+
+// Config describes a client registration with a provider.
+type Config struct {
+	// Issuer is the provider's issuer URL; discovery reads
+	// Issuer + "/.well-known/openid-configuration".
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is our callback, registered with the
+	// provider.
+	RedirectURL string
+	// HTTPClient defaults to a client with a 10s timeout.
+	HTTPClient *http.Client
+}
+
+// Provider is a discovered OIDC provider. It implements
+// auth.IdentityProvider.
+type Provider struct {
+	cfg           Config
+	authEndpoint  string
+	tokenEndpoint string
+	keys          *keySet
+	// timeNow is used for testing; defaults to time.Now.
+	timeNow func() time.Time
+}
+
+// discovery is the subset of the provider metadata document
+// we use.
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Discover fetches the provider's metadata and returns a
+// Provider for it. The metadata's issuer must match
+// cfg.Issuer exactly.
+func Discover(ctx context.Context, cfg Config) (*Provider, error) {
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: httpTimeout}
+	}
+	cfg.Issuer = strings.TrimSuffix(cfg.Issuer, "/")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		cfg.Issuer+"/.well-known/openid-configuration", nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("building discovery request: %w", err)
+	}
+	var d discovery
+	if err := doJSON(cfg.HTTPClient, req, &d); err != nil {
+		return nil, fmt.Errorf("oidc discovery: %w", err)
+	}
+	if d.Issuer != cfg.Issuer {
+		return nil, fmt.Errorf(
+			"oidc discovery: issuer %q does not match %q",
+			d.Issuer, cfg.Issuer,
+		)
+	}
+	if d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" ||
+		d.JWKSURI == "" {
+		return nil, errors.New("oidc discovery: missing endpoints")
+	}
+
+	return &Provider{
+		cfg:           cfg,
+		authEndpoint:  d.AuthorizationEndpoint,
+		tokenEndpoint: d.TokenEndpoint,
+		keys:          newKeySet(cfg.HTTPClient, d.JWKSURI),
+		timeNow:       time.Now,
+	}, nil
+}
+
+// AuthCodeURL returns the authorization endpoint URL for a
+// code flow request using the S256 PKCE challenge.
+func (p *Provider) AuthCodeURL(state, nonce, codeChallenge string) string {
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {p.cfg.RedirectURL},
+		"scope":                 {"openid email profile"},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+	sep := "?"
+	if strings.Contains(p.authEndpoint, "?") {
+		sep = "&"
+	}
+	return p.authEndpoint + sep + q.Encode()
+}
+
+// tokenResponse is the subset of the token endpoint
+// response we use.
+type tokenResponse struct {
+	IDToken string `json:"id_token"`
+}
+
+// Exchange redeems an authorization code and returns the
+// identity from the validated ID token.
+func (p *Provider) Exchange(
+	ctx context.Context,
+	code, codeVerifier, nonce string,
+) (auth.ExternalIdentity, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"client_id":     {p.cfg.ClientID},
+		"code_verifier": {codeVerifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		p.tokenEndpoint, strings.NewReader(form.Encode()),
+	)
+	if err != nil {
+		return auth.ExternalIdentity{}, fmt.Errorf(
+			"building token request: %w", err,
+		)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if p.cfg.ClientSecret != "" {
+		req.SetBasicAuth(
+			url.QueryEscape(p.cfg.ClientID),
+			url.QueryEscape(p.cfg.ClientSecret),
+		)
+	}
+
+	var tr tokenResponse
+	if err := doJSON(p.cfg.HTTPClient, req, &tr); err != nil {
+		return auth.ExternalIdentity{}, fmt.Errorf(
+			"%w: token exchange: %w", auth.ErrIdentityRejected, err,
+		)
+	}
+	if tr.IDToken == "" {
+		return auth.ExternalIdentity{}, fmt.Errorf(
+			"%w: no id_token in response", ErrInvalidIDToken,
+		)
+	}
+	return p.verify(ctx, tr.IDToken, nonce)
+}
+
+// idClaims are the ID token claims we read.
+type idClaims struct {
+	jwt.RegisteredClaims
+	Nonce         string   `json:"nonce"`
+	AuthorizedBy  string   `json:"azp"`
+	Email         string   `json:"email"`
+	EmailVerified bool     `json:"email_verified"`
+	Name          string   `json:"name"`
+	AMR           []string `json:"amr"`
+}
+
+// verify validates an ID token's signature, issuer,
+// audience, expiry, and nonce.
+func (p *Provider) verify(
+	ctx context.Context, raw, nonce string,
+) (auth.ExternalIdentity, error) {
+	var c idClaims
+	_, err := jwt.ParseWithClaims(raw, &c,
+		func(t *jwt.Token) (any, error) {
+			kid, _ := t.Header["kid"].(string)
+			return p.keys.key(ctx, kid, t.Method.Alg())
+		},
+		jwt.WithValidMethods([]string{"RS256", "EdDSA"}),
+		jwt.WithIssuer(p.cfg.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(p.timeNow),
+	)
+	if err != nil {
+		return auth.ExternalIdentity{}, fmt.Errorf(
+			"%w: %w", ErrInvalidIDToken, err,
+		)
+	}
+	if c.Nonce != nonce {
+		return auth.ExternalIdentity{}, fmt.Errorf(
+			"%w: nonce mismatch", ErrInvalidIDToken,
+		)
+	}
+	if len(c.Audience) > 1 && c.AuthorizedBy != p.cfg.ClientID {
+		return auth.ExternalIdentity{}, fmt.Errorf(
+			"%w: azp %q is not this client", ErrInvalidIDToken,
+			c.AuthorizedBy,
+		)
+	}
+	if c.Subject == "" {
+		return auth.ExternalIdentity{}, fmt.Errorf(
+			"%w: missing sub", ErrInvalidIDToken,
+		)
+	}
+
+	return auth.ExternalIdentity{
+		Issuer:        c.Issuer,
+		Subject:       c.Subject,
+		Email:         c.Email,
+		EmailVerified: c.EmailVerified,
+		Name:          c.Name,
+		AMR:           slices.Clone(c.AMR),
+	}, nil
+}
+
+// doJSON sends req and decodes a 200 JSON response into v.
+func doJSON(client *http.Client, req *http.Request, v any) error {
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: status %d", req.URL.Redacted(), resp.StatusCode)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/oidc"
+	"github.com/hhubris/petstore/internal/oidc/oidctest"
+)
+
+const verifier = "test-verifier-with-enough-entropy-0123456789"
+
+func challenge() string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func discover(t *testing.T, op *oidctest.Provider) *oidc.Provider {
+	t.Helper()
+	p, err := oidc.Discover(context.Background(), oidc.Config{
+		Issuer:      op.Issuer(),
+		ClientID:    op.ClientID,
+		RedirectURL: "http://localhost/callback",
+	})
+	if err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+	return p
+}
+
+func TestExchange(t *testing.T) {
+	tests := []struct {
+		name     string
+		hook     func(jwt.MapClaims)
+		verifier string
+		wantErr  error
+	}{
+		{name: "valid", verifier: verifier},
+		{
+			name:     "wrong verifier",
+			verifier: "not-the-verifier",
+			wantErr:  auth.ErrIdentityRejected,
+		},
+		{
+			name:     "nonce mismatch",
+			hook:     func(c jwt.MapClaims) { c["nonce"] = "other" },
+			verifier: verifier,
+			wantErr:  oidc.ErrInvalidIDToken,
+		},
+		{
+			name:     "wrong audience",
+			hook:     func(c jwt.MapClaims) { c["aud"] = "someone-else" },
+			verifier: verifier,
+			wantErr:  oidc.ErrInvalidIDToken,
+		},
+		{
+			name:     "wrong issuer",
+			hook:     func(c jwt.MapClaims) { c["iss"] = "https://evil.example" },
+			verifier: verifier,
+			wantErr:  oidc.ErrInvalidIDToken,
+		},
+		{
+			name: "foreign azp",
+			hook: func(c jwt.MapClaims) {
+				c["aud"] = []string{"petstore", "other"}
+				c["azp"] = "other"
+			},
+			verifier: verifier,
+			wantErr:  oidc.ErrInvalidIDToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := oidctest.New("petstore")
+			defer op.Close()
+			op.SetUser(oidctest.User{
+				Subject:       "sub-1",
+				Email:         "alice@example.com",
+				EmailVerified: true,
+			})
+			op.SetIDTokenHook(tt.hook)
+			p := discover(t, op)
+
+			code, state, err := op.Authorize(
+				p.AuthCodeURL("st", "n0nce", challenge()),
+			)
+			if err != nil {
+				t.Fatalf("Authorize: %v", err)
+			}
+			if state != "st" {
+				t.Errorf("state = %q, want %q", state, "st")
+			}
+
+			id, err := p.Exchange(
+				context.Background(), code, tt.verifier, "n0nce",
+			)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if id.Issuer != op.Issuer() || id.Subject != "sub-1" ||
+				!id.EmailVerified {
+				t.Errorf("identity = %+v", id)
+			}
+		})
+	}
+}
+
+func TestDiscoverIssuerMismatch(t *testing.T) {
+	op := oidctest.New("petstore")
+	defer op.Close()
+	// Same server, different spelling: the metadata names
+	// 127.0.0.1, so this must be rejected.
+	_, err := oidc.Discover(context.Background(), oidc.Config{
+		Issuer: strings.Replace(
+			op.Issuer(), "127.0.0.1", "localhost", 1,
+		),
+		ClientID: op.ClientID,
+	})
+	if err == nil {
+		t.Fatal("expected error for mismatched issuer")
+	}
+}
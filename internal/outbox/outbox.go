@@ -0,0 +1,72 @@
+// Package outbox relays domain events recorded in the
+// outbox table. Triggers on pet_revisions and users write a
+// row in the same transaction as each mutation, so an event
+// exists exactly when its change committed; a background
+// Relay claims unpublished rows, hands them to every Sink,
+// and marks them published. Delivery is at-least-once:
+// sinks must tolerate seeing an event ID twice.
+//
+// Nothing consumes the outbox yet. The server relays to
+// LogSink, or to no sink at all, as OUTBOX_SINK selects, so
+// events are only logged and then purged; the webhook queue
+// and the pet event stream still read pet_revisions
+// directly. A broker or other consumer would be a new Sink.
+//
+// The API has no orders, so no order events exist yet; an
+// orders table would get a trigger of its own.
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Aggregate types: the kind of entity an event is about.
+const (
+	AggregatePet  = "pet"
+	AggregateUser = "user"
+)
+
+// Event types written by the outbox_pet_revision and
+// outbox_user triggers. Payloads never include secrets
+// such as password hashes or TOTP secrets.
+const (
+	// Pet events carry revisionId, petId, name, tag,
+	// actorUserId and actorApiKeyId.
+	TypePetCreated              = "pet.created"
+	TypePetDeleted              = "pet.deleted"
+	TypePetRestored             = "pet.restored"
+	TypePetReserved             = "pet.reserved"
+	TypePetReservationCancelled = "pet.reservation_cancelled"
+	TypePetReservationExpired   = "pet.reservation_expired"
+
+	// TypeUserCreated carries userId, name, email and role.
+	TypeUserCreated = "user.created"
+	// TypeUserEmailChanged carries userId, email and
+	// previousEmail.
+	TypeUserEmailChanged = "user.email_changed"
+	// TypeUserEmailVerified carries userId and email.
+	TypeUserEmailVerified = "user.email_verified"
+	// TypeUserRoleChanged carries userId, role and
+	// previousRole.
+	TypeUserRoleChanged = "user.role_changed"
+	// The remaining user events carry only userId.
+	TypeUserDisabled        = "user.disabled"
+	TypeUserEnabled         = "user.enabled"
+	TypeUserPasswordChanged = "user.password_changed"
+)
+
+// Event is one row of the outbox.
+type Event struct {
+	// ID is unique and increases with insertion order;
+	// sinks use it to drop duplicates.
+	ID            int64
+	AggregateType string
+	AggregateID   int64
+	Type          string
+	Payload       json.RawMessage
+	// Attempts counts claims so far, including the current
+	// one.
+	Attempts  int
+	CreatedAt time.Time
+}
@@ -0,0 +1,171 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+const (
+	// batchSize bounds how many events a relay claims at a
+	// time.
+	batchSize = 100
+
+	// leaseDuration is how long a claimed event is hidden
+	// from other relays. It must exceed the time sinks take
+	// to publish a batch.
+	leaseDuration = time.Minute
+
+	// baseBackoff is the wait after the first failed
+	// attempt; each further failure doubles it, up to
+	// maxBackoff. Events are never given up on.
+	baseBackoff = 5 * time.Second
+	maxBackoff  = time.Hour
+
+	// maxReasonLength truncates the failure reason kept on
+	// the event.
+	maxReasonLength = 500
+)
+
+// Sink receives published events. Publish must be safe to
+// call again with an event it has already seen: a failure
+// in any sink retries the event in all of them.
+type Sink interface {
+	Publish(ctx context.Context, e Event) error
+}
+
+// SinkFunc adapts a function to a Sink.
+type SinkFunc func(ctx context.Context, e Event) error
+
+// Publish calls f(ctx, e).
+func (f SinkFunc) Publish(ctx context.Context, e Event) error {
+	return f(ctx, e)
+}
+
+// LogSink writes each event to the default slog logger. It
+// is the server's default sink while no broker or other
+// consumer exists.
+type LogSink struct{}
+
+// Publish logs e at INFO.
+func (LogSink) Publish(ctx context.Context, e Event) error {
+	slog.InfoContext(ctx, "outbox event",
+		"event_id", e.ID, "event_type", e.Type,
+		"aggregate_type", e.AggregateType,
+		"aggregate_id", e.AggregateID)
+	return nil
+}
+
+// Store is the persistence interface the relay depends on.
+// EventRepository satisfies it via duck typing.
+type Store interface {
+	ClaimDue(ctx context.Context,
+		limit int, leaseUntil time.Time,
+	) ([]Event, error)
+	MarkPublished(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context,
+		id int64, reason string, retryAt time.Time,
+	) error
+}
+
+// Relay publishes outbox events to its sinks.
+type Relay struct {
+	store Store
+	sinks []Sink
+}
+
+// NewRelay returns a Relay publishing the events in store
+// to every sink, in order. Call Run to start it.
+func NewRelay(store Store, sinks ...Sink) *Relay {
+	return &Relay{store: store, sinks: sinks}
+}
+
+// Run publishes available events every interval until ctx
+// is cancelled. Several relays, in this process or others,
+// may share a store; each event is claimed by one at a
+// time.
+func (r *Relay) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		r.drain(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// drain publishes batches of available events until fewer
+// than a full batch are available.
+func (r *Relay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		events, err := r.store.ClaimDue(ctx,
+			batchSize, time.Now().Add(leaseDuration))
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.ErrorContext(ctx, "claiming outbox events",
+					"error", err)
+			}
+			return
+		}
+		for _, e := range events {
+			if ctx.Err() != nil {
+				return
+			}
+			r.relay(ctx, e)
+		}
+		if len(events) < batchSize {
+			return
+		}
+	}
+}
+
+// relay publishes e to every sink and records the outcome.
+// Publishing cut short by shutdown is not recorded; the
+// event is claimed again once its lease passes.
+func (r *Relay) relay(ctx context.Context, e Event) {
+	err := r.publish(ctx, e)
+	if ctx.Err() != nil {
+		return
+	}
+	if err == nil {
+		err = r.store.MarkPublished(ctx, e.ID)
+	} else {
+		reason := err.Error()
+		if len(reason) > maxReasonLength {
+			reason = reason[:maxReasonLength]
+		}
+		slog.WarnContext(ctx, "publishing outbox event failed",
+			"event_id", e.ID, "event_type", e.Type,
+			"attempt", e.Attempts, "error", reason)
+		err = r.store.MarkFailed(ctx, e.ID, reason,
+			time.Now().Add(backoff(e.Attempts)))
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "recording outbox event",
+			"event_id", e.ID, "error", err)
+	}
+}
+
+// publish hands e to each sink, stopping at the first
+// error.
+func (r *Relay) publish(ctx context.Context, e Event) error {
+	for _, s := range r.sinks {
+		if err := s.Publish(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backoff returns the wait after the given number of failed
+// attempts.
+func backoff(attempts int) time.Duration {
+	d := baseBackoff
+	for i := 1; i < attempts && d < maxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxBackoff)
+}
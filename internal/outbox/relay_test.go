@@ -0,0 +1,195 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hhubris/petstore/internal/outbox"
+)
+
+// outcome is what the relay recorded for an event.
+type outcome struct {
+	id        int64
+	published bool
+	reason    string
+	retryAt   time.Time
+}
+
+// memStore is an in-memory outbox.Store that hands out its
+// events on the first claim and reports each outcome.
+type memStore struct {
+	mu       sync.Mutex
+	events   []outbox.Event
+	outcomes chan outcome
+}
+
+func newMemStore(events ...outbox.Event) *memStore {
+	return &memStore{events: events, outcomes: make(chan outcome, len(events))}
+}
+
+func (s *memStore) ClaimDue(
+	_ context.Context, limit int, _ time.Time,
+) ([]outbox.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := min(limit, len(s.events))
+	out := s.events[:n]
+	s.events = s.events[n:]
+	return out, nil
+}
+
+func (s *memStore) MarkPublished(_ context.Context, id int64) error {
+	s.outcomes <- outcome{id: id, published: true}
+	return nil
+}
+
+func (s *memStore) MarkFailed(
+	_ context.Context, id int64, reason string, retryAt time.Time,
+) error {
+	s.outcomes <- outcome{id: id, reason: reason, retryAt: retryAt}
+	return nil
+}
+
+// runRelay runs a relay over s until the test ends.
+func runRelay(t *testing.T, s *memStore, sinks ...outbox.Sink) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		outbox.NewRelay(s, sinks...).Run(ctx, time.Hour)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+// nextOutcome returns the next outcome recorded in s.
+func nextOutcome(t *testing.T, s *memStore) outcome {
+	t.Helper()
+	select {
+	case o := <-s.outcomes:
+		return o
+	case <-time.After(5 * time.Second):
+		t.Fatal("no outcome recorded")
+		return outcome{}
+	}
+}
+
+func TestRelayPublishesInOrder(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		seen [2][]int64
+	)
+	sink := func(i int) outbox.Sink {
+		return outbox.SinkFunc(func(_ context.Context, e outbox.Event) error {
+			mu.Lock()
+			defer mu.Unlock()
+			seen[i] = append(seen[i], e.ID)
+			return nil
+		})
+	}
+	s := newMemStore(
+		outbox.Event{ID: 1, Type: outbox.TypePetCreated, Attempts: 1},
+		outbox.Event{ID: 2, Type: outbox.TypeUserCreated, Attempts: 1},
+	)
+	runRelay(t, s, sink(0), sink(1))
+
+	for _, want := range []int64{1, 2} {
+		o := nextOutcome(t, s)
+		if !o.published || o.id != want {
+			t.Errorf("got outcome %+v, want %d published", o, want)
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for i, ids := range seen {
+		if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+			t.Errorf("sink %d saw %v, want [1 2]", i, ids)
+		}
+	}
+}
+
+func TestRelayWithoutSinks(t *testing.T) {
+	s := newMemStore(outbox.Event{ID: 1, Type: outbox.TypePetCreated, Attempts: 1})
+	runRelay(t, s)
+
+	if o := nextOutcome(t, s); !o.published || o.id != 1 {
+		t.Errorf("got outcome %+v, want 1 published", o)
+	}
+}
+
+func TestRelayRetries(t *testing.T) {
+	tests := []struct {
+		name      string
+		attempts  int
+		wantDelay time.Duration
+	}{
+		{"first attempt", 1, 5 * time.Second},
+		{"fourth attempt", 4, 40 * time.Second},
+		{"capped", 30, time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var later bool
+			s := newMemStore(outbox.Event{ID: 7, Attempts: tt.attempts})
+			start := time.Now()
+			runRelay(t, s,
+				outbox.SinkFunc(func(context.Context, outbox.Event) error {
+					return errors.New("broker unavailable")
+				}),
+				outbox.SinkFunc(func(context.Context, outbox.Event) error {
+					later = true
+					return nil
+				}),
+			)
+
+			o := nextOutcome(t, s)
+			if o.published || o.id != 7 || o.reason != "broker unavailable" {
+				t.Fatalf("got outcome %+v", o)
+			}
+			if later {
+				t.Error("sink after the failing one was called")
+			}
+			delay := o.retryAt.Sub(start)
+			if delay < tt.wantDelay || delay > tt.wantDelay+time.Minute {
+				t.Errorf("retry in %v, want %v", delay, tt.wantDelay)
+			}
+		})
+	}
+}
+
+func TestRelayShutdown(t *testing.T) {
+	started := make(chan struct{})
+	s := newMemStore(outbox.Event{ID: 7, Attempts: 1})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		outbox.NewRelay(s, outbox.SinkFunc(
+			func(ctx context.Context, _ outbox.Event) error {
+				close(started)
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		)).Run(ctx, time.Hour)
+		close(done)
+	}()
+
+	<-started
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("relay did not stop")
+	}
+	select {
+	case o := <-s.outcomes:
+		t.Errorf("recorded %+v for an interrupted publish", o)
+	default:
+	}
+}
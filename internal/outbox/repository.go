@@ -0,0 +1,136 @@
+package outbox
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// dbtx is the database interface required by
+// EventRepository. Satisfied by *pgxpool.Pool, pgx.Tx, and
+// pgxmock.
+type dbtx interface {
+	Query(ctx context.Context, sql string,
+		args ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string,
+		args ...any) (pgconn.CommandTag, error)
+}
+
+// EventRepository provides database access for the outbox.
+// It does not insert events; the triggers do.
+type EventRepository struct {
+	db dbtx
+}
+
+// NewEventRepository returns an EventRepository backed by
+// the given database connection.
+func NewEventRepository(conn dbtx) *EventRepository {
+	return &EventRepository{db: conn}
+}
+
+// ClaimDue claims up to limit unpublished events that are
+// available, oldest first, and hides them from other relays
+// until leaseUntil. Events locked by a concurrent claim are
+// skipped rather than waited for.
+func (r *EventRepository) ClaimDue(
+	ctx context.Context,
+	limit int,
+	leaseUntil time.Time,
+) ([]Event, error) {
+	rows, err := r.db.Query(ctx,
+		"WITH due AS ("+
+			"SELECT id FROM outbox "+
+			"WHERE published_at IS NULL AND available_at <= now() "+
+			"ORDER BY id LIMIT $1 "+
+			"FOR UPDATE SKIP LOCKED"+
+			") "+
+			"UPDATE outbox o SET attempts = o.attempts + 1, "+
+			"available_at = $2 "+
+			"FROM due WHERE o.id = due.id "+
+			"RETURNING o.id, o.aggregate_type, o.aggregate_id, "+
+			"o.event_type, o.payload, o.attempts, o.created_at",
+		limit, leaseUntil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("claim outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		var e Event
+		err := rows.Scan(
+			&e.ID, &e.AggregateType, &e.AggregateID, &e.Type,
+			&e.Payload, &e.Attempts, &e.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan outbox event: %w", err)
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate outbox events: %w", err)
+	}
+	// UPDATE ... RETURNING does not keep the CTE's order.
+	slices.SortFunc(events, func(a, b Event) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return events, nil
+}
+
+// MarkPublished records that every sink accepted the
+// event.
+func (r *EventRepository) MarkPublished(
+	ctx context.Context,
+	id int64,
+) error {
+	_, err := r.db.Exec(ctx,
+		"UPDATE outbox SET published_at = now(), last_error = NULL "+
+			"WHERE id = $1",
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("mark outbox event published: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records why publishing the event failed and
+// makes it available again at retryAt.
+func (r *EventRepository) MarkFailed(
+	ctx context.Context,
+	id int64,
+	reason string,
+	retryAt time.Time,
+) error {
+	_, err := r.db.Exec(ctx,
+		"UPDATE outbox SET last_error = $2, available_at = $3 "+
+			"WHERE id = $1",
+		id, reason, retryAt,
+	)
+	if err != nil {
+		return fmt.Errorf("mark outbox event failed: %w", err)
+	}
+	return nil
+}
+
+// DeletePublished deletes events published before the
+// given time and returns how many were removed.
+func (r *EventRepository) DeletePublished(
+	ctx context.Context,
+	before time.Time,
+) (int64, error) {
+	tag, err := r.db.Exec(ctx,
+		"DELETE FROM outbox WHERE published_at < $1",
+		before,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("delete published outbox events: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,113 @@
+package outbox_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+
+	"github.com/hhubris/petstore/internal/outbox"
+)
+
+func TestRepositoryClaimDue(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	now := time.Now()
+	lease := now.Add(time.Minute)
+	columns := []string{
+		"id", "aggregate_type", "aggregate_id", "event_type", "payload",
+		"attempts", "created_at",
+	}
+	mock.ExpectQuery(`FOR UPDATE SKIP LOCKED.+UPDATE outbox`).
+		WithArgs(100, lease).
+		WillReturnRows(pgxmock.NewRows(columns).
+			AddRow(int64(9), "user", int64(2), "user.created",
+				[]byte(`{"userId":2}`), 1, now).
+			AddRow(int64(8), "pet", int64(5), "pet.created",
+				[]byte(`{"petId":5}`), 3, now))
+
+	repo := outbox.NewEventRepository(mock)
+	got, err := repo.ClaimDue(context.Background(), 100, lease)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != 8 || got[1].ID != 9 {
+		t.Fatalf("got %+v, want events 8 and 9 in order", got)
+	}
+	if got[0].Type != outbox.TypePetCreated || got[0].Attempts != 3 ||
+		string(got[0].Payload) != `{"petId":5}` {
+		t.Errorf("got %+v", got[0])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepositoryMarkPublished(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectExec("UPDATE outbox SET published_at = now()").
+		WithArgs(int64(9)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	repo := outbox.NewEventRepository(mock)
+	if err := repo.MarkPublished(context.Background(), 9); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepositoryMarkFailed(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	retryAt := time.Now().Add(time.Minute)
+	mock.ExpectExec("UPDATE outbox SET last_error").
+		WithArgs(int64(9), "broker unavailable", retryAt).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	repo := outbox.NewEventRepository(mock)
+	err = repo.MarkFailed(context.Background(), 9, "broker unavailable", retryAt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepositoryDeletePublished(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	before := time.Now()
+	mock.ExpectExec("DELETE FROM outbox WHERE published_at <").
+		WithArgs(before).
+		WillReturnResult(pgxmock.NewResult("DELETE", 4))
+
+	repo := outbox.NewEventRepository(mock)
+	n, err := repo.DeletePublished(context.Background(), before)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 4 {
+		t.Errorf("deleted %d, want 4", n)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
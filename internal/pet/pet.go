@@ -1,8 +1,124 @@
 package pet
 
-// Pet is the domain model for a pet.
+import (
+	"time"
+
+	"github.com/hhubris/petstore/internal/store"
+)
+
+// Pet is the domain model for a pet. Every field but ID,
+// Name, and StoreID is optional.
 type Pet struct {
 	ID   int64
 	Name string
-	Tag  *string
+	// StoreID is the store the pet lives at. Services fill
+	// it in from the caller's store when it is 0; for a
+	// global caller it stays 0 and the repository uses the
+	// default store.
+	StoreID int64
+	Tag     *string
+	Species *string
+	// Breed is a breed code of Species; it requires Species.
+	Breed *string
+	// BirthDate is a date; its time of day is ignored.
+	BirthDate   *time.Time
+	Sex         *string
+	Description *string
+	Price       *Money
+	// ReservedUntil is when the pet's current hold lapses,
+	// if it is reserved.
+	ReservedUntil *time.Time
+	// DistanceKm is how far the pet's store is from the Near
+	// point of the filter that found it. It is set only by
+	// such a filter.
+	DistanceKm *float64
+}
+
+// Sexes a pet may have. An unknown sex is left unset.
+const (
+	SexFemale = "female"
+	SexMale   = "male"
+)
+
+// Money is an amount in the minor units of a currency, such
+// as cents, so that it is exact.
+type Money struct {
+	Amount int64
+	// Currency is an ISO 4217 code such as "EUR".
+	Currency string
+}
+
+// Species is a kind of animal a pet may be, with the breeds
+// it may be given.
+type Species struct {
+	Code   string
+	Name   string
+	Breeds []Breed
+}
+
+// Breed is a breed of a Species.
+type Breed struct {
+	Code string
+	Name string
+}
+
+// Filter selects pets for ListPets and ExportPets. Zero
+// fields do not filter.
+type Filter struct {
+	// Store selects the pets of one store.
+	Store   *int64
+	Tags    []string
+	Species []string
+	Breeds  []string
+	Sex     string
+	// BornAfter and BornBefore bound the birth date,
+	// inclusive; pets without one are excluded.
+	BornAfter  *time.Time
+	BornBefore *time.Time
+	// Currency selects pets priced in it. MinPrice and
+	// MaxPrice bound the price, inclusive, in its minor
+	// units, and so require it.
+	Currency string
+	MinPrice *int64
+	MaxPrice *int64
+	// Near orders the pets by the distance of their store
+	// from it, nearest first, and leaves out stores without
+	// a location. RadiusKm bounds that distance, inclusive,
+	// and so requires it.
+	Near     *store.Location
+	RadiusKm *float64
+	Limit    *int32
+}
+
+// Revision actions. The API has no pet update operation;
+// one would add an "update" action alongside these. The
+// reservation actions are written by the reservation
+// package when a hold is placed, cancelled, or lapses.
+const (
+	RevisionCreate            = "create"
+	RevisionDelete            = "delete"
+	RevisionRestore           = "restore"
+	RevisionReserve           = "reserve"
+	RevisionCancelReservation = "cancel_reservation"
+	RevisionExpireReservation = "expire_reservation"
+)
+
+// Actor identifies who made a change. Both fields are nil
+// for changes made outside an authenticated request.
+type Actor struct {
+	UserID   *int64
+	APIKeyID *int64
+}
+
+// Revision is a snapshot of a pet taken when it was
+// created, deleted, or restored, or a hold on it was
+// placed, cancelled, or lapsed.
+type Revision struct {
+	ID        int64
+	PetID     int64
+	Action    string
+	Name      string
+	Tag       *string
+	Actor     Actor
+	CreatedAt time.Time
 }
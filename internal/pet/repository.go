@@ -11,6 +11,7 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 
 	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/store"
 )
 
 // dbtx is the database interface required by
@@ -23,8 +24,60 @@ type dbtx interface {
 		args ...any) pgx.Row
 	Exec(ctx context.Context, sql string,
 		args ...any) (pgconn.CommandTag, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
 }
 
+// foreignKeyViolation is the PostgreSQL error code for a
+// foreign key constraint violation.
+const foreignKeyViolation = "23503"
+
+// storeForeignKey is the constraint tying a pet to its
+// store.
+const storeForeignKey = "pets_store_id_fkey"
+
+// petColumns is the column list scanned by scanPet.
+const petColumns = "id, name, tag, species, breed, birth_date, sex, " +
+	"description, price_amount, price_currency, store_id"
+
+// reservedUntil selects when the current hold on the pet
+// in the %s relation lapses, or NULL if it is not reserved.
+// The reservations exclusion constraint allows one such
+// hold; max makes that explicit.
+const reservedUntil = "(SELECT max(expires_at) FROM reservations r " +
+	"WHERE r.pet_id = %s.id AND r.status = 'active' AND r.expires_at > now())"
+
+// petSelect returns the column list scanned by scanPet for
+// pets selected from the named relation: petColumns and
+// reservedUntil.
+func petSelect(relation string) string {
+	return petColumns + ", " + fmt.Sprintf(reservedUntil, relation)
+}
+
+// petValues is the pets column list written from petArgs.
+const petValues = "name, tag, species, breed, birth_date, sex, " +
+	"description, price_amount, price_currency, store_id"
+
+// storeOrDefault selects the store ID in its %s expression,
+// or the default store if that is 0: the first store, which
+// is the seeded Main Store, or the only one.
+const storeOrDefault = "COALESCE(NULLIF(%s, 0), (SELECT min(id) FROM stores))"
+
+// inStore is the predicate confining a statement to the
+// store in its %d parameter, or to none if that is NULL.
+const inStore = "($%[1]d::bigint IS NULL OR store_id = $%[1]d)"
+
+// storeDistances selects the distance_km of each located
+// store from the latitude and longitude in its first and
+// second %d parameters, as the store column. It uses the
+// haversine formula on a sphere of the Earth's mean radius,
+// which is within 0.5% of the true distance; LEAST guards
+// asin against rounding just past 1.
+const storeDistances = "SELECT id AS store, 2 * 6371.0088 * asin(LEAST(1, sqrt(" +
+	"power(sin(radians(latitude - $%[1]d::float8) / 2), 2) + " +
+	"cos(radians($%[1]d::float8)) * cos(radians(latitude)) * " +
+	"power(sin(radians(longitude - $%[2]d::float8) / 2), 2)))) AS distance_km " +
+	"FROM stores WHERE latitude IS NOT NULL"
+
 // PetRepository provides database access for pets.
 type PetRepository struct {
 	db dbtx
@@ -36,36 +89,110 @@ func NewPetRepository(conn dbtx) *PetRepository {
 	return &PetRepository{db: conn}
 }
 
-// Create inserts a new pet and returns it with the
-// generated ID.
+// Create inserts a new pet and its create revision, and
+// returns the pet with the generated ID. The ID of p is
+// ignored. A StoreID of 0 means the default store. Returns
+// ErrUnknownBreed if p names a species or breed that is not
+// in the reference tables, and store.ErrUnknownStore if its
+// store does not exist.
 func (r *PetRepository) Create(
 	ctx context.Context,
-	name string,
-	tag *string,
+	p Pet,
+	actor Actor,
 ) (Pet, error) {
-	var pet Pet
-	err := r.db.QueryRow(ctx,
-		"INSERT INTO pets (name, tag) VALUES ($1, $2) "+
-			"RETURNING id, name, tag",
-		name, tag,
-	).Scan(&pet.ID, &pet.Name, &pet.Tag)
+	pet, err := scanPet(r.db.QueryRow(ctx,
+		"WITH p AS ("+
+			"INSERT INTO pets ("+petValues+") "+
+			"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, "+
+			fmt.Sprintf(storeOrDefault, "$10::bigint")+") "+
+			"RETURNING "+petColumns+"), "+
+			"r AS ("+revisionInsert+" SELECT id, 'create', name, tag, $11, $12 FROM p) "+
+			"SELECT "+petSelect("p")+" FROM p",
+		append(petArgs(p), actor.UserID, actor.APIKeyID)...,
+	))
 	if err != nil {
+		if fkErr := foreignKeyError(err); fkErr != nil {
+			return Pet{}, fkErr
+		}
 		return Pet{}, fmt.Errorf("create pet: %w", err)
 	}
 	return pet, nil
 }
 
+// Import inserts pets and their create revisions in one
+// transaction and returns how many were created. The rows
+// are streamed with COPY into a temporary table, then
+// inserted in order by a single statement. A StoreID of 0
+// means the default store, as in Create.
+func (r *PetRepository) Import(
+	ctx context.Context,
+	pets []Pet,
+	actor Actor,
+) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("begin pet import: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx,
+		"CREATE TEMPORARY TABLE pet_import "+
+			"(ord INTEGER NOT NULL, name TEXT NOT NULL, tag TEXT, "+
+			"species TEXT, breed TEXT, birth_date DATE, sex TEXT, "+
+			"description TEXT, price_amount BIGINT, price_currency TEXT, "+
+			"store_id BIGINT NOT NULL) "+
+			"ON COMMIT DROP",
+	)
+	if err != nil {
+		return 0, fmt.Errorf("create pet import table: %w", err)
+	}
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"pet_import"},
+		append([]string{"ord"}, strings.Split(petValues, ", ")...),
+		pgx.CopyFromSlice(len(pets), func(i int) ([]any, error) {
+			return append([]any{int32(i)}, petArgs(pets[i])...), nil
+		}),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("copy pet import rows: %w", err)
+	}
+
+	var n int
+	err = tx.QueryRow(ctx,
+		"WITH p AS ("+
+			"INSERT INTO pets ("+petValues+") "+
+			"SELECT name, tag, species, breed, birth_date, sex, "+
+			"description, price_amount, price_currency, "+
+			fmt.Sprintf(storeOrDefault, "store_id")+
+			" FROM pet_import ORDER BY ord "+
+			"RETURNING id, name, tag), "+
+			"r AS ("+revisionInsert+" SELECT id, 'create', name, tag, $1, $2 FROM p) "+
+			"SELECT count(*) FROM p",
+		actor.UserID, actor.APIKeyID,
+	).Scan(&n)
+	if err != nil {
+		if fkErr := foreignKeyError(err); fkErr != nil {
+			return 0, fkErr
+		}
+		return 0, fmt.Errorf("import pets: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("commit pet import: %w", err)
+	}
+	return n, nil
+}
+
 // FindByID returns the pet with the given ID, or
-// db.ErrNotFound if it does not exist.
+// db.ErrNotFound if it does not exist or is deleted.
 func (r *PetRepository) FindByID(
 	ctx context.Context,
 	id int64,
 ) (Pet, error) {
-	var pet Pet
-	err := r.db.QueryRow(ctx,
-		"SELECT id, name, tag FROM pets WHERE id = $1",
+	pet, err := scanPet(r.db.QueryRow(ctx,
+		"SELECT "+petSelect("pets")+" FROM pets "+
+			"WHERE id = $1 AND deleted_at IS NULL",
 		id,
-	).Scan(&pet.ID, &pet.Name, &pet.Tag)
+	))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return Pet{}, db.ErrNotFound
@@ -75,70 +202,194 @@ func (r *PetRepository) FindByID(
 	return pet, nil
 }
 
-// FindAll returns pets, optionally filtered by tags and
-// limited to a maximum number of results.
+// FindAll returns the pets that are not deleted and match
+// f, in ID order, or nearest first if f has a Near point.
 func (r *PetRepository) FindAll(
 	ctx context.Context,
-	tags []string,
-	limit *int32,
+	f Filter,
 ) ([]Pet, error) {
-	var (
-		query strings.Builder
-		args  []any
-		argN  int
-	)
-	query.WriteString("SELECT id, name, tag FROM pets")
-
-	if len(tags) > 0 {
-		query.WriteString(" WHERE tag IN (")
-		for i, t := range tags {
-			if i > 0 {
-				query.WriteString(", ")
-			}
-			argN++
-			query.WriteString("$" + strconv.Itoa(argN))
-			args = append(args, t)
+	var pets []Pet
+	err := r.Each(ctx, f, func(p Pet) error {
+		pets = append(pets, p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pets, nil
+}
+
+// Each calls fn with each pet FindAll would return, in the
+// same order, as the rows arrive, so the result is never held in
+// memory. It stops at the first error fn returns and
+// returns that error unwrapped.
+func (r *PetRepository) Each(
+	ctx context.Context,
+	f Filter,
+	fn func(Pet) error,
+) error {
+	where := []string{"deleted_at IS NULL"}
+	var args []any
+	add := func(cond string, v any) {
+		args = append(args, v)
+		where = append(where, fmt.Sprintf(cond, len(args)))
+	}
+	in := func(col string, vals []string) {
+		if len(vals) == 0 {
+			return
+		}
+		params := make([]string, len(vals))
+		for i, v := range vals {
+			args = append(args, v)
+			params[i] = "$" + strconv.Itoa(len(args))
 		}
-		query.WriteString(")")
+		where = append(where,
+			col+" IN ("+strings.Join(params, ", ")+")")
+	}
+	if f.Store != nil {
+		add("store_id = $%d", *f.Store)
+	}
+	in("tag", f.Tags)
+	in("species", f.Species)
+	in("breed", f.Breeds)
+	if f.Sex != "" {
+		add("sex = $%d", f.Sex)
+	}
+	if f.BornAfter != nil {
+		add("birth_date >= $%d", *f.BornAfter)
+	}
+	if f.BornBefore != nil {
+		add("birth_date <= $%d", *f.BornBefore)
+	}
+	if f.Currency != "" {
+		add("price_currency = $%d", f.Currency)
+	}
+	if f.MinPrice != nil {
+		add("price_amount >= $%d", *f.MinPrice)
+	}
+	if f.MaxPrice != nil {
+		add("price_amount <= $%d", *f.MaxPrice)
 	}
 
-	query.WriteString(" ORDER BY id")
+	cols, from, order := petSelect("pets"), "pets", "id"
+	if f.Near != nil {
+		args = append(args, f.Near.Latitude, f.Near.Longitude)
+		cols += ", distance_km"
+		from += " JOIN (" + fmt.Sprintf(storeDistances, len(args)-1, len(args)) +
+			") d ON d.store = store_id"
+		order = "distance_km, id"
+		if f.RadiusKm != nil {
+			add("distance_km <= $%d", *f.RadiusKm)
+		}
+	}
 
-	if limit != nil {
-		argN++
-		query.WriteString(" LIMIT $" + strconv.Itoa(argN))
-		args = append(args, *limit)
+	q := "SELECT " + cols + " FROM " + from + " WHERE " +
+		strings.Join(where, " AND ") + " ORDER BY " + order
+	if f.Limit != nil {
+		args = append(args, *f.Limit)
+		q += " LIMIT $" + strconv.Itoa(len(args))
 	}
 
-	rows, err := r.db.Query(ctx, query.String(), args...)
+	rows, err := r.db.Query(ctx, q, args...)
 	if err != nil {
-		return nil, fmt.Errorf("find pets: %w", err)
+		return fmt.Errorf("find pets: %w", err)
 	}
 	defer rows.Close()
 
-	var pets []Pet
 	for rows.Next() {
-		var pet Pet
-		if err := rows.Scan(&pet.ID, &pet.Name, &pet.Tag); err != nil {
-			return nil, fmt.Errorf("scan pet: %w", err)
+		var (
+			pet Pet
+			err error
+		)
+		if f.Near != nil {
+			var distance float64
+			pet, err = scanPet(rows, &distance)
+			pet.DistanceKm = &distance
+		} else {
+			pet, err = scanPet(rows)
+		}
+		if err != nil {
+			return fmt.Errorf("scan pet: %w", err)
+		}
+		if err := fn(pet); err != nil {
+			return err
 		}
-		pets = append(pets, pet)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterate pets: %w", err)
+		return fmt.Errorf("iterate pets: %w", err)
 	}
-	return pets, nil
+	return nil
+}
+
+// FindSpecies returns the species and their breeds, each
+// sorted by name.
+func (r *PetRepository) FindSpecies(
+	ctx context.Context,
+) ([]Species, error) {
+	rows, err := r.db.Query(ctx,
+		"SELECT s.code, s.name, b.code, b.name "+
+			"FROM species s LEFT JOIN breeds b ON b.species = s.code "+
+			"ORDER BY s.name, b.name",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("find species: %w", err)
+	}
+	defer rows.Close()
+
+	var species []Species
+	for rows.Next() {
+		var (
+			code, name           string
+			breedCode, breedName *string
+		)
+		if err := rows.Scan(&code, &name, &breedCode, &breedName); err != nil {
+			return nil, fmt.Errorf("scan species: %w", err)
+		}
+		if len(species) == 0 || species[len(species)-1].Code != code {
+			species = append(species, Species{
+				Code: code, Name: name, Breeds: []Breed{},
+			})
+		}
+		if breedCode != nil {
+			s := &species[len(species)-1]
+			s.Breeds = append(s.Breeds,
+				Breed{Code: *breedCode, Name: *breedName})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate species: %w", err)
+	}
+	return species, nil
 }
 
-// Delete removes the pet with the given ID, or returns
-// db.ErrNotFound if it does not exist.
+// revisionInsert starts the statement that records a
+// revision; callers append a SELECT of (pet_id, action,
+// name, tag, actor_user_id, actor_api_key_id).
+const revisionInsert = "INSERT INTO pet_revisions " +
+	"(pet_id, action, name, tag, actor_user_id, actor_api_key_id)"
+
+// Delete soft-deletes the pet with the given ID, cancels
+// its active hold, if any, and records a delete revision.
+// The delete event stands for the cancellation, which gets
+// no revision of its own. Returns db.ErrNotFound if the pet
+// does not exist, is already deleted, or is outside the
+// store scope unless scope is nil.
 func (r *PetRepository) Delete(
 	ctx context.Context,
 	id int64,
+	scope *int64,
+	actor Actor,
 ) error {
 	tag, err := r.db.Exec(ctx,
-		"DELETE FROM pets WHERE id = $1",
-		id,
+		"WITH p AS ("+
+			"UPDATE pets SET deleted_at = now() "+
+			"WHERE id = $1 AND deleted_at IS NULL AND "+fmt.Sprintf(inStore, 2)+" "+
+			"RETURNING id, name, tag), "+
+			"h AS (UPDATE reservations SET status = 'cancelled', cancelled_at = now() "+
+			"FROM p WHERE reservations.pet_id = p.id "+
+			"AND reservations.status = 'active' AND reservations.expires_at > now()) "+
+			revisionInsert+" SELECT id, 'delete', name, tag, $3, $4 FROM p",
+		id, scope, actor.UserID, actor.APIKeyID,
 	)
 	if err != nil {
 		return fmt.Errorf("delete pet: %w", err)
@@ -148,3 +399,163 @@ func (r *PetRepository) Delete(
 	}
 	return nil
 }
+
+// Restore undeletes the pet with the given ID, records a
+// restore revision, and returns the pet. Returns
+// db.ErrNotFound unless the pet exists, is deleted, and is
+// in the store scope, if any.
+func (r *PetRepository) Restore(
+	ctx context.Context,
+	id int64,
+	scope *int64,
+	actor Actor,
+) (Pet, error) {
+	pet, err := scanPet(r.db.QueryRow(ctx,
+		"WITH p AS ("+
+			"UPDATE pets SET deleted_at = NULL "+
+			"WHERE id = $1 AND deleted_at IS NOT NULL AND "+fmt.Sprintf(inStore, 2)+" "+
+			"RETURNING "+petColumns+"), "+
+			"r AS ("+revisionInsert+" SELECT id, 'restore', name, tag, $3, $4 FROM p) "+
+			"SELECT "+petSelect("p")+" FROM p",
+		id, scope, actor.UserID, actor.APIKeyID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Pet{}, db.ErrNotFound
+		}
+		return Pet{}, fmt.Errorf("restore pet: %w", err)
+	}
+	return pet, nil
+}
+
+// revisionColumns lists the pet_revisions columns scanned
+// by scanRevisions.
+const revisionColumns = "id, pet_id, action, name, tag, " +
+	"actor_user_id, actor_api_key_id, created_at"
+
+// FindRevisions returns the revisions of the pet with the
+// given ID, oldest first, or none if it is outside the
+// store scope unless scope is nil. Deleted pets keep their
+// history.
+func (r *PetRepository) FindRevisions(
+	ctx context.Context,
+	petID int64,
+	scope *int64,
+) ([]Revision, error) {
+	rows, err := r.db.Query(ctx,
+		"SELECT "+revisionColumns+" FROM pet_revisions "+
+			"WHERE pet_id = $1 AND pet_id IN "+
+			"(SELECT id FROM pets WHERE "+fmt.Sprintf(inStore, 2)+") "+
+			"ORDER BY id",
+		petID, scope,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("find pet revisions: %w", err)
+	}
+	return scanRevisions(rows)
+}
+
+// RevisionsAfter returns up to limit revisions of any pet
+// with an ID greater than after, oldest first. Revision IDs
+// serve as the cursor of the pet event stream.
+func (r *PetRepository) RevisionsAfter(
+	ctx context.Context,
+	after int64,
+	limit int,
+) ([]Revision, error) {
+	rows, err := r.db.Query(ctx,
+		"SELECT "+revisionColumns+" FROM pet_revisions "+
+			"WHERE id > $1 ORDER BY id LIMIT $2",
+		after, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("find pet revisions after %d: %w", after, err)
+	}
+	return scanRevisions(rows)
+}
+
+// LatestRevisionID returns the ID of the newest revision,
+// or 0 if there are none.
+func (r *PetRepository) LatestRevisionID(ctx context.Context) (int64, error) {
+	var id int64
+	err := r.db.QueryRow(ctx,
+		"SELECT COALESCE(max(id), 0) FROM pet_revisions",
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("find latest pet revision: %w", err)
+	}
+	return id, nil
+}
+
+// scanRevisions reads revisionColumns rows and closes rows.
+func scanRevisions(rows pgx.Rows) ([]Revision, error) {
+	defer rows.Close()
+
+	var revs []Revision
+	for rows.Next() {
+		var rev Revision
+		if err := rows.Scan(
+			&rev.ID, &rev.PetID, &rev.Action, &rev.Name, &rev.Tag,
+			&rev.Actor.UserID, &rev.Actor.APIKeyID, &rev.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan pet revision: %w", err)
+		}
+		revs = append(revs, rev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate pet revisions: %w", err)
+	}
+	return revs, nil
+}
+
+// petArgs returns the values of p for the petValues
+// columns.
+func petArgs(p Pet) []any {
+	var (
+		amount   *int64
+		currency *string
+	)
+	if p.Price != nil {
+		amount, currency = &p.Price.Amount, &p.Price.Currency
+	}
+	return []any{
+		p.Name, p.Tag, p.Species, p.Breed, p.BirthDate, p.Sex,
+		p.Description, amount, currency, p.StoreID,
+	}
+}
+
+// scanPet reads a pet from the columns of petSelect, and
+// any columns selected after them into extra.
+func scanPet(row pgx.Row, extra ...any) (Pet, error) {
+	var (
+		p        Pet
+		amount   *int64
+		currency *string
+	)
+	err := row.Scan(append([]any{
+		&p.ID, &p.Name, &p.Tag, &p.Species, &p.Breed, &p.BirthDate,
+		&p.Sex, &p.Description, &amount, &currency, &p.StoreID,
+		&p.ReservedUntil,
+	}, extra...)...)
+	if err != nil {
+		return Pet{}, err
+	}
+	if amount != nil && currency != nil {
+		p.Price = &Money{Amount: *amount, Currency: *currency}
+	}
+	return p, nil
+}
+
+// foreignKeyError maps a foreign key violation to
+// store.ErrUnknownStore or ErrUnknownBreed, and any other
+// error to nil.
+func foreignKeyError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != foreignKeyViolation {
+		return nil
+	}
+	if pgErr.ConstraintName == storeForeignKey {
+		return store.ErrUnknownStore
+	}
+	return ErrUnknownBreed
+}
@@ -3,73 +3,163 @@ package pet_test
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pashagolub/pgxmock/v4"
 
 	"github.com/hhubris/petstore/internal/db"
 	"github.com/hhubris/petstore/internal/pet"
+	"github.com/hhubris/petstore/internal/store"
 )
 
 func ptrStr(s string) *string { return &s }
 
+// anyArgs returns n matchers that accept any argument.
+func anyArgs(n int) []any {
+	args := make([]any, n)
+	for i := range args {
+		args[i] = pgxmock.AnyArg()
+	}
+	return args
+}
+
+// petRows returns rows with the pet columns the repository
+// scans.
+func petRows() *pgxmock.Rows {
+	return pgxmock.NewRows([]string{
+		"id", "name", "tag", "species", "breed", "birth_date", "sex",
+		"description", "price_amount", "price_currency", "store_id",
+		"reserved_until",
+	})
+}
+
+// petRow returns an unreserved pet row of store 1 with only
+// a name and tag.
+func petRow(id int64, name string, tag *string) []any {
+	return []any{
+		id, name, tag, (*string)(nil), (*string)(nil), (*time.Time)(nil),
+		(*string)(nil), (*string)(nil), (*int64)(nil), (*string)(nil),
+		int64(1), (*time.Time)(nil),
+	}
+}
+
 func TestCreate(t *testing.T) {
 	ctx := context.Background()
 	tagVal := "dog"
+	born := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	full := pet.Pet{
+		Name:        "Rex",
+		Tag:         &tagVal,
+		Species:     ptrStr("dog"),
+		Breed:       ptrStr("beagle"),
+		BirthDate:   &born,
+		Sex:         ptrStr(pet.SexMale),
+		Description: ptrStr("Loves walks"),
+		Price:       &pet.Money{Amount: 25000, Currency: "EUR"},
+		StoreID:     1,
+	}
+	amount, currency := int64(25000), "EUR"
+	errQuery := errors.New("connection reset")
 
 	tests := []struct {
 		name    string
-		petName string
-		tag     *string
+		pet     pet.Pet
 		mock    func(m pgxmock.PgxPoolIface)
 		want    pet.Pet
-		wantErr bool
+		wantErr error
 	}{
 		{
-			name:    "success with tag",
-			petName: "Fido",
-			tag:     &tagVal,
+			name: "success with tag",
+			pet:  pet.Pet{Name: "Fido", Tag: &tagVal, StoreID: 1},
 			mock: func(m pgxmock.PgxPoolIface) {
 				m.ExpectQuery("INSERT INTO pets").
-					WithArgs("Fido", &tagVal).
+					WithArgs("Fido", &tagVal, (*string)(nil), (*string)(nil),
+						(*time.Time)(nil), (*string)(nil), (*string)(nil),
+						(*int64)(nil), (*string)(nil), int64(1),
+						(*int64)(nil), (*int64)(nil)).
 					WillReturnRows(
-						pgxmock.NewRows([]string{"id", "name", "tag"}).
-							AddRow(int64(1), "Fido", &tagVal),
+						petRows().
+							AddRow(petRow(1, "Fido", &tagVal)...),
 					)
 			},
 			want: pet.Pet{
-				ID:   1,
-				Name: "Fido",
-				Tag:  ptrStr("dog"),
+				ID:      1,
+				Name:    "Fido",
+				Tag:     ptrStr("dog"),
+				StoreID: 1,
+			},
+		},
+		{
+			name: "default store",
+			pet:  pet.Pet{Name: "Fido"},
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(`INSERT INTO pets .+ `+
+					`COALESCE\(NULLIF\(\$10::bigint, 0\), \(SELECT min\(id\) FROM stores\)\)`).
+					WithArgs("Fido", (*string)(nil), (*string)(nil), (*string)(nil),
+						(*time.Time)(nil), (*string)(nil), (*string)(nil),
+						(*int64)(nil), (*string)(nil), int64(0),
+						(*int64)(nil), (*int64)(nil)).
+					WillReturnRows(
+						petRows().
+							AddRow(petRow(1, "Fido", nil)...),
+					)
 			},
+			want: pet.Pet{ID: 1, Name: "Fido", StoreID: 1},
 		},
 		{
-			name:    "success without tag",
-			petName: "Luna",
-			tag:     nil,
+			name: "full profile",
+			pet:  full,
 			mock: func(m pgxmock.PgxPoolIface) {
 				m.ExpectQuery("INSERT INTO pets").
-					WithArgs("Luna", (*string)(nil)).
+					WithArgs("Rex", &tagVal, full.Species, full.Breed,
+						&born, full.Sex, full.Description,
+						&amount, &currency, int64(1),
+						(*int64)(nil), (*int64)(nil)).
 					WillReturnRows(
-						pgxmock.NewRows([]string{"id", "name", "tag"}).
-							AddRow(int64(2), "Luna", (*string)(nil)),
+						petRows().AddRow(int64(2), "Rex", &tagVal,
+							full.Species, full.Breed, &born, full.Sex,
+							full.Description, &amount, &currency, int64(1), (*time.Time)(nil)),
 					)
 			},
-			want: pet.Pet{
-				ID:   2,
-				Name: "Luna",
+			want: func() pet.Pet { p := full; p.ID = 2; return p }(),
+		},
+		{
+			name: "unknown breed",
+			pet:  pet.Pet{Name: "Rex", Species: ptrStr("dog"), Breed: ptrStr("wolf")},
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("INSERT INTO pets").
+					WithArgs(anyArgs(12)...).
+					WillReturnError(&pgconn.PgError{
+						Code: "23503", ConstraintName: "pets_breed_fkey",
+					})
 			},
+			wantErr: pet.ErrUnknownBreed,
 		},
 		{
-			name:    "scan error",
-			petName: "Bad",
-			tag:     nil,
+			name: "unknown store",
+			pet:  pet.Pet{Name: "Rex", StoreID: 99},
 			mock: func(m pgxmock.PgxPoolIface) {
 				m.ExpectQuery("INSERT INTO pets").
-					WithArgs("Bad", (*string)(nil)).
-					WillReturnError(errors.New("scan failed"))
+					WithArgs(anyArgs(12)...).
+					WillReturnError(&pgconn.PgError{
+						Code: "23503", ConstraintName: "pets_store_id_fkey",
+					})
 			},
-			wantErr: true,
+			wantErr: store.ErrUnknownStore,
+		},
+		{
+			name: "query error",
+			pet:  pet.Pet{Name: "Bad"},
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("INSERT INTO pets").
+					WithArgs(anyArgs(12)...).
+					WillReturnError(errQuery)
+			},
+			wantErr: errQuery,
 		},
 	}
 
@@ -84,20 +174,18 @@ func TestCreate(t *testing.T) {
 			tt.mock(mock)
 
 			repo := pet.NewPetRepository(mock)
-			got, err := repo.Create(ctx, tt.petName, tt.tag)
+			got, err := repo.Create(ctx, tt.pet, pet.Actor{})
 
-			if tt.wantErr {
-				if err == nil {
-					t.Fatal("expected error, got nil")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v", err, tt.wantErr)
 				}
 				return
 			}
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
-			if got.ID != tt.want.ID ||
-				got.Name != tt.want.Name ||
-				!ptrStrEq(got.Tag, tt.want.Tag) {
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("got %+v, want %+v", got, tt.want)
 			}
 			if err := mock.ExpectationsWereMet(); err != nil {
@@ -110,6 +198,7 @@ func TestCreate(t *testing.T) {
 func TestFindByID(t *testing.T) {
 	ctx := context.Background()
 	tagVal := "cat"
+	until := time.Now().Add(48 * time.Hour)
 
 	tests := []struct {
 		name    string
@@ -122,11 +211,11 @@ func TestFindByID(t *testing.T) {
 			name: "found",
 			id:   1,
 			mock: func(m pgxmock.PgxPoolIface) {
-				m.ExpectQuery("SELECT id, name, tag FROM pets").
+				m.ExpectQuery("SELECT .+ FROM pets WHERE id = .+ AND deleted_at IS NULL").
 					WithArgs(int64(1)).
 					WillReturnRows(
-						pgxmock.NewRows([]string{"id", "name", "tag"}).
-							AddRow(int64(1), "Whiskers", &tagVal),
+						petRows().
+							AddRow(petRow(int64(1), "Whiskers", &tagVal)...),
 					)
 			},
 			want: pet.Pet{
@@ -135,14 +224,28 @@ func TestFindByID(t *testing.T) {
 				Tag:  ptrStr("cat"),
 			},
 		},
+		{
+			name: "reserved",
+			id:   3,
+			mock: func(m pgxmock.PgxPoolIface) {
+				row := petRow(int64(3), "Bella", nil)
+				row[len(row)-1] = &until
+				m.ExpectQuery(`SELECT .+, \(SELECT max\(expires_at\) FROM reservations r ` +
+					`WHERE r.pet_id = pets.id AND r.status = 'active' ` +
+					`AND r.expires_at > now\(\)\) FROM pets WHERE id = .+`).
+					WithArgs(int64(3)).
+					WillReturnRows(petRows().AddRow(row...))
+			},
+			want: pet.Pet{ID: 3, Name: "Bella", ReservedUntil: &until},
+		},
 		{
 			name: "not found",
 			id:   999,
 			mock: func(m pgxmock.PgxPoolIface) {
-				m.ExpectQuery("SELECT id, name, tag FROM pets").
+				m.ExpectQuery("SELECT .+ FROM pets").
 					WithArgs(int64(999)).
 					WillReturnRows(
-						pgxmock.NewRows([]string{"id", "name", "tag"}),
+						petRows(),
 					)
 			},
 			wantErr: db.ErrNotFound,
@@ -174,7 +277,8 @@ func TestFindByID(t *testing.T) {
 			}
 			if got.ID != tt.want.ID ||
 				got.Name != tt.want.Name ||
-				!ptrStrEq(got.Tag, tt.want.Tag) {
+				!ptrStrEq(got.Tag, tt.want.Tag) ||
+				(got.ReservedUntil == nil) != (tt.want.ReservedUntil == nil) {
 				t.Errorf("got %+v, want %+v", got, tt.want)
 			}
 			if err := mock.ExpectationsWereMet(); err != nil {
@@ -203,11 +307,11 @@ func TestFindAll(t *testing.T) {
 			tags:  nil,
 			limit: nil,
 			mock: func(m pgxmock.PgxPoolIface) {
-				m.ExpectQuery("SELECT id, name, tag FROM pets ORDER BY id").
+				m.ExpectQuery("SELECT .+ FROM pets WHERE deleted_at IS NULL ORDER BY id").
 					WillReturnRows(
-						pgxmock.NewRows([]string{"id", "name", "tag"}).
-							AddRow(int64(1), "Fido", &tagDog).
-							AddRow(int64(2), "Luna", (*string)(nil)),
+						petRows().
+							AddRow(petRow(int64(1), "Fido", &tagDog)...).
+							AddRow(petRow(int64(2), "Luna", (*string)(nil))...),
 					)
 			},
 			want: []pet.Pet{
@@ -221,12 +325,12 @@ func TestFindAll(t *testing.T) {
 			limit: nil,
 			mock: func(m pgxmock.PgxPoolIface) {
 				m.ExpectQuery(
-					"SELECT id, name, tag FROM pets WHERE tag IN").
+					"SELECT .+ FROM pets WHERE deleted_at IS NULL AND tag IN").
 					WithArgs("dog", "cat").
 					WillReturnRows(
-						pgxmock.NewRows([]string{"id", "name", "tag"}).
-							AddRow(int64(1), "Fido", &tagDog).
-							AddRow(int64(3), "Mimi", &tagCat),
+						petRows().
+							AddRow(petRow(int64(1), "Fido", &tagDog)...).
+							AddRow(petRow(int64(3), "Mimi", &tagCat)...),
 					)
 			},
 			want: []pet.Pet{
@@ -240,11 +344,11 @@ func TestFindAll(t *testing.T) {
 			limit: &limit10,
 			mock: func(m pgxmock.PgxPoolIface) {
 				m.ExpectQuery(
-					"SELECT id, name, tag FROM pets ORDER BY id LIMIT").
+					"SELECT .+ FROM pets WHERE deleted_at IS NULL ORDER BY id LIMIT").
 					WithArgs(limit10).
 					WillReturnRows(
-						pgxmock.NewRows([]string{"id", "name", "tag"}).
-							AddRow(int64(1), "Fido", &tagDog),
+						petRows().
+							AddRow(petRow(int64(1), "Fido", &tagDog)...),
 					)
 			},
 			want: []pet.Pet{
@@ -257,11 +361,11 @@ func TestFindAll(t *testing.T) {
 			limit: &limit10,
 			mock: func(m pgxmock.PgxPoolIface) {
 				m.ExpectQuery(
-					"SELECT id, name, tag FROM pets WHERE tag IN").
+					"SELECT .+ FROM pets WHERE deleted_at IS NULL AND tag IN").
 					WithArgs("dog", limit10).
 					WillReturnRows(
-						pgxmock.NewRows([]string{"id", "name", "tag"}).
-							AddRow(int64(1), "Fido", &tagDog),
+						petRows().
+							AddRow(petRow(int64(1), "Fido", &tagDog)...),
 					)
 			},
 			want: []pet.Pet{
@@ -273,9 +377,9 @@ func TestFindAll(t *testing.T) {
 			tags:  nil,
 			limit: nil,
 			mock: func(m pgxmock.PgxPoolIface) {
-				m.ExpectQuery("SELECT id, name, tag FROM pets ORDER BY id").
+				m.ExpectQuery("SELECT .+ FROM pets WHERE deleted_at IS NULL ORDER BY id").
 					WillReturnRows(
-						pgxmock.NewRows([]string{"id", "name", "tag"}),
+						petRows(),
 					)
 			},
 			want: nil,
@@ -293,7 +397,7 @@ func TestFindAll(t *testing.T) {
 			tt.mock(mock)
 
 			repo := pet.NewPetRepository(mock)
-			got, err := repo.FindAll(ctx, tt.tags, tt.limit)
+			got, err := repo.FindAll(ctx, pet.Filter{Tags: tt.tags, Limit: tt.limit})
 
 			if tt.wantErr {
 				if err == nil {
@@ -323,12 +427,179 @@ func TestFindAll(t *testing.T) {
 	}
 }
 
+func TestEach(t *testing.T) {
+	ctx := context.Background()
+	tagDog := "dog"
+	stop := errors.New("stop")
+
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectQuery(
+		"SELECT .+ FROM pets WHERE deleted_at IS NULL AND tag IN").
+		WithArgs("dog").
+		WillReturnRows(
+			petRows().
+				AddRow(petRow(int64(1), "Fido", &tagDog)...).
+				AddRow(petRow(int64(2), "Rex", &tagDog)...).
+				AddRow(petRow(int64(3), "Spot", &tagDog)...),
+		).
+		RowsWillBeClosed()
+
+	repo := pet.NewPetRepository(mock)
+	var got []int64
+	err = repo.Each(ctx, pet.Filter{Tags: []string{"dog"}}, func(p pet.Pet) error {
+		got = append(got, p.ID)
+		if len(got) == 2 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop {
+		t.Fatalf("got error %v, want the one fn returned", err)
+	}
+	if len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("got ids %v, want [1 2]", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestFindAllProfileFilters(t *testing.T) {
+	ctx := context.Background()
+	after := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	low, high := int64(1000), int64(50000)
+	limit := int32(5)
+
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	storeID := int64(2)
+	mock.ExpectQuery(`SELECT .+ FROM pets WHERE deleted_at IS NULL `+
+		`AND store_id = \$1 `+
+		`AND species IN \(\$2, \$3\) AND breed IN \(\$4\) AND sex = \$5 `+
+		`AND birth_date >= \$6 AND birth_date <= \$7 `+
+		`AND price_currency = \$8 AND price_amount >= \$9 AND price_amount <= \$10 `+
+		`ORDER BY id LIMIT \$11`).
+		WithArgs(storeID, "dog", "cat", "beagle", pet.SexFemale, after, before,
+			"EUR", low, high, limit).
+		WillReturnRows(petRows().AddRow(petRow(4, "Bella", nil)...))
+
+	repo := pet.NewPetRepository(mock)
+	got, err := repo.FindAll(ctx, pet.Filter{
+		Store:      &storeID,
+		Species:    []string{"dog", "cat"},
+		Breeds:     []string{"beagle"},
+		Sex:        pet.SexFemale,
+		BornAfter:  &after,
+		BornBefore: &before,
+		Currency:   "EUR",
+		MinPrice:   &low,
+		MaxPrice:   &high,
+		Limit:      &limit,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != 4 {
+		t.Errorf("got %+v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestFindAllNear(t *testing.T) {
+	ctx := context.Background()
+	near := store.Location{Latitude: 53.35, Longitude: -6.26}
+	radius := 25.0
+	limit := int32(10)
+
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectQuery(`SELECT .+, distance_km FROM pets JOIN \(SELECT id AS store, `+
+		`.+latitude - \$2::float8.+longitude - \$3::float8.+ AS distance_km `+
+		`FROM stores WHERE latitude IS NOT NULL\) d ON d.store = store_id `+
+		`WHERE deleted_at IS NULL AND tag IN \(\$1\) AND distance_km <= \$4 `+
+		`ORDER BY distance_km, id LIMIT \$5`).
+		WithArgs("dog", near.Latitude, near.Longitude, radius, limit).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "name", "tag", "species", "breed", "birth_date", "sex",
+			"description", "price_amount", "price_currency", "store_id",
+			"reserved_until", "distance_km",
+		}).
+			AddRow(append(petRow(4, "Bella", nil), 1.5)...).
+			AddRow(append(petRow(2, "Rex", nil), 12.25)...))
+
+	repo := pet.NewPetRepository(mock)
+	got, err := repo.FindAll(ctx, pet.Filter{
+		Tags:     []string{"dog"},
+		Near:     &near,
+		RadiusKm: &radius,
+		Limit:    &limit,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != 4 || got[1].ID != 2 {
+		t.Fatalf("got %+v", got)
+	}
+	if got[0].DistanceKm == nil || *got[0].DistanceKm != 1.5 ||
+		got[1].DistanceKm == nil || *got[1].DistanceKm != 12.25 {
+		t.Errorf("got distances %v, %v", got[0].DistanceKm, got[1].DistanceKm)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestFindSpecies(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectQuery(`SELECT .+ FROM species s LEFT JOIN breeds b .+ ORDER BY s.name, b.name`).
+		WillReturnRows(pgxmock.NewRows([]string{"code", "name", "code", "name"}).
+			AddRow("cat", "Cat", ptrStr("persian"), ptrStr("Persian")).
+			AddRow("cat", "Cat", ptrStr("siamese"), ptrStr("Siamese")).
+			AddRow("ferret", "Ferret", (*string)(nil), (*string)(nil)))
+
+	repo := pet.NewPetRepository(mock)
+	got, err := repo.FindSpecies(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []pet.Species{
+		{Code: "cat", Name: "Cat", Breeds: []pet.Breed{
+			{Code: "persian", Name: "Persian"},
+			{Code: "siamese", Name: "Siamese"},
+		}},
+		{Code: "ferret", Name: "Ferret", Breeds: []pet.Breed{}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
 func TestDelete(t *testing.T) {
 	ctx := context.Background()
+	actorID := int64(4)
+	storeID := int64(2)
 
 	tests := []struct {
 		name    string
 		id      int64
+		scope   *int64
 		mock    func(m pgxmock.PgxPoolIface)
 		wantErr error
 	}{
@@ -336,18 +607,34 @@ func TestDelete(t *testing.T) {
 			name: "row affected",
 			id:   1,
 			mock: func(m pgxmock.PgxPoolIface) {
-				m.ExpectExec("DELETE FROM pets").
-					WithArgs(int64(1)).
-					WillReturnResult(pgxmock.NewResult("DELETE", 1))
+				m.ExpectExec(`UPDATE pets SET deleted_at = now\(\) .+`+
+					`UPDATE reservations SET status = 'cancelled', cancelled_at = now\(\) FROM p `+
+					`WHERE reservations.pet_id = p.id AND reservations.status = 'active' .+ `+
+					`INSERT INTO pet_revisions`).
+					WithArgs(int64(1), (*int64)(nil), &actorID, (*int64)(nil)).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
 			},
 		},
+		{
+			name:  "outside store",
+			id:    1,
+			scope: &storeID,
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectExec(`UPDATE pets SET deleted_at = now\(\) `+
+					`WHERE id = \$1 AND deleted_at IS NULL `+
+					`AND \(\$2::bigint IS NULL OR store_id = \$2\)`).
+					WithArgs(int64(1), &storeID, &actorID, (*int64)(nil)).
+					WillReturnResult(pgxmock.NewResult("INSERT", 0))
+			},
+			wantErr: db.ErrNotFound,
+		},
 		{
 			name: "no row affected",
 			id:   999,
 			mock: func(m pgxmock.PgxPoolIface) {
-				m.ExpectExec("DELETE FROM pets").
-					WithArgs(int64(999)).
-					WillReturnResult(pgxmock.NewResult("DELETE", 0))
+				m.ExpectExec(`UPDATE pets SET deleted_at = now\(\)`).
+					WithArgs(int64(999), (*int64)(nil), &actorID, (*int64)(nil)).
+					WillReturnResult(pgxmock.NewResult("INSERT", 0))
 			},
 			wantErr: db.ErrNotFound,
 		},
@@ -364,7 +651,7 @@ func TestDelete(t *testing.T) {
 			tt.mock(mock)
 
 			repo := pet.NewPetRepository(mock)
-			err = repo.Delete(ctx, tt.id)
+			err = repo.Delete(ctx, tt.id, tt.scope, pet.Actor{UserID: &actorID})
 
 			if tt.wantErr != nil {
 				if !errors.Is(err, tt.wantErr) {
@@ -383,6 +670,251 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestRestore(t *testing.T) {
+	ctx := context.Background()
+	keyID := int64(2)
+
+	tests := []struct {
+		name    string
+		mock    func(m pgxmock.PgxPoolIface)
+		wantErr error
+	}{
+		{
+			name: "restored",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(`UPDATE pets SET deleted_at = NULL .+`+
+					`INSERT INTO pet_revisions .+ 'restore'`).
+					WithArgs(int64(1), (*int64)(nil), (*int64)(nil), &keyID).
+					WillReturnRows(
+						petRows().
+							AddRow(petRow(int64(1), "Fido", (*string)(nil))...),
+					)
+			},
+		},
+		{
+			name: "not deleted or missing",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery(`UPDATE pets SET deleted_at = NULL`).
+					WithArgs(int64(1), (*int64)(nil), (*int64)(nil), &keyID).
+					WillReturnRows(
+						petRows(),
+					)
+			},
+			wantErr: db.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			tt.mock(mock)
+
+			repo := pet.NewPetRepository(mock)
+			got, err := repo.Restore(ctx, 1, nil, pet.Actor{APIKeyID: &keyID})
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v",
+						err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.ID != 1 || got.Name != "Fido" {
+				t.Errorf("got %+v", got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestImport(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(3)
+	pets := []pet.Pet{
+		{Name: "Fido", Tag: ptrStr("dog"), StoreID: 1},
+		{Name: "Luna", StoreID: 1},
+	}
+
+	tests := []struct {
+		name    string
+		mock    func(m pgxmock.PgxPoolIface)
+		wantErr bool
+	}{
+		{
+			name: "imported",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectBegin()
+				m.ExpectExec(`CREATE TEMPORARY TABLE pet_import`).
+					WillReturnResult(pgxmock.NewResult("CREATE TABLE", 0))
+				m.ExpectCopyFrom(pgx.Identifier{"pet_import"},
+					[]string{"ord", "name", "tag", "species", "breed", "birth_date",
+						"sex", "description", "price_amount", "price_currency",
+						"store_id"}).
+					WillReturnResult(2)
+				m.ExpectQuery(`INSERT INTO pets .+ `+
+					`COALESCE\(NULLIF\(store_id, 0\), \(SELECT min\(id\) FROM stores\)\) `+
+					`FROM pet_import ORDER BY ord .+`+
+					`INSERT INTO pet_revisions .+ 'create'`).
+					WithArgs(&userID, (*int64)(nil)).
+					WillReturnRows(
+						pgxmock.NewRows([]string{"count"}).AddRow(2),
+					)
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "copy fails",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectBegin()
+				m.ExpectExec(`CREATE TEMPORARY TABLE pet_import`).
+					WillReturnResult(pgxmock.NewResult("CREATE TABLE", 0))
+				m.ExpectCopyFrom(pgx.Identifier{"pet_import"},
+					[]string{"ord", "name", "tag", "species", "breed", "birth_date",
+						"sex", "description", "price_amount", "price_currency",
+						"store_id"}).
+					WillReturnError(errors.New("connection reset"))
+				m.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			tt.mock(mock)
+
+			repo := pet.NewPetRepository(mock)
+			n, err := repo.Import(ctx, pets, pet.Actor{UserID: &userID})
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if n != 2 {
+				t.Errorf("imported %d, want 2", n)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestFindRevisions(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	now := time.Now()
+	actorID := int64(4)
+	tagDog := "dog"
+	storeID := int64(2)
+	mock.ExpectQuery(`SELECT .+ FROM pet_revisions WHERE pet_id = \$1 `+
+		`AND pet_id IN \(SELECT id FROM pets WHERE `+
+		`\(\$2::bigint IS NULL OR store_id = \$2\)\) ORDER BY id`).
+		WithArgs(int64(1), &storeID).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "pet_id", "action", "name", "tag", "actor_user_id",
+			"actor_api_key_id", "created_at",
+		}).
+			AddRow(int64(10), int64(1), pet.RevisionCreate, "Fido",
+				&tagDog, (*int64)(nil), (*int64)(nil), now).
+			AddRow(int64(11), int64(1), pet.RevisionDelete, "Fido",
+				&tagDog, &actorID, (*int64)(nil), now),
+		)
+
+	repo := pet.NewPetRepository(mock)
+	got, err := repo.FindRevisions(context.Background(), 1, &storeID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].Action != pet.RevisionCreate ||
+		got[0].Actor.UserID != nil || got[1].Action != pet.RevisionDelete ||
+		got[1].Actor.UserID == nil || *got[1].Actor.UserID != actorID {
+		t.Errorf("got %+v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRevisionsAfter(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT .+ FROM pet_revisions WHERE id > \$1 ORDER BY id LIMIT \$2`).
+		WithArgs(int64(10), 100).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "pet_id", "action", "name", "tag", "actor_user_id",
+			"actor_api_key_id", "created_at",
+		}).
+			AddRow(int64(11), int64(1), pet.RevisionDelete, "Fido",
+				(*string)(nil), (*int64)(nil), (*int64)(nil), now).
+			AddRow(int64(12), int64(2), pet.RevisionCreate, "Rex",
+				(*string)(nil), (*int64)(nil), (*int64)(nil), now),
+		)
+
+	repo := pet.NewPetRepository(mock)
+	got, err := repo.RevisionsAfter(context.Background(), 10, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != 11 || got[1].PetID != 2 {
+		t.Errorf("got %+v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestLatestRevisionID(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	mock.ExpectQuery(`SELECT COALESCE\(max\(id\), 0\) FROM pet_revisions`).
+		WillReturnRows(pgxmock.NewRows([]string{"coalesce"}).
+			AddRow(int64(42)))
+
+	repo := pet.NewPetRepository(mock)
+	got, err := repo.LatestRevisionID(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 42 {
+		t.Errorf("got %d, want 42", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
 // ptrStrEq compares two *string values for equality.
 func ptrStrEq(a, b *string) bool {
 	if a == nil && b == nil {
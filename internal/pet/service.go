@@ -1,22 +1,77 @@
 package pet
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/store"
+)
+
+// MaxImportRows bounds the rows a single import may hold,
+// valid or not.
+const MaxImportRows = 10000
+
+var (
+	// ErrImportTooLarge is returned for an import of more
+	// than MaxImportRows rows.
+	ErrImportTooLarge = fmt.Errorf(
+		"import exceeds %d rows", MaxImportRows)
+
+	// ErrUnknownBreed is returned for a pet whose species,
+	// or breed of that species, is not in the reference
+	// tables.
+	ErrUnknownBreed = errors.New("unknown species or breed")
+
+	// ErrBreedWithoutSpecies is returned for a pet with a
+	// breed but no species.
+	ErrBreedWithoutSpecies = errors.New("breed requires species")
+
+	// ErrFutureBirthDate is returned for a pet born after
+	// today.
+	ErrFutureBirthDate = errors.New("birth date is in the future")
+
+	// ErrPriceFilterWithoutCurrency is returned for a price
+	// bound without a currency to compare it in.
+	ErrPriceFilterWithoutCurrency = errors.New(
+		"minPrice and maxPrice require currency")
+
+	// ErrRadiusWithoutNear is returned for a distance bound
+	// without a point to measure it from.
+	ErrRadiusWithoutNear = errors.New("radius_km requires near")
+)
 
 // Repository is the persistence interface the service
 // depends on. PetRepository satisfies it via duck typing.
 type Repository interface {
 	Create(ctx context.Context,
-		name string, tag *string,
+		p Pet, actor Actor,
 	) (Pet, error)
+	Import(ctx context.Context,
+		pets []Pet, actor Actor,
+	) (int, error)
 	FindByID(ctx context.Context,
 		id int64,
 	) (Pet, error)
 	FindAll(ctx context.Context,
-		tags []string, limit *int32,
+		f Filter,
 	) ([]Pet, error)
+	Each(ctx context.Context,
+		f Filter, fn func(Pet) error,
+	) error
+	FindSpecies(ctx context.Context) ([]Species, error)
 	Delete(ctx context.Context,
-		id int64,
+		id int64, scope *int64, actor Actor,
 	) error
+	Restore(ctx context.Context,
+		id int64, scope *int64, actor Actor,
+	) (Pet, error)
+	FindRevisions(ctx context.Context,
+		petID int64, scope *int64,
+	) ([]Revision, error)
 }
 
 // Service implements pet business logic on top of a
@@ -31,14 +86,65 @@ func NewService(repo Repository) *Service {
 	return &Service{repo: repo}
 }
 
+// Validate checks the rules of p that do not need the
+// database. Whether its species and breed exist is checked
+// when it is written.
+func (p Pet) Validate() error {
+	if p.Breed != nil && p.Species == nil {
+		return ErrBreedWithoutSpecies
+	}
+	if p.BirthDate != nil && p.BirthDate.After(time.Now()) {
+		return ErrFutureBirthDate
+	}
+	return nil
+}
+
 // CreatePet creates a new pet and returns it with the
-// generated ID.
+// generated ID; the ID of p is ignored. The caller in ctx
+// is recorded as the actor, and its store is the pet's if
+// p names none; a global caller's goes to the default
+// store. See store.Resolve.
 func (s *Service) CreatePet(
 	ctx context.Context,
-	name string,
-	tag *string,
+	p Pet,
 ) (Pet, error) {
-	return s.repo.Create(ctx, name, tag)
+	if err := p.Validate(); err != nil {
+		return Pet{}, err
+	}
+	id, err := store.Resolve(auth.StoreScope(ctx), p.StoreID)
+	if err != nil {
+		return Pet{}, err
+	}
+	p.StoreID = id
+	return s.repo.Create(ctx, p, actorFromContext(ctx))
+}
+
+// ImportPets creates pets in bulk, all or none, and returns
+// how many were created. IDs in pets are ignored. The
+// caller in ctx is recorded as the actor of every pet, and
+// stores are resolved as in CreatePet.
+func (s *Service) ImportPets(
+	ctx context.Context,
+	pets []Pet,
+) (int, error) {
+	if len(pets) > MaxImportRows {
+		return 0, ErrImportTooLarge
+	}
+	if len(pets) == 0 {
+		return 0, nil
+	}
+	scope := auth.StoreScope(ctx)
+	for i, p := range pets {
+		if err := p.Validate(); err != nil {
+			return 0, err
+		}
+		id, err := store.Resolve(scope, p.StoreID)
+		if err != nil {
+			return 0, err
+		}
+		pets[i].StoreID = id
+	}
+	return s.repo.Import(ctx, pets, actorFromContext(ctx))
 }
 
 // GetPet returns the pet with the given ID.
@@ -49,20 +155,115 @@ func (s *Service) GetPet(
 	return s.repo.FindByID(ctx, id)
 }
 
-// ListPets returns pets, optionally filtered by tags and
-// limited to a maximum number of results.
+// ListPets returns the pets matching f, in ID order, or by
+// distance if f has a Near point.
 func (s *Service) ListPets(
 	ctx context.Context,
-	tags []string,
-	limit *int32,
+	f Filter,
 ) ([]Pet, error) {
-	return s.repo.FindAll(ctx, tags, limit)
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.FindAll(ctx, f)
 }
 
-// DeletePet removes the pet with the given ID.
+// ExportPets calls fn with each pet ListPets would return,
+// in the same order, without holding them all in memory. It stops
+// at the first error fn returns. A caller confined to a
+// store exports only that store's pets.
+func (s *Service) ExportPets(
+	ctx context.Context,
+	f Filter,
+	fn func(Pet) error,
+) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	var err error
+	f.Store, err = store.Confine(auth.StoreScope(ctx), f.Store)
+	if err != nil {
+		return err
+	}
+	return s.repo.Each(ctx, f, fn)
+}
+
+// ListSpecies returns the species pets may be given, with
+// their breeds, each sorted by name.
+func (s *Service) ListSpecies(ctx context.Context) ([]Species, error) {
+	return s.repo.FindSpecies(ctx)
+}
+
+// Validate reports a filter whose fields cannot be used
+// together, or whose Near point is off the globe.
+func (f Filter) Validate() error {
+	if (f.MinPrice != nil || f.MaxPrice != nil) && f.Currency == "" {
+		return ErrPriceFilterWithoutCurrency
+	}
+	if f.RadiusKm != nil && f.Near == nil {
+		return ErrRadiusWithoutNear
+	}
+	if f.Near != nil && !f.Near.Valid() {
+		return store.ErrInvalidLocation
+	}
+	return nil
+}
+
+// DeletePet soft-deletes the pet with the given ID. The
+// caller in ctx is recorded as the actor. A caller confined
+// to a store gets db.ErrNotFound for another store's pet.
 func (s *Service) DeletePet(
 	ctx context.Context,
 	id int64,
 ) error {
-	return s.repo.Delete(ctx, id)
+	return s.repo.Delete(ctx, id, auth.StoreScope(ctx),
+		actorFromContext(ctx))
+}
+
+// RestorePet undeletes the pet with the given ID. Returns
+// db.ErrNotFound unless the pet exists, is deleted, and is
+// in the caller's store, if it has one.
+func (s *Service) RestorePet(
+	ctx context.Context,
+	id int64,
+) (Pet, error) {
+	return s.repo.Restore(ctx, id, auth.StoreScope(ctx),
+		actorFromContext(ctx))
+}
+
+// PetHistory returns the revisions of the pet with the
+// given ID, oldest first. Every pet has at least its create
+// revision, so an empty history means the pet does not
+// exist, or is outside the caller's store, and
+// db.ErrNotFound is returned.
+func (s *Service) PetHistory(
+	ctx context.Context,
+	id int64,
+) ([]Revision, error) {
+	revs, err := s.repo.FindRevisions(ctx, id, auth.StoreScope(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if len(revs) == 0 {
+		return nil, db.ErrNotFound
+	}
+	return revs, nil
+}
+
+// actorFromContext returns the user or API key that
+// authenticated the request in ctx.
+func actorFromContext(ctx context.Context) Actor {
+	var a Actor
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return a
+	}
+	if claims.UserID != 0 {
+		id := claims.UserID
+		a.UserID = &id
+	}
+	if claims.APIKeyID != 0 {
+		id := claims.APIKeyID
+		a.APIKeyID = &id
+	}
+	return a
 }
@@ -4,25 +4,41 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/hhubris/petstore/internal/auth"
 	"github.com/hhubris/petstore/internal/db"
 	"github.com/hhubris/petstore/internal/pet"
+	"github.com/hhubris/petstore/internal/store"
 )
 
 // mockRepo is a hand-written mock of pet.Repository.
 type mockRepo struct {
-	createFn   func(ctx context.Context, name string, tag *string) (pet.Pet, error)
-	findByIDFn func(ctx context.Context, id int64) (pet.Pet, error)
-	findAllFn  func(ctx context.Context, tags []string, limit *int32) ([]pet.Pet, error)
-	deleteFn   func(ctx context.Context, id int64) error
+	createFn        func(ctx context.Context, p pet.Pet, actor pet.Actor) (pet.Pet, error)
+	importFn        func(ctx context.Context, pets []pet.Pet, actor pet.Actor) (int, error)
+	findByIDFn      func(ctx context.Context, id int64) (pet.Pet, error)
+	findAllFn       func(ctx context.Context, f pet.Filter) ([]pet.Pet, error)
+	eachFn          func(ctx context.Context, f pet.Filter, fn func(pet.Pet) error) error
+	findSpeciesFn   func(ctx context.Context) ([]pet.Species, error)
+	deleteFn        func(ctx context.Context, id int64, scope *int64, actor pet.Actor) error
+	restoreFn       func(ctx context.Context, id int64, scope *int64, actor pet.Actor) (pet.Pet, error)
+	findRevisionsFn func(ctx context.Context, petID int64, scope *int64) ([]pet.Revision, error)
 }
 
 func (m *mockRepo) Create(
 	ctx context.Context,
-	name string,
-	tag *string,
+	p pet.Pet,
+	actor pet.Actor,
 ) (pet.Pet, error) {
-	return m.createFn(ctx, name, tag)
+	return m.createFn(ctx, p, actor)
+}
+
+func (m *mockRepo) Import(
+	ctx context.Context,
+	pets []pet.Pet,
+	actor pet.Actor,
+) (int, error) {
+	return m.importFn(ctx, pets, actor)
 }
 
 func (m *mockRepo) FindByID(
@@ -34,77 +50,163 @@ func (m *mockRepo) FindByID(
 
 func (m *mockRepo) FindAll(
 	ctx context.Context,
-	tags []string,
-	limit *int32,
+	f pet.Filter,
 ) ([]pet.Pet, error) {
-	return m.findAllFn(ctx, tags, limit)
+	return m.findAllFn(ctx, f)
+}
+
+func (m *mockRepo) Each(
+	ctx context.Context,
+	f pet.Filter,
+	fn func(pet.Pet) error,
+) error {
+	return m.eachFn(ctx, f, fn)
+}
+
+func (m *mockRepo) FindSpecies(
+	ctx context.Context,
+) ([]pet.Species, error) {
+	return m.findSpeciesFn(ctx)
 }
 
 func (m *mockRepo) Delete(
 	ctx context.Context,
 	id int64,
+	scope *int64,
+	actor pet.Actor,
 ) error {
-	return m.deleteFn(ctx, id)
+	return m.deleteFn(ctx, id, scope, actor)
+}
+
+func (m *mockRepo) Restore(
+	ctx context.Context,
+	id int64,
+	scope *int64,
+	actor pet.Actor,
+) (pet.Pet, error) {
+	return m.restoreFn(ctx, id, scope, actor)
+}
+
+func (m *mockRepo) FindRevisions(
+	ctx context.Context,
+	petID int64,
+	scope *int64,
+) ([]pet.Revision, error) {
+	return m.findRevisionsFn(ctx, petID, scope)
 }
 
 func TestServiceCreatePet(t *testing.T) {
+	created := func(
+		_ context.Context, p pet.Pet, _ pet.Actor,
+	) (pet.Pet, error) {
+		p.ID = 1
+		return p, nil
+	}
+	future := time.Now().AddDate(0, 0, 2)
 	tests := []struct {
 		name    string
-		repo    *mockRepo
-		wantErr bool
+		pet     pet.Pet
+		create  func(context.Context, pet.Pet, pet.Actor) (pet.Pet, error)
+		wantErr error
 	}{
 		{
-			name: "success",
-			repo: &mockRepo{
-				createFn: func(
-					_ context.Context,
-					name string, tag *string,
-				) (pet.Pet, error) {
-					return pet.Pet{
-						ID: 1, Name: name, Tag: tag,
-					}, nil
-				},
+			name:   "success",
+			pet:    pet.Pet{Name: "Fido", Tag: ptrStr("dog"), Species: ptrStr("dog"), Breed: ptrStr("beagle"), StoreID: 1},
+			create: created,
+		},
+		{
+			// A global caller naming no store leaves 0 for the
+			// repository's default store.
+			name: "no store",
+			pet:  pet.Pet{Name: "Fido"},
+			create: func(
+				ctx context.Context, p pet.Pet, a pet.Actor,
+			) (pet.Pet, error) {
+				if p.StoreID != 0 {
+					t.Errorf("store = %d, want 0", p.StoreID)
+				}
+				return created(ctx, p, a)
 			},
 		},
 		{
 			name: "repo error",
-			repo: &mockRepo{
-				createFn: func(
-					context.Context, string, *string,
-				) (pet.Pet, error) {
-					return pet.Pet{},
-						errors.New("db down")
-				},
+			pet:  pet.Pet{Name: "Fido", StoreID: 1},
+			create: func(
+				context.Context, pet.Pet, pet.Actor,
+			) (pet.Pet, error) {
+				return pet.Pet{}, pet.ErrUnknownBreed
 			},
-			wantErr: true,
+			wantErr: pet.ErrUnknownBreed,
+		},
+		{
+			name:    "breed without species",
+			pet:     pet.Pet{Name: "Fido", Breed: ptrStr("beagle")},
+			wantErr: pet.ErrBreedWithoutSpecies,
+		},
+		{
+			name:    "born in the future",
+			pet:     pet.Pet{Name: "Fido", BirthDate: &future},
+			wantErr: pet.ErrFutureBirthDate,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			svc := pet.NewService(tt.repo)
-			tag := ptrStr("dog")
-			got, err := svc.CreatePet(
-				context.Background(), "Fido", tag,
-			)
-			if tt.wantErr {
-				if err == nil {
-					t.Fatal("expected error, got nil")
+			svc := pet.NewService(&mockRepo{createFn: tt.create})
+			got, err := svc.CreatePet(context.Background(), tt.pet)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
 				}
 				return
 			}
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
-			if got.Name != "Fido" {
-				t.Errorf(
-					"Name = %q, want %q",
-					got.Name, "Fido",
-				)
+			if got.ID != 1 || got.Name != "Fido" ||
+				!ptrStrEq(got.Tag, tt.pet.Tag) ||
+				!ptrStrEq(got.Breed, tt.pet.Breed) {
+				t.Errorf("got %+v, want %+v", got, tt.pet)
 			}
-			if !ptrStrEq(got.Tag, tag) {
-				t.Errorf("Tag = %v, want %v",
-					got.Tag, tag)
+		})
+	}
+}
+
+func TestServiceImportPets(t *testing.T) {
+	imported := func(
+		_ context.Context, pets []pet.Pet, _ pet.Actor,
+	) (int, error) {
+		return len(pets), nil
+	}
+	tests := []struct {
+		name    string
+		pets    int
+		want    int
+		wantErr error
+	}{
+		{name: "some", pets: 3, want: 3},
+		{name: "none", pets: 0, want: 0},
+		{name: "at the limit", pets: pet.MaxImportRows, want: pet.MaxImportRows},
+		{name: "too many", pets: pet.MaxImportRows + 1, wantErr: pet.ErrImportTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockRepo{importFn: imported}
+			if tt.pets == 0 {
+				repo.importFn = nil
+			}
+			svc := pet.NewService(repo)
+			pets := make([]pet.Pet, tt.pets)
+			for i := range pets {
+				pets[i].StoreID = 1
+			}
+			got, err := svc.ImportPets(context.Background(), pets)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("imported %d, want %d", got, tt.want)
 			}
 		})
 	}
@@ -178,8 +280,7 @@ func TestServiceListPets(t *testing.T) {
 			name: "success",
 			repo: &mockRepo{
 				findAllFn: func(
-					_ context.Context,
-					_ []string, _ *int32,
+					context.Context, pet.Filter,
 				) ([]pet.Pet, error) {
 					return []pet.Pet{
 						{ID: 1, Name: "Fido"},
@@ -193,7 +294,7 @@ func TestServiceListPets(t *testing.T) {
 			name: "empty",
 			repo: &mockRepo{
 				findAllFn: func(
-					context.Context, []string, *int32,
+					context.Context, pet.Filter,
 				) ([]pet.Pet, error) {
 					return nil, nil
 				},
@@ -206,7 +307,7 @@ func TestServiceListPets(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			svc := pet.NewService(tt.repo)
 			got, err := svc.ListPets(
-				context.Background(), nil, nil,
+				context.Background(), pet.Filter{},
 			)
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
@@ -221,6 +322,116 @@ func TestServiceListPets(t *testing.T) {
 	}
 }
 
+func TestServiceExportPets(t *testing.T) {
+	limit := int32(5)
+	repo := &mockRepo{
+		eachFn: func(
+			_ context.Context, f pet.Filter, fn func(pet.Pet) error,
+		) error {
+			if len(f.Tags) != 1 || f.Tags[0] != "dog" || f.Limit != &limit {
+				t.Errorf("got filter %+v", f)
+			}
+			for _, p := range []pet.Pet{{ID: 1, Name: "Fido"}, {ID: 2, Name: "Rex"}} {
+				if err := fn(p); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	svc := pet.NewService(repo)
+	var got []string
+	err := svc.ExportPets(context.Background(),
+		pet.Filter{Tags: []string{"dog"}, Limit: &limit},
+		func(p pet.Pet) error {
+			got = append(got, p.Name)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0] != "Fido" || got[1] != "Rex" {
+		t.Errorf("got pets %v", got)
+	}
+}
+
+func TestServicePriceFilterNeedsCurrency(t *testing.T) {
+	svc := pet.NewService(&mockRepo{})
+	low := int64(100)
+	f := pet.Filter{MinPrice: &low}
+	if _, err := svc.ListPets(context.Background(), f); !errors.Is(err, pet.ErrPriceFilterWithoutCurrency) {
+		t.Errorf("ListPets err = %v", err)
+	}
+	err := svc.ExportPets(context.Background(), f, func(pet.Pet) error { return nil })
+	if !errors.Is(err, pet.ErrPriceFilterWithoutCurrency) {
+		t.Errorf("ExportPets err = %v", err)
+	}
+}
+
+func TestServiceNearFilter(t *testing.T) {
+	radius := 10.0
+	tests := []struct {
+		name    string
+		f       pet.Filter
+		wantErr error
+	}{
+		{
+			name: "near",
+			f:    pet.Filter{Near: &store.Location{Latitude: -33.87, Longitude: 151.21}},
+		},
+		{
+			name: "near with radius",
+			f: pet.Filter{
+				Near:     &store.Location{Latitude: 90, Longitude: -180},
+				RadiusKm: &radius,
+			},
+		},
+		{
+			name:    "radius without near",
+			f:       pet.Filter{RadiusKm: &radius},
+			wantErr: pet.ErrRadiusWithoutNear,
+		},
+		{
+			name:    "latitude off the globe",
+			f:       pet.Filter{Near: &store.Location{Latitude: -90.5}},
+			wantErr: store.ErrInvalidLocation,
+		},
+		{
+			name:    "longitude off the globe",
+			f:       pet.Filter{Near: &store.Location{Longitude: 181}},
+			wantErr: store.ErrInvalidLocation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := pet.NewService(&mockRepo{
+				findAllFn: func(context.Context, pet.Filter) ([]pet.Pet, error) {
+					return nil, nil
+				},
+			})
+			_, err := svc.ListPets(context.Background(), tt.f)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ListPets err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServiceListSpecies(t *testing.T) {
+	want := []pet.Species{{Code: "dog", Name: "Dog", Breeds: []pet.Breed{}}}
+	svc := pet.NewService(&mockRepo{
+		findSpeciesFn: func(context.Context) ([]pet.Species, error) {
+			return want, nil
+		},
+	})
+	got, err := svc.ListSpecies(context.Background())
+	if err != nil || len(got) != 1 || got[0].Code != "dog" {
+		t.Errorf("got %+v, %v", got, err)
+	}
+}
+
 func TestServiceDeletePet(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -231,7 +442,7 @@ func TestServiceDeletePet(t *testing.T) {
 			name: "success",
 			repo: &mockRepo{
 				deleteFn: func(
-					context.Context, int64,
+					context.Context, int64, *int64, pet.Actor,
 				) error {
 					return nil
 				},
@@ -241,7 +452,7 @@ func TestServiceDeletePet(t *testing.T) {
 			name: "not found",
 			repo: &mockRepo{
 				deleteFn: func(
-					context.Context, int64,
+					context.Context, int64, *int64, pet.Actor,
 				) error {
 					return db.ErrNotFound
 				},
@@ -271,3 +482,193 @@ func TestServiceDeletePet(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceRecordsActor(t *testing.T) {
+	tests := []struct {
+		name     string
+		claims   *auth.Claims
+		wantUser int64
+		wantKey  int64
+	}{
+		{name: "anonymous"},
+		{
+			name:     "user",
+			claims:   &auth.Claims{UserID: 4, Role: "admin"},
+			wantUser: 4,
+		},
+		{
+			name:    "api key",
+			claims:  &auth.Claims{APIKeyID: 2, Scopes: []string{"pets:write"}},
+			wantKey: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []pet.Actor
+			repo := &mockRepo{
+				createFn: func(_ context.Context, p pet.Pet, a pet.Actor) (pet.Pet, error) {
+					got = append(got, a)
+					p.ID = 1
+					return p, nil
+				},
+				deleteFn: func(_ context.Context, _ int64, _ *int64, a pet.Actor) error {
+					got = append(got, a)
+					return nil
+				},
+				restoreFn: func(_ context.Context, id int64, _ *int64, a pet.Actor) (pet.Pet, error) {
+					got = append(got, a)
+					return pet.Pet{ID: id}, nil
+				},
+			}
+			svc := pet.NewService(repo)
+			ctx := context.Background()
+			if tt.claims != nil {
+				ctx = auth.ContextWithClaims(ctx, *tt.claims)
+			}
+
+			if _, err := svc.CreatePet(ctx, pet.Pet{Name: "Fido", StoreID: 1}); err != nil {
+				t.Fatal(err)
+			}
+			if err := svc.DeletePet(ctx, 1); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := svc.RestorePet(ctx, 1); err != nil {
+				t.Fatal(err)
+			}
+
+			for i, a := range got {
+				if (a.UserID != nil) != (tt.wantUser != 0) ||
+					(a.UserID != nil && *a.UserID != tt.wantUser) {
+					t.Errorf("call %d: user = %v, want %d", i, a.UserID, tt.wantUser)
+				}
+				if (a.APIKeyID != nil) != (tt.wantKey != 0) ||
+					(a.APIKeyID != nil && *a.APIKeyID != tt.wantKey) {
+					t.Errorf("call %d: key = %v, want %d", i, a.APIKeyID, tt.wantKey)
+				}
+			}
+		})
+	}
+}
+
+func TestServicePetHistory(t *testing.T) {
+	tests := []struct {
+		name    string
+		revs    []pet.Revision
+		err     error
+		wantErr error
+	}{
+		{
+			name: "history",
+			revs: []pet.Revision{
+				{ID: 1, PetID: 7, Action: pet.RevisionCreate},
+				{ID: 2, PetID: 7, Action: pet.RevisionDelete},
+			},
+		},
+		{name: "unknown pet", wantErr: db.ErrNotFound},
+		{
+			name:    "repo error",
+			err:     errors.New("db down"),
+			wantErr: errors.New("db down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := pet.NewService(&mockRepo{
+				findRevisionsFn: func(context.Context, int64, *int64) ([]pet.Revision, error) {
+					return tt.revs, tt.err
+				},
+			})
+			got, err := svc.PetHistory(context.Background(), 7)
+			if tt.wantErr != nil {
+				if err == nil || err.Error() != tt.wantErr.Error() {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.revs) {
+				t.Errorf("got %d revisions, want %d", len(got), len(tt.revs))
+			}
+		})
+	}
+}
+
+func TestServiceStoreScope(t *testing.T) {
+	own, other := int64(2), int64(3)
+	ctx := auth.ContextWithClaims(context.Background(),
+		auth.Claims{UserID: 4, Role: "admin", StoreID: &own})
+
+	var scopes []*int64
+	repo := &mockRepo{
+		createFn: func(_ context.Context, p pet.Pet, _ pet.Actor) (pet.Pet, error) {
+			if p.StoreID != own {
+				t.Errorf("created in store %d, want %d", p.StoreID, own)
+			}
+			return p, nil
+		},
+		importFn: func(_ context.Context, pets []pet.Pet, _ pet.Actor) (int, error) {
+			for _, p := range pets {
+				if p.StoreID != own {
+					t.Errorf("imported into store %d, want %d", p.StoreID, own)
+				}
+			}
+			return len(pets), nil
+		},
+		eachFn: func(_ context.Context, f pet.Filter, _ func(pet.Pet) error) error {
+			scopes = append(scopes, f.Store)
+			return nil
+		},
+		deleteFn: func(_ context.Context, _ int64, scope *int64, _ pet.Actor) error {
+			scopes = append(scopes, scope)
+			return nil
+		},
+		restoreFn: func(_ context.Context, id int64, scope *int64, _ pet.Actor) (pet.Pet, error) {
+			scopes = append(scopes, scope)
+			return pet.Pet{ID: id}, nil
+		},
+		findRevisionsFn: func(_ context.Context, _ int64, scope *int64) ([]pet.Revision, error) {
+			scopes = append(scopes, scope)
+			return []pet.Revision{{ID: 1}}, nil
+		},
+	}
+	svc := pet.NewService(repo)
+	noop := func(pet.Pet) error { return nil }
+
+	if _, err := svc.CreatePet(ctx, pet.Pet{Name: "Fido"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.ImportPets(ctx, []pet.Pet{{Name: "Rex", StoreID: own}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ExportPets(ctx, pet.Filter{}, noop); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.DeletePet(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.RestorePet(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.PetHistory(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	for i, s := range scopes {
+		if s == nil || *s != own {
+			t.Errorf("call %d: scope = %v, want %d", i, s, own)
+		}
+	}
+
+	if _, err := svc.CreatePet(ctx, pet.Pet{Name: "Fido", StoreID: other}); !errors.Is(err, store.ErrOtherStore) {
+		t.Errorf("CreatePet in another store: err = %v", err)
+	}
+	if _, err := svc.ImportPets(ctx, []pet.Pet{{Name: "Rex", StoreID: other}}); !errors.Is(err, store.ErrOtherStore) {
+		t.Errorf("ImportPets into another store: err = %v", err)
+	}
+	if err := svc.ExportPets(ctx, pet.Filter{Store: &other}, noop); !errors.Is(err, store.ErrOtherStore) {
+		t.Errorf("ExportPets of another store: err = %v", err)
+	}
+}
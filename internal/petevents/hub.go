@@ -0,0 +1,250 @@
+package petevents
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hhubris/petstore/internal/pet"
+)
+
+// Channel is the NOTIFY channel signalled by the trigger on
+// pet_revisions.
+const Channel = "pet_events"
+
+// pageSize bounds each read of the revision log.
+const pageSize = 500
+
+// bufferSize is how many events a slow stream may fall
+// behind before the hub drops it. A dropped client
+// reconnects and catches up with Last-Event-ID.
+const bufferSize = 64
+
+// retryDelay is how long the hub waits before listening
+// again after its connection fails.
+const retryDelay = 5 * time.Second
+
+// defaultGapTimeout is how long the hub holds back newer
+// revisions while an older ID is missing; see Hub.
+const defaultGapTimeout = 10 * time.Second
+
+// Source reads the revision log. Satisfied by
+// *pet.PetRepository.
+type Source interface {
+	RevisionsAfter(ctx context.Context, after int64, limit int) ([]pet.Revision, error)
+	LatestRevisionID(ctx context.Context) (int64, error)
+}
+
+// ListenFunc subscribes to a NOTIFY channel; see
+// (*db.DB).Listen, which satisfies it.
+type ListenFunc func(ctx context.Context, channel string, fn func(payload string)) error
+
+// Hub reads new revisions when notified and publishes them
+// to every subscribed stream, in ID order.
+//
+// Revision IDs are taken when a row is inserted, not when
+// its transaction commits, so a revision can become visible
+// after one with a higher ID. When the next ID is missing
+// but later ones are visible, the hub holds the later ones
+// back until the missing revision commits, or until the gap
+// timeout passes and it is taken for a rolled-back
+// transaction. Everything up to the hub's watermark has
+// therefore been published or given up on, and a stream may
+// use it as its cursor.
+type Hub struct {
+	source     Source
+	gapTimeout time.Duration
+
+	// pollMu serialises polls, which come from notifications
+	// and from the gap recheck.
+	pollMu sync.Mutex
+	// gapSince is when poll first found the revision after
+	// last missing; zero while there is no gap.
+	gapSince time.Time
+
+	// last is the watermark: the newest revision published
+	// or skipped. started is set once it is known.
+	last    atomic.Int64
+	started atomic.Bool
+
+	mu     sync.Mutex
+	subs   map[chan Event]struct{}
+	closed bool
+}
+
+// HubOption configures optional Hub behaviour.
+type HubOption func(*Hub)
+
+// WithGapTimeout sets how long the hub waits for a missing
+// revision before skipping it. The default is 10 seconds.
+func WithGapTimeout(d time.Duration) HubOption {
+	return func(h *Hub) { h.gapTimeout = d }
+}
+
+// NewHub returns a Hub reading from source. Call Run to
+// start it.
+func NewHub(source Source, opts ...HubOption) *Hub {
+	h := &Hub{
+		source:     source,
+		gapTimeout: defaultGapTimeout,
+		subs:       map[chan Event]struct{}{},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Run listens for notifications until ctx is cancelled,
+// reconnecting after failures, then closes every
+// subscription so open streams end. While a gap holds
+// revisions back it also polls on its own, since the
+// missing revision may never send a notification.
+func (h *Hub) Run(ctx context.Context, listen ListenFunc) {
+	defer h.close()
+	var recheck sync.WaitGroup
+	defer recheck.Wait()
+	recheck.Go(func() { h.recheck(ctx) })
+	for {
+		err := listen(ctx, Channel, func(string) { h.poll(ctx) })
+		if ctx.Err() != nil {
+			return
+		}
+		slog.ErrorContext(ctx, "pet event listener failed",
+			"error", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+// recheck polls while a gap is open, so held revisions are
+// published once the gap times out, until ctx is cancelled.
+func (h *Hub) recheck(ctx context.Context) {
+	ticker := time.NewTicker(h.gapTimeout / 5)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		h.pollMu.Lock()
+		open := !h.gapSince.IsZero()
+		h.pollMu.Unlock()
+		if open {
+			h.poll(ctx)
+		}
+	}
+}
+
+// poll publishes the revisions added since the last poll,
+// stopping at a gap that has not timed out. The first poll
+// only records where the log ends: streams replay history
+// themselves.
+func (h *Hub) poll(ctx context.Context) {
+	h.pollMu.Lock()
+	defer h.pollMu.Unlock()
+	if !h.started.Load() {
+		last, err := h.source.LatestRevisionID(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "reading pet events", "error", err)
+			return
+		}
+		h.last.Store(last)
+		h.started.Store(true)
+		return
+	}
+	for {
+		last := h.last.Load()
+		revs, err := h.source.RevisionsAfter(ctx, last, pageSize)
+		if err != nil {
+			slog.ErrorContext(ctx, "reading pet events", "error", err)
+			return
+		}
+		for _, r := range revs {
+			if r.ID != last+1 {
+				if h.gapSince.IsZero() {
+					h.gapSince = time.Now()
+				}
+				if time.Since(h.gapSince) < h.gapTimeout {
+					return
+				}
+				slog.WarnContext(ctx, "skipping missing pet events",
+					"after", last, "next", r.ID)
+			}
+			h.gapSince = time.Time{}
+			// The watermark moves before the event is sent, so
+			// a stream that subscribes in between replays it.
+			last = r.ID
+			h.last.Store(last)
+			h.publish(eventFromRevision(r))
+		}
+		if len(revs) < pageSize {
+			return
+		}
+	}
+}
+
+// watermark returns the ID up to which every revision has
+// been published or skipped. Before the first poll it is
+// the newest revision in the log.
+func (h *Hub) watermark(ctx context.Context) (int64, error) {
+	if h.started.Load() {
+		return h.last.Load(), nil
+	}
+	return h.source.LatestRevisionID(ctx)
+}
+
+// publish sends e to every subscriber, dropping those whose
+// buffer is full.
+func (h *Hub) publish(e Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs {
+		select {
+		case ch <- e:
+		default:
+			delete(h.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// Subscribe returns a channel of new events and a function
+// that ends the subscription. The channel is closed when
+// the subscription ends, the subscriber falls too far
+// behind, or the hub stops.
+func (h *Hub) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, bufferSize)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	h.subs[ch] = struct{}{}
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subs[ch]; ok {
+			delete(h.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// close ends every subscription and refuses new ones.
+func (h *Hub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for ch := range h.subs {
+		delete(h.subs, ch)
+		close(ch)
+	}
+}
@@ -0,0 +1,267 @@
+package petevents_test
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hhubris/petstore/internal/pet"
+	"github.com/hhubris/petstore/internal/petevents"
+)
+
+// memSource is an in-memory revision log.
+type memSource struct {
+	mu   sync.Mutex
+	revs []pet.Revision
+}
+
+func (s *memSource) add(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for range n {
+		id := s.latest() + 1
+		s.revs = append(s.revs, pet.Revision{
+			ID: id, PetID: id, Action: pet.RevisionCreate,
+			Name: "pet", CreatedAt: time.Now(),
+		})
+	}
+}
+
+// addAction appends one revision with the given action.
+func (s *memSource) addAction(action string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.revs = append(s.revs, pet.Revision{
+		ID: s.latest() + 1, PetID: 1, Action: action,
+		Name: "pet", CreatedAt: time.Now(),
+	})
+}
+
+// commit makes the revision with the given ID visible, as
+// when a transaction holding an older ID commits late.
+func (s *memSource) commit(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.revs = append(s.revs, pet.Revision{
+		ID: id, PetID: id, Action: pet.RevisionCreate,
+		Name: "pet", CreatedAt: time.Now(),
+	})
+	slices.SortFunc(s.revs, func(a, b pet.Revision) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+}
+
+func (s *memSource) latest() int64 {
+	if len(s.revs) == 0 {
+		return 0
+	}
+	return s.revs[len(s.revs)-1].ID
+}
+
+func (s *memSource) RevisionsAfter(
+	_ context.Context, after int64, limit int,
+) ([]pet.Revision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []pet.Revision
+	for _, r := range s.revs {
+		if r.ID > after && len(out) < limit {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (s *memSource) LatestRevisionID(context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.latest(), nil
+}
+
+// notifier is a ListenFunc driven by the test: it signals
+// readiness, then delivers one notification per send.
+type notifier struct {
+	ready chan struct{}
+	c     chan struct{}
+}
+
+func newNotifier() *notifier {
+	return &notifier{ready: make(chan struct{}), c: make(chan struct{})}
+}
+
+func (n *notifier) listen(
+	ctx context.Context, _ string, fn func(string),
+) error {
+	fn("")
+	close(n.ready)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-n.c:
+			fn("")
+		}
+	}
+}
+
+// startHub runs a hub until the test ends and waits for it
+// to start listening.
+func startHub(
+	t *testing.T, src *memSource, opts ...petevents.HubOption,
+) (*petevents.Hub, *notifier) {
+	t.Helper()
+	hub := petevents.NewHub(src, opts...)
+	n := newNotifier()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		hub.Run(ctx, n.listen)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	<-n.ready
+	return hub, n
+}
+
+func TestHubPublishesNewRevisions(t *testing.T) {
+	src := &memSource{}
+	src.add(2)
+	hub, n := startHub(t, src)
+	events, cancel := hub.Subscribe()
+	defer cancel()
+
+	src.add(1)
+	n.c <- struct{}{}
+
+	select {
+	case e := <-events:
+		if e.ID != 3 || e.Type != petevents.TypeCreated {
+			t.Errorf("got %+v, want created event 3", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no event published")
+	}
+}
+
+// nextEvent returns the next published event, failing the
+// test if none arrives within d.
+func nextEvent(
+	t *testing.T, events <-chan petevents.Event, d time.Duration,
+) petevents.Event {
+	t.Helper()
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(d):
+		t.Fatal("no event published")
+		return petevents.Event{}
+	}
+}
+
+func TestHubPublishesOutOfOrderCommitsInOrder(t *testing.T) {
+	src := &memSource{}
+	src.add(1)
+	hub, n := startHub(t, src, petevents.WithGapTimeout(time.Hour))
+	events, cancel := hub.Subscribe()
+	defer cancel()
+
+	// Revision 3 commits while 2 is still in flight.
+	src.commit(3)
+	n.c <- struct{}{}
+	select {
+	case e := <-events:
+		t.Fatalf("published %d while 2 was missing", e.ID)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	src.commit(2)
+	n.c <- struct{}{}
+	for _, want := range []int64{2, 3} {
+		if e := nextEvent(t, events, time.Second); e.ID != want {
+			t.Errorf("got event %d, want %d", e.ID, want)
+		}
+	}
+}
+
+func TestHubSkipsGapAfterTimeout(t *testing.T) {
+	src := &memSource{}
+	src.add(1)
+	hub, n := startHub(t, src,
+		petevents.WithGapTimeout(50*time.Millisecond))
+	events, cancel := hub.Subscribe()
+	defer cancel()
+
+	// Revision 2 is rolled back and never commits; the hub
+	// publishes 3 on its own once the gap times out.
+	src.commit(3)
+	n.c <- struct{}{}
+	if e := nextEvent(t, events, time.Second); e.ID != 3 {
+		t.Errorf("got event %d, want 3", e.ID)
+	}
+}
+
+func TestHubPublishesHoldEvents(t *testing.T) {
+	src := &memSource{}
+	hub, n := startHub(t, src)
+	events, cancel := hub.Subscribe()
+	defer cancel()
+
+	want := map[string]string{
+		pet.RevisionReserve:           petevents.TypeReserved,
+		pet.RevisionCancelReservation: petevents.TypeReservationCancelled,
+		pet.RevisionExpireReservation: petevents.TypeReservationExpired,
+	}
+	for action, typ := range want {
+		src.addAction(action)
+		n.c <- struct{}{}
+		select {
+		case e := <-events:
+			if e.Type != typ {
+				t.Errorf("%s: got type %q, want %q", action, e.Type, typ)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s: no event published", action)
+		}
+	}
+}
+
+func TestHubDropsSlowSubscriber(t *testing.T) {
+	src := &memSource{}
+	hub, n := startHub(t, src)
+	events, cancel := hub.Subscribe()
+	defer cancel()
+
+	src.add(100)
+	n.c <- struct{}{}
+
+	got := 0
+	for range events {
+		got++
+	}
+	if got == 0 || got >= 100 {
+		t.Errorf("received %d events before the drop", got)
+	}
+}
+
+func TestHubRunClosesSubscriptions(t *testing.T) {
+	hub := petevents.NewHub(&memSource{})
+	events, _ := hub.Subscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	hub.Run(ctx, newNotifier().listen)
+
+	if _, ok := <-events; ok {
+		t.Error("subscription still open after Run returned")
+	}
+	late, _ := hub.Subscribe()
+	if _, ok := <-late; ok {
+		t.Error("subscription opened after Run returned")
+	}
+}
@@ -0,0 +1,58 @@
+// Package petevents streams pet catalog changes to clients
+// as Server-Sent Events. The pet_revisions table is the
+// event log: each revision is an event and its ID the
+// stream cursor, so clients resume with Last-Event-ID. A
+// trigger on the table NOTIFYs every server, whose Hub
+// fans new events out to its open streams.
+package petevents
+
+import (
+	"time"
+
+	"github.com/hhubris/petstore/internal/pet"
+)
+
+// Event types, one per revision action. The API has no pet
+// update operation, so there is no such event; holds are
+// the only status change.
+const (
+	TypeCreated              = "created"
+	TypeDeleted              = "deleted"
+	TypeRestored             = "restored"
+	TypeReserved             = "reserved"
+	TypeReservationCancelled = "reservation_cancelled"
+	TypeReservationExpired   = "reservation_expired"
+)
+
+// eventTypes maps revision actions to event types.
+var eventTypes = map[string]string{
+	pet.RevisionCreate:            TypeCreated,
+	pet.RevisionDelete:            TypeDeleted,
+	pet.RevisionRestore:           TypeRestored,
+	pet.RevisionReserve:           TypeReserved,
+	pet.RevisionCancelReservation: TypeReservationCancelled,
+	pet.RevisionExpireReservation: TypeReservationExpired,
+}
+
+// Event is a change to the pet catalog.
+type Event struct {
+	ID        int64
+	Type      string
+	PetID     int64
+	Name      string
+	Tag       *string
+	CreatedAt time.Time
+}
+
+// eventFromRevision converts a revision to an event. The
+// actor is left out: the stream is public.
+func eventFromRevision(r pet.Revision) Event {
+	return Event{
+		ID:        r.ID,
+		Type:      eventTypes[r.Action],
+		PetID:     r.PetID,
+		Name:      r.Name,
+		Tag:       r.Tag,
+		CreatedAt: r.CreatedAt,
+	}
+}
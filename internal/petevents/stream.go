@@ -0,0 +1,140 @@
+package petevents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+)
+
+// retryMillis is the reconnection delay suggested to
+// clients.
+const retryMillis = 3000
+
+// Streamer opens pet event streams for GET /pets/events.
+type Streamer struct {
+	hub       *Hub
+	source    Source
+	heartbeat time.Duration
+}
+
+// NewStreamer creates a Streamer that follows hub, replays
+// from source, and sends a comment line every heartbeat to
+// keep proxies from closing an idle stream.
+func NewStreamer(
+	hub *Hub, source Source, heartbeat time.Duration,
+) *Streamer {
+	return &Streamer{hub: hub, source: source, heartbeat: heartbeat}
+}
+
+// Open returns the text/event-stream body of a new stream.
+// The stream starts with live events, or, when after is set
+// (the client's Last-Event-ID), with every event after that
+// ID. It ends when ctx is done, the hub drops the
+// subscriber, or the body is closed.
+func (s *Streamer) Open(
+	ctx context.Context, after *int64,
+) (io.ReadCloser, error) {
+	// Subscribe before reading the watermark and replaying
+	// up to it, so nothing published meanwhile is missed;
+	// duplicates are skipped by cursor below. Replaying no
+	// further than the hub keeps the stream in ID order even
+	// when revisions commit out of order.
+	events, cancel := s.hub.Subscribe()
+	upTo, err := s.hub.watermark(ctx)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("reading pet event watermark: %w", err)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		defer cancel()
+		defer pw.Close()
+		s.run(ctx, pw, events, after, upTo)
+	}()
+	return pr, nil
+}
+
+// run writes the stream to w until it ends.
+func (s *Streamer) run(
+	ctx context.Context, w io.Writer, events <-chan Event,
+	after *int64, upTo int64,
+) {
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", retryMillis); err != nil {
+		return
+	}
+
+	var cursor int64
+	if after != nil {
+		cursor = *after
+	replay:
+		for cursor < upTo {
+			revs, err := s.source.RevisionsAfter(ctx, cursor, pageSize)
+			if err != nil {
+				slog.ErrorContext(ctx, "replaying pet events",
+					"error", err)
+				return
+			}
+			for _, rev := range revs {
+				if rev.ID > upTo {
+					break replay
+				}
+				if err := writeEvent(w, eventFromRevision(rev)); err != nil {
+					return
+				}
+				cursor = rev.ID
+			}
+			if len(revs) < pageSize {
+				break
+			}
+		}
+		// IDs missing up to the watermark were skipped by the
+		// hub as well.
+		cursor = max(cursor, upTo)
+	}
+
+	ticker := time.NewTicker(s.heartbeat)
+	defer ticker.Stop()
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err = io.WriteString(w, ": heartbeat\n\n")
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			if e.ID <= cursor {
+				continue
+			}
+			err = writeEvent(w, e)
+			cursor = e.ID
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// eventData is the JSON payload of an event.
+type eventData struct {
+	PetID     int64     `json:"petId"`
+	Name      string    `json:"name"`
+	Tag       *string   `json:"tag,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// writeEvent writes e in the text/event-stream format.
+func writeEvent(w io.Writer, e Event) error {
+	data, _ := json.Marshal(eventData{
+		PetID: e.PetID, Name: e.Name, Tag: e.Tag, CreatedAt: e.CreatedAt,
+	})
+	_, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n",
+		e.ID, e.Type, data)
+	return err
+}
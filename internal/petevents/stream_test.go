@@ -0,0 +1,135 @@
+package petevents_test
+
+import (
+	"bufio"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hhubris/petstore/internal/petevents"
+)
+
+// openStream opens a stream on s, resuming after the given
+// ID when set.
+func openStream(
+	t *testing.T, s *petevents.Streamer, after *int64,
+) *bufio.Scanner {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	body, err := s.Open(ctx, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = body.Close() })
+	return bufio.NewScanner(body)
+}
+
+// nextLine returns the next line starting with prefix.
+func nextLine(t *testing.T, sc *bufio.Scanner, prefix string) string {
+	t.Helper()
+	for sc.Scan() {
+		if strings.HasPrefix(sc.Text(), prefix) {
+			return sc.Text()
+		}
+	}
+	t.Fatalf("stream ended before a %q line: %v", prefix, sc.Err())
+	return ""
+}
+
+func ptr(v int64) *int64 { return &v }
+
+func TestStreamResumesAndFollows(t *testing.T) {
+	src := &memSource{}
+	src.add(3)
+	hub, n := startHub(t, src)
+	s := petevents.NewStreamer(hub, src, time.Hour)
+
+	sc := openStream(t, s, ptr(1))
+	if got := nextLine(t, sc, "retry:"); got != "retry: 3000" {
+		t.Errorf("got %q", got)
+	}
+	for _, want := range []string{"id: 2", "id: 3"} {
+		if got := nextLine(t, sc, "id:"); got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	}
+	if got := nextLine(t, sc, "event:"); got != "event: created" {
+		t.Errorf("got %q", got)
+	}
+	if got := nextLine(t, sc, "data:"); !strings.Contains(got, `"petId":3`) {
+		t.Errorf("got %q", got)
+	}
+
+	src.add(1)
+	n.c <- struct{}{}
+	if got := nextLine(t, sc, "id:"); got != "id: 4" {
+		t.Errorf("got %q, want live event 4", got)
+	}
+}
+
+func TestStreamStartsLive(t *testing.T) {
+	src := &memSource{}
+	src.add(2)
+	hub, n := startHub(t, src)
+	s := petevents.NewStreamer(hub, src, time.Hour)
+
+	sc := openStream(t, s, nil)
+	src.add(1)
+	n.c <- struct{}{}
+	if got := nextLine(t, sc, "id:"); got != "id: 3" {
+		t.Errorf("got %q, want only the live event", got)
+	}
+}
+
+func TestStreamReplaysUpToHub(t *testing.T) {
+	src := &memSource{}
+	src.add(1)
+	hub, n := startHub(t, src, petevents.WithGapTimeout(time.Hour))
+	s := petevents.NewStreamer(hub, src, time.Hour)
+
+	// The hub holds 3 back until 2 commits, so a resuming
+	// stream must not replay 3 first and then miss 2.
+	src.commit(3)
+	n.c <- struct{}{}
+	sc := openStream(t, s, ptr(1))
+
+	src.commit(2)
+	n.c <- struct{}{}
+	for _, want := range []string{"id: 2", "id: 3"} {
+		if got := nextLine(t, sc, "id:"); got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	}
+}
+
+func TestStreamHeartbeat(t *testing.T) {
+	src := &memSource{}
+	hub, _ := startHub(t, src)
+	s := petevents.NewStreamer(hub, src, 10*time.Millisecond)
+
+	sc := openStream(t, s, nil)
+	nextLine(t, sc, ": heartbeat")
+}
+
+func TestStreamEndsWithContext(t *testing.T) {
+	src := &memSource{}
+	hub, _ := startHub(t, src)
+	s := petevents.NewStreamer(hub, src, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	body, err := s.Open(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	cancel()
+
+	sc := bufio.NewScanner(body)
+	for sc.Scan() {
+	}
+	if err := sc.Err(); err != nil {
+		t.Errorf("stream ended with %v, want EOF", err)
+	}
+}
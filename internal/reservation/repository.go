@@ -0,0 +1,256 @@
+package reservation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/pet"
+)
+
+// dbtx is the database interface required by
+// ReservationRepository. Satisfied by *pgxpool.Pool,
+// pgx.Tx, and pgxmock.
+type dbtx interface {
+	Query(ctx context.Context, sql string,
+		args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string,
+		args ...any) pgx.Row
+	Exec(ctx context.Context, sql string,
+		args ...any) (pgconn.CommandTag, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// exclusionViolation is the PostgreSQL error code for an
+// exclusion constraint violation.
+const exclusionViolation = "23P01"
+
+// holdConstraint is the exclusion constraint allowing one
+// active hold per pet at a time.
+const holdConstraint = "reservations_one_hold_per_pet"
+
+// reservationColumns is the column list scanned by
+// scanReservation. An active hold past its expiry reads as
+// expired.
+const reservationColumns = "id, pet_id, user_id, " +
+	"CASE WHEN status = 'active' AND expires_at <= now() " +
+	"THEN 'expired' ELSE status END, " +
+	"created_at, expires_at, cancelled_at"
+
+// reservationFields is the reservations column list a
+// writing CTE returns, for reservationColumns to select
+// from.
+const reservationFields = "id, pet_id, user_id, status, " +
+	"created_at, expires_at, cancelled_at"
+
+// revisionInsert writes a pet revision for each
+// reservation row of the "res" CTE, with the action in its
+// %s parameter and the pet's name and tag, so the pet event
+// stream, webhooks and outbox see holds as they do other
+// pet changes.
+const revisionInsert = "INSERT INTO pet_revisions " +
+	"(pet_id, action, name, tag, actor_user_id) " +
+	"SELECT p.id, '%s', p.name, p.tag, %s FROM res JOIN pets p ON p.id = res.pet_id"
+
+// scanReservation reads a row selected with
+// reservationColumns.
+func scanReservation(row pgx.Row) (Reservation, error) {
+	var r Reservation
+	err := row.Scan(&r.ID, &r.PetID, &r.UserID, &r.Status,
+		&r.CreatedAt, &r.ExpiresAt, &r.CancelledAt)
+	return r, err
+}
+
+// ReservationRepository provides database access for
+// reservations.
+type ReservationRepository struct {
+	db dbtx
+}
+
+// NewReservationRepository returns a ReservationRepository
+// backed by the given database connection.
+func NewReservationRepository(conn dbtx) *ReservationRepository {
+	return &ReservationRepository{db: conn}
+}
+
+// Create places a hold on the pet for userID lasting
+// policy.Hold from now, and records a reserve revision of
+// the pet. Returns ErrTooManyHolds if the user already has
+// policy.MaxActive active holds, ErrHoldCooldown if their
+// last hold on the pet ended within policy.Cooldown,
+// db.ErrNotFound if the pet does not exist or is deleted,
+// and ErrPetReserved if it already has an active hold.
+//
+// The user's row is locked for the transaction, so
+// concurrent holds by one user are counted one after the
+// other and cannot together exceed the cap.
+func (r *ReservationRepository) Create(
+	ctx context.Context,
+	petID, userID int64,
+	policy Policy,
+) (Reservation, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return Reservation{}, fmt.Errorf("begin reservation: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx,
+		"SELECT 1 FROM users WHERE id = $1 FOR NO KEY UPDATE",
+		userID,
+	)
+	if err != nil {
+		return Reservation{}, fmt.Errorf("lock reservation user: %w", err)
+	}
+
+	var active int
+	var cooling bool
+	err = tx.QueryRow(ctx,
+		"SELECT count(*) FILTER (WHERE status = 'active' AND expires_at > now()), "+
+			"COALESCE(bool_or(pet_id = $2 "+
+			"AND NOT (status = 'active' AND expires_at > now()) "+
+			"AND COALESCE(cancelled_at, expires_at) > now() - $3 * interval '1 second'), false) "+
+			"FROM reservations WHERE user_id = $1",
+		userID, petID, policy.Cooldown.Seconds(),
+	).Scan(&active, &cooling)
+	if err != nil {
+		return Reservation{}, fmt.Errorf("count reservations: %w", err)
+	}
+	switch {
+	case active >= policy.MaxActive:
+		return Reservation{}, ErrTooManyHolds
+	case cooling:
+		return Reservation{}, ErrHoldCooldown
+	}
+
+	res, err := scanReservation(tx.QueryRow(ctx,
+		"WITH res AS ("+
+			"INSERT INTO reservations (pet_id, user_id, expires_at) "+
+			"SELECT id, $2, now() + $3 * interval '1 second' FROM pets "+
+			"WHERE id = $1 AND deleted_at IS NULL "+
+			"RETURNING "+reservationFields+"), "+
+			"r AS ("+fmt.Sprintf(revisionInsert, pet.RevisionReserve, "res.user_id")+") "+
+			"SELECT "+reservationColumns+" FROM res",
+		petID, userID, policy.Hold.Seconds(),
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Reservation{}, db.ErrNotFound
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) &&
+			pgErr.Code == exclusionViolation &&
+			pgErr.ConstraintName == holdConstraint {
+			return Reservation{}, ErrPetReserved
+		}
+		return Reservation{}, fmt.Errorf("create reservation: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return Reservation{}, fmt.Errorf("commit reservation: %w", err)
+	}
+	return res, nil
+}
+
+// FindByUser returns the user's reservations, newest
+// first.
+func (r *ReservationRepository) FindByUser(
+	ctx context.Context,
+	userID int64,
+) ([]Reservation, error) {
+	rows, err := r.db.Query(ctx,
+		"SELECT "+reservationColumns+" FROM reservations "+
+			"WHERE user_id = $1 ORDER BY id DESC",
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("find reservations: %w", err)
+	}
+	defer rows.Close()
+
+	var out []Reservation
+	for rows.Next() {
+		res, err := scanReservation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan reservation: %w", err)
+		}
+		out = append(out, res)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reservations: %w", err)
+	}
+	return out, nil
+}
+
+// Cancel releases the user's hold with the given ID,
+// records a cancel_reservation revision of the pet, and
+// returns the hold. Returns db.ErrNotFound unless the hold
+// is the user's and still active.
+func (r *ReservationRepository) Cancel(
+	ctx context.Context,
+	id, userID int64,
+) (Reservation, error) {
+	res, err := scanReservation(r.db.QueryRow(ctx,
+		"WITH res AS ("+
+			"UPDATE reservations SET status = 'cancelled', cancelled_at = now() "+
+			"WHERE id = $1 AND user_id = $2 "+
+			"AND status = 'active' AND expires_at > now() "+
+			"RETURNING "+reservationFields+"), "+
+			"r AS ("+fmt.Sprintf(revisionInsert, pet.RevisionCancelReservation, "res.user_id")+") "+
+			"SELECT "+reservationColumns+" FROM res",
+		id, userID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Reservation{}, db.ErrNotFound
+		}
+		return Reservation{}, fmt.Errorf("cancel reservation: %w", err)
+	}
+	return res, nil
+}
+
+// Expire marks the active holds that expired at or before
+// now as expired, records an expire_reservation revision of
+// each pet with no actor, and returns how many there were.
+// Reads already treat the holds as expired; this records
+// it and tells event consumers the pets are available.
+func (r *ReservationRepository) Expire(
+	ctx context.Context,
+	now time.Time,
+) (int64, error) {
+	var n int64
+	err := r.db.QueryRow(ctx,
+		"WITH res AS ("+
+			"UPDATE reservations SET status = 'expired' "+
+			"WHERE status = 'active' AND expires_at <= $1 "+
+			"RETURNING pet_id), "+
+			"r AS ("+fmt.Sprintf(revisionInsert, pet.RevisionExpireReservation, "NULL::bigint")+") "+
+			"SELECT count(*) FROM res",
+		now,
+	).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("expire reservations: %w", err)
+	}
+	return n, nil
+}
+
+// DeleteFinished removes the cancelled and expired holds
+// that ended before before and returns how many there were.
+func (r *ReservationRepository) DeleteFinished(
+	ctx context.Context,
+	before time.Time,
+) (int64, error) {
+	tag, err := r.db.Exec(ctx,
+		"DELETE FROM reservations WHERE status <> 'active' "+
+			"AND COALESCE(cancelled_at, expires_at) < $1",
+		before,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("delete finished reservations: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
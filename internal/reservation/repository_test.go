@@ -0,0 +1,246 @@
+package reservation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/reservation"
+)
+
+// reservationRowColumns matches the repository's
+// reservationColumns.
+var reservationRowColumns = []string{
+	"id", "pet_id", "user_id", "status",
+	"created_at", "expires_at", "cancelled_at",
+}
+
+func TestRepositoryCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		active  int
+		cooling bool
+		err     error
+		wantErr error
+	}{
+		{name: "success", active: 2},
+		{
+			name:    "pet not found",
+			err:     pgx.ErrNoRows,
+			wantErr: db.ErrNotFound,
+		},
+		{
+			name: "pet already held",
+			err: &pgconn.PgError{
+				Code:           "23P01",
+				ConstraintName: "reservations_one_hold_per_pet",
+			},
+			wantErr: reservation.ErrPetReserved,
+		},
+		{
+			name:    "too many holds",
+			active:  3,
+			wantErr: reservation.ErrTooManyHolds,
+		},
+		{
+			name:    "cooldown",
+			cooling: true,
+			wantErr: reservation.ErrHoldCooldown,
+		},
+	}
+
+	policy := reservation.Policy{
+		Hold: 48 * time.Hour, MaxActive: 3, Cooldown: 24 * time.Hour,
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			now := time.Now()
+			mock.ExpectBegin()
+			mock.ExpectExec("SELECT 1 FROM users WHERE id = \\$1 FOR NO KEY UPDATE").
+				WithArgs(int64(7)).
+				WillReturnResult(pgxmock.NewResult("SELECT", 1))
+			mock.ExpectQuery("SELECT count\\(\\*\\) FILTER .* FROM reservations WHERE user_id = \\$1").
+				WithArgs(int64(7), int64(4), float64(24*60*60)).
+				WillReturnRows(pgxmock.NewRows([]string{"count", "bool_or"}).
+					AddRow(tt.active, tt.cooling))
+			if tt.active < 3 && !tt.cooling {
+				q := mock.ExpectQuery("INSERT INTO reservations .* FROM pets .*"+
+					"INSERT INTO pet_revisions .* 'reserve', p.name, p.tag, res.user_id").
+					WithArgs(int64(4), int64(7), float64(48*60*60))
+				if tt.err != nil {
+					q.WillReturnError(tt.err)
+				} else {
+					q.WillReturnRows(pgxmock.NewRows(reservationRowColumns).
+						AddRow(int64(1), int64(4), int64(7), reservation.StatusActive,
+							now, now.Add(48*time.Hour), (*time.Time)(nil)))
+					mock.ExpectCommit()
+				}
+			}
+			if tt.wantErr != nil {
+				mock.ExpectRollback()
+			}
+
+			repo := reservation.NewReservationRepository(mock)
+			got, err := repo.Create(context.Background(), 4, 7, policy)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if got.ID != 1 || got.PetID != 4 ||
+				got.Status != reservation.StatusActive {
+				t.Errorf("got %+v", got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestRepositoryFindByUser(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT .* FROM reservations WHERE user_id = \\$1 ORDER BY id DESC").
+		WithArgs(int64(7)).
+		WillReturnRows(pgxmock.NewRows(reservationRowColumns).
+			AddRow(int64(2), int64(5), int64(7), reservation.StatusActive,
+				now, now.Add(time.Hour), (*time.Time)(nil)).
+			AddRow(int64(1), int64(4), int64(7), reservation.StatusCancelled,
+				now.Add(-time.Hour), now.Add(47*time.Hour), &now))
+
+	repo := reservation.NewReservationRepository(mock)
+	got, err := repo.FindByUser(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != 2 || got[1].CancelledAt == nil {
+		t.Errorf("got %+v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepositoryCancel(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{name: "success"},
+		{
+			name:    "not active or not the user's",
+			err:     pgx.ErrNoRows,
+			wantErr: db.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			now := time.Now()
+			q := mock.ExpectQuery("UPDATE reservations SET status = 'cancelled'.*"+
+				"INSERT INTO pet_revisions .* 'cancel_reservation'").
+				WithArgs(int64(1), int64(7))
+			if tt.err != nil {
+				q.WillReturnError(tt.err)
+			} else {
+				q.WillReturnRows(pgxmock.NewRows(reservationRowColumns).
+					AddRow(int64(1), int64(4), int64(7), reservation.StatusCancelled,
+						now.Add(-time.Hour), now.Add(47*time.Hour), &now))
+			}
+
+			repo := reservation.NewReservationRepository(mock)
+			got, err := repo.Cancel(context.Background(), 1, 7)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if got.Status != reservation.StatusCancelled || got.PetID != 4 {
+				t.Errorf("got %+v", got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestRepositoryExpire(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	now := time.Now()
+	mock.ExpectQuery("UPDATE reservations SET status = 'expired' " +
+		"WHERE status = 'active' AND expires_at <= \\$1 .*" +
+		"INSERT INTO pet_revisions .* 'expire_reservation', p.name, p.tag, NULL::bigint .*" +
+		"SELECT count\\(\\*\\) FROM res").
+		WithArgs(now).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(3)))
+
+	repo := reservation.NewReservationRepository(mock)
+	n, err := repo.Expire(context.Background(), now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("got %d expired, want 3", n)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepositoryDeleteFinished(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	before := time.Now().Add(-90 * 24 * time.Hour)
+	mock.ExpectExec("DELETE FROM reservations WHERE status <> 'active'").
+		WithArgs(before).
+		WillReturnResult(pgxmock.NewResult("DELETE", 2))
+
+	repo := reservation.NewReservationRepository(mock)
+	n, err := repo.DeleteFinished(context.Background(), before)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("got %d deleted, want 2", n)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
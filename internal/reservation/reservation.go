@@ -0,0 +1,77 @@
+// Package reservation manages the time-limited holds
+// customers place on pets before coming in to see them. A
+// pet with an active hold that has not expired is reserved,
+// and no one else may hold it until the hold is cancelled or
+// lapses. The database enforces this with an exclusion
+// constraint, so concurrent holds cannot both succeed.
+package reservation
+
+import (
+	"errors"
+	"time"
+)
+
+// HoldDuration is how long a hold lasts.
+const HoldDuration = 48 * time.Hour
+
+// MaxActiveHolds is how many pets one user may hold at a
+// time.
+const MaxActiveHolds = 3
+
+// HoldCooldown is how long after a hold ends, by
+// cancellation or lapse, before its holder may hold the
+// same pet again, so one user cannot keep a pet off the
+// market by re-holding it.
+const HoldCooldown = 24 * time.Hour
+
+// Policy is the terms a new hold is placed under.
+type Policy struct {
+	// Hold is how long the hold lasts.
+	Hold time.Duration
+	// MaxActive is how many active holds the user may have,
+	// the new one included.
+	MaxActive int
+	// Cooldown is how long after the user's last hold on
+	// the pet ended before they may hold it again.
+	Cooldown time.Duration
+}
+
+// DefaultPolicy is the Policy holds are placed under.
+var DefaultPolicy = Policy{
+	Hold:      HoldDuration,
+	MaxActive: MaxActiveHolds,
+	Cooldown:  HoldCooldown,
+}
+
+// Reservation statuses. An active hold that has passed its
+// expiry is reported as expired even before the expiry
+// sweep records it.
+const (
+	StatusActive    = "active"
+	StatusCancelled = "cancelled"
+	StatusExpired   = "expired"
+)
+
+// ErrPetReserved is returned when a pet already has an
+// active hold.
+var ErrPetReserved = errors.New("pet is already reserved")
+
+// ErrTooManyHolds is returned when the user already has
+// the most active holds allowed.
+var ErrTooManyHolds = errors.New("too many active holds")
+
+// ErrHoldCooldown is returned when the user's last hold on
+// the pet ended less than the cooldown ago.
+var ErrHoldCooldown = errors.New("pet was held too recently")
+
+// Reservation is the domain model for a hold on a pet.
+type Reservation struct {
+	ID        int64
+	PetID     int64
+	UserID    int64
+	Status    string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	// CancelledAt is set for a cancelled hold.
+	CancelledAt *time.Time
+}
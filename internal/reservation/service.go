@@ -0,0 +1,58 @@
+package reservation
+
+import "context"
+
+// Repository is the persistence interface the service
+// depends on. ReservationRepository satisfies it via duck
+// typing.
+type Repository interface {
+	Create(ctx context.Context,
+		petID, userID int64, policy Policy,
+	) (Reservation, error)
+	FindByUser(ctx context.Context, userID int64) ([]Reservation, error)
+	Cancel(ctx context.Context, id, userID int64) (Reservation, error)
+}
+
+// Service implements reservations on top of a Repository.
+// Lapsed holds are recorded by a background job calling
+// ReservationRepository.Expire.
+type Service struct {
+	repo Repository
+}
+
+// NewService returns a Service wired to the given
+// repository.
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Reserve places a hold on the pet for the user under
+// DefaultPolicy. Returns db.ErrNotFound if the pet does not
+// exist, ErrPetReserved if it is already held, by the user
+// or anyone else, ErrTooManyHolds if the user holds
+// MaxActiveHolds pets, and ErrHoldCooldown if the user's
+// last hold on the pet ended within HoldCooldown.
+func (s *Service) Reserve(
+	ctx context.Context,
+	userID, petID int64,
+) (Reservation, error) {
+	return s.repo.Create(ctx, petID, userID, DefaultPolicy)
+}
+
+// List returns the user's reservations, newest first.
+func (s *Service) List(
+	ctx context.Context,
+	userID int64,
+) ([]Reservation, error) {
+	return s.repo.FindByUser(ctx, userID)
+}
+
+// Cancel releases the user's hold with the given ID, making
+// the pet available again. Returns db.ErrNotFound unless
+// the hold is the user's and still active.
+func (s *Service) Cancel(
+	ctx context.Context,
+	userID, id int64,
+) (Reservation, error) {
+	return s.repo.Cancel(ctx, id, userID)
+}
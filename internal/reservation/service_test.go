@@ -0,0 +1,97 @@
+package reservation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/reservation"
+)
+
+// mockRepo is a hand-written mock of reservation.Repository.
+type mockRepo struct {
+	createFn     func(ctx context.Context, petID, userID int64, policy reservation.Policy) (reservation.Reservation, error)
+	findByUserFn func(ctx context.Context, userID int64) ([]reservation.Reservation, error)
+	cancelFn     func(ctx context.Context, id, userID int64) (reservation.Reservation, error)
+}
+
+func (m *mockRepo) Create(ctx context.Context, petID, userID int64, policy reservation.Policy) (reservation.Reservation, error) {
+	return m.createFn(ctx, petID, userID, policy)
+}
+
+func (m *mockRepo) FindByUser(ctx context.Context, userID int64) ([]reservation.Reservation, error) {
+	return m.findByUserFn(ctx, userID)
+}
+
+func (m *mockRepo) Cancel(ctx context.Context, id, userID int64) (reservation.Reservation, error) {
+	return m.cancelFn(ctx, id, userID)
+}
+
+func TestServiceReserve(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoErr error
+	}{
+		{name: "success"},
+		{name: "pet not found", repoErr: db.ErrNotFound},
+		{name: "pet already held", repoErr: reservation.ErrPetReserved},
+		{name: "too many holds", repoErr: reservation.ErrTooManyHolds},
+		{name: "cooldown", repoErr: reservation.ErrHoldCooldown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := reservation.NewService(&mockRepo{
+				createFn: func(_ context.Context, petID, userID int64, policy reservation.Policy) (reservation.Reservation, error) {
+					if petID != 4 || userID != 7 {
+						t.Errorf("got pet %d user %d, want pet 4 user 7", petID, userID)
+					}
+					if policy != reservation.DefaultPolicy {
+						t.Errorf("got policy %+v, want %+v", policy, reservation.DefaultPolicy)
+					}
+					if tt.repoErr != nil {
+						return reservation.Reservation{}, tt.repoErr
+					}
+					return reservation.Reservation{ID: 1, PetID: petID, UserID: userID}, nil
+				},
+			})
+			got, err := svc.Reserve(context.Background(), 7, 4)
+			if !errors.Is(err, tt.repoErr) {
+				t.Fatalf("got error %v, want %v", err, tt.repoErr)
+			}
+			if err == nil && got.ID != 1 {
+				t.Errorf("got %+v", got)
+			}
+		})
+	}
+}
+
+func TestServiceList(t *testing.T) {
+	svc := reservation.NewService(&mockRepo{
+		findByUserFn: func(_ context.Context, userID int64) ([]reservation.Reservation, error) {
+			return []reservation.Reservation{{ID: 2, UserID: userID}}, nil
+		},
+	})
+	got, err := svc.List(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].UserID != 7 {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestServiceCancel(t *testing.T) {
+	svc := reservation.NewService(&mockRepo{
+		cancelFn: func(_ context.Context, id, userID int64) (reservation.Reservation, error) {
+			if id != 1 || userID != 7 {
+				t.Errorf("got id %d user %d, want id 1 user 7", id, userID)
+			}
+			return reservation.Reservation{}, db.ErrNotFound
+		},
+	})
+	if _, err := svc.Cancel(context.Background(), 7, 1); !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, db.ErrNotFound)
+	}
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/hhubris/petstore/internal/apikey"
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/idempotency"
+	"github.com/hhubris/petstore/internal/jobs"
+	"github.com/hhubris/petstore/internal/outbox"
+	"github.com/hhubris/petstore/internal/reservation"
+)
+
+const (
+	// outboxRetention is how long published outbox events
+	// are kept.
+	outboxRetention = 7 * 24 * time.Hour
+
+	// spentTokenRetention is how long one-time tokens are
+	// kept after they expire or are used. It must exceed the
+	// hour over which verification resends are counted.
+	spentTokenRetention = 24 * time.Hour
+
+	// revokedKeyRetention is how long revoked and expired API
+	// keys stay listed before they are deleted.
+	revokedKeyRetention = 90 * 24 * time.Hour
+
+	// reservationRetention is how long cancelled and expired
+	// reservations stay listed before they are deleted.
+	reservationRetention = 90 * 24 * time.Hour
+)
+
+// backgroundJobs returns the periodic jobs the elected
+// replica runs.
+func backgroundJobs(database *db.DB) []jobs.Job {
+	keys := idempotency.NewKeyRepository(database)
+	events := outbox.NewEventRepository(database)
+	users := auth.NewUserRepository(database)
+	apiKeys := apikey.NewAPIKeyRepository(database)
+	reservations := reservation.NewReservationRepository(database)
+
+	return []jobs.Job{
+		{
+			Name:     "purge-idempotency-keys",
+			Schedule: "0 * * * *",
+			Timeout:  time.Minute,
+			Run: func(ctx context.Context) error {
+				return purge(ctx, "idempotency keys", keys.DeleteExpired)
+			},
+		},
+		{
+			Name:     "purge-outbox",
+			Schedule: "15 * * * *",
+			Timeout:  time.Minute,
+			Run: func(ctx context.Context) error {
+				return purge(ctx, "outbox events",
+					func(ctx context.Context) (int64, error) {
+						return events.DeletePublished(ctx,
+							time.Now().Add(-outboxRetention))
+					})
+			},
+		},
+		{
+			Name:     "purge-spent-tokens",
+			Schedule: "30 * * * *",
+			Timeout:  time.Minute,
+			Run: func(ctx context.Context) error {
+				return purge(ctx, "spent tokens",
+					func(ctx context.Context) (int64, error) {
+						return users.DeleteSpentTokens(ctx,
+							time.Now().Add(-spentTokenRetention))
+					})
+			},
+		},
+		{
+			Name:     "purge-revoked-api-keys",
+			Schedule: "45 3 * * *",
+			Timeout:  time.Minute,
+			Run: func(ctx context.Context) error {
+				return purge(ctx, "revoked api keys",
+					func(ctx context.Context) (int64, error) {
+						return apiKeys.DeleteRevoked(ctx,
+							time.Now().Add(-revokedKeyRetention))
+					})
+			},
+		},
+		{
+			Name:     "expire-reservations",
+			Schedule: "* * * * *",
+			Timeout:  time.Minute,
+			Run: func(ctx context.Context) error {
+				n, err := reservations.Expire(ctx, time.Now())
+				if err != nil {
+					return err
+				}
+				if n > 0 {
+					jobs.Logger(ctx).InfoContext(ctx,
+						"expired reservations", "count", n)
+				}
+				return nil
+			},
+		},
+		{
+			Name:     "purge-reservations",
+			Schedule: "15 4 * * *",
+			Timeout:  time.Minute,
+			Run: func(ctx context.Context) error {
+				return purge(ctx, "finished reservations",
+					func(ctx context.Context) (int64, error) {
+						return reservations.DeleteFinished(ctx,
+							time.Now().Add(-reservationRetention))
+					})
+			},
+		},
+	}
+}
+
+// purge runs del and logs how many rows of what it removed.
+func purge(
+	ctx context.Context,
+	what string,
+	del func(ctx context.Context) (int64, error),
+) error {
+	n, err := del(ctx)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		jobs.Logger(ctx).InfoContext(ctx, "purged "+what, "count", n)
+	}
+	return nil
+}
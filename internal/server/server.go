@@ -7,52 +7,284 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/apikey"
+	"github.com/hhubris/petstore/internal/audit"
 	"github.com/hhubris/petstore/internal/auth"
 	"github.com/hhubris/petstore/internal/db"
 	"github.com/hhubris/petstore/internal/handler"
+	"github.com/hhubris/petstore/internal/idempotency"
+	"github.com/hhubris/petstore/internal/jobs"
+	"github.com/hhubris/petstore/internal/mail"
 	"github.com/hhubris/petstore/internal/middleware"
+	"github.com/hhubris/petstore/internal/oidc"
+	"github.com/hhubris/petstore/internal/outbox"
 	"github.com/hhubris/petstore/internal/pet"
+	"github.com/hhubris/petstore/internal/petevents"
+	"github.com/hhubris/petstore/internal/reservation"
+	"github.com/hhubris/petstore/internal/store"
+	"github.com/hhubris/petstore/internal/webhook"
 )
 
 // shutdownTimeout is the maximum time to wait for in-flight
 // requests to complete during graceful shutdown.
 const shutdownTimeout = 10 * time.Second
 
+// userCacheTTL bounds how long a user record backs the
+// per-request session and role checks. Changes made through
+// this instance take effect at once; changes made elsewhere
+// within this time.
+const userCacheTTL = 30 * time.Second
+
+// idempotencyTTL is how long a response stays available for
+// replay to requests retrying its Idempotency-Key.
+const idempotencyTTL = 24 * time.Hour
+
+// petEventsHeartbeat is how often an idle pet event stream
+// sends a comment to keep proxies from closing it.
+const petEventsHeartbeat = 15 * time.Second
+
+// webhookPollInterval is how often the webhook worker
+// looks for deliveries that are due.
+const webhookPollInterval = 5 * time.Second
+
+// outboxPollInterval is how often the outbox relay looks
+// for events to publish.
+const outboxPollInterval = time.Second
+
+// config holds settings read from the environment by Run.
+type config struct {
+	addr      string
+	jwtSecret string
+	secure    bool
+	appURL    string
+	mailer    mail.Mailer
+
+	// jwtSecretPrevious is the HS256 secret jwtSecret
+	// replaced; it only verifies, so sessions survive a
+	// rotation until their tokens expire.
+	jwtSecretPrevious string
+
+	// jwtPrivateKeyFile, when set, switches token signing
+	// from jwtSecret to an Ed25519 or RSA key.
+	jwtPrivateKeyFile string
+	jwtVerifyKeyFiles []string
+
+	requireVerifiedEmail bool
+	requireAdminMFA      bool
+	requireAdminSSO      bool
+	requireFreshRoles    bool
+
+	// webhookAllowPrivate lets webhooks target loopback and
+	// private addresses, for receivers on a developer's
+	// machine.
+	webhookAllowPrivate bool
+
+	// oidc enables single sign-on when its Issuer is set;
+	// Run discovers the provider into idp before build.
+	oidc oidc.Config
+	idp  auth.IdentityProvider
+
+	// petEvents feeds GET /pets/events when set; Run
+	// creates and starts it before build.
+	petEvents *petevents.Hub
+
+	// jobs runs periodic work and reports its status to
+	// GET /admin/jobs; Run creates it before build.
+	jobs *jobs.Scheduler
+
+	// outboxSinks receive the events the outbox relay
+	// publishes; see outboxSinksFromEnv.
+	outboxSinks []outbox.Sink
+
+	// background tracks work services start that outlives
+	// its request, such as password reset emails; Run waits
+	// for it before closing the database.
+	background *sync.WaitGroup
+}
+
+// loadConfig reads server configuration from environment
+// variables.
+func loadConfig() (config, error) {
+	cfg := config{
+		addr:      os.Getenv("ADDRESS"),
+		jwtSecret: os.Getenv("JWT_SECRET"),
+		secure:    os.Getenv("ENVIRONMENT") != "development",
+		appURL:    os.Getenv("FRONTEND_URL"),
+
+		jwtSecretPrevious: os.Getenv("JWT_SECRET_PREVIOUS"),
+		jwtPrivateKeyFile: os.Getenv("JWT_PRIVATE_KEY_FILE"),
+
+		requireVerifiedEmail: os.Getenv(
+			"REQUIRE_EMAIL_VERIFICATION",
+		) == "true",
+		requireAdminMFA: os.Getenv("REQUIRE_ADMIN_MFA") == "true",
+		requireAdminSSO: os.Getenv("REQUIRE_ADMIN_SSO") == "true",
+		requireFreshRoles: os.Getenv(
+			"REQUIRE_FRESH_ROLES",
+		) == "true",
+		webhookAllowPrivate: os.Getenv(
+			"WEBHOOK_ALLOW_PRIVATE",
+		) == "true",
+
+		oidc: oidc.Config{
+			Issuer:       os.Getenv("OIDC_ISSUER_URL"),
+			ClientID:     os.Getenv("OIDC_CLIENT_ID"),
+			ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+			RedirectURL:  os.Getenv("OIDC_REDIRECT_URL"),
+		},
+	}
+	if cfg.addr == "" {
+		cfg.addr = ":8080"
+	}
+	if v := os.Getenv("JWT_VERIFY_KEY_FILES"); v != "" {
+		cfg.jwtVerifyKeyFiles = strings.Split(v, ",")
+	}
+	if cfg.jwtSecret == "" && cfg.jwtPrivateKeyFile == "" {
+		return config{}, fmt.Errorf("JWT_SECRET is required")
+	}
+	if cfg.appURL == "" {
+		cfg.appURL = "http://localhost:5173"
+	}
+	if cfg.oidc.Issuer != "" && cfg.oidc.ClientID == "" {
+		return config{}, fmt.Errorf(
+			"OIDC_CLIENT_ID is required with OIDC_ISSUER_URL",
+		)
+	}
+	if cfg.oidc.RedirectURL == "" {
+		cfg.oidc.RedirectURL = "http://localhost:8080/auth/oidc/callback"
+	}
+	if cfg.requireAdminSSO && cfg.oidc.Issuer == "" {
+		return config{}, fmt.Errorf(
+			"REQUIRE_ADMIN_SSO needs OIDC_ISSUER_URL",
+		)
+	}
+	cfg.mailer = mailerFromEnv()
+	sinks, err := outboxSinksFromEnv()
+	if err != nil {
+		return config{}, err
+	}
+	cfg.outboxSinks = sinks
+	return cfg, nil
+}
+
+// outboxSinksFromEnv returns the outbox sinks OUTBOX_SINK
+// names: "log" (the default) logs each event, and "none"
+// marks events published without handing them anywhere.
+// Nothing else consumes the outbox yet.
+func outboxSinksFromEnv() ([]outbox.Sink, error) {
+	switch v := os.Getenv("OUTBOX_SINK"); v {
+	case "", "log":
+		return []outbox.Sink{outbox.LogSink{}}, nil
+	case "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf(
+			"OUTBOX_SINK must be log or none, got %q", v,
+		)
+	}
+}
+
+// mailerFromEnv returns an SMTP mailer when SMTP_HOST is
+// set, otherwise a file mailer writing to MAIL_DIR (default
+// ".mail") for local development.
+func mailerFromEnv() mail.Mailer {
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = "Pet Store <noreply@petstore.local>"
+	}
+
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		dir := os.Getenv("MAIL_DIR")
+		if dir == "" {
+			dir = ".mail"
+		}
+		return mail.NewFileMailer(dir, from)
+	}
+
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "1025"
+	}
+	return mail.NewSMTPMailer(
+		host, port, from,
+		os.Getenv("SMTP_USERNAME"),
+		os.Getenv("SMTP_PASSWORD"),
+	)
+}
+
+// webhookOptions returns the options shared by the webhook
+// service and worker.
+func (cfg config) webhookOptions() []webhook.Option {
+	if cfg.webhookAllowPrivate {
+		return []webhook.Option{webhook.AllowPrivateAddresses()}
+	}
+	return nil
+}
+
 // Run is the public entry point for the server. It reads
 // configuration from environment variables, wires up all
 // dependencies, and starts the HTTP server. It blocks until
 // ctx is cancelled, then performs a graceful shutdown.
 func Run(ctx context.Context) error {
-	addr := os.Getenv("ADDRESS")
-	if addr == "" {
-		addr = ":8080"
-	}
-
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		return fmt.Errorf("JWT_SECRET is required")
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
 	}
 
-	env := os.Getenv("ENVIRONMENT")
-	secure := env != "development"
-
 	database, err := db.New(ctx)
 	if err != nil {
 		return fmt.Errorf("connecting to database: %w", err)
 	}
 	defer database.Close()
 
-	h, err := build(database, jwtSecret, secure)
+	// Background goroutines stop when Run returns, and Run
+	// waits for them before closing the database.
+	var background sync.WaitGroup
+	defer background.Wait()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if cfg.oidc.Issuer != "" {
+		p, err := oidc.Discover(ctx, cfg.oidc)
+		if err != nil {
+			return err
+		}
+		cfg.idp = p
+	}
+
+	cfg.background = &background
+	cfg.petEvents = petevents.NewHub(pet.NewPetRepository(database))
+	background.Go(func() { cfg.petEvents.Run(ctx, database.Listen) })
+
+	cfg.jobs, err = jobs.NewScheduler(backgroundJobs(database)...)
+	if err != nil {
+		return fmt.Errorf("creating job scheduler: %w", err)
+	}
+
+	h, err := build(database, cfg)
 	if err != nil {
 		return fmt.Errorf("building server: %w", err)
 	}
 
-	slog.Info("server starting", "addr", addr)
+	background.Go(func() {
+		webhook.NewWorker(webhook.NewWebhookRepository(database),
+			cfg.webhookOptions()...).Run(ctx, webhookPollInterval)
+	})
+	background.Go(func() {
+		outbox.NewRelay(outbox.NewEventRepository(database),
+			cfg.outboxSinks...).Run(ctx, outboxPollInterval)
+	})
+	background.Go(func() { cfg.jobs.Run(ctx, database.WithAdvisoryLock) })
+
+	slog.Info("server starting", "addr", cfg.addr)
 
-	if err := serve(ctx, addr, h); err != nil {
+	if err := serve(ctx, cfg.addr, h); err != nil {
 		return fmt.Errorf("serving: %w", err)
 	}
 
@@ -64,10 +296,13 @@ func Run(ctx context.Context) error {
 // http.Handler ready to serve requests.
 func build(
 	database *db.DB,
-	jwtSecret string,
-	secure bool,
+	cfg config,
 ) (http.Handler, error) {
-	tc, err := auth.NewTokenConfig([]byte(jwtSecret))
+	if err := api.CheckPolicies(); err != nil {
+		return nil, err
+	}
+
+	tc, err := tokenConfig(cfg)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"creating token config: %w", err,
@@ -75,15 +310,56 @@ func build(
 	}
 
 	userRepo := auth.NewUserRepository(database)
-	authSvc := auth.NewService(userRepo, tc)
-	secHandler := auth.NewSecurityHandler(tc)
+	userCache := auth.NewUserCache(userRepo, userCacheTTL)
+	authOpts := []auth.Option{
+		auth.WithUserCache(userCache),
+		auth.WithMailer(cfg.mailer),
+		auth.WithAppURL(cfg.appURL),
+		auth.WithRequireAdminSSO(cfg.requireAdminSSO),
+	}
+	if cfg.background != nil {
+		authOpts = append(authOpts, auth.WithBackground(cfg.background))
+	}
+	if cfg.idp != nil {
+		authOpts = append(authOpts, auth.WithIdentityProvider(cfg.idp))
+	}
+	authSvc := auth.NewService(userRepo, tc, authOpts...)
+	keyRepo := apikey.NewAPIKeyRepository(database)
+	keySvc := apikey.NewService(keyRepo)
+	secHandler := auth.NewSecurityHandler(tc,
+		auth.WithRequireVerifiedEmail(cfg.requireVerifiedEmail),
+		auth.WithRequireAdminMFA(cfg.requireAdminMFA),
+		auth.WithAPIKeys(keySvc),
+		auth.WithSessionCheck(userCache),
+		auth.WithRoleCheck(cfg.requireFreshRoles),
+	)
 
 	petRepo := pet.NewPetRepository(database)
 	petSvc := pet.NewService(petRepo)
 
-	h := handler.New(petSvc, authSvc, secure)
+	auditSvc := audit.NewService(audit.NewEventRepository(database))
+	webhookSvc := webhook.NewService(
+		webhook.NewWebhookRepository(database), cfg.webhookOptions()...,
+	)
+	storeSvc := store.NewService(store.NewStoreRepository(database))
+	reservationSvc := reservation.NewService(
+		reservation.NewReservationRepository(database))
+
+	var stream handler.PetEventStreamer
+	if cfg.petEvents != nil {
+		stream = petevents.NewStreamer(
+			cfg.petEvents, petRepo, petEventsHeartbeat,
+		)
+	}
+
+	h := handler.New(
+		petSvc, authSvc, keySvc, auditSvc, webhookSvc, storeSvc,
+		reservationSvc, cfg.jobs, stream, cfg.secure,
+	)
 
-	srv, err := api.NewServer(h, secHandler)
+	srv, err := api.NewServer(h, secHandler,
+		api.WithErrorHandler(handler.ErrorHandler),
+	)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"creating ogen server: %w", err,
@@ -94,11 +370,75 @@ func build(
 	return middleware.Chain(inner,
 		middleware.Recovery(),
 		middleware.CorrelationID(),
+		middleware.ClientInfo(),
 		middleware.Logging(),
+		idempotency.Middleware(
+			idempotency.NewKeyRepository(database), idempotencyTTL,
+		),
 		middleware.Spec(),
 	), nil
 }
 
+// tokenConfig returns an HS256 TokenConfig for jwtSecret,
+// or, when a private key file is configured, one that signs
+// with that key. In the latter case the verify key files and
+// jwtSecret, if set, stay valid for verification so tokens
+// issued before a rotation keep working until they expire.
+// jwtSecretPrevious, if set, verifies in either case.
+func tokenConfig(cfg config) (*auth.TokenConfig, error) {
+	var (
+		signing auth.Key
+		verify  []auth.Key
+		err     error
+	)
+	if cfg.jwtPrivateKeyFile == "" {
+		signing, err = auth.NewHMACKey([]byte(cfg.jwtSecret))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		signing, err = readPEMKey(cfg.jwtPrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range cfg.jwtVerifyKeyFiles {
+			k, err := readPEMKey(strings.TrimSpace(path))
+			if err != nil {
+				return nil, err
+			}
+			verify = append(verify, k)
+		}
+		if cfg.jwtSecret != "" {
+			k, err := auth.NewHMACKey([]byte(cfg.jwtSecret))
+			if err != nil {
+				return nil, err
+			}
+			verify = append(verify, k)
+		}
+	}
+	if cfg.jwtSecretPrevious != "" {
+		k, err := auth.NewHMACKey([]byte(cfg.jwtSecretPrevious))
+		if err != nil {
+			return nil, fmt.Errorf("JWT_SECRET_PREVIOUS: %w", err)
+		}
+		verify = append(verify, k)
+	}
+	return auth.NewKeyTokenConfig(signing, verify...)
+}
+
+// readPEMKey loads a JWT key from a PEM file.
+func readPEMKey(path string) (auth.Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return auth.Key{}, fmt.Errorf("reading jwt key: %w", err)
+	}
+	k, err := auth.ParsePEMKey(data)
+	if err != nil {
+		return auth.Key{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return k, nil
+}
+
 // serve starts an HTTP server and blocks until ctx is
 // cancelled, then gracefully shuts down.
 func serve(
@@ -1,11 +1,29 @@
 package server
 
 import (
+	"bufio"
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"net"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/hhubris/petstore/internal/api"
+	"github.com/hhubris/petstore/internal/auth"
+	"github.com/hhubris/petstore/internal/jobs"
+	"github.com/hhubris/petstore/internal/pet"
+	"github.com/hhubris/petstore/internal/petevents"
 )
 
 func TestRunMissingPetstoreUser(t *testing.T) {
@@ -35,13 +53,154 @@ func TestRunMissingJWTSecret(t *testing.T) {
 	}
 }
 
+func TestRunRequireAdminSSOWithoutIssuer(t *testing.T) {
+	t.Setenv("JWT_SECRET",
+		"some-secret-that-is-long-enough-32b")
+	t.Setenv("REQUIRE_ADMIN_SSO", "true")
+	t.Setenv("OIDC_ISSUER_URL", "")
+
+	err := Run(context.Background())
+	if err == nil {
+		t.Fatal("expected error for REQUIRE_ADMIN_SSO without issuer")
+	}
+	want := "REQUIRE_ADMIN_SSO needs OIDC_ISSUER_URL"
+	if err.Error() != want {
+		t.Errorf("got %q, want %q", err.Error(), want)
+	}
+}
+
+func TestOutboxSinksFromEnv(t *testing.T) {
+	tests := []struct {
+		value     string
+		wantSinks int
+		wantErr   bool
+	}{
+		{value: "", wantSinks: 1},
+		{value: "log", wantSinks: 1},
+		{value: "none", wantSinks: 0},
+		{value: "kafka", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("OUTBOX_SINK", tt.value)
+			sinks, err := outboxSinksFromEnv()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(sinks) != tt.wantSinks {
+				t.Errorf("got %d sinks, want %d", len(sinks), tt.wantSinks)
+			}
+		})
+	}
+}
+
 func TestBuildShortJWTSecret(t *testing.T) {
-	_, err := build(nil, "short", true)
+	_, err := build(nil, config{jwtSecret: "short", secure: true})
 	if err == nil {
 		t.Fatal("expected error for short JWT secret")
 	}
 }
 
+func TestBuildWritesProblems(t *testing.T) {
+	h, err := build(nil, config{
+		jwtSecret: "some-secret-that-is-long-enough-32b",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		header     map[string]string
+		body       string
+		wantStatus int
+		wantType   string
+		wantField  string
+	}{
+		{
+			name:       "missing credentials",
+			method:     http.MethodGet,
+			path:       "/admin/audit-events",
+			wantStatus: http.StatusUnauthorized,
+			wantType:   "urn:petstore:problem:unauthorized",
+		},
+		{
+			name:   "invalid body",
+			method: http.MethodPost,
+			path:   "/auth/register",
+			body: `{"name":"Ann","email":"ann@example.com",` +
+				`"password":"short"}`,
+			wantStatus: http.StatusBadRequest,
+			wantType:   "urn:petstore:problem:validation",
+			wantField:  "password",
+		},
+		{
+			name:       "malformed body",
+			method:     http.MethodPost,
+			path:       "/auth/register",
+			body:       `{`,
+			wantStatus: http.StatusBadRequest,
+			wantType:   "urn:petstore:problem:invalid-request",
+		},
+		{
+			name:       "invalid Last-Event-ID",
+			method:     http.MethodGet,
+			path:       "/pets/events",
+			header:     map[string]string{"Last-Event-ID": "abc"},
+			wantStatus: http.StatusBadRequest,
+			wantType:   "urn:petstore:problem:validation",
+			wantField:  "header.Last-Event-ID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path,
+				strings.NewReader(tt.body))
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s",
+					rec.Code, tt.wantStatus, rec.Body)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+				t.Errorf("Content-Type = %q", ct)
+			}
+			var p api.Problem
+			if err := p.UnmarshalJSON(rec.Body.Bytes()); err != nil {
+				t.Fatal(err)
+			}
+			if p.Type != tt.wantType {
+				t.Errorf("type = %q, want %q", p.Type, tt.wantType)
+			}
+			if p.CorrelationId.Value != rec.Header().Get("X-Correlation-ID") {
+				t.Errorf("correlationId = %q, want the response's",
+					p.CorrelationId.Value)
+			}
+			if tt.wantField != "" &&
+				(len(p.Errors) != 1 || p.Errors[0].Field != tt.wantField) {
+				t.Errorf("errors = %+v, want one for %q",
+					p.Errors, tt.wantField)
+			}
+		})
+	}
+}
+
 func TestServeGracefulShutdown(t *testing.T) {
 	// Find a free port.
 	ln, err := net.Listen("tcp", "127.0.0.1:0")
@@ -109,3 +268,234 @@ func TestServeGracefulShutdown(t *testing.T) {
 		)
 	}
 }
+
+// writeKey writes a new Ed25519 private key to dir/name
+// and returns its path.
+func writeKey(t *testing.T, dir, name string) string {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey: %v", err)
+	}
+	path := filepath.Join(dir, name)
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+func TestTokenConfigFromKeyFiles(t *testing.T) {
+	dir := t.TempDir()
+	current := writeKey(t, dir, "current.pem")
+	previous := writeKey(t, dir, "previous.pem")
+
+	tc, err := tokenConfig(config{
+		jwtSecret:         "some-secret-that-is-long-enough-32b",
+		jwtPrivateKeyFile: current,
+		jwtVerifyKeyFiles: []string{previous},
+	})
+	if err != nil {
+		t.Fatalf("tokenConfig: %v", err)
+	}
+	// The HMAC secret verifies but is never published.
+	if n := len(tc.PublicKeys()); n != 2 {
+		t.Errorf("got %d public keys, want 2", n)
+	}
+
+	_, err = tokenConfig(config{
+		jwtPrivateKeyFile: filepath.Join(dir, "missing.pem"),
+	})
+	if err == nil {
+		t.Error("expected error for missing key file")
+	}
+}
+
+func TestTokenConfigPreviousSecret(t *testing.T) {
+	const (
+		oldSecret = "the-old-secret-that-is-long-enough-32b"
+		newSecret = "the-new-secret-that-is-long-enough-32b"
+	)
+	old, err := tokenConfig(config{jwtSecret: oldSecret})
+	if err != nil {
+		t.Fatalf("tokenConfig: %v", err)
+	}
+	tok, err := old.CreateToken(auth.Claims{UserID: 1, Role: "customer"})
+	if err != nil {
+		t.Fatalf("CreateToken: %v", err)
+	}
+
+	rotated, err := tokenConfig(config{
+		jwtSecret: newSecret, jwtSecretPrevious: oldSecret,
+	})
+	if err != nil {
+		t.Fatalf("tokenConfig: %v", err)
+	}
+	if _, err := rotated.ParseToken(tok); err != nil {
+		t.Errorf("token signed with the previous secret rejected: %v", err)
+	}
+	fresh, err := rotated.CreateToken(auth.Claims{UserID: 1, Role: "customer"})
+	if err != nil {
+		t.Fatalf("CreateToken: %v", err)
+	}
+	if _, err := old.ParseToken(fresh); err == nil {
+		t.Error("new token signed with the previous secret")
+	}
+
+	unrotated, err := tokenConfig(config{jwtSecret: newSecret})
+	if err != nil {
+		t.Fatalf("tokenConfig: %v", err)
+	}
+	if _, err := unrotated.ParseToken(tok); err == nil {
+		t.Error("token signed with a dropped secret accepted")
+	}
+
+	// Tokens from before kid headers verify against either
+	// secret, also once signing has moved to a key file.
+	rotatedPEM, err := tokenConfig(config{
+		jwtSecret: newSecret, jwtSecretPrevious: oldSecret,
+		jwtPrivateKeyFile: writeKey(t, t.TempDir(), "signing.pem"),
+	})
+	if err != nil {
+		t.Fatalf("tokenConfig: %v", err)
+	}
+	for _, secret := range []string{oldSecret, newSecret} {
+		legacy, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"sub": "1", "role": "customer",
+			"exp": jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		}).SignedString([]byte(secret))
+		if err != nil {
+			t.Fatalf("signing legacy token: %v", err)
+		}
+		for _, tc := range []*auth.TokenConfig{rotated, rotatedPEM} {
+			if _, err := tc.ParseToken(legacy); err != nil {
+				t.Errorf("token without kid rejected: %v", err)
+			}
+		}
+	}
+
+	_, err = tokenConfig(config{jwtSecret: newSecret, jwtSecretPrevious: "short"})
+	if err == nil {
+		t.Error("expected error for a short previous secret")
+	}
+}
+
+func TestBackgroundJobsSchedule(t *testing.T) {
+	s, err := jobs.NewScheduler(backgroundJobs(nil)...)
+	if err != nil {
+		t.Fatalf("invalid background jobs: %v", err)
+	}
+	if got := len(s.Status()); got != 6 {
+		t.Errorf("got %d jobs, want 6", got)
+	}
+}
+
+// eventSource is a petevents.Source whose log is revs.
+type eventSource struct {
+	mu   sync.Mutex
+	revs []pet.Revision
+}
+
+func (s *eventSource) add(r pet.Revision) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.revs = append(s.revs, r)
+}
+
+func (s *eventSource) RevisionsAfter(
+	_ context.Context, after int64, _ int,
+) ([]pet.Revision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []pet.Revision
+	for _, r := range s.revs {
+		if r.ID > after {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (s *eventSource) LatestRevisionID(context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return int64(len(s.revs)), nil
+}
+
+func TestBuildStreamsPetEvents(t *testing.T) {
+	src := &eventSource{}
+	hub := petevents.NewHub(src)
+	notify := make(chan struct{})
+	ready := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		hub.Run(ctx, func(
+			ctx context.Context, _ string, fn func(string),
+		) error {
+			fn("")
+			close(ready)
+			for {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-notify:
+					fn("")
+				}
+			}
+		})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	<-ready
+
+	h, err := build(nil, config{
+		jwtSecret: "some-secret-that-is-long-enough-32b",
+		petEvents: hub,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	// The heartbeat is far off, so each line only arrives if
+	// the stream is flushed as it is written.
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get(srv.URL + "/pets/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if cc := resp.Header.Get("Cache-Control"); cc != "no-cache" {
+		t.Errorf("Cache-Control = %q", cc)
+	}
+
+	sc := bufio.NewScanner(resp.Body)
+	if !sc.Scan() || sc.Text() != "retry: 3000" {
+		t.Fatalf("first line = %q, %v", sc.Text(), sc.Err())
+	}
+	src.add(pet.Revision{
+		ID: 1, PetID: 7, Action: pet.RevisionCreate, Name: "Rex",
+	})
+	notify <- struct{}{}
+	for sc.Scan() {
+		if strings.HasPrefix(sc.Text(), "id:") {
+			if sc.Text() != "id: 1" {
+				t.Errorf("got %q, want id: 1", sc.Text())
+			}
+			return
+		}
+	}
+	t.Fatalf("stream ended before the event: %v", sc.Err())
+}
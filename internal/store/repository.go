@@ -0,0 +1,109 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/hhubris/petstore/internal/db"
+)
+
+// dbtx is the database interface required by
+// StoreRepository. Satisfied by *pgxpool.Pool, pgx.Tx, and
+// pgxmock.
+type dbtx interface {
+	Query(ctx context.Context, sql string,
+		args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string,
+		args ...any) pgx.Row
+}
+
+// uniqueViolation is the PostgreSQL error code for a unique
+// constraint violation.
+const uniqueViolation = "23505"
+
+// storeColumns is the column list scanned by scanStore.
+const storeColumns = "id, name, latitude, longitude, created_at"
+
+// scanStore scans a row selected with storeColumns.
+func scanStore(row pgx.Row) (Store, error) {
+	var (
+		s        Store
+		lat, lng *float64
+	)
+	if err := row.Scan(&s.ID, &s.Name, &lat, &lng, &s.CreatedAt); err != nil {
+		return Store{}, err
+	}
+	if lat != nil && lng != nil {
+		s.Location = &Location{Latitude: *lat, Longitude: *lng}
+	}
+	return s, nil
+}
+
+// StoreRepository provides database access for stores.
+type StoreRepository struct {
+	db dbtx
+}
+
+// NewStoreRepository returns a StoreRepository backed by
+// the given database connection.
+func NewStoreRepository(conn dbtx) *StoreRepository {
+	return &StoreRepository{db: conn}
+}
+
+// Create inserts a new store at loc, which may be nil, and
+// returns it with the generated ID and timestamp. Returns
+// db.ErrConflict if the name is taken.
+func (r *StoreRepository) Create(
+	ctx context.Context,
+	name string,
+	loc *Location,
+) (Store, error) {
+	var lat, lng *float64
+	if loc != nil {
+		lat, lng = &loc.Latitude, &loc.Longitude
+	}
+	s, err := scanStore(r.db.QueryRow(ctx,
+		"INSERT INTO stores (name, latitude, longitude) "+
+			"VALUES ($1, $2, $3) RETURNING "+storeColumns,
+		name, lat, lng,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) &&
+			pgErr.Code == uniqueViolation {
+			return Store{}, db.ErrConflict
+		}
+		return Store{}, fmt.Errorf("create store: %w", err)
+	}
+	return s, nil
+}
+
+// FindAll returns every store, sorted by name.
+func (r *StoreRepository) FindAll(
+	ctx context.Context,
+) ([]Store, error) {
+	rows, err := r.db.Query(ctx,
+		"SELECT "+storeColumns+" FROM stores ORDER BY name",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("find stores: %w", err)
+	}
+	defer rows.Close()
+
+	var stores []Store
+	for rows.Next() {
+		s, err := scanStore(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan store: %w", err)
+		}
+		stores = append(stores, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate stores: %w", err)
+	}
+	return stores, nil
+}
@@ -0,0 +1,105 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/store"
+)
+
+// storeRowColumns matches the repository's storeColumns.
+var storeRowColumns = []string{"id", "name", "latitude", "longitude", "created_at"}
+
+func TestRepositoryCreate(t *testing.T) {
+	lat, lng := 53.35, -6.26
+	tests := []struct {
+		name    string
+		loc     *store.Location
+		err     error
+		wantErr error
+	}{
+		{name: "success"},
+		{
+			name: "with location",
+			loc:  &store.Location{Latitude: lat, Longitude: lng},
+		},
+		{
+			name:    "name taken",
+			err:     &pgconn.PgError{Code: "23505"},
+			wantErr: db.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+
+			var latArg, lngArg *float64
+			if tt.loc != nil {
+				latArg, lngArg = &lat, &lng
+			}
+			q := mock.ExpectQuery("INSERT INTO stores").
+				WithArgs("Harbour Road", latArg, lngArg)
+			if tt.err != nil {
+				q.WillReturnError(tt.err)
+			} else {
+				q.WillReturnRows(pgxmock.NewRows(storeRowColumns).
+					AddRow(int64(2), "Harbour Road", latArg, lngArg, time.Now()))
+			}
+
+			repo := store.NewStoreRepository(mock)
+			got, err := repo.Create(context.Background(), "Harbour Road", tt.loc)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if got.ID != 2 || got.Name != "Harbour Road" {
+				t.Errorf("got %+v", got)
+			} else if (got.Location == nil) != (tt.loc == nil) ||
+				(tt.loc != nil && *got.Location != *tt.loc) {
+				t.Errorf("got location %+v, want %+v", got.Location, tt.loc)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestRepositoryFindAll(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT .* FROM stores ORDER BY name").
+		WillReturnRows(pgxmock.NewRows(storeRowColumns).
+			AddRow(int64(2), "Harbour Road", nil, nil, now).
+			AddRow(int64(1), "Main Store", nil, nil, now))
+
+	repo := store.NewStoreRepository(mock)
+	got, err := repo.FindAll(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != 2 || got[1].Name != "Main Store" {
+		t.Errorf("got %+v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
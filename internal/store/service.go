@@ -0,0 +1,42 @@
+package store
+
+import "context"
+
+// Repository is the persistence interface the service
+// depends on. StoreRepository satisfies it via duck typing.
+type Repository interface {
+	Create(ctx context.Context, name string, loc *Location) (Store, error)
+	FindAll(ctx context.Context) ([]Store, error)
+}
+
+// Service implements store management on top of a
+// Repository.
+type Service struct {
+	repo Repository
+}
+
+// NewService returns a Service wired to the given
+// repository.
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// CreateStore opens a store with the given name at loc,
+// which may be nil for a store whose location is not
+// known. Returns db.ErrConflict if the name is taken and
+// ErrInvalidLocation for a point off the globe.
+func (s *Service) CreateStore(
+	ctx context.Context,
+	name string,
+	loc *Location,
+) (Store, error) {
+	if loc != nil && !loc.Valid() {
+		return Store{}, ErrInvalidLocation
+	}
+	return s.repo.Create(ctx, name, loc)
+}
+
+// ListStores returns every store, sorted by name.
+func (s *Service) ListStores(ctx context.Context) ([]Store, error) {
+	return s.repo.FindAll(ctx)
+}
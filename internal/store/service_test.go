@@ -0,0 +1,100 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/store"
+)
+
+// mockRepo is a hand-written mock of store.Repository.
+type mockRepo struct {
+	createFn  func(ctx context.Context, name string, loc *store.Location) (store.Store, error)
+	findAllFn func(ctx context.Context) ([]store.Store, error)
+}
+
+func (m *mockRepo) Create(ctx context.Context, name string, loc *store.Location) (store.Store, error) {
+	return m.createFn(ctx, name, loc)
+}
+
+func (m *mockRepo) FindAll(ctx context.Context) ([]store.Store, error) {
+	return m.findAllFn(ctx)
+}
+
+func TestServiceCreateStore(t *testing.T) {
+	tests := []struct {
+		name     string
+		loc      *store.Location
+		repoErr  error
+		wantErr  error
+		wantRepo bool
+	}{
+		{name: "success", wantRepo: true},
+		{
+			name:     "with location",
+			loc:      &store.Location{Latitude: 53.35, Longitude: -6.26},
+			wantRepo: true,
+		},
+		{
+			name:     "name taken",
+			repoErr:  db.ErrConflict,
+			wantErr:  db.ErrConflict,
+			wantRepo: true,
+		},
+		{
+			name:    "latitude off the globe",
+			loc:     &store.Location{Latitude: 91},
+			wantErr: store.ErrInvalidLocation,
+		},
+		{
+			name:    "longitude off the globe",
+			loc:     &store.Location{Longitude: -180.5},
+			wantErr: store.ErrInvalidLocation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			svc := store.NewService(&mockRepo{
+				createFn: func(_ context.Context, name string, loc *store.Location) (store.Store, error) {
+					called = true
+					if tt.repoErr != nil {
+						return store.Store{}, tt.repoErr
+					}
+					return store.Store{ID: 2, Name: name, Location: loc}, nil
+				},
+			})
+			got, err := svc.CreateStore(context.Background(), "Harbour Road", tt.loc)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if called != tt.wantRepo {
+				t.Errorf("repo called = %v, want %v", called, tt.wantRepo)
+			}
+			if err == nil && got.Location != tt.loc {
+				t.Errorf("got location %+v, want %+v", got.Location, tt.loc)
+			}
+			if err == nil && got.Name != "Harbour Road" {
+				t.Errorf("got %+v", got)
+			}
+		})
+	}
+}
+
+func TestServiceListStores(t *testing.T) {
+	svc := store.NewService(&mockRepo{
+		findAllFn: func(context.Context) ([]store.Store, error) {
+			return []store.Store{{ID: 1, Name: "Main Store"}}, nil
+		},
+	})
+	got, err := svc.ListStores(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != 1 {
+		t.Errorf("got %+v", got)
+	}
+}
@@ -0,0 +1,77 @@
+// Package store manages the shop locations that pets, staff,
+// and API keys belong to. An account with a store is
+// confined to it: repositories add the store to every query
+// it makes on the store's data. An account without one is
+// global.
+package store
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrUnknownStore is returned when a store ID does not
+	// name a store.
+	ErrUnknownStore = errors.New("unknown store")
+
+	// ErrOtherStore is returned when an account confined to
+	// one store names another.
+	ErrOtherStore = errors.New("forbidden: outside the account's store")
+
+	// ErrInvalidLocation is returned for a latitude outside
+	// ±90 or a longitude outside ±180.
+	ErrInvalidLocation = errors.New("latitude must be within ±90 and longitude within ±180")
+)
+
+// Store is the domain model for a shop location.
+type Store struct {
+	ID   int64
+	Name string
+	// Location is where the store is, if known. Pets of a
+	// store without one are never near anywhere.
+	Location  *Location
+	CreatedAt time.Time
+}
+
+// Location is a point on the Earth in decimal degrees.
+type Location struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Valid reports whether l has a latitude within ±90 and a
+// longitude within ±180.
+func (l Location) Valid() bool {
+	return l.Latitude >= -90 && l.Latitude <= 90 &&
+		l.Longitude >= -180 && l.Longitude <= 180
+}
+
+// Resolve returns the store a new record made by an account
+// confined to scope belongs to, given the store it names,
+// or 0 for none. A confined account gets its own store and
+// may name no other. A global one (nil scope) gets the
+// store it names, or 0 if it names none, which repositories
+// take as the default store: the first, seeded Main Store.
+func Resolve(scope *int64, id int64) (int64, error) {
+	switch {
+	case scope == nil:
+		return id, nil
+	case id != 0 && id != *scope:
+		return 0, ErrOtherStore
+	}
+	return *scope, nil
+}
+
+// Confine returns the store an account confined to scope may
+// read, given the store it asks for, or nil for any. A
+// confined account reads only its own store.
+func Confine(scope, id *int64) (*int64, error) {
+	if scope == nil {
+		return id, nil
+	}
+	if id != nil && *id != *scope {
+		return nil, ErrOtherStore
+	}
+	return scope, nil
+}
@@ -0,0 +1,67 @@
+package store_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/store"
+)
+
+func ptr(v int64) *int64 { return &v }
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name    string
+		scope   *int64
+		id      int64
+		want    int64
+		wantErr error
+	}{
+		{name: "global names a store", id: 2, want: 2},
+		{name: "global names none", want: 0},
+		{name: "confined names none", scope: ptr(3), want: 3},
+		{name: "confined names its own", scope: ptr(3), id: 3, want: 3},
+		{name: "confined names another", scope: ptr(3), id: 2, wantErr: store.ErrOtherStore},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.Resolve(tt.scope, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got store %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfine(t *testing.T) {
+	tests := []struct {
+		name    string
+		scope   *int64
+		id      *int64
+		want    *int64
+		wantErr error
+	}{
+		{name: "global reads any"},
+		{name: "global reads one", id: ptr(2), want: ptr(2)},
+		{name: "confined reads its own", scope: ptr(3), want: ptr(3)},
+		{name: "confined asks for its own", scope: ptr(3), id: ptr(3), want: ptr(3)},
+		{name: "confined asks for another", scope: ptr(3), id: ptr(2), wantErr: store.ErrOtherStore},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.Confine(tt.scope, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if (got == nil) != (tt.want == nil) ||
+				(got != nil && *got != *tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// dialTimeout bounds connecting to a webhook host.
+const dialTimeout = 5 * time.Second
+
+// errPrivateAddress is returned when a delivery would
+// connect to an address that is not public.
+var errPrivateAddress = errors.New("webhook host resolves to a non-public address")
+
+// Option configures optional Service and Worker behaviour.
+type Option func(*options)
+
+type options struct {
+	allowPrivate bool
+}
+
+// AllowPrivateAddresses lets webhooks target loopback,
+// link-local and private addresses. Without it an admin
+// could make the server POST to its own network (the cloud
+// metadata service, an internal admin port), so it is only
+// for local development and tests.
+func AllowPrivateAddresses() Option {
+	return func(o *options) { o.allowPrivate = true }
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// publicAddress reports whether ip is a routable unicast
+// address outside the private ranges.
+func publicAddress(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsGlobalUnicast() && !ip.IsPrivate()
+}
+
+// publicHost reports whether host may be a webhook target.
+// Names other than localhost are accepted here: what they
+// resolve to is checked when the worker connects, so a name
+// cannot be re-pointed after creation.
+func publicHost(host string) bool {
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return publicAddress(ip)
+	}
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	return host != "localhost" && !strings.HasSuffix(host, ".localhost")
+}
+
+// newClient returns the client deliveries are sent with.
+// It never follows redirects, which could lead to a
+// non-public host, and unless o allows private addresses it
+// refuses to connect to one, checking the address actually
+// dialled so DNS cannot be used to get around it.
+func newClient(o options) *http.Client {
+	c := &http.Client{
+		Timeout: requestTimeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	if o.allowPrivate {
+		return c
+	}
+	d := &net.Dialer{
+		Timeout: dialTimeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			ap, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return fmt.Errorf("parsing dialled address: %w", err)
+			}
+			if !publicAddress(ap.Addr()) {
+				return errPrivateAddress
+			}
+			return nil
+		},
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would be dialled instead of the webhook host
+	// and is usually private itself.
+	t.Proxy = nil
+	t.DialContext = d.DialContext
+	c.Transport = t
+	return c
+}
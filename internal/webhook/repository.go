@@ -0,0 +1,333 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/hhubris/petstore/internal/db"
+)
+
+// dbtx is the database interface required by
+// WebhookRepository. Satisfied by *pgxpool.Pool, pgx.Tx,
+// and pgxmock.
+type dbtx interface {
+	Query(ctx context.Context, sql string,
+		args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string,
+		args ...any) pgx.Row
+	Exec(ctx context.Context, sql string,
+		args ...any) (pgconn.CommandTag, error)
+}
+
+// webhookColumns is the column list scanned by scanWebhook.
+const webhookColumns = "id, url, secret, event_types, created_by, created_at"
+
+// scanWebhook scans a row selected with webhookColumns.
+func scanWebhook(row pgx.Row) (Webhook, error) {
+	var w Webhook
+	err := row.Scan(
+		&w.ID, &w.URL, &w.Secret, &w.EventTypes, &w.CreatedBy,
+		&w.CreatedAt,
+	)
+	return w, err
+}
+
+// deliveryColumns is the column list scanned by
+// scanDelivery.
+const deliveryColumns = "id, webhook_id, event_id, event_type, status, " +
+	"attempts, next_attempt_at, last_attempt_at, last_status_code, " +
+	"last_error, delivered_at, created_at"
+
+// scanDelivery scans a row selected with deliveryColumns.
+func scanDelivery(row pgx.Row) (Delivery, error) {
+	var d Delivery
+	err := row.Scan(
+		&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.Status,
+		&d.Attempts, &d.NextAttemptAt, &d.LastAttemptAt,
+		&d.LastStatusCode, &d.LastError, &d.DeliveredAt, &d.CreatedAt,
+	)
+	return d, err
+}
+
+// WebhookRepository provides database access for webhooks
+// and their deliveries.
+type WebhookRepository struct {
+	db dbtx
+}
+
+// NewWebhookRepository returns a WebhookRepository backed
+// by the given database connection.
+func NewWebhookRepository(conn dbtx) *WebhookRepository {
+	return &WebhookRepository{db: conn}
+}
+
+// Create inserts a new webhook and returns it with the
+// generated ID and timestamp.
+func (r *WebhookRepository) Create(
+	ctx context.Context,
+	w Webhook,
+) (Webhook, error) {
+	created, err := scanWebhook(r.db.QueryRow(ctx,
+		"INSERT INTO webhooks (url, secret, event_types, created_by) "+
+			"VALUES ($1, $2, $3, $4) "+
+			"RETURNING "+webhookColumns,
+		w.URL, w.Secret, w.EventTypes, w.CreatedBy,
+	))
+	if err != nil {
+		return Webhook{}, fmt.Errorf("create webhook: %w", err)
+	}
+	return created, nil
+}
+
+// FindAll returns every webhook, newest first.
+func (r *WebhookRepository) FindAll(
+	ctx context.Context,
+) ([]Webhook, error) {
+	rows, err := r.db.Query(ctx,
+		"SELECT "+webhookColumns+" FROM webhooks ORDER BY id DESC",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("find webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var hooks []Webhook
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan webhook: %w", err)
+		}
+		hooks = append(hooks, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate webhooks: %w", err)
+	}
+	return hooks, nil
+}
+
+// FindByID returns the webhook with the given ID, or
+// db.ErrNotFound.
+func (r *WebhookRepository) FindByID(
+	ctx context.Context,
+	id int64,
+) (Webhook, error) {
+	w, err := scanWebhook(r.db.QueryRow(ctx,
+		"SELECT "+webhookColumns+" FROM webhooks WHERE id = $1",
+		id,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Webhook{}, db.ErrNotFound
+		}
+		return Webhook{}, fmt.Errorf("find webhook: %w", err)
+	}
+	return w, nil
+}
+
+// Delete removes the webhook and its deliveries. Returns
+// db.ErrNotFound if it does not exist.
+func (r *WebhookRepository) Delete(
+	ctx context.Context,
+	id int64,
+) error {
+	tag, err := r.db.Exec(ctx,
+		"DELETE FROM webhooks WHERE id = $1",
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("delete webhook: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+// FindDeliveries returns the webhook's deliveries matching
+// f, newest first, up to f.Limit of them.
+func (r *WebhookRepository) FindDeliveries(
+	ctx context.Context,
+	webhookID int64,
+	f DeliveryFilter,
+) ([]Delivery, error) {
+	q := "SELECT " + deliveryColumns + " FROM webhook_deliveries " +
+		"WHERE webhook_id = $1"
+	args := []any{webhookID}
+	if f.Status != "" {
+		args = append(args, f.Status)
+		q += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+	if f.Before != nil {
+		args = append(args, *f.Before)
+		q += fmt.Sprintf(" AND id < $%d", len(args))
+	}
+	args = append(args, f.Limit)
+	q += fmt.Sprintf(" ORDER BY id DESC LIMIT $%d", len(args))
+
+	rows, err := r.db.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("find webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []Delivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// Redeliver queues a finished delivery of the webhook to be
+// sent again at once, with a fresh set of attempts. Returns
+// ErrDeliveryPending if it has not finished, or
+// db.ErrNotFound if it does not exist.
+func (r *WebhookRepository) Redeliver(
+	ctx context.Context,
+	webhookID, deliveryID int64,
+) (Delivery, error) {
+	d, err := scanDelivery(r.db.QueryRow(ctx,
+		"UPDATE webhook_deliveries SET status = 'pending', "+
+			"attempts = 0, next_attempt_at = now(), delivered_at = NULL "+
+			"WHERE id = $1 AND webhook_id = $2 AND status <> 'pending' "+
+			"RETURNING "+deliveryColumns,
+		deliveryID, webhookID,
+	))
+	if err == nil {
+		return d, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return Delivery{}, fmt.Errorf("redeliver webhook delivery: %w", err)
+	}
+
+	var exists bool
+	err = r.db.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM webhook_deliveries "+
+			"WHERE id = $1 AND webhook_id = $2)",
+		deliveryID, webhookID,
+	).Scan(&exists)
+	if err != nil {
+		return Delivery{}, fmt.Errorf("find webhook delivery: %w", err)
+	}
+	if exists {
+		return Delivery{}, ErrDeliveryPending
+	}
+	return Delivery{}, db.ErrNotFound
+}
+
+// ClaimDue claims up to limit pending deliveries whose next
+// attempt is due, counting the attempt and leasing them
+// until leaseUntil so no other worker sends them
+// meanwhile. Rows another worker is claiming are skipped.
+// A worker that dies mid-send leaves the delivery to be
+// claimed again once the lease passes.
+func (r *WebhookRepository) ClaimDue(
+	ctx context.Context,
+	limit int,
+	leaseUntil time.Time,
+) ([]Job, error) {
+	rows, err := r.db.Query(ctx,
+		"WITH due AS ("+
+			"SELECT id FROM webhook_deliveries "+
+			"WHERE status = 'pending' AND next_attempt_at <= now() "+
+			"ORDER BY next_attempt_at LIMIT $1 "+
+			"FOR UPDATE SKIP LOCKED"+
+			"), claimed AS ("+
+			"UPDATE webhook_deliveries d SET attempts = d.attempts + 1, "+
+			"last_attempt_at = now(), next_attempt_at = $2 "+
+			"FROM due WHERE d.id = due.id "+
+			"RETURNING d.*"+
+			") "+
+			"SELECT c.id, c.webhook_id, c.event_id, c.event_type, c.status, "+
+			"c.attempts, c.next_attempt_at, c.last_attempt_at, "+
+			"c.last_status_code, c.last_error, c.delivered_at, c.created_at, "+
+			"w.url, w.secret, r.pet_id, r.action, r.name, r.tag, r.created_at "+
+			"FROM claimed c "+
+			"JOIN webhooks w ON w.id = c.webhook_id "+
+			"JOIN pet_revisions r ON r.id = c.event_id "+
+			"ORDER BY c.id",
+		limit, leaseUntil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("claim webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []Job
+	for rows.Next() {
+		var j Job
+		d := &j.Delivery
+		err := rows.Scan(
+			&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.Status,
+			&d.Attempts, &d.NextAttemptAt, &d.LastAttemptAt,
+			&d.LastStatusCode, &d.LastError, &d.DeliveredAt,
+			&d.CreatedAt,
+			&j.URL, &j.Secret, &j.Revision.PetID, &j.Revision.Action,
+			&j.Revision.Name, &j.Revision.Tag, &j.Revision.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan webhook delivery: %w", err)
+		}
+		j.Revision.ID = d.EventID
+		jobs = append(jobs, j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate webhook deliveries: %w", err)
+	}
+	return jobs, nil
+}
+
+// MarkSucceeded records that the delivery was accepted with
+// the given HTTP status.
+func (r *WebhookRepository) MarkSucceeded(
+	ctx context.Context,
+	id int64,
+	statusCode int,
+) error {
+	_, err := r.db.Exec(ctx,
+		"UPDATE webhook_deliveries SET status = 'succeeded', "+
+			"last_status_code = $2, last_error = NULL, delivered_at = now() "+
+			"WHERE id = $1",
+		id, statusCode,
+	)
+	if err != nil {
+		return fmt.Errorf("mark webhook delivery succeeded: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed attempt: the HTTP status, if
+// there was a response, and why it failed. The delivery is
+// retried at retryAt, or dead-lettered when retryAt is nil.
+func (r *WebhookRepository) MarkFailed(
+	ctx context.Context,
+	id int64,
+	statusCode *int,
+	reason string,
+	retryAt *time.Time,
+) error {
+	_, err := r.db.Exec(ctx,
+		"UPDATE webhook_deliveries SET last_status_code = $2, "+
+			"last_error = $3, "+
+			"status = CASE WHEN $4::timestamptz IS NULL "+
+			"THEN 'dead' ELSE status END, "+
+			"next_attempt_at = COALESCE($4, next_attempt_at) "+
+			"WHERE id = $1",
+		id, statusCode, reason, retryAt,
+	)
+	if err != nil {
+		return fmt.Errorf("mark webhook delivery failed: %w", err)
+	}
+	return nil
+}
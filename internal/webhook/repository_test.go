@@ -0,0 +1,357 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/webhook"
+)
+
+// webhookRowColumns matches the repository's webhookColumns.
+var webhookRowColumns = []string{
+	"id", "url", "secret", "event_types", "created_by", "created_at",
+}
+
+// deliveryRowColumns matches the repository's
+// deliveryColumns.
+var deliveryRowColumns = []string{
+	"id", "webhook_id", "event_id", "event_type", "status", "attempts",
+	"next_attempt_at", "last_attempt_at", "last_status_code",
+	"last_error", "delivered_at", "created_at",
+}
+
+// deliveryRow returns the values of a delivery row with the
+// given ID and status.
+func deliveryRow(id int64, status string, attempts int) []any {
+	now := time.Now()
+	return []any{
+		id, int64(3), int64(42), "pet.created", status, attempts,
+		now, (*time.Time)(nil), (*int)(nil), (*string)(nil),
+		(*time.Time)(nil), now,
+	}
+}
+
+func TestRepositoryCreate(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	types := []string{"pet.created"}
+	mock.ExpectQuery("INSERT INTO webhooks").
+		WithArgs("https://partner.example/hook", "whsec_x", types, int64(1)).
+		WillReturnRows(pgxmock.NewRows(webhookRowColumns).AddRow(
+			int64(3), "https://partner.example/hook", "whsec_x", types,
+			int64(1), time.Now(),
+		))
+
+	repo := webhook.NewWebhookRepository(mock)
+	got, err := repo.Create(context.Background(), webhook.Webhook{
+		URL: "https://partner.example/hook", Secret: "whsec_x",
+		EventTypes: types, CreatedBy: 1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ID != 3 || got.Secret != "whsec_x" {
+		t.Errorf("got %+v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepositoryFindByID(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    *pgxmock.Rows
+		wantErr error
+	}{
+		{
+			name: "found",
+			rows: pgxmock.NewRows(webhookRowColumns).AddRow(
+				int64(3), "https://partner.example/hook", "whsec_x",
+				[]string{"pet.created"}, int64(1), time.Now(),
+			),
+		},
+		{
+			name:    "not found",
+			rows:    pgxmock.NewRows(webhookRowColumns),
+			wantErr: db.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+			mock.ExpectQuery("SELECT .+ FROM webhooks WHERE id").
+				WithArgs(int64(3)).
+				WillReturnRows(tt.rows)
+
+			repo := webhook.NewWebhookRepository(mock)
+			_, err = repo.FindByID(context.Background(), 3)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestRepositoryDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    int64
+		wantErr error
+	}{
+		{name: "deleted", rows: 1},
+		{name: "missing", rows: 0, wantErr: db.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+			mock.ExpectExec("DELETE FROM webhooks WHERE id").
+				WithArgs(int64(3)).
+				WillReturnResult(pgxmock.NewResult("DELETE", tt.rows))
+
+			repo := webhook.NewWebhookRepository(mock)
+			err = repo.Delete(context.Background(), 3)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestRepositoryFindDeliveries(t *testing.T) {
+	before := int64(50)
+	tests := []struct {
+		name   string
+		filter webhook.DeliveryFilter
+		query  string
+		args   []any
+	}{
+		{
+			name:   "no filters",
+			filter: webhook.DeliveryFilter{Limit: 100},
+			query: `SELECT .+ FROM webhook_deliveries WHERE webhook_id = \$1 ` +
+				`ORDER BY id DESC LIMIT \$2`,
+			args: []any{int64(3), 100},
+		},
+		{
+			name: "status and before",
+			filter: webhook.DeliveryFilter{
+				Status: webhook.StatusDead, Before: &before, Limit: 10,
+			},
+			query: `WHERE webhook_id = \$1 AND status = \$2 AND id < \$3 ` +
+				`ORDER BY id DESC LIMIT \$4`,
+			args: []any{int64(3), "dead", int64(50), 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+			mock.ExpectQuery(tt.query).
+				WithArgs(tt.args...).
+				WillReturnRows(pgxmock.NewRows(deliveryRowColumns).
+					AddRow(deliveryRow(9, webhook.StatusDead, 8)...))
+
+			repo := webhook.NewWebhookRepository(mock)
+			got, err := repo.FindDeliveries(context.Background(), 3, tt.filter)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != 1 || got[0].ID != 9 || got[0].Attempts != 8 {
+				t.Errorf("got %+v", got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestRepositoryRedeliver(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(m pgxmock.PgxPoolIface)
+		wantErr error
+	}{
+		{
+			name: "requeued",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("UPDATE webhook_deliveries SET status = 'pending'").
+					WithArgs(int64(9), int64(3)).
+					WillReturnRows(pgxmock.NewRows(deliveryRowColumns).
+						AddRow(deliveryRow(9, webhook.StatusPending, 0)...))
+			},
+		},
+		{
+			name: "still pending",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("UPDATE webhook_deliveries").
+					WithArgs(int64(9), int64(3)).
+					WillReturnRows(pgxmock.NewRows(deliveryRowColumns))
+				m.ExpectQuery("SELECT EXISTS").
+					WithArgs(int64(9), int64(3)).
+					WillReturnRows(pgxmock.NewRows([]string{"exists"}).
+						AddRow(true))
+			},
+			wantErr: webhook.ErrDeliveryPending,
+		},
+		{
+			name: "not found",
+			mock: func(m pgxmock.PgxPoolIface) {
+				m.ExpectQuery("UPDATE webhook_deliveries").
+					WithArgs(int64(9), int64(3)).
+					WillReturnRows(pgxmock.NewRows(deliveryRowColumns))
+				m.ExpectQuery("SELECT EXISTS").
+					WithArgs(int64(9), int64(3)).
+					WillReturnRows(pgxmock.NewRows([]string{"exists"}).
+						AddRow(false))
+			},
+			wantErr: db.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+			tt.mock(mock)
+
+			repo := webhook.NewWebhookRepository(mock)
+			got, err := repo.Redeliver(context.Background(), 3, 9)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got.Status != webhook.StatusPending {
+				t.Errorf("status = %q, want pending", got.Status)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestRepositoryClaimDue(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	now := time.Now()
+	lease := now.Add(time.Minute)
+	tag := "dog"
+	mock.ExpectQuery(`FOR UPDATE SKIP LOCKED.+UPDATE webhook_deliveries`).
+		WithArgs(20, lease).
+		WillReturnRows(pgxmock.NewRows(append(deliveryRowColumns,
+			"url", "secret", "pet_id", "action", "name", "tag",
+			"revision_created_at",
+		)).AddRow(append(deliveryRow(9, webhook.StatusPending, 1),
+			"https://partner.example/hook", "whsec_x", int64(5), "create",
+			"Rex", &tag, now,
+		)...))
+
+	repo := webhook.NewWebhookRepository(mock)
+	got, err := repo.ClaimDue(context.Background(), 20, lease)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d jobs, want 1", len(got))
+	}
+	j := got[0]
+	if j.Delivery.ID != 9 || j.URL != "https://partner.example/hook" ||
+		j.Secret != "whsec_x" || j.Revision.ID != 42 ||
+		j.Revision.PetID != 5 || j.Revision.Name != "Rex" {
+		t.Errorf("got %+v", j)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestRepositoryMarkFailed(t *testing.T) {
+	retryAt := time.Now().Add(time.Minute)
+	code := 503
+	tests := []struct {
+		name    string
+		retryAt *time.Time
+	}{
+		{name: "retry", retryAt: &retryAt},
+		{name: "dead letter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+			mock.ExpectExec("UPDATE webhook_deliveries SET last_status_code").
+				WithArgs(int64(9), &code, "unexpected status 503", tt.retryAt).
+				WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+			repo := webhook.NewWebhookRepository(mock)
+			err = repo.MarkFailed(context.Background(),
+				9, &code, "unexpected status 503", tt.retryAt)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestRepositoryMarkSucceeded(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectExec("UPDATE webhook_deliveries SET status = 'succeeded'").
+		WithArgs(int64(9), 204).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	repo := webhook.NewWebhookRepository(mock)
+	if err := repo.MarkSucceeded(context.Background(), 9, 204); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
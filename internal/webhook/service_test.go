@@ -0,0 +1,162 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hhubris/petstore/internal/db"
+	"github.com/hhubris/petstore/internal/webhook"
+)
+
+// mockRepo is a hand-written mock of webhook.Repository.
+type mockRepo struct {
+	createFn         func(ctx context.Context, w webhook.Webhook) (webhook.Webhook, error)
+	findAllFn        func(ctx context.Context) ([]webhook.Webhook, error)
+	findByIDFn       func(ctx context.Context, id int64) (webhook.Webhook, error)
+	deleteFn         func(ctx context.Context, id int64) error
+	findDeliveriesFn func(ctx context.Context, webhookID int64, f webhook.DeliveryFilter) ([]webhook.Delivery, error)
+	redeliverFn      func(ctx context.Context, webhookID, deliveryID int64) (webhook.Delivery, error)
+}
+
+func (m *mockRepo) Create(
+	ctx context.Context, w webhook.Webhook,
+) (webhook.Webhook, error) {
+	return m.createFn(ctx, w)
+}
+
+func (m *mockRepo) FindAll(ctx context.Context) ([]webhook.Webhook, error) {
+	return m.findAllFn(ctx)
+}
+
+func (m *mockRepo) FindByID(
+	ctx context.Context, id int64,
+) (webhook.Webhook, error) {
+	return m.findByIDFn(ctx, id)
+}
+
+func (m *mockRepo) Delete(ctx context.Context, id int64) error {
+	return m.deleteFn(ctx, id)
+}
+
+func (m *mockRepo) FindDeliveries(
+	ctx context.Context, webhookID int64, f webhook.DeliveryFilter,
+) ([]webhook.Delivery, error) {
+	return m.findDeliveriesFn(ctx, webhookID, f)
+}
+
+func (m *mockRepo) Redeliver(
+	ctx context.Context, webhookID, deliveryID int64,
+) (webhook.Delivery, error) {
+	return m.redeliverFn(ctx, webhookID, deliveryID)
+}
+
+func TestServiceCreate(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		allowPrivate bool
+		wantErr      error
+	}{
+		{name: "https", url: "https://partner.example/hooks/pets"},
+		{name: "http with port", url: "http://partner.example:9000/hook"},
+		{name: "public ip", url: "https://203.0.113.7/hook"},
+		{name: "localhost", url: "http://localhost:9000/hook", wantErr: webhook.ErrInvalidURL},
+		{name: "loopback", url: "http://127.0.0.1/hook", wantErr: webhook.ErrInvalidURL},
+		{name: "loopback v6", url: "http://[::1]/hook", wantErr: webhook.ErrInvalidURL},
+		{name: "private", url: "http://10.0.0.5/hook", wantErr: webhook.ErrInvalidURL},
+		{name: "metadata", url: "http://169.254.169.254/latest", wantErr: webhook.ErrInvalidURL},
+		{name: "mapped loopback", url: "http://[::ffff:127.0.0.1]/hook", wantErr: webhook.ErrInvalidURL},
+		{name: "localhost allowed", url: "http://localhost:9000/hook", allowPrivate: true},
+		{name: "relative", url: "/hook", wantErr: webhook.ErrInvalidURL},
+		{name: "other scheme", url: "ftp://partner.example/", wantErr: webhook.ErrInvalidURL},
+		{name: "no host", url: "https:///hook", wantErr: webhook.ErrInvalidURL},
+		{name: "unparseable", url: "https://[::1", wantErr: webhook.ErrInvalidURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []webhook.Option
+			if tt.allowPrivate {
+				opts = append(opts, webhook.AllowPrivateAddresses())
+			}
+			var stored webhook.Webhook
+			svc := webhook.NewService(&mockRepo{
+				createFn: func(_ context.Context, w webhook.Webhook) (webhook.Webhook, error) {
+					w.ID = 3
+					stored = w
+					return w, nil
+				},
+			}, opts...)
+
+			got, err := svc.Create(context.Background(),
+				1, tt.url, []string{webhook.EventPetCreated})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.ID != 3 || stored.URL != tt.url || stored.CreatedBy != 1 {
+				t.Errorf("stored %+v", stored)
+			}
+			if !strings.HasPrefix(got.Secret, "whsec_") || len(got.Secret) < 40 {
+				t.Errorf("got secret %q", got.Secret)
+			}
+		})
+	}
+}
+
+func TestServiceCreateUniqueSecrets(t *testing.T) {
+	svc := webhook.NewService(&mockRepo{
+		createFn: func(_ context.Context, w webhook.Webhook) (webhook.Webhook, error) {
+			return w, nil
+		},
+	})
+	a, _ := svc.Create(context.Background(), 1, "https://a.example/", nil)
+	b, _ := svc.Create(context.Background(), 1, "https://a.example/", nil)
+	if a.Secret == b.Secret {
+		t.Error("two webhooks got the same secret")
+	}
+}
+
+func TestServiceDeliveries(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		findErr   error
+		wantLimit int
+		wantErr   error
+	}{
+		{name: "default limit", limit: 0, wantLimit: 100},
+		{name: "given limit", limit: 20, wantLimit: 20},
+		{name: "oversized limit", limit: 5000, wantLimit: 500},
+		{name: "unknown webhook", findErr: db.ErrNotFound, wantErr: db.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := webhook.NewService(&mockRepo{
+				findByIDFn: func(_ context.Context, id int64) (webhook.Webhook, error) {
+					return webhook.Webhook{ID: id}, tt.findErr
+				},
+				findDeliveriesFn: func(
+					_ context.Context, id int64, f webhook.DeliveryFilter,
+				) ([]webhook.Delivery, error) {
+					if id != 3 || f.Limit != tt.wantLimit {
+						t.Errorf("got id=%d limit=%d, want 3 and %d",
+							id, f.Limit, tt.wantLimit)
+					}
+					return nil, nil
+				},
+			})
+
+			_, err := svc.Deliveries(context.Background(), 3,
+				webhook.DeliveryFilter{Limit: tt.limit})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
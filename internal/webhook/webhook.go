@@ -0,0 +1,101 @@
+// Package webhook notifies partner systems of pet catalog
+// changes. Admins subscribe a URL to event types; a trigger
+// on pet_revisions queues one delivery per matching
+// subscription in the same transaction as the change, and a
+// background Worker POSTs each delivery, signed with the
+// subscription's secret, retrying with exponential backoff
+// until it succeeds or is dead-lettered.
+package webhook
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hhubris/petstore/internal/pet"
+)
+
+// Event types a webhook can subscribe to, one per revision
+// action; the enqueue_webhook_deliveries trigger maps
+// actions to them. The API has no adoption or update
+// operation, so there are no such events.
+const (
+	EventPetCreated              = "pet.created"
+	EventPetDeleted              = "pet.deleted"
+	EventPetRestored             = "pet.restored"
+	EventPetReserved             = "pet.reserved"
+	EventPetReservationCancelled = "pet.reservation_cancelled"
+	EventPetReservationExpired   = "pet.reservation_expired"
+)
+
+// Delivery statuses. A pending delivery is waiting for its
+// next attempt; a dead one has used every attempt and is
+// only retried by Redeliver.
+const (
+	StatusPending   = "pending"
+	StatusSucceeded = "succeeded"
+	StatusDead      = "dead"
+)
+
+var (
+	// ErrInvalidURL is returned when a webhook is created
+	// with a URL that is not absolute http or https, or
+	// whose host is not public.
+	ErrInvalidURL = errors.New("webhook url must be an absolute http or https url on a public host")
+
+	// ErrDeliveryPending is returned when redelivering a
+	// delivery that is still queued or being attempted.
+	ErrDeliveryPending = errors.New("delivery is still pending")
+)
+
+// Webhook is a subscription: events of EventTypes are
+// POSTed to URL, signed with Secret.
+type Webhook struct {
+	ID         int64
+	URL        string
+	EventTypes []string
+	// Secret signs deliveries. It is stored in plaintext
+	// because signing needs it, and is shown to the admin
+	// only when the webhook is created.
+	Secret    string
+	CreatedBy int64
+	CreatedAt time.Time
+}
+
+// Delivery is one event queued for one webhook, and the
+// log of its attempts so far.
+type Delivery struct {
+	ID        int64
+	WebhookID int64
+	// EventID is the pet revision the delivery reports.
+	EventID       int64
+	EventType     string
+	Status        string
+	Attempts      int
+	NextAttemptAt time.Time
+	LastAttemptAt *time.Time
+	// LastStatusCode is nil when the last attempt got no
+	// response; LastError then says why.
+	LastStatusCode *int
+	LastError      *string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+}
+
+// DeliveryFilter selects deliveries for Service.Deliveries.
+// Zero fields do not filter.
+type DeliveryFilter struct {
+	Status string
+	// Before returns only deliveries with a smaller ID, for
+	// paging backwards through the log.
+	Before *int64
+	Limit  int
+}
+
+// Job is a claimed delivery with what the worker needs to
+// send it.
+type Job struct {
+	Delivery Delivery
+	URL      string
+	Secret   string
+	Revision pet.Revision
+}
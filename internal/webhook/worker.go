@@ -0,0 +1,248 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Headers set on every delivery. Receivers verify
+// SignatureHeader against Sign(secret, timestamp, body) and
+// should reject timestamps more than a few minutes old.
+const (
+	SignatureHeader = "Petstore-Signature"
+	TimestampHeader = "Petstore-Timestamp"
+	EventHeader     = "Petstore-Event"
+	DeliveryHeader  = "Petstore-Delivery"
+)
+
+const (
+	// batchSize bounds how many deliveries a worker claims
+	// and sends concurrently.
+	batchSize = 20
+
+	// maxAttempts is how many times a delivery is sent
+	// before it is dead-lettered.
+	maxAttempts = 8
+
+	// baseBackoff is the wait after the first failed
+	// attempt; each further failure doubles it, so the last
+	// retry comes about an hour after the first attempt.
+	baseBackoff = 30 * time.Second
+
+	// requestTimeout bounds a single attempt.
+	requestTimeout = 10 * time.Second
+
+	// leaseDuration is how long a claimed delivery is
+	// hidden from other workers. It must exceed
+	// requestTimeout.
+	leaseDuration = time.Minute
+
+	// maxReasonLength truncates the failure reason kept in
+	// the delivery log.
+	maxReasonLength = 500
+)
+
+// Queue is the persistence interface the worker depends
+// on. WebhookRepository satisfies it via duck typing.
+type Queue interface {
+	ClaimDue(ctx context.Context,
+		limit int, leaseUntil time.Time,
+	) ([]Job, error)
+	MarkSucceeded(ctx context.Context, id int64, statusCode int) error
+	MarkFailed(ctx context.Context,
+		id int64, statusCode *int, reason string, retryAt *time.Time,
+	) error
+}
+
+// Worker sends queued deliveries.
+type Worker struct {
+	queue  Queue
+	client *http.Client
+}
+
+// NewWorker returns a Worker sending the deliveries in
+// queue. Call Run to start it. Deliveries to non-public
+// addresses fail unless AllowPrivateAddresses is given.
+func NewWorker(queue Queue, opts ...Option) *Worker {
+	return &Worker{
+		queue:  queue,
+		client: newClient(newOptions(opts)),
+	}
+}
+
+// Run sends due deliveries every interval until ctx is
+// cancelled. Several workers, in this process or others,
+// may share a queue.
+func (w *Worker) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		w.drain(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// drain sends batches of due deliveries until fewer than a
+// full batch are due.
+func (w *Worker) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		jobs, err := w.queue.ClaimDue(ctx,
+			batchSize, time.Now().Add(leaseDuration))
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.ErrorContext(ctx, "claiming webhook deliveries",
+					"error", err)
+			}
+			return
+		}
+
+		var wg sync.WaitGroup
+		for _, j := range jobs {
+			wg.Go(func() { w.deliver(ctx, j) })
+		}
+		wg.Wait()
+
+		if len(jobs) < batchSize {
+			return
+		}
+	}
+}
+
+// deliver makes one attempt at j and records the outcome.
+// An attempt cut short by shutdown is not recorded; the
+// delivery is claimed again once its lease passes.
+func (w *Worker) deliver(ctx context.Context, j Job) {
+	d := j.Delivery
+	code, err := w.send(ctx, j)
+	if ctx.Err() != nil {
+		return
+	}
+	if err == nil {
+		err = w.queue.MarkSucceeded(ctx, d.ID, code)
+	} else {
+		var statusCode *int
+		if code != 0 {
+			statusCode = &code
+		}
+		reason := err.Error()
+		if len(reason) > maxReasonLength {
+			reason = reason[:maxReasonLength]
+		}
+		var retryAt *time.Time
+		if d.Attempts < maxAttempts {
+			at := time.Now().Add(backoff(d.Attempts))
+			retryAt = &at
+		}
+		slog.WarnContext(ctx, "webhook delivery failed",
+			"delivery_id", d.ID, "webhook_id", d.WebhookID,
+			"attempt", d.Attempts, "dead", retryAt == nil,
+			"error", reason)
+		err = w.queue.MarkFailed(ctx, d.ID, statusCode, reason, retryAt)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "recording webhook delivery",
+			"delivery_id", d.ID, "error", err)
+	}
+}
+
+// send POSTs the signed event to the webhook. It returns
+// the response status, or 0 when there was none, and an
+// error unless the status is 2xx.
+func (w *Worker) send(ctx context.Context, j Job) (int, error) {
+	body, err := json.Marshal(newPayload(j))
+	if err != nil {
+		return 0, fmt.Errorf("encoding payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx,
+		http.MethodPost, j.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	ts := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "petstore-webhooks/1")
+	req.Header.Set(EventHeader, j.Delivery.EventType)
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(j.Delivery.ID, 10))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(ts, 10))
+	req.Header.Set(SignatureHeader, Sign(j.Secret, ts, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d",
+			resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature header value for a delivery
+// body sent at timestamp (Unix seconds): "sha256=" and the
+// hex HMAC-SHA256, keyed by secret, of the timestamp, a
+// dot, and the body.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff returns the wait after the given number of failed
+// attempts.
+func backoff(attempts int) time.Duration {
+	return baseBackoff << (attempts - 1)
+}
+
+// payload is the JSON body of a delivery.
+type payload struct {
+	// ID identifies the event, not the delivery: redeliveries
+	// and deliveries to other webhooks share it, so
+	// receivers can drop duplicates.
+	ID        int64       `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"createdAt"`
+	Data      payloadData `json:"data"`
+}
+
+// payloadData describes the pet the event is about, as it
+// was after the change. The actor is left out.
+type payloadData struct {
+	PetID int64   `json:"petId"`
+	Name  string  `json:"name"`
+	Tag   *string `json:"tag,omitempty"`
+}
+
+// newPayload builds the body sent for j.
+func newPayload(j Job) payload {
+	r := j.Revision
+	return payload{
+		ID:        r.ID,
+		Type:      j.Delivery.EventType,
+		CreatedAt: r.CreatedAt,
+		Data: payloadData{
+			PetID: r.PetID,
+			Name:  r.Name,
+			Tag:   r.Tag,
+		},
+	}
+}
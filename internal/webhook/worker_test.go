@@ -0,0 +1,296 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hhubris/petstore/internal/pet"
+	"github.com/hhubris/petstore/internal/webhook"
+)
+
+// outcome is what the worker recorded for a delivery.
+type outcome struct {
+	id         int64
+	succeeded  bool
+	statusCode *int
+	reason     string
+	retryAt    *time.Time
+}
+
+// memQueue is an in-memory webhook.Queue that hands out its
+// jobs on the first claim and reports each outcome.
+type memQueue struct {
+	mu       sync.Mutex
+	jobs     []webhook.Job
+	outcomes chan outcome
+}
+
+func newMemQueue(jobs ...webhook.Job) *memQueue {
+	return &memQueue{jobs: jobs, outcomes: make(chan outcome, len(jobs))}
+}
+
+func (q *memQueue) ClaimDue(
+	_ context.Context, limit int, _ time.Time,
+) ([]webhook.Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	n := min(limit, len(q.jobs))
+	out := q.jobs[:n]
+	q.jobs = q.jobs[n:]
+	return out, nil
+}
+
+func (q *memQueue) MarkSucceeded(
+	_ context.Context, id int64, statusCode int,
+) error {
+	q.outcomes <- outcome{id: id, succeeded: true, statusCode: &statusCode}
+	return nil
+}
+
+func (q *memQueue) MarkFailed(
+	_ context.Context, id int64, statusCode *int, reason string,
+	retryAt *time.Time,
+) error {
+	q.outcomes <- outcome{
+		id: id, statusCode: statusCode, reason: reason, retryAt: retryAt,
+	}
+	return nil
+}
+
+// runWorker runs a worker over q until the test ends and
+// returns the next outcome. Test servers listen on
+// loopback, so most tests pass webhook.AllowPrivateAddresses.
+func runWorker(
+	t *testing.T, q *memQueue, opts ...webhook.Option,
+) outcome {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		webhook.NewWorker(q, opts...).Run(ctx, time.Hour)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	select {
+	case o := <-q.outcomes:
+		return o
+	case <-time.After(5 * time.Second):
+		t.Fatal("no delivery recorded")
+		return outcome{}
+	}
+}
+
+// testJob returns a job for a pet.created event sent to url
+// on its given attempt.
+func testJob(url string, attempts int) webhook.Job {
+	tag := "dog"
+	return webhook.Job{
+		Delivery: webhook.Delivery{
+			ID: 7, WebhookID: 3, EventID: 42,
+			EventType: webhook.EventPetCreated, Attempts: attempts,
+		},
+		URL:    url,
+		Secret: "whsec_test",
+		Revision: pet.Revision{
+			ID: 42, PetID: 5, Action: pet.RevisionCreate,
+			Name: "Rex", Tag: &tag, CreatedAt: time.Now(),
+		},
+	}
+}
+
+func TestWorkerDelivers(t *testing.T) {
+	received := make(chan *http.Request, 1)
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			body, _ = io.ReadAll(r.Body)
+			received <- r
+			w.WriteHeader(http.StatusNoContent)
+		},
+	))
+	defer srv.Close()
+
+	o := runWorker(t, newMemQueue(testJob(srv.URL, 1)),
+		webhook.AllowPrivateAddresses())
+	r := <-received
+
+	if !o.succeeded || o.id != 7 || *o.statusCode != http.StatusNoContent {
+		t.Errorf("got outcome %+v", o)
+	}
+	if r.Method != http.MethodPost {
+		t.Errorf("got method %s", r.Method)
+	}
+	if got := r.Header.Get(webhook.EventHeader); got != "pet.created" {
+		t.Errorf("got event header %q", got)
+	}
+	if got := r.Header.Get(webhook.DeliveryHeader); got != "7" {
+		t.Errorf("got delivery header %q", got)
+	}
+
+	ts, err := strconv.ParseInt(r.Header.Get(webhook.TimestampHeader), 10, 64)
+	if err != nil || time.Since(time.Unix(ts, 0)) > time.Minute {
+		t.Errorf("got timestamp %q",
+			r.Header.Get(webhook.TimestampHeader))
+	}
+	want := webhook.Sign("whsec_test", ts, body)
+	if got := r.Header.Get(webhook.SignatureHeader); got != want {
+		t.Errorf("got signature %q, want %q", got, want)
+	}
+
+	var p struct {
+		ID   int64  `json:"id"`
+		Type string `json:"type"`
+		Data struct {
+			PetID int64  `json:"petId"`
+			Name  string `json:"name"`
+			Tag   string `json:"tag"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &p); err != nil {
+		t.Fatalf("decoding body %s: %v", body, err)
+	}
+	if p.ID != 42 || p.Type != "pet.created" || p.Data.PetID != 5 ||
+		p.Data.Name != "Rex" || p.Data.Tag != "dog" {
+		t.Errorf("got payload %s", body)
+	}
+}
+
+func TestWorkerRetries(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		},
+	))
+	defer srv.Close()
+
+	tests := []struct {
+		name      string
+		attempts  int
+		wantDelay time.Duration
+	}{
+		{"first attempt", 1, 30 * time.Second},
+		{"third attempt", 3, 2 * time.Minute},
+		{"last retry", 7, 32 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			o := runWorker(t, newMemQueue(testJob(srv.URL, tt.attempts)),
+				webhook.AllowPrivateAddresses())
+
+			if o.succeeded || o.statusCode == nil ||
+				*o.statusCode != http.StatusServiceUnavailable {
+				t.Fatalf("got outcome %+v", o)
+			}
+			if o.retryAt == nil {
+				t.Fatal("delivery was dead-lettered")
+			}
+			delay := o.retryAt.Sub(start)
+			if delay < tt.wantDelay || delay > tt.wantDelay+time.Minute {
+				t.Errorf("retry in %v, want %v", delay, tt.wantDelay)
+			}
+		})
+	}
+}
+
+func TestWorkerDeadLetters(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		},
+	))
+	defer srv.Close()
+
+	o := runWorker(t, newMemQueue(testJob(srv.URL, 8)),
+		webhook.AllowPrivateAddresses())
+	if o.succeeded || o.retryAt != nil {
+		t.Errorf("got outcome %+v, want dead-lettered", o)
+	}
+	if o.reason != "unexpected status 500" {
+		t.Errorf("got reason %q", o.reason)
+	}
+}
+
+func TestWorkerUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	o := runWorker(t, newMemQueue(testJob(url, 1)),
+		webhook.AllowPrivateAddresses())
+	if o.succeeded || o.statusCode != nil || o.reason == "" ||
+		o.retryAt == nil {
+		t.Errorf("got outcome %+v", o)
+	}
+}
+
+func TestWorkerRefusesPrivateAddress(t *testing.T) {
+	called := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			called <- struct{}{}
+			w.WriteHeader(http.StatusNoContent)
+		},
+	))
+	defer srv.Close()
+
+	o := runWorker(t, newMemQueue(testJob(srv.URL, 1)))
+	if o.succeeded || o.statusCode != nil ||
+		!strings.Contains(o.reason, "non-public address") {
+		t.Errorf("got outcome %+v, want refused", o)
+	}
+	select {
+	case <-called:
+		t.Error("request reached a loopback server")
+	default:
+	}
+}
+
+func TestWorkerDoesNotFollowRedirects(t *testing.T) {
+	followed := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/internal" {
+				followed <- struct{}{}
+				return
+			}
+			http.Redirect(w, r, "/internal", http.StatusTemporaryRedirect)
+		},
+	))
+	defer srv.Close()
+
+	o := runWorker(t, newMemQueue(testJob(srv.URL, 1)),
+		webhook.AllowPrivateAddresses())
+	if o.succeeded || o.statusCode == nil ||
+		*o.statusCode != http.StatusTemporaryRedirect {
+		t.Errorf("got outcome %+v, want failed with 307", o)
+	}
+	select {
+	case <-followed:
+		t.Error("redirect was followed")
+	default:
+	}
+}
+
+func TestSign(t *testing.T) {
+	got := webhook.Sign("secret", 1700000000, []byte(`{"id":1}`))
+	// echo -n '1700000000.{"id":1}' | openssl dgst -sha256 -hmac secret
+	want := "sha256=" +
+		"3dd1b9aef568d75f6790a84bd2e5dfa1f44409eef3cbdbd3f10b837376100c11"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,5 @@
+CREATE UNIQUE INDEX idx_password_reset_tokens_token_hash
+    ON password_reset_tokens (token_hash);
+
+CREATE INDEX idx_password_reset_tokens_user_id
+    ON password_reset_tokens (user_id);
@@ -0,0 +1,5 @@
+REVOKE SELECT, INSERT, UPDATE, DELETE
+    ON password_reset_tokens FROM petstore;
+
+REVOKE USAGE, SELECT
+    ON SEQUENCE password_reset_tokens_id_seq FROM petstore;
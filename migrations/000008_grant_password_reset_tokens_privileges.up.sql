@@ -0,0 +1,5 @@
+GRANT SELECT, INSERT, UPDATE, DELETE
+    ON password_reset_tokens TO petstore;
+
+GRANT USAGE, SELECT
+    ON SEQUENCE password_reset_tokens_id_seq TO petstore;
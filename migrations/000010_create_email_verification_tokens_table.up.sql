@@ -0,0 +1,9 @@
+CREATE TABLE email_verification_tokens (
+    id         BIGSERIAL    PRIMARY KEY,
+    user_id    BIGINT       NOT NULL
+               REFERENCES users (id) ON DELETE CASCADE,
+    token_hash TEXT         NOT NULL,
+    expires_at TIMESTAMPTZ  NOT NULL,
+    used_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
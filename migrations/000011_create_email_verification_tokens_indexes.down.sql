@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_email_verification_tokens_user_id_created_at;
+DROP INDEX IF EXISTS idx_email_verification_tokens_token_hash;
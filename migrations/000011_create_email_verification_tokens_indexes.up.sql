@@ -0,0 +1,5 @@
+CREATE UNIQUE INDEX idx_email_verification_tokens_token_hash
+    ON email_verification_tokens (token_hash);
+
+CREATE INDEX idx_email_verification_tokens_user_id_created_at
+    ON email_verification_tokens (user_id, created_at);
@@ -0,0 +1,5 @@
+REVOKE SELECT, INSERT, UPDATE, DELETE
+    ON email_verification_tokens FROM petstore;
+
+REVOKE USAGE, SELECT
+    ON SEQUENCE email_verification_tokens_id_seq FROM petstore;
@@ -0,0 +1,8 @@
+CREATE TABLE mfa_recovery_codes (
+    id         BIGSERIAL    PRIMARY KEY,
+    user_id    BIGINT       NOT NULL
+               REFERENCES users (id) ON DELETE CASCADE,
+    code_hash  TEXT         NOT NULL,
+    used_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
@@ -0,0 +1,2 @@
+CREATE UNIQUE INDEX idx_mfa_recovery_codes_user_id_code_hash
+    ON mfa_recovery_codes (user_id, code_hash);
@@ -0,0 +1,5 @@
+REVOKE SELECT, INSERT, UPDATE, DELETE
+    ON mfa_recovery_codes FROM petstore;
+
+REVOKE USAGE, SELECT
+    ON SEQUENCE mfa_recovery_codes_id_seq FROM petstore;
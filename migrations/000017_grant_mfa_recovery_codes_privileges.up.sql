@@ -0,0 +1,5 @@
+GRANT SELECT, INSERT, UPDATE, DELETE
+    ON mfa_recovery_codes TO petstore;
+
+GRANT USAGE, SELECT
+    ON SEQUENCE mfa_recovery_codes_id_seq TO petstore;
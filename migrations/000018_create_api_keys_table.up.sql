@@ -0,0 +1,13 @@
+CREATE TABLE api_keys (
+    id           BIGSERIAL    PRIMARY KEY,
+    name         TEXT         NOT NULL,
+    prefix       TEXT         NOT NULL,
+    key_hash     TEXT         NOT NULL,
+    scopes       TEXT[]       NOT NULL,
+    created_by   BIGINT       NOT NULL
+                 REFERENCES users (id) ON DELETE CASCADE,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    expires_at   TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at   TIMESTAMPTZ
+);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_api_keys_key_hash;
@@ -0,0 +1,2 @@
+CREATE UNIQUE INDEX idx_api_keys_key_hash
+    ON api_keys (key_hash);
@@ -0,0 +1,5 @@
+REVOKE SELECT, INSERT, UPDATE, DELETE
+    ON api_keys FROM petstore;
+
+REVOKE USAGE, SELECT
+    ON SEQUENCE api_keys_id_seq FROM petstore;
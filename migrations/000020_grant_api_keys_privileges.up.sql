@@ -0,0 +1,5 @@
+GRANT SELECT, INSERT, UPDATE, DELETE
+    ON api_keys TO petstore;
+
+GRANT USAGE, SELECT
+    ON SEQUENCE api_keys_id_seq TO petstore;
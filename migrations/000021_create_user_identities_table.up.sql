@@ -0,0 +1,8 @@
+CREATE TABLE user_identities (
+    id         BIGSERIAL    PRIMARY KEY,
+    user_id    BIGINT       NOT NULL
+               REFERENCES users (id) ON DELETE CASCADE,
+    issuer     TEXT         NOT NULL,
+    subject    TEXT         NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
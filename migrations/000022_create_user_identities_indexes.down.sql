@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_user_identities_user_id;
+DROP INDEX IF EXISTS idx_user_identities_issuer_subject;
@@ -0,0 +1,5 @@
+CREATE UNIQUE INDEX idx_user_identities_issuer_subject
+    ON user_identities (issuer, subject);
+
+CREATE INDEX idx_user_identities_user_id
+    ON user_identities (user_id);
@@ -0,0 +1,5 @@
+REVOKE SELECT, INSERT, UPDATE, DELETE
+    ON user_identities FROM petstore;
+
+REVOKE USAGE, SELECT
+    ON SEQUENCE user_identities_id_seq FROM petstore;
@@ -0,0 +1,5 @@
+GRANT SELECT, INSERT, UPDATE, DELETE
+    ON user_identities TO petstore;
+
+GRANT USAGE, SELECT
+    ON SEQUENCE user_identities_id_seq TO petstore;
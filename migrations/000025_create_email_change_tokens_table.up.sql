@@ -0,0 +1,10 @@
+CREATE TABLE email_change_tokens (
+    id         BIGSERIAL    PRIMARY KEY,
+    user_id    BIGINT       NOT NULL
+               REFERENCES users (id) ON DELETE CASCADE,
+    new_email  TEXT         NOT NULL,
+    token_hash TEXT         NOT NULL,
+    expires_at TIMESTAMPTZ  NOT NULL,
+    used_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
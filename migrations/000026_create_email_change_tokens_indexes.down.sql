@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_email_change_tokens_user_id;
+DROP INDEX IF EXISTS idx_email_change_tokens_token_hash;
@@ -0,0 +1,5 @@
+CREATE UNIQUE INDEX idx_email_change_tokens_token_hash
+    ON email_change_tokens (token_hash);
+
+CREATE INDEX idx_email_change_tokens_user_id
+    ON email_change_tokens (user_id);
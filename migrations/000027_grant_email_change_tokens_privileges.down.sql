@@ -0,0 +1,5 @@
+REVOKE SELECT, INSERT, UPDATE, DELETE
+    ON email_change_tokens FROM petstore;
+
+REVOKE USAGE, SELECT
+    ON SEQUENCE email_change_tokens_id_seq FROM petstore;
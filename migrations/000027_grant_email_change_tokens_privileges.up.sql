@@ -0,0 +1,5 @@
+GRANT SELECT, INSERT, UPDATE, DELETE
+    ON email_change_tokens TO petstore;
+
+GRANT USAGE, SELECT
+    ON SEQUENCE email_change_tokens_id_seq TO petstore;
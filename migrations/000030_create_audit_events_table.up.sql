@@ -0,0 +1,14 @@
+CREATE TABLE audit_events (
+    id               BIGSERIAL    PRIMARY KEY,
+    action           TEXT         NOT NULL,
+    outcome          TEXT         NOT NULL
+                     CHECK (outcome IN ('success', 'failure')),
+    actor_user_id    BIGINT,
+    actor_api_key_id BIGINT,
+    target           TEXT         NOT NULL DEFAULT '',
+    ip               TEXT         NOT NULL DEFAULT '',
+    user_agent       TEXT         NOT NULL DEFAULT '',
+    correlation_id   TEXT         NOT NULL DEFAULT '',
+    details          JSONB        NOT NULL DEFAULT '{}',
+    created_at       TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
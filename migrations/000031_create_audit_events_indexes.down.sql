@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_audit_events_action_id;
+DROP INDEX IF EXISTS idx_audit_events_actor_user_id_id;
+DROP INDEX IF EXISTS idx_audit_events_created_at;
@@ -0,0 +1,8 @@
+CREATE INDEX idx_audit_events_created_at
+    ON audit_events (created_at);
+
+CREATE INDEX idx_audit_events_actor_user_id_id
+    ON audit_events (actor_user_id, id);
+
+CREATE INDEX idx_audit_events_action_id
+    ON audit_events (action, id);
@@ -0,0 +1,5 @@
+REVOKE SELECT, INSERT
+    ON audit_events FROM petstore;
+
+REVOKE USAGE, SELECT
+    ON SEQUENCE audit_events_id_seq FROM petstore;
@@ -0,0 +1,5 @@
+GRANT SELECT, INSERT
+    ON audit_events TO petstore;
+
+GRANT USAGE, SELECT
+    ON SEQUENCE audit_events_id_seq TO petstore;
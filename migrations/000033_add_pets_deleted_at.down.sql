@@ -0,0 +1,2 @@
+ALTER TABLE pets
+    DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,2 @@
+ALTER TABLE pets
+    ADD COLUMN deleted_at TIMESTAMPTZ;
@@ -0,0 +1,12 @@
+CREATE TABLE pet_revisions (
+    id               BIGSERIAL    PRIMARY KEY,
+    pet_id           BIGINT       NOT NULL
+                     REFERENCES pets (id) ON DELETE CASCADE,
+    action           TEXT         NOT NULL
+                     CHECK (action IN ('create', 'delete', 'restore')),
+    name             TEXT         NOT NULL,
+    tag              TEXT,
+    actor_user_id    BIGINT,
+    actor_api_key_id BIGINT,
+    created_at       TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
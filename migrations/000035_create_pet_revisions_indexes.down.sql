@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_pet_revisions_pet_id_id;
@@ -0,0 +1,2 @@
+CREATE INDEX idx_pet_revisions_pet_id_id
+    ON pet_revisions (pet_id, id);
@@ -0,0 +1,5 @@
+REVOKE SELECT, INSERT
+    ON pet_revisions FROM petstore;
+
+REVOKE USAGE, SELECT
+    ON SEQUENCE pet_revisions_id_seq FROM petstore;
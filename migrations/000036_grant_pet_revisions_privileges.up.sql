@@ -0,0 +1,5 @@
+GRANT SELECT, INSERT
+    ON pet_revisions TO petstore;
+
+GRANT USAGE, SELECT
+    ON SEQUENCE pet_revisions_id_seq TO petstore;
@@ -0,0 +1,3 @@
+DELETE FROM pet_revisions
+WHERE action = 'create' AND actor_user_id IS NULL
+    AND actor_api_key_id IS NULL;
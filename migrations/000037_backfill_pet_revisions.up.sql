@@ -0,0 +1,3 @@
+INSERT INTO pet_revisions (pet_id, action, name, tag)
+SELECT id, 'create', name, tag FROM pets
+ORDER BY id;
@@ -0,0 +1,11 @@
+CREATE TABLE idempotency_keys (
+    scope        TEXT         NOT NULL,
+    key          TEXT         NOT NULL,
+    fingerprint  TEXT         NOT NULL,
+    status_code  INTEGER,
+    content_type TEXT         NOT NULL DEFAULT '',
+    body         BYTEA,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    expires_at   TIMESTAMPTZ  NOT NULL,
+    PRIMARY KEY (scope, key)
+);
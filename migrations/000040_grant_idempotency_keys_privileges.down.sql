@@ -0,0 +1,2 @@
+REVOKE SELECT, INSERT, UPDATE, DELETE
+    ON idempotency_keys FROM petstore;
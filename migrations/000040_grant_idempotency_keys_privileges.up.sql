@@ -0,0 +1,2 @@
+GRANT SELECT, INSERT, UPDATE, DELETE
+    ON idempotency_keys TO petstore;
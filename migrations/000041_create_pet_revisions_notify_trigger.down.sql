@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS pet_revisions_notify ON pet_revisions;
+
+DROP FUNCTION IF EXISTS notify_pet_revision();
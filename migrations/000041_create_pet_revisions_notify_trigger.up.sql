@@ -0,0 +1,10 @@
+CREATE FUNCTION notify_pet_revision() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('pet_events', NEW.id::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER pet_revisions_notify
+    AFTER INSERT ON pet_revisions
+    FOR EACH ROW EXECUTE FUNCTION notify_pet_revision();
@@ -0,0 +1,9 @@
+CREATE TABLE webhooks (
+    id          BIGSERIAL    PRIMARY KEY,
+    url         TEXT         NOT NULL,
+    secret      TEXT         NOT NULL,
+    event_types TEXT[]       NOT NULL,
+    created_by  BIGINT       NOT NULL
+                REFERENCES users (id) ON DELETE CASCADE,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
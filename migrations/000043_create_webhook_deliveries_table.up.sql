@@ -0,0 +1,17 @@
+CREATE TABLE webhook_deliveries (
+    id               BIGSERIAL    PRIMARY KEY,
+    webhook_id       BIGINT       NOT NULL
+                     REFERENCES webhooks (id) ON DELETE CASCADE,
+    event_id         BIGINT       NOT NULL
+                     REFERENCES pet_revisions (id) ON DELETE CASCADE,
+    event_type       TEXT         NOT NULL,
+    status           TEXT         NOT NULL DEFAULT 'pending'
+                     CHECK (status IN ('pending', 'succeeded', 'dead')),
+    attempts         INTEGER      NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    last_attempt_at  TIMESTAMPTZ,
+    last_status_code INTEGER,
+    last_error       TEXT,
+    delivered_at     TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_webhook_deliveries_event_id;
+
+DROP INDEX IF EXISTS idx_webhook_deliveries_webhook_id_id;
+
+DROP INDEX IF EXISTS idx_webhook_deliveries_next_attempt_at;
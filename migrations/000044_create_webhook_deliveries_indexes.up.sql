@@ -0,0 +1,9 @@
+CREATE INDEX idx_webhook_deliveries_next_attempt_at
+    ON webhook_deliveries (next_attempt_at)
+    WHERE status = 'pending';
+
+CREATE INDEX idx_webhook_deliveries_webhook_id_id
+    ON webhook_deliveries (webhook_id, id);
+
+CREATE INDEX idx_webhook_deliveries_event_id
+    ON webhook_deliveries (event_id);
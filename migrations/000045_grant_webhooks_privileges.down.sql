@@ -0,0 +1,11 @@
+REVOKE SELECT, INSERT, UPDATE, DELETE
+    ON webhook_deliveries FROM petstore;
+
+REVOKE USAGE, SELECT
+    ON SEQUENCE webhook_deliveries_id_seq FROM petstore;
+
+REVOKE SELECT, INSERT, UPDATE, DELETE
+    ON webhooks FROM petstore;
+
+REVOKE USAGE, SELECT
+    ON SEQUENCE webhooks_id_seq FROM petstore;
@@ -0,0 +1,11 @@
+GRANT SELECT, INSERT, UPDATE, DELETE
+    ON webhooks TO petstore;
+
+GRANT USAGE, SELECT
+    ON SEQUENCE webhooks_id_seq TO petstore;
+
+GRANT SELECT, INSERT, UPDATE, DELETE
+    ON webhook_deliveries TO petstore;
+
+GRANT USAGE, SELECT
+    ON SEQUENCE webhook_deliveries_id_seq TO petstore;
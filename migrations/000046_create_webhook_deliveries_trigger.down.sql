@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS pet_revisions_enqueue_webhooks ON pet_revisions;
+
+DROP FUNCTION IF EXISTS enqueue_webhook_deliveries();
@@ -0,0 +1,19 @@
+CREATE FUNCTION enqueue_webhook_deliveries() RETURNS trigger AS $$
+DECLARE
+    delivery_type TEXT := 'pet.' || CASE NEW.action
+        WHEN 'create' THEN 'created'
+        WHEN 'delete' THEN 'deleted'
+        WHEN 'restore' THEN 'restored'
+    END;
+BEGIN
+    INSERT INTO webhook_deliveries (webhook_id, event_id, event_type)
+    SELECT id, NEW.id, delivery_type
+    FROM webhooks
+    WHERE delivery_type = ANY (event_types);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER pet_revisions_enqueue_webhooks
+    AFTER INSERT ON pet_revisions
+    FOR EACH ROW EXECUTE FUNCTION enqueue_webhook_deliveries();
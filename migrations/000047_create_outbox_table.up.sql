@@ -0,0 +1,12 @@
+CREATE TABLE outbox (
+    id             BIGSERIAL    PRIMARY KEY,
+    aggregate_type TEXT         NOT NULL,
+    aggregate_id   BIGINT       NOT NULL,
+    event_type     TEXT         NOT NULL,
+    payload        JSONB        NOT NULL DEFAULT '{}',
+    attempts       INTEGER      NOT NULL DEFAULT 0,
+    available_at   TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    last_error     TEXT,
+    published_at   TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
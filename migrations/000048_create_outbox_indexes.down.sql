@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_outbox_published_at;
+
+DROP INDEX IF EXISTS idx_outbox_available_at;
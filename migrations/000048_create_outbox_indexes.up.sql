@@ -0,0 +1,7 @@
+CREATE INDEX idx_outbox_available_at
+    ON outbox (available_at)
+    WHERE published_at IS NULL;
+
+CREATE INDEX idx_outbox_published_at
+    ON outbox (published_at)
+    WHERE published_at IS NOT NULL;
@@ -0,0 +1,5 @@
+REVOKE SELECT, INSERT, UPDATE, DELETE
+    ON outbox FROM petstore;
+
+REVOKE USAGE, SELECT
+    ON SEQUENCE outbox_id_seq FROM petstore;
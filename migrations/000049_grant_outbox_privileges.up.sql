@@ -0,0 +1,5 @@
+GRANT SELECT, INSERT, UPDATE, DELETE
+    ON outbox TO petstore;
+
+GRANT USAGE, SELECT
+    ON SEQUENCE outbox_id_seq TO petstore;
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS pet_revisions_outbox ON pet_revisions;
+
+DROP FUNCTION IF EXISTS outbox_pet_revision();
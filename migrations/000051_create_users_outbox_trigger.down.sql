@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS users_outbox ON users;
+
+DROP FUNCTION IF EXISTS outbox_user();
@@ -0,0 +1,67 @@
+CREATE FUNCTION outbox_user() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO outbox (aggregate_type, aggregate_id, event_type, payload)
+        VALUES ('user', NEW.id, 'user.created', jsonb_build_object(
+            'userId', NEW.id,
+            'name', NEW.name,
+            'email', NEW.email,
+            'role', NEW.role
+        ));
+        RETURN NULL;
+    END IF;
+
+    IF NEW.email IS DISTINCT FROM OLD.email THEN
+        INSERT INTO outbox (aggregate_type, aggregate_id, event_type, payload)
+        VALUES ('user', NEW.id, 'user.email_changed', jsonb_build_object(
+            'userId', NEW.id,
+            'email', NEW.email,
+            'previousEmail', OLD.email
+        ));
+    ELSIF OLD.email_verified_at IS NULL
+        AND NEW.email_verified_at IS NOT NULL THEN
+        INSERT INTO outbox (aggregate_type, aggregate_id, event_type, payload)
+        VALUES ('user', NEW.id, 'user.email_verified', jsonb_build_object(
+            'userId', NEW.id,
+            'email', NEW.email
+        ));
+    END IF;
+
+    IF NEW.role IS DISTINCT FROM OLD.role THEN
+        INSERT INTO outbox (aggregate_type, aggregate_id, event_type, payload)
+        VALUES ('user', NEW.id, 'user.role_changed', jsonb_build_object(
+            'userId', NEW.id,
+            'role', NEW.role,
+            'previousRole', OLD.role
+        ));
+    END IF;
+
+    IF OLD.disabled_at IS NULL AND NEW.disabled_at IS NOT NULL THEN
+        INSERT INTO outbox (aggregate_type, aggregate_id, event_type, payload)
+        VALUES ('user', NEW.id, 'user.disabled', jsonb_build_object(
+            'userId', NEW.id
+        ));
+    ELSIF OLD.disabled_at IS NOT NULL AND NEW.disabled_at IS NULL THEN
+        INSERT INTO outbox (aggregate_type, aggregate_id, event_type, payload)
+        VALUES ('user', NEW.id, 'user.enabled', jsonb_build_object(
+            'userId', NEW.id
+        ));
+    END IF;
+
+    IF NEW.password_hash IS DISTINCT FROM OLD.password_hash
+        AND NEW.session_version > OLD.session_version THEN
+        INSERT INTO outbox (aggregate_type, aggregate_id, event_type, payload)
+        VALUES ('user', NEW.id, 'user.password_changed', jsonb_build_object(
+            'userId', NEW.id
+        ));
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER users_outbox
+    AFTER INSERT OR UPDATE OF email, email_verified_at, role,
+        disabled_at, session_version
+    ON users
+    FOR EACH ROW EXECUTE FUNCTION outbox_user();
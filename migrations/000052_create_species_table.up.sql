@@ -0,0 +1,5 @@
+CREATE TABLE species (
+    code TEXT PRIMARY KEY
+         CHECK (code ~ '^[a-z][a-z0-9-]*$'),
+    name TEXT NOT NULL UNIQUE
+);
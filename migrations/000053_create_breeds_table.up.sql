@@ -0,0 +1,9 @@
+CREATE TABLE breeds (
+    species TEXT NOT NULL
+            REFERENCES species (code) ON DELETE CASCADE,
+    code    TEXT NOT NULL
+            CHECK (code ~ '^[a-z][a-z0-9-]*$'),
+    name    TEXT NOT NULL,
+    PRIMARY KEY (species, code),
+    UNIQUE (species, name)
+);
@@ -0,0 +1,2 @@
+DELETE FROM species
+WHERE code IN ('dog', 'cat', 'rabbit', 'bird', 'fish', 'reptile');
@@ -0,0 +1,31 @@
+INSERT INTO species (code, name) VALUES
+    ('dog', 'Dog'),
+    ('cat', 'Cat'),
+    ('rabbit', 'Rabbit'),
+    ('bird', 'Bird'),
+    ('fish', 'Fish'),
+    ('reptile', 'Reptile');
+
+INSERT INTO breeds (species, code, name) VALUES
+    ('dog', 'beagle', 'Beagle'),
+    ('dog', 'border-collie', 'Border Collie'),
+    ('dog', 'dachshund', 'Dachshund'),
+    ('dog', 'german-shepherd', 'German Shepherd'),
+    ('dog', 'golden-retriever', 'Golden Retriever'),
+    ('dog', 'labrador-retriever', 'Labrador Retriever'),
+    ('dog', 'poodle', 'Poodle'),
+    ('dog', 'mixed', 'Mixed'),
+    ('cat', 'british-shorthair', 'British Shorthair'),
+    ('cat', 'maine-coon', 'Maine Coon'),
+    ('cat', 'persian', 'Persian'),
+    ('cat', 'siamese', 'Siamese'),
+    ('cat', 'domestic-shorthair', 'Domestic Shorthair'),
+    ('rabbit', 'holland-lop', 'Holland Lop'),
+    ('rabbit', 'netherland-dwarf', 'Netherland Dwarf'),
+    ('bird', 'budgerigar', 'Budgerigar'),
+    ('bird', 'canary', 'Canary'),
+    ('bird', 'cockatiel', 'Cockatiel'),
+    ('fish', 'betta', 'Betta'),
+    ('fish', 'goldfish', 'Goldfish'),
+    ('reptile', 'bearded-dragon', 'Bearded Dragon'),
+    ('reptile', 'leopard-gecko', 'Leopard Gecko');
@@ -0,0 +1,11 @@
+ALTER TABLE pets
+    DROP CONSTRAINT IF EXISTS pets_price_complete,
+    DROP CONSTRAINT IF EXISTS pets_breed_needs_species,
+    DROP CONSTRAINT IF EXISTS pets_breed_fkey,
+    DROP COLUMN IF EXISTS price_currency,
+    DROP COLUMN IF EXISTS price_amount,
+    DROP COLUMN IF EXISTS description,
+    DROP COLUMN IF EXISTS sex,
+    DROP COLUMN IF EXISTS birth_date,
+    DROP COLUMN IF EXISTS breed,
+    DROP COLUMN IF EXISTS species;
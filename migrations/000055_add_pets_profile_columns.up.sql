@@ -0,0 +1,19 @@
+ALTER TABLE pets
+    ADD COLUMN species        TEXT
+                              REFERENCES species (code),
+    ADD COLUMN breed          TEXT,
+    ADD COLUMN birth_date     DATE,
+    ADD COLUMN sex            TEXT
+                              CHECK (sex IN ('female', 'male')),
+    ADD COLUMN description    TEXT
+                              CHECK (char_length(description) <= 2000),
+    ADD COLUMN price_amount   BIGINT
+                              CHECK (price_amount >= 0),
+    ADD COLUMN price_currency TEXT
+                              CHECK (price_currency ~ '^[A-Z]{3}$'),
+    ADD CONSTRAINT pets_breed_fkey
+        FOREIGN KEY (species, breed) REFERENCES breeds (species, code),
+    ADD CONSTRAINT pets_breed_needs_species
+        CHECK (breed IS NULL OR species IS NOT NULL),
+    ADD CONSTRAINT pets_price_complete
+        CHECK ((price_amount IS NULL) = (price_currency IS NULL));
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_pets_price;
+
+DROP INDEX IF EXISTS idx_pets_birth_date;
+
+DROP INDEX IF EXISTS idx_pets_species_breed;
@@ -0,0 +1,5 @@
+CREATE INDEX idx_pets_species_breed ON pets (species, breed);
+
+CREATE INDEX idx_pets_birth_date ON pets (birth_date);
+
+CREATE INDEX idx_pets_price ON pets (price_currency, price_amount);
@@ -0,0 +1,2 @@
+REVOKE SELECT
+    ON species, breeds FROM petstore;
@@ -0,0 +1,2 @@
+GRANT SELECT
+    ON species, breeds TO petstore;
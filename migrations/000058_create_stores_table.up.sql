@@ -0,0 +1,6 @@
+CREATE TABLE stores (
+    id         BIGSERIAL    PRIMARY KEY,
+    name       TEXT         NOT NULL UNIQUE
+               CHECK (char_length(name) BETWEEN 1 AND 100),
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
@@ -0,0 +1,5 @@
+REVOKE SELECT, INSERT
+    ON stores FROM petstore;
+
+REVOKE USAGE, SELECT
+    ON SEQUENCE stores_id_seq FROM petstore;
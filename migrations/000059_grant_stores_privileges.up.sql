@@ -0,0 +1,5 @@
+GRANT SELECT, INSERT
+    ON stores TO petstore;
+
+GRANT USAGE, SELECT
+    ON SEQUENCE stores_id_seq TO petstore;
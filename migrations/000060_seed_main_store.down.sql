@@ -0,0 +1,2 @@
+DELETE FROM stores
+WHERE name = 'Main Store';
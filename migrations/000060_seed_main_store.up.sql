@@ -0,0 +1 @@
+INSERT INTO stores (name) VALUES ('Main Store');
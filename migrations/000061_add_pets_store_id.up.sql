@@ -0,0 +1,8 @@
+ALTER TABLE pets
+    ADD COLUMN store_id BIGINT
+                        REFERENCES stores (id);
+
+UPDATE pets SET store_id = (SELECT min(id) FROM stores);
+
+ALTER TABLE pets
+    ALTER COLUMN store_id SET NOT NULL;
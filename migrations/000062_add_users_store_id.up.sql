@@ -0,0 +1,3 @@
+ALTER TABLE users
+    ADD COLUMN store_id BIGINT
+                        REFERENCES stores (id);
@@ -0,0 +1,3 @@
+ALTER TABLE api_keys
+    ADD COLUMN store_id BIGINT
+                        REFERENCES stores (id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_store_id;
+
+DROP INDEX IF EXISTS idx_pets_store_id;
@@ -0,0 +1,3 @@
+CREATE INDEX idx_pets_store_id ON pets (store_id, id);
+
+CREATE INDEX idx_users_store_id ON users (store_id);
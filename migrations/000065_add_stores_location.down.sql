@@ -0,0 +1,4 @@
+ALTER TABLE stores
+    DROP CONSTRAINT IF EXISTS stores_location_complete,
+    DROP COLUMN IF EXISTS longitude,
+    DROP COLUMN IF EXISTS latitude;
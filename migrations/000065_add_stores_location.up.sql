@@ -0,0 +1,7 @@
+ALTER TABLE stores
+    ADD COLUMN latitude  DOUBLE PRECISION
+                         CHECK (latitude BETWEEN -90 AND 90),
+    ADD COLUMN longitude DOUBLE PRECISION
+                         CHECK (longitude BETWEEN -180 AND 180),
+    ADD CONSTRAINT stores_location_complete
+        CHECK ((latitude IS NULL) = (longitude IS NULL));
@@ -0,0 +1,19 @@
+CREATE TABLE reservations (
+    id           BIGSERIAL    PRIMARY KEY,
+    pet_id       BIGINT       NOT NULL
+                 REFERENCES pets (id) ON DELETE CASCADE,
+    user_id      BIGINT       NOT NULL
+                 REFERENCES users (id) ON DELETE CASCADE,
+    status       TEXT         NOT NULL DEFAULT 'active'
+                 CHECK (status IN ('active', 'cancelled', 'expired')),
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    expires_at   TIMESTAMPTZ  NOT NULL,
+    cancelled_at TIMESTAMPTZ,
+    CONSTRAINT reservations_expires_after_created
+        CHECK (expires_at > created_at),
+    CONSTRAINT reservations_one_hold_per_pet
+        EXCLUDE USING gist (
+            pet_id WITH =,
+            tstzrange(created_at, expires_at) WITH &&
+        ) WHERE (status = 'active')
+);
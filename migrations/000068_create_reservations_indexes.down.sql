@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_reservations_expires_at;
+
+DROP INDEX IF EXISTS idx_reservations_user_id_id;
@@ -0,0 +1,6 @@
+CREATE INDEX idx_reservations_user_id_id
+    ON reservations (user_id, id);
+
+CREATE INDEX idx_reservations_expires_at
+    ON reservations (expires_at)
+    WHERE status = 'active';
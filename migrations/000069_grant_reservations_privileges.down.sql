@@ -0,0 +1,5 @@
+REVOKE SELECT, INSERT, UPDATE, DELETE
+    ON reservations FROM petstore;
+
+REVOKE USAGE, SELECT
+    ON SEQUENCE reservations_id_seq FROM petstore;
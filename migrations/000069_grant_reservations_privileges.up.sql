@@ -0,0 +1,5 @@
+GRANT SELECT, INSERT, UPDATE, DELETE
+    ON reservations TO petstore;
+
+GRANT USAGE, SELECT
+    ON SEQUENCE reservations_id_seq TO petstore;
@@ -0,0 +1,3 @@
+ALTER TABLE idempotency_keys
+    DROP COLUMN IF EXISTS heartbeat_at,
+    DROP COLUMN IF EXISTS claim_token;
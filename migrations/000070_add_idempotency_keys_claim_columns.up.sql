@@ -0,0 +1,3 @@
+ALTER TABLE idempotency_keys
+    ADD COLUMN claim_token TEXT NOT NULL DEFAULT '',
+    ADD COLUMN heartbeat_at TIMESTAMPTZ NOT NULL DEFAULT now();
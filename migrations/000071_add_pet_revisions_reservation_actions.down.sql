@@ -0,0 +1,7 @@
+DELETE FROM pet_revisions
+WHERE action IN ('reserve', 'cancel_reservation', 'expire_reservation');
+
+ALTER TABLE pet_revisions
+    DROP CONSTRAINT pet_revisions_action_check,
+    ADD CONSTRAINT pet_revisions_action_check
+    CHECK (action IN ('create', 'delete', 'restore'));
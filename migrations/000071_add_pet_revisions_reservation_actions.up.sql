@@ -0,0 +1,5 @@
+ALTER TABLE pet_revisions
+    DROP CONSTRAINT pet_revisions_action_check,
+    ADD CONSTRAINT pet_revisions_action_check
+    CHECK (action IN ('create', 'delete', 'restore',
+        'reserve', 'cancel_reservation', 'expire_reservation'));
@@ -0,0 +1,15 @@
+CREATE OR REPLACE FUNCTION enqueue_webhook_deliveries() RETURNS trigger AS $$
+DECLARE
+    delivery_type TEXT := 'pet.' || CASE NEW.action
+        WHEN 'create' THEN 'created'
+        WHEN 'delete' THEN 'deleted'
+        WHEN 'restore' THEN 'restored'
+    END;
+BEGIN
+    INSERT INTO webhook_deliveries (webhook_id, event_id, event_type)
+    SELECT id, NEW.id, delivery_type
+    FROM webhooks
+    WHERE delivery_type = ANY (event_types);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
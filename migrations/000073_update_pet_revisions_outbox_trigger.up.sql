@@ -0,0 +1,23 @@
+CREATE OR REPLACE FUNCTION outbox_pet_revision() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO outbox (aggregate_type, aggregate_id, event_type, payload)
+    VALUES ('pet', NEW.pet_id,
+        'pet.' || CASE NEW.action
+            WHEN 'create' THEN 'created'
+            WHEN 'delete' THEN 'deleted'
+            WHEN 'restore' THEN 'restored'
+            WHEN 'reserve' THEN 'reserved'
+            WHEN 'cancel_reservation' THEN 'reservation_cancelled'
+            WHEN 'expire_reservation' THEN 'reservation_expired'
+        END,
+        jsonb_build_object(
+            'revisionId', NEW.id,
+            'petId', NEW.pet_id,
+            'name', NEW.name,
+            'tag', NEW.tag,
+            'actorUserId', NEW.actor_user_id,
+            'actorApiKeyId', NEW.actor_api_key_id
+        ));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
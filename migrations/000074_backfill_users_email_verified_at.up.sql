@@ -0,0 +1,6 @@
+ALTER TABLE users DISABLE TRIGGER users_outbox;
+
+UPDATE users SET email_verified_at = created_at
+WHERE email_verified_at IS NULL;
+
+ALTER TABLE users ENABLE TRIGGER users_outbox;